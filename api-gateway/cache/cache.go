@@ -0,0 +1,178 @@
+// Package cache keeps the answers of the services for a while, so read-heavy
+// endpoints do not reach the database on every request. Values are bytes, so
+// a shared store such as Redis can implement Cache when the gateway runs on
+// several instances; LRU keeps them in the memory of one instance.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache holds values under keys for a time to live. Implementations must be
+// safe for concurrent use.
+type Cache interface {
+	// Get returns the value of key, and false when it is missing or expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// DeletePrefix drops the values whose key starts with prefix, every value
+	// for an empty prefix.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// Key joins the parts of a key with colons, Key("campaign", 1, "donations")
+// is campaign:1:donations. Keys that share their first parts can be dropped
+// together with DeletePrefix.
+func Key(parts ...interface{}) string {
+	s := make([]string, len(parts))
+	for i, part := range parts {
+		s[i] = fmt.Sprint(part)
+	}
+	return strings.Join(s, ":")
+}
+
+// Prefix is the prefix of the keys starting with parts, for DeletePrefix. It
+// ends with a colon, so campaign:1: does not match campaign:10.
+func Prefix(parts ...interface{}) string {
+	return Key(parts...) + ":"
+}
+
+// GetJSON decodes the value of key into v and reports whether it was found.
+// A failing cache is logged and reported as a miss, the services are asked
+// instead.
+func GetJSON(ctx context.Context, c Cache, key string, v interface{}) bool {
+	data, ok, err := c.Get(ctx, key)
+	if err != nil {
+		slog.WarnContext(ctx, "cache get failed", "key", key, "error", err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		slog.WarnContext(ctx, "cached value is not valid", "key", key, "error", err)
+		return false
+	}
+	return true
+}
+
+// SetJSON encodes v as the value of key for ttl. Failures are logged only.
+func SetJSON(ctx context.Context, c Cache, key string, v interface{}, ttl time.Duration) {
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = c.Set(ctx, key, data, ttl)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "cache set failed", "key", key, "error", err)
+	}
+}
+
+// Delete drops the values under the prefixes. Failures are logged only, the
+// values then expire with their time to live.
+func Delete(ctx context.Context, c Cache, prefixes ...string) {
+	for _, prefix := range prefixes {
+		if err := c.DeletePrefix(ctx, prefix); err != nil {
+			slog.WarnContext(ctx, "cache delete failed", "prefix", prefix, "error", err)
+		}
+	}
+}
+
+// None is a Cache that holds nothing, for when caching is off.
+var None Cache = none{}
+
+type none struct{}
+
+func (none) Get(ctx context.Context, key string) ([]byte, bool, error) { return nil, false, nil }
+
+func (none) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error { return nil }
+
+func (none) DeletePrefix(ctx context.Context, prefix string) error { return nil }
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// LRU is an in-memory Cache of at most size values. When it is full the
+// least recently used value makes room for a new one, expired values are
+// dropped when they are read.
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+
+	// Now is the clock of the cache, time.Now by default.
+	Now func() time.Time
+}
+
+// NewLRU returns an empty LRU holding up to size values.
+func NewLRU(size int) *LRU {
+	return &LRU{size: size, order: list.New(), entries: map[string]*list.Element{}, Now: time.Now}
+}
+
+func (c *LRU) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	e := element.Value.(*entry)
+	if !c.Now().Before(e.expiresAt) {
+		c.remove(element)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(element)
+	return e.value, true, nil
+}
+
+func (c *LRU) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.Now().Add(ttl)
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry)
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+func (c *LRU) DeletePrefix(ctx context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(element)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of values held, expired ones included.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry).key)
+}
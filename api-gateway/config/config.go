@@ -1,68 +1,239 @@
 package config
 
 import (
-	"database/sql"
-	"log"
-	"os"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
 	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/joho/godotenv"
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
+	"github.com/labstack/gommon/bytes"
+
+	"github.com/rayhanadri/crowdfunding/common/envconfig"
+	"github.com/rayhanadri/crowdfunding/common/logging"
+	"github.com/rayhanadri/crowdfunding/common/tracing"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/ratelimit"
 )
 
-var DB *gorm.DB
-
-// Connect initializes the database connection using GORM and PostgreSQL.
-func Connect() {
-	// Get database connection details from environment variables
-	dsn := "host=" + os.Getenv("POSTGRES_HOST") +
-		" user=" + os.Getenv("POSTGRES_USER") +
-		" password=" + os.Getenv("POSTGRES_PASSWORD") +
-		" dbname=" + os.Getenv("POSTGRES_DB") +
-		" port=" + os.Getenv("POSTGRES_PORT") +
-		" sslmode=require"
-	if os.Getenv("POSTGRES_HOST") == "" || os.Getenv("POSTGRES_USER") == "" || os.Getenv("POSTGRES_PASSWORD") == "" || os.Getenv("POSTGRES_DB") == "" || os.Getenv("POSTGRES_PORT") == "" {
-		log.Fatal("One or more required environment variables are not set")
-	}
-
-	// Connect to PostgreSQL database
-	var err error
-
-	// Set Connection Pooling
-	sqlDB, err := sql.Open("pgx", dsn)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer sqlDB.Close()
-
-	// Set pool settings
-	sqlDB.SetMaxOpenConns(20)
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetConnMaxLifetime(2 * time.Hour)
-
-	// var db *gorm.DB
-	DB, err = gorm.Open(postgres.New(postgres.Config{
-		Conn: sqlDB,
-	}), &gorm.Config{})
-	if err != nil {
-		log.Fatalf("Failed to connect to the database: %v", err)
-	}
-	log.Println("Database connection established")
-
-	// DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	// if err != nil {
-	// 	log.Fatalf("Failed to connect to the database: %v", err)
-	// }
-	// log.Println("Database connection established")
+// Config is the configuration of the gateway, read from the environment and an
+// optional dotenv file, see the envconfig package.
+type Config struct {
+	Port int `env:"PORT" default:"8080"`
+
+	// ShutdownTimeout bounds the drain of in-flight requests on SIGTERM, Cloud
+	// Run kills the container 10 seconds after it.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" default:"8s"`
+
+	// UserServiceAddr and DonationServiceAddr are dialed with TLS, e.g.
+	// user-service-273575294549.asia-southeast2.run.app:443
+	UserServiceAddr     string `env:"USER_SERVICE_ADDR" required:"true"`
+	DonationServiceAddr string `env:"DONATION_SERVICE_ADDR" required:"true"`
+
+	JWT JWT
+
+	// XenditCallbackToken authenticates payment callbacks, they are refused
+	// while it is unset.
+	XenditCallbackToken string `env:"XENDIT_CALLBACK_TOKEN" secret:"true"`
+
+	// AdminUserIDs are the users allowed to see the stats of every campaign,
+	// not only of the campaigns they own.
+	AdminUserIDs []string `env:"ADMIN_USER_IDS"`
+
+	RateLimit RateLimit
+	HTTP      HTTP
+	Cache     Cache
+
+	Log     logging.Config
+	Tracing tracing.Config
+}
+
+// JWT signs the access and refresh tokens.
+type JWT struct {
+	AccessKey  string        `env:"JWT_ACCESS_KEY" required:"true" secret:"true"`
+	RefreshKey string        `env:"JWT_REFRESH_KEY" required:"true" secret:"true"`
+	AccessTTL  time.Duration `env:"JWT_ACCESS_TTL" default:"24h"`
+	RefreshTTL time.Duration `env:"JWT_REFRESH_TTL" default:"24h"`
+}
+
+// RateLimit limits the requests of clients with token buckets, see the
+// ratelimit package. Rates are written as limit/period, e.g. 10/1m.
+type RateLimit struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED" default:"true"`
+	// IP limits every API request of a client IP.
+	IP ratelimit.Rate `env:"RATE_LIMIT_IP" default:"300/1m"`
+	// User limits the requests of a signed in user.
+	User ratelimit.Rate `env:"RATE_LIMIT_USER" default:"120/1m"`
+	// Auth limits registration, login and token refresh per client IP.
+	Auth ratelimit.Rate `env:"RATE_LIMIT_AUTH" default:"10/1m"`
+	// Payments limits the requests that open or refresh a payment at Xendit,
+	// per user or, for guests, per client IP.
+	Payments ratelimit.Rate `env:"RATE_LIMIT_PAYMENTS" default:"10/1m"`
+	// TrustedProxies are the CIDR ranges, besides private ones, whose
+	// X-Forwarded-For is trusted to find the client IP.
+	TrustedProxies []string `env:"TRUSTED_PROXIES"`
+}
+
+// TrustedRanges parses TrustedProxies.
+func (r RateLimit) TrustedRanges() ([]*net.IPNet, error) {
+	ranges := make([]*net.IPNet, 0, len(r.TrustedProxies))
+	for _, cidr := range r.TrustedProxies {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("TRUSTED_PROXIES: invalid CIDR %q", cidr)
+		}
+		ranges = append(ranges, ipNet)
+	}
+	return ranges, nil
+}
+
+// HTTP shapes the requests the gateway accepts and the headers it answers with.
+type HTTP struct {
+	CORS     CORS
+	Security Security
+
+	// BodyLimit caps request bodies, in bytes or with a unit, e.g. 64K (64000
+	// bytes), 64KiB or 1M.
+	BodyLimit string `env:"BODY_LIMIT" default:"64K"`
+	// BodyLimits overrides BodyLimit per route, as route=limit pairs with the
+	// route as registered, e.g. /api/v1/callbacks/xendit/invoice=256K.
+	BodyLimits []string `env:"BODY_LIMITS"`
+
+	// RequestTimeout bounds the handling of a request and the service calls
+	// made for it. The progress stream is not bounded.
+	RequestTimeout time.Duration `env:"REQUEST_TIMEOUT" default:"30s"`
+	// ReadHeaderTimeout, ReadTimeout and IdleTimeout bound the reading of a
+	// request and the wait for the next one on a kept-alive connection. There
+	// is no write timeout, it would cut the progress stream.
+	ReadHeaderTimeout time.Duration `env:"READ_HEADER_TIMEOUT" default:"5s"`
+	ReadTimeout       time.Duration `env:"READ_TIMEOUT" default:"30s"`
+	IdleTimeout       time.Duration `env:"IDLE_TIMEOUT" default:"2m"`
+}
+
+// CORS lets browser apps on other origins call the API. It is off while
+// AllowOrigins is empty.
+type CORS struct {
+	// AllowOrigins are origins such as https://app.example.com, a wildcard
+	// subdomain such as https://*.example.com, or * for any.
+	AllowOrigins     []string `env:"CORS_ALLOW_ORIGINS"`
+	AllowCredentials bool     `env:"CORS_ALLOW_CREDENTIALS"`
+	// MaxAge is how long browsers cache a preflight response.
+	MaxAge time.Duration `env:"CORS_MAX_AGE" default:"10m"`
+}
+
+// Security sets the security headers of every response.
+type Security struct {
+	// HSTSMaxAge is how long browsers keep to HTTPS, sent on HTTPS requests
+	// only. 0 leaves Strict-Transport-Security out.
+	HSTSMaxAge            time.Duration `env:"HSTS_MAX_AGE" default:"8760h"`
+	HSTSIncludeSubdomains bool          `env:"HSTS_INCLUDE_SUBDOMAINS"`
+	// FrameOptions is the X-Frame-Options header, DENY or SAMEORIGIN.
+	FrameOptions string `env:"FRAME_OPTIONS" default:"DENY"`
+}
+
+// Cache keeps the answers of the services in memory, see the cache package.
+// Cached donations are dropped when they settle or are written through the
+// gateway, TTLs bound how stale they get otherwise.
+type Cache struct {
+	Enabled bool `env:"CACHE_ENABLED" default:"true"`
+	// Size is the number of answers kept, the least recently used go first.
+	Size int `env:"CACHE_SIZE" default:"10000"`
+	// PublicTTL is how long public listings, such as donor walls and
+	// leaderboards, are kept. Browsers and CDNs may keep them as long.
+	PublicTTL time.Duration `env:"CACHE_PUBLIC_TTL" default:"30s"`
+	// PrivateTTL is how long donations and user profiles are kept.
+	PrivateTTL time.Duration `env:"CACHE_PRIVATE_TTL" default:"10s"`
 }
 
-func LoadEnv() {
-	// Load environment variables from .env file
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
+// Limits parses BodyLimit and BodyLimits, in bytes.
+func (h HTTP) Limits() (int64, map[string]int64, error) {
+	limit, err := bytes.Parse(h.BodyLimit)
+	if err != nil || limit <= 0 {
+		return 0, nil, fmt.Errorf("BODY_LIMIT: invalid size %q", h.BodyLimit)
+	}
+	routes := make(map[string]int64, len(h.BodyLimits))
+	for _, pair := range h.BodyLimits {
+		route, size, ok := strings.Cut(pair, "=")
+		n, err := bytes.Parse(size)
+		if !ok || !strings.HasPrefix(route, "/") || err != nil || n <= 0 {
+			return 0, nil, fmt.Errorf("BODY_LIMITS: invalid route=limit pair %q", pair)
+		}
+		routes[route] = n
+	}
+	return limit, routes, nil
+}
+
+// IsAdmin tells whether userID is one of AdminUserIDs.
+func (c *Config) IsAdmin(userID int) bool {
+	for _, id := range c.AdminUserIDs {
+		if strings.TrimSpace(id) == strconv.Itoa(userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the rules across fields.
+func (c *Config) Validate() error {
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("PORT must be between 1 and 65535")
+	}
+	if c.JWT.AccessKey == c.JWT.RefreshKey {
+		return fmt.Errorf("JWT_ACCESS_KEY and JWT_REFRESH_KEY must differ, or refresh tokens pass as access tokens")
+	}
+	if c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.JWT.AccessTTL <= 0 || c.JWT.RefreshTTL <= 0 {
+		return fmt.Errorf("JWT_ACCESS_TTL and JWT_REFRESH_TTL must be positive")
+	}
+	if _, err := c.RateLimit.TrustedRanges(); err != nil {
+		return err
+	}
+	if _, _, err := c.HTTP.Limits(); err != nil {
+		return err
 	}
-	log.Println("Environment variables loaded successfully")
+	if c.HTTP.RequestTimeout <= 0 || c.HTTP.ReadHeaderTimeout <= 0 || c.HTTP.ReadTimeout <= 0 || c.HTTP.IdleTimeout <= 0 {
+		return fmt.Errorf("REQUEST_TIMEOUT, READ_HEADER_TIMEOUT, READ_TIMEOUT and IDLE_TIMEOUT must be positive")
+	}
+	for _, origin := range c.HTTP.CORS.AllowOrigins {
+		if origin == "*" && c.HTTP.CORS.AllowCredentials {
+			return fmt.Errorf("CORS_ALLOW_ORIGINS cannot be * with CORS_ALLOW_CREDENTIALS, list the origins")
+		}
+		if origin != "*" && !strings.HasPrefix(origin, "https://") && !strings.HasPrefix(origin, "http://") {
+			return fmt.Errorf("CORS_ALLOW_ORIGINS: origin %q must start with https:// or http://", origin)
+		}
+	}
+	if c.HTTP.CORS.MaxAge < 0 || c.HTTP.Security.HSTSMaxAge < 0 {
+		return fmt.Errorf("CORS_MAX_AGE and HSTS_MAX_AGE cannot be negative")
+	}
+	if c.HTTP.Security.FrameOptions != "DENY" && c.HTTP.Security.FrameOptions != "SAMEORIGIN" {
+		return fmt.Errorf("FRAME_OPTIONS must be DENY or SAMEORIGIN")
+	}
+	if c.Cache.Enabled && (c.Cache.Size < 1 || c.Cache.PublicTTL <= 0 || c.Cache.PrivateTTL <= 0) {
+		return fmt.Errorf("CACHE_SIZE, CACHE_PUBLIC_TTL and CACHE_PRIVATE_TTL must be positive")
+	}
+	for _, id := range c.AdminUserIDs {
+		if n, err := strconv.Atoi(strings.TrimSpace(id)); err != nil || n <= 0 {
+			return fmt.Errorf("ADMIN_USER_IDS: invalid user ID %q", id)
+		}
+	}
+	if err := c.Log.Validate(); err != nil {
+		return err
+	}
+	return c.Tracing.Validate()
+}
+
+// App is the configuration loaded by Load.
+var App Config
+
+// Load reads and validates the configuration into App.
+func Load() error {
+	return envconfig.Load(&App)
+}
+
+// Print writes the configuration with its secrets redacted.
+func Print(w io.Writer) error {
+	return envconfig.Print(w, &App)
 }
@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/donations": {
-            "get": {
-                "description": "Get all donations for an active user",
+        "/callbacks/xendit/ewallet": {
+            "post": {
+                "description": "Called by Xendit when an e-wallet charge changes status, authenticated with the x-callback-token header",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,16 +25,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "donations"
+                    "callbacks"
                 ],
-                "summary": "Get all donations for a user",
+                "summary": "Xendit e-wallet charge callback",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
+                        "description": "Xendit callback verification token",
+                        "name": "x-callback-token",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "E-wallet charge callback",
+                        "name": "entity.EWalletCallback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.EWalletCallback"
+                        }
                     }
                 ],
                 "responses": {
@@ -45,9 +54,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/callbacks/xendit/invoice": {
             "post": {
-                "description": "Create a new donation for a user",
+                "description": "Called by Xendit when an invoice changes status, authenticated with the x-callback-token header",
                 "consumes": [
                     "application/json"
                 ],
@@ -55,30 +66,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "donations"
+                    "callbacks"
                 ],
-                "summary": "Create a new donation",
+                "summary": "Xendit invoice callback",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
+                        "description": "Xendit callback verification token",
+                        "name": "x-callback-token",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Donation object",
-                        "name": "entity.DonationRequest",
+                        "description": "Invoice callback",
+                        "name": "entity.InvoiceCallback",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.DonationRequest"
+                            "$ref": "#/definitions/entity.InvoiceCallback"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/entity.Response"
                         }
@@ -86,9 +97,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/donations/{id}": {
-            "get": {
-                "description": "Get details of a specific donation by its ID",
+        "/callbacks/xendit/qris": {
+            "post": {
+                "description": "Called by Xendit when a QR code is paid, authenticated with the x-callback-token header",
                 "consumes": [
                     "application/json"
                 ],
@@ -96,23 +107,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "donations"
+                    "callbacks"
                 ],
-                "summary": "Get Donation details by Donation ID",
+                "summary": "Xendit QRIS payment callback",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
+                        "description": "Xendit callback verification token",
+                        "name": "x-callback-token",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Donation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "QR code payment callback",
+                        "name": "entity.QRCodeCallback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.QRCodeCallback"
+                        }
                     }
                 ],
                 "responses": {
@@ -123,9 +136,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Get details of a specific donation by its ID",
+            }
+        },
+        "/callbacks/xendit/virtual-account": {
+            "post": {
+                "description": "Called by Xendit when a virtual account is paid, authenticated with the x-callback-token header",
                 "consumes": [
                     "application/json"
                 ],
@@ -133,31 +148,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "donations"
+                    "callbacks"
                 ],
-                "summary": "Update a donation based on the invoice status",
+                "summary": "Xendit virtual account payment callback",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
+                        "description": "Xendit callback verification token",
+                        "name": "x-callback-token",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Donation ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Donation object",
-                        "name": "entity.DonationRequest",
+                        "description": "Virtual account payment callback",
+                        "name": "entity.VirtualAccountCallback",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.DonationRequest"
+                            "$ref": "#/definitions/entity.VirtualAccountCallback"
                         }
                     }
                 ],
@@ -171,9 +179,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions": {
+        "/campaigns/{id}/donations": {
             "get": {
-                "description": "Get all transactions for an active user",
+                "description": "Get the completed donations of a campaign, anonymous donors are masked",
                 "consumes": [
                     "application/json"
                 ],
@@ -181,16 +189,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "campaigns"
                 ],
-                "summary": "Get all transactions for a user",
+                "summary": "Get the donor wall of a campaign",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starts at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -198,63 +224,77 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The copy held by the client is current"
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new transaction for a user",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/campaigns/{id}/progress/stream": {
+            "get": {
+                "description": "Server-Sent Events stream with the campaign totals every time a donation settles. Send Last-Event-ID (or last_event_id) to resume.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "transactions"
+                    "campaigns"
                 ],
-                "summary": "Create a new transaction",
+                "summary": "Stream the progress of a campaign",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Transaction object",
-                        "name": "entity.TransactionRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.TransactionRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the last event received, for clients that cannot set headers",
+                        "name": "last_event_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "event stream",
                         "schema": {
-                            "$ref": "#/definitions/entity.Response"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/transactions/sync-transaction/{id}": {
-            "put": {
-                "description": "Get details of a specific transaction by its ID and check its status",
-                "consumes": [
-                    "application/json"
-                ],
+        "/campaigns/{id}/stats": {
+            "get": {
+                "description": "Get the total raised, donor count, average and median gift, repeat donor rate and the donations per day or week of a campaign. Only its owner and admins can see them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "campaigns"
                 ],
-                "summary": "Check and update a transaction based on the invoice status",
+                "summary": "Get the stats of a campaign",
                 "parameters": [
                     {
                         "type": "string",
@@ -265,25 +305,82 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
+                        "description": "Campaign ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Buckets of the time series: day (default) or week, weeks start on Monday",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone the buckets start at midnight in, UTC by default",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the time series, YYYY-MM-DD, 30 days or 12 weeks before to by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the time series, YYYY-MM-DD, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.CampaignStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The copy held by the client is current"
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
                         }
                     }
                 }
             }
         },
-        "/transactions/{id}": {
+        "/campaigns/{id}/top-donors": {
             "get": {
-                "description": "Get details of a specific transaction by its ID",
+                "description": "Get the donors that gave the most to a campaign, anonymous donors are masked",
                 "consumes": [
                     "application/json"
                 ],
@@ -291,23 +388,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "campaigns"
                 ],
-                "summary": "Get Transaction details by Transaction ID",
+                "summary": "Get the top donors of a campaign",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
+                        "description": "Campaign ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of donors, at most 50",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -315,12 +417,29 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The copy held by the client is current"
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Get details of a specific transaction by its ID",
+            }
+        },
+        "/donations": {
+            "get": {
+                "description": "Get all donations for an active user",
                 "consumes": [
                     "application/json"
                 ],
@@ -328,9 +447,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "donations"
                 ],
-                "summary": "Update a transaction based on the invoice status",
+                "summary": "Get all donations for a user",
                 "parameters": [
                     {
                         "type": "string",
@@ -340,20 +459,10 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Transaction ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Transaction object",
-                        "name": "entity.TransactionRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.TransactionRequest"
-                        }
+                        "type": "string",
+                        "description": "ETag of the copy held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -361,14 +470,27 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The copy held by the client is current"
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/users/login": {
+            },
             "post": {
-                "description": "Authenticate and return a token for the user",
+                "description": "Create a new donation for a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -376,33 +498,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "donations"
                 ],
-                "summary": "Login user",
+                "summary": "Create a new donation",
                 "parameters": [
                     {
-                        "description": "User object",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Donation object",
+                        "name": "entity.DonationRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserLogin"
+                            "$ref": "#/definitions/entity.DonationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/entity.Response"
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
                     }
                 }
             }
         },
-        "/users/me": {
-            "get": {
-                "description": "Current User Details",
+        "/donations/claim": {
+            "post": {
+                "description": "Move guest donations made with the email of the logged in user to the user account. The email must be verified, refresh the tokens after verifying it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -410,9 +551,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "donations"
                 ],
-                "summary": "Get Current User Details",
+                "summary": "Claim guest donations",
                 "parameters": [
                     {
                         "type": "string",
@@ -428,11 +569,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/entity.Response"
                         }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Update user information",
+            }
+        },
+        "/donations/guest": {
+            "post": {
+                "description": "Create a donation and its payment without an account. payment_method is INVOICE (default, sent to the guest email), VIRTUAL_ACCOUNT with a bank as payment_channel, EWALLET with OVO, DANA or SHOPEEPAY as payment_channel, or QRIS. The transaction carries the payment instructions",
                 "consumes": [
                     "application/json"
                 ],
@@ -440,24 +589,267 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "donations"
                 ],
-                "summary": "Update user details",
+                "summary": "Create a donation as a guest",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "Guest donation object",
+                        "name": "entity.GuestDonationRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.GuestDonationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/donations/receipts/{year}": {
+            "get": {
+                "description": "Download the PDF summary of every receipt issued to the current user in a year",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "donations"
+                ],
+                "summary": "Download the annual donation summary",
+                "parameters": [
                     {
-                        "description": "User object",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/donations/{id}": {
+            "get": {
+                "description": "Get details of a specific donation by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "donations"
+                ],
+                "summary": "Get Donation details by Donation ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Donation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the donation"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The copy held by the client is current"
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the fields set in the body. A paid donation answers 409, and so does a new amount once the donation has a payment under way or was abandoned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "donations"
+                ],
+                "summary": "Update a donation based on the invoice status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Donation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the donation",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Donation object",
+                        "name": "entity.DonationUpdate",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.UserRegister"
+                            "$ref": "#/definitions/entity.DonationUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the donation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change only the fields set in the body, zero values included, the others are kept. A paid donation answers 409, and so does a new amount once the donation has a payment under way or was abandoned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "donations"
+                ],
+                "summary": "Partially update a donation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Donation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the donation",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "donation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.DonationPatch"
                         }
                     }
                 ],
@@ -466,105 +858,1909 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the donation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/donations/{id}/receipt": {
+            "get": {
+                "description": "Download the PDF receipt of a paid donation of the current user",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "donations"
+                ],
+                "summary": "Download the receipt of a donation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Donation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
                         }
                     }
                 }
             }
         },
-        "/users/refresh-token": {
-            "post": {
-                "description": "Refresh the access token using the refresh token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Refresh user access token",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer \u003caccess_token\u003e",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/entity.Response"
-                        }
+        "/donations/{id}/receipt/guest": {
+            "post": {
+                "description": "Download the PDF receipt of a paid guest donation, with the email it was made with and the invoice ID given at checkout. Any other donation is reported as missing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "donations"
+                ],
+                "summary": "Download the receipt of a guest donation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Donation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Guest email and invoice ID",
+                        "name": "entity.GuestReceiptRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.GuestReceiptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboard": {
+            "get": {
+                "description": "Get the top donors and top campaigns for a time window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "campaigns"
+                ],
+                "summary": "Get the global leaderboard",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Time window: day, week, month or all",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries, at most 50",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the response"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The copy held by the client is current"
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "description": "Get all transactions for an active user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get all transactions for a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Open a payment for a donation of the current user. payment_method is INVOICE (default), VIRTUAL_ACCOUNT with a bank as payment_channel, EWALLET with OVO, DANA or SHOPEEPAY as payment_channel, or QRIS. The amount is the donation amount and may be left out. A paid donation, or one with a payment under way, answers 409. The transaction carries the payment instructions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Create a new transaction for a donation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction object",
+                        "name": "entity.TransactionRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Transaction"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/sync-transaction/{id}": {
+            "put": {
+                "description": "Fetch the payment of a pending transaction of the current user from the provider again and settle it when it was paid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Check and update a transaction based on the payment status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Transaction"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "description": "Get details of a transaction of the current user by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get Transaction details by Transaction ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Transaction"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the transaction"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The copy held by the client is current"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Move a transaction of the current user to another status, such as FAILED to abandon it. PAID and SETTLED are only set when the payment settles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Update the status of a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the transaction",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction status",
+                        "name": "entity.TransactionUpdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.TransactionUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.Transaction"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the transaction"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/reissue": {
+            "post": {
+                "description": "Create a fresh payment for the donation of an expired or failed transaction, linked to it. The donation is pending again. Without a payment method the previous one is used",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Reissue the invoice of an expired transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment method of the new invoice",
+                        "name": "entity.ReissueInvoiceRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ReissueInvoiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/reissue/guest": {
+            "post": {
+                "description": "Create a fresh payment for the guest donation of an expired or failed transaction, with the email it was made with and the invoice ID of the transaction. Any other transaction is reported as missing. Without a payment method the previous one is used",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Reissue the invoice of an expired guest transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Guest email, invoice ID and payment method of the new invoice",
+                        "name": "entity.GuestReissueRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.GuestReissueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "Authenticate and return a token for the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "User object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "get": {
+                "description": "Current User Details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get Current User Details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the copy held by the client",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The copy held by the client is current"
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the name and the email of the current user, the password is changed with /users/me/password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "User object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change only the fields set in the body, the others are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Partially update user details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the user",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the user"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notification-preferences": {
+            "get": {
+                "description": "Get how the current user is notified about donation events",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.NotificationPreference"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace how the current user is notified. Locale is id or en, webhook_url must be https.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update notification preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification preferences",
+                        "name": "entity.NotificationPreference",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.NotificationPreference"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.NotificationPreference"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/password": {
+            "put": {
+                "description": "Replace the password of the current user, the current password is required",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Current and new password",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.PasswordChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/verification-email": {
+            "post": {
+                "description": "Mail the current user a new link verifying their email, earlier links stop working",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Send the email verification link again",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/refresh-token": {
+            "post": {
+                "description": "Refresh the access token using the refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Refresh user access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "Register a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User object",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.UserRegister"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/verify-email": {
+            "get": {
+                "description": "Verify the email a verification link was sent to. Refresh the tokens afterwards, they tell whether the email is verified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Verify the email of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token of the verification link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Get the active webhook subscriptions of the current user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook subscriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entity.WebhookSubscription"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Subscribe a partner URL to events of a campaign owned by the current user. Deliveries are signed with HMAC-SHA256 over \"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\" in X-Webhook-Signature. The secret is only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Subscribe a webhook to campaign events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook subscription",
+                        "name": "entity.WebhookSubscriptionRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/entity.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entity.WebhookSubscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "description": "Stop deliveries to a webhook of the current user, its delivery log is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "description": "Get the deliveries of a webhook of the current user, newest first, with their attempts and last error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get the delivery log of a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starts at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deliveries per page, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "description": "Send a delivery again right away, e.g. a dead one after the endpoint was fixed. The payload and its ID stay the same.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Redeliver a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer \u003caccess_token\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Response"
+                        }
+                    },
+                    "default": {
+                        "description": "",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.Campaign": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "collected_amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_donation": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target_amount": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/entity.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.CampaignStats": {
+            "type": "object",
+            "properties": {
+                "average_donation": {
+                    "type": "number"
+                },
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "donation_count": {
+                    "type": "integer"
+                },
+                "donor_count": {
+                    "type": "integer"
+                },
+                "median_donation": {
+                    "type": "number"
+                },
+                "repeat_donor_rate": {
+                    "description": "RepeatDonorRate is the share of donors who gave more than once, from 0\nto 1.",
+                    "type": "number"
+                },
+                "time_series": {
+                    "$ref": "#/definitions/entity.DonationTimeSeries"
+                },
+                "total_raised": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.Donation": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "campaign": {
+                    "$ref": "#/definitions/entity.Campaign"
+                },
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/entity.User"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.DonationPatch": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "is_anonymous": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "COMPLETED",
+                        "ABANDONED"
+                    ]
+                }
+            }
+        },
+        "entity.DonationRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_anonymous": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.DonationTimeSeries": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.TimeSeriesBucket"
+                    }
+                },
+                "interval": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.DonationUpdate": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "is_anonymous": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "COMPLETED",
+                        "ABANDONED"
+                    ]
+                }
+            }
+        },
+        "entity.EWalletCallback": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "id": {
+                            "type": "string"
+                        },
+                        "status": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "event": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GuestDonationRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "is_anonymous": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "mobile_number": {
+                    "type": "string"
+                },
+                "payment_channel": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string",
+                    "enum": [
+                        "INVOICE",
+                        "VIRTUAL_ACCOUNT",
+                        "EWALLET",
+                        "QRIS"
+                    ]
+                }
+            }
+        },
+        "entity.GuestReceiptRequest": {
+            "type": "object",
+            "required": [
+                "invoice_id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "invoice_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.GuestReissueRequest": {
+            "type": "object",
+            "required": [
+                "invoice_id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "invoice_id": {
+                    "type": "string"
+                },
+                "mobile_number": {
+                    "type": "string"
+                },
+                "payment_channel": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.InvoiceCallback": {
+            "type": "object",
+            "properties": {
+                "external_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.NotificationPreference": {
+            "type": "object",
+            "properties": {
+                "email_enabled": {
+                    "type": "boolean"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "muted_events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
+                },
+                "webhook_url": {
+                    "type": "string"
                 }
             }
         },
-        "/users/register": {
-            "post": {
-                "description": "Register a new user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Register a new user",
-                "parameters": [
-                    {
-                        "description": "User object",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/entity.UserRegister"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/entity.Response"
-                        }
-                    }
+        "entity.PasswordChange": {
+            "type": "object",
+            "required": [
+                "current_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.DonationRequest": {
+        },
+        "entity.Problem": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "detail": {
+                    "type": "string"
                 },
-                "campaign_id": {
-                    "type": "integer"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.FieldError"
+                    }
                 },
-                "created_at": {
+                "instance": {
                     "type": "string"
                 },
-                "id": {
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "integer"
                 },
-                "message": {
+                "title": {
                     "type": "string"
                 },
-                "status": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.QRCodeCallback": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "id": {
+                            "type": "string"
+                        },
+                        "qr_id": {
+                            "type": "string"
+                        },
+                        "status": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "event": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ReissueInvoiceRequest": {
+            "type": "object",
+            "properties": {
+                "mobile_number": {
                     "type": "string"
                 },
-                "updated_at": {
+                "payment_channel": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "payment_method": {
+                    "type": "string"
                 }
             }
         },
@@ -580,7 +2776,21 @@ const docTemplate = `{
                 }
             }
         },
-        "entity.TransactionRequest": {
+        "entity.TimeSeriesBucket": {
+            "type": "object",
+            "properties": {
+                "donation_count": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "entity.Transaction": {
             "type": "object",
             "properties": {
                 "amount": {
@@ -589,6 +2799,9 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "donation": {
+                    "$ref": "#/definitions/entity.Donation"
+                },
                 "donation_id": {
                     "type": "integer"
                 },
@@ -612,11 +2825,113 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.TransactionRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "donation_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invoice_description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "invoice_id": {
+                    "type": "string"
+                },
+                "invoice_url": {
+                    "type": "string"
+                },
+                "mobile_number": {
+                    "type": "string"
+                },
+                "payment_channel": {
+                    "type": "string"
+                },
+                "payment_method": {
+                    "type": "string",
+                    "enum": [
+                        "INVOICE",
+                        "VIRTUAL_ACCOUNT",
+                        "EWALLET",
+                        "QRIS"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.TransactionUpdate": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "PAID",
+                        "SETTLED",
+                        "EXPIRED",
+                        "FAILED"
+                    ]
+                }
+            }
+        },
+        "entity.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
         "entity.UserLogin": {
             "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
             "properties": {
                 "email": {
                     "type": "string"
@@ -626,6 +2941,17 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.UserPatch": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "entity.UserRegister": {
             "type": "object",
             "properties": {
@@ -639,6 +2965,77 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "entity.UserUpdate": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.VirtualAccountCallback": {
+            "type": "object",
+            "properties": {
+                "callback_virtual_account_id": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WebhookSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "campaign_id": {
+                    "type": "integer"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
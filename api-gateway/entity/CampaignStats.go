@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// CampaignStats is how the fundraising of a campaign is going, for its owner
+// and admins. Only completed donations count.
+type CampaignStats struct {
+	CampaignID      int     `json:"campaign_id"`
+	TotalRaised     float64 `json:"total_raised"`
+	DonationCount   int     `json:"donation_count"`
+	DonorCount      int     `json:"donor_count"`
+	AverageDonation float64 `json:"average_donation"`
+	MedianDonation  float64 `json:"median_donation"`
+	// RepeatDonorRate is the share of donors who gave more than once, from 0
+	// to 1.
+	RepeatDonorRate float64            `json:"repeat_donor_rate"`
+	TimeSeries      DonationTimeSeries `json:"time_series"`
+}
+
+// DonationTimeSeries has a bucket for every day or week, from Monday, of the
+// requested range.
+type DonationTimeSeries struct {
+	Interval string             `json:"interval"`
+	Timezone string             `json:"timezone"`
+	Buckets  []TimeSeriesBucket `json:"buckets"`
+}
+
+// TimeSeriesBucket starts at midnight in the timezone of the series.
+type TimeSeriesBucket struct {
+	Start         time.Time `json:"start"`
+	TotalAmount   float64   `json:"total_amount"`
+	DonationCount int       `json:"donation_count"`
+}
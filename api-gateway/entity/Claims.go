@@ -6,9 +6,12 @@ import (
 )
 
 type Claims struct {
-	UserID int     `json:"user_id"`
-	Email  string  `json:"email"`
-	Exp    float64 `json:"exp"`
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+	// EmailVerified tells whether the user proved they own Email when the
+	// token was issued, a token refreshed after verifying carries it.
+	EmailVerified bool    `json:"email_verified"`
+	Exp           float64 `json:"exp"`
 }
 
 // Valid method to implement jwt.Claims interface
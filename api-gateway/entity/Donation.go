@@ -14,6 +14,7 @@ type Donation struct {
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int       `json:"version"`
 }
 
 func (Donation) TableName() string {
@@ -21,12 +22,50 @@ func (Donation) TableName() string {
 }
 
 type DonationRequest struct {
-	ID         int       `gorm:"primaryKey" json:"id"`
-	UserID     int       `json:"user_id"`
-	CampaignID int       `json:"campaign_id"`
-	Amount     float64   `json:"amount"`
-	Message    string    `json:"message"`
-	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID          int       `gorm:"primaryKey" json:"id"`
+	UserID      int       `json:"user_id"`
+	CampaignID  int       `json:"campaign_id" validate:"gt=0"`
+	Amount      float64   `json:"amount" validate:"amount"`
+	Message     string    `json:"message" validate:"message"`
+	Status      string    `json:"status" validate:"omitempty,oneof=PENDING"`
+	IsAnonymous bool      `json:"is_anonymous"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DonationUpdate changes the fields it sets. COMPLETED is only set when the
+// payment settles.
+type DonationUpdate struct {
+	Amount      float64 `json:"amount" validate:"omitempty,amount"`
+	Message     string  `json:"message" validate:"message"`
+	Status      string  `json:"status" validate:"omitempty,oneof=PENDING COMPLETED ABANDONED"`
+	IsAnonymous bool    `json:"is_anonymous"`
+}
+
+// DonationPatch changes the fields it sets, the others are kept.
+type DonationPatch struct {
+	Amount      *float64 `json:"amount" validate:"omitnil,amount"`
+	Message     *string  `json:"message" validate:"omitnil,message"`
+	Status      *string  `json:"status" validate:"omitnil,oneof=PENDING COMPLETED ABANDONED"`
+	IsAnonymous *bool    `json:"is_anonymous"`
+}
+
+// GuestDonationRequest is the body of a guest checkout, no account is needed.
+// Without a payment method the guest pays through an invoice.
+type GuestDonationRequest struct {
+	Email          string  `json:"email" validate:"email"`
+	CampaignID     int     `json:"campaign_id" validate:"gt=0"`
+	Amount         float64 `json:"amount" validate:"amount"`
+	Message        string  `json:"message" validate:"message"`
+	IsAnonymous    bool    `json:"is_anonymous"`
+	PaymentMethod  string  `json:"payment_method" validate:"omitempty,oneof=INVOICE VIRTUAL_ACCOUNT EWALLET QRIS"`
+	PaymentChannel string  `json:"payment_channel"`
+	MobileNumber   string  `json:"mobile_number"`
+}
+
+// GuestReceiptRequest is the body of a guest asking for their receipt: the
+// email they donated with and the invoice ID they were given at checkout.
+type GuestReceiptRequest struct {
+	Email     string `json:"email" validate:"email"`
+	InvoiceID string `json:"invoice_id" validate:"required"`
 }
@@ -0,0 +1,8 @@
+package entity
+
+// Health is the body of /healthz and /readyz, Checks maps every backend to
+// "ok" or the reason it is not ready.
+type Health struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+// NotificationPreference is how the current user wants to be notified.
+// Locale is id or en, muted_events lists event types to skip, e.g.
+// "donation.created".
+type NotificationPreference struct {
+	Locale       string   `json:"locale"`
+	EmailEnabled bool     `json:"email_enabled"`
+	WebhookURL   string   `json:"webhook_url"`
+	MutedEvents  []string `json:"muted_events"`
+}
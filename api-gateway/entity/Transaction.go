@@ -15,21 +15,87 @@ type Transaction struct {
 	Status             string    `gorm:"size:50;default:'PENDING'" json:"status"`
 	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	Version            int       `json:"version"`
 }
 
+// TransactionRequest opens a payment for a donation. Amount may be left out,
+// it must be the donation amount.
 type TransactionRequest struct {
 	ID                 int       `gorm:"primaryKey" json:"id"`
-	DonationID         int       `gorm:"not null;index" json:"donation_id"`
+	DonationID         int       `gorm:"not null;index" json:"donation_id" validate:"gt=0"`
 	InvoiceID          string    `gorm:"size:255" json:"invoice_id"`
 	InvoiceURL         string    `gorm:"size:255" json:"invoice_url"`
-	InvoiceDescription string    `gorm:"size:255" json:"invoice_description"`
-	PaymentMethod      string    `gorm:"size:50" json:"payment_method"`
-	Amount             float64   `gorm:"not null" json:"amount"`
-	Status             string    `gorm:"size:50;default:'PENDING'" json:"status"`
+	InvoiceDescription string    `gorm:"size:255" json:"invoice_description" validate:"max=255"`
+	PaymentMethod      string    `gorm:"size:50" json:"payment_method" validate:"omitempty,oneof=INVOICE VIRTUAL_ACCOUNT EWALLET QRIS"`
+	PaymentChannel     string    `json:"payment_channel"`
+	MobileNumber       string    `json:"mobile_number"`
+	Amount             float64   `gorm:"not null" json:"amount" validate:"omitempty,amount"`
+	Status             string    `gorm:"size:50;default:'PENDING'" json:"status" validate:"omitempty,oneof=PENDING"`
 	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
+// TransactionUpdate moves a transaction to another status. PAID and SETTLED
+// are only set when the payment settles.
+type TransactionUpdate struct {
+	Status string `json:"status" validate:"required,oneof=PENDING PAID SETTLED EXPIRED FAILED"`
+}
+
+// ReissueInvoiceRequest is the optional body of an invoice reissue. Without a
+// payment method the previous one is used again.
+type ReissueInvoiceRequest struct {
+	PaymentMethod  string `json:"payment_method"`
+	PaymentChannel string `json:"payment_channel"`
+	MobileNumber   string `json:"mobile_number"`
+}
+
+// GuestReissueRequest is the body of a guest reissuing their invoice: the
+// email they donated with, the invoice ID of the expired transaction and,
+// optionally, another payment method.
+type GuestReissueRequest struct {
+	Email          string `json:"email" validate:"email"`
+	InvoiceID      string `json:"invoice_id" validate:"required"`
+	PaymentMethod  string `json:"payment_method"`
+	PaymentChannel string `json:"payment_channel"`
+	MobileNumber   string `json:"mobile_number"`
+}
+
 func (Transaction) TableName() string {
 	return "transactions"
 }
+
+// InvoiceCallback is the part of the Xendit invoice callback the gateway reads.
+type InvoiceCallback struct {
+	ID         string `json:"id"`
+	ExternalID string `json:"external_id"`
+	Status     string `json:"status"`
+}
+
+// VirtualAccountCallback is the part of the Xendit virtual account payment
+// callback the gateway reads.
+type VirtualAccountCallback struct {
+	PaymentID                string `json:"payment_id"`
+	CallbackVirtualAccountID string `json:"callback_virtual_account_id"`
+	ExternalID               string `json:"external_id"`
+}
+
+// EWalletCallback is the part of the Xendit e-wallet charge callback the
+// gateway reads.
+type EWalletCallback struct {
+	Event string `json:"event"`
+	Data  struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	} `json:"data"`
+}
+
+// QRCodeCallback is the part of the Xendit QR code payment callback the
+// gateway reads.
+type QRCodeCallback struct {
+	Event string `json:"event"`
+	Data  struct {
+		ID     string `json:"id"`
+		QRID   string `json:"qr_id"`
+		Status string `json:"status"`
+	} `json:"data"`
+}
@@ -13,6 +13,7 @@ type User struct {
 	Password  string    `json:"password,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int       `json:"version"`
 }
 
 func (User) TableName() string {
@@ -30,12 +31,31 @@ func (u *User) Validate() error {
 }
 
 type UserRegister struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password,omitempty"`
+	Name     string `json:"name" validate:"name"`
+	Email    string `json:"email" validate:"email"`
+	Password string `json:"password,omitempty" validate:"password"`
+}
+
+// UserUpdate replaces the name and the email of a user. Passwords are changed
+// with a PasswordChange.
+type UserUpdate struct {
+	Name  string `json:"name" validate:"name"`
+	Email string `json:"email" validate:"email"`
+}
+
+// UserPatch changes the fields it sets, the others are kept.
+type UserPatch struct {
+	Name  *string `json:"name" validate:"omitnil,name"`
+	Email *string `json:"email" validate:"omitnil,email"`
+}
+
+// PasswordChange replaces the password of a user who knows the current one.
+type PasswordChange struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"password"`
 }
 
 type UserLogin struct {
-	Email    string `json:"email"`
-	Password string `json:"password,omitempty"`
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password,omitempty" validate:"required"`
 }
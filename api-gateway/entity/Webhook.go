@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// WebhookSubscriptionRequest subscribes a URL to events of a campaign the
+// current user owns.
+type WebhookSubscriptionRequest struct {
+	CampaignID int      `json:"campaign_id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+// WebhookSubscription is returned with its secret only when it is created.
+type WebhookSubscription struct {
+	ID         int       `json:"id"`
+	CampaignID int       `json:"campaign_id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"secret,omitempty"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/config"
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+type CallbackHandler interface {
+	XenditInvoiceCallback(c echo.Context) error
+	XenditVirtualAccountCallback(c echo.Context) error
+	XenditEWalletCallback(c echo.Context) error
+	XenditQRCodeCallback(c echo.Context) error
+}
+
+type callbackHandler struct {
+	transactionRepo repository.TransactionRepository
+}
+
+func NewCallbackHandler(transactionRepo repository.TransactionRepository) CallbackHandler {
+	return &callbackHandler{transactionRepo: transactionRepo}
+}
+
+// verifyCallbackToken checks the callback comes from Xendit.
+func verifyCallbackToken(c echo.Context) bool {
+	expectedToken := config.App.XenditCallbackToken
+	token := c.Request().Header.Get("x-callback-token")
+	return expectedToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) == 1
+}
+
+// settlePayment hands a verified callback to donation-service, which fetches
+// the payment again, the body is not trusted.
+func (h *callbackHandler) settlePayment(c echo.Context, reference string, paymentID string) error {
+	transaction, err := h.transactionRepo.HandleInvoiceCallback(c.Request().Context(), reference, paymentID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    transaction,
+	})
+}
+
+// XenditInvoiceCallback godoc
+// @Summary Xendit invoice callback
+// @Description Called by Xendit when an invoice changes status, authenticated with the x-callback-token header
+// @Tags callbacks
+// @Accept json
+// @Produce json
+// @Param x-callback-token header string true "Xendit callback verification token"
+// @Param entity.InvoiceCallback body entity.InvoiceCallback true "Invoice callback"
+// @Success 200 {object} entity.Response
+// @Router /callbacks/xendit/invoice [post]
+func (h *callbackHandler) XenditInvoiceCallback(c echo.Context) error {
+	if !verifyCallbackToken(c) {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "Invalid callback token",
+		})
+	}
+
+	callback := new(entity.InvoiceCallback)
+	if err := c.Bind(callback); err != nil || callback.ID == "" {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Bad Request, Invalid request body",
+		})
+	}
+
+	return h.settlePayment(c, callback.ID, "")
+}
+
+// XenditVirtualAccountCallback godoc
+// @Summary Xendit virtual account payment callback
+// @Description Called by Xendit when a virtual account is paid, authenticated with the x-callback-token header
+// @Tags callbacks
+// @Accept json
+// @Produce json
+// @Param x-callback-token header string true "Xendit callback verification token"
+// @Param entity.VirtualAccountCallback body entity.VirtualAccountCallback true "Virtual account payment callback"
+// @Success 200 {object} entity.Response
+// @Router /callbacks/xendit/virtual-account [post]
+func (h *callbackHandler) XenditVirtualAccountCallback(c echo.Context) error {
+	if !verifyCallbackToken(c) {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "Invalid callback token",
+		})
+	}
+
+	callback := new(entity.VirtualAccountCallback)
+	if err := c.Bind(callback); err != nil || callback.CallbackVirtualAccountID == "" || callback.PaymentID == "" {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Bad Request, Invalid request body",
+		})
+	}
+
+	return h.settlePayment(c, callback.CallbackVirtualAccountID, callback.PaymentID)
+}
+
+// XenditEWalletCallback godoc
+// @Summary Xendit e-wallet charge callback
+// @Description Called by Xendit when an e-wallet charge changes status, authenticated with the x-callback-token header
+// @Tags callbacks
+// @Accept json
+// @Produce json
+// @Param x-callback-token header string true "Xendit callback verification token"
+// @Param entity.EWalletCallback body entity.EWalletCallback true "E-wallet charge callback"
+// @Success 200 {object} entity.Response
+// @Router /callbacks/xendit/ewallet [post]
+func (h *callbackHandler) XenditEWalletCallback(c echo.Context) error {
+	if !verifyCallbackToken(c) {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "Invalid callback token",
+		})
+	}
+
+	callback := new(entity.EWalletCallback)
+	if err := c.Bind(callback); err != nil || callback.Data.ID == "" {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Bad Request, Invalid request body",
+		})
+	}
+
+	return h.settlePayment(c, callback.Data.ID, "")
+}
+
+// XenditQRCodeCallback godoc
+// @Summary Xendit QRIS payment callback
+// @Description Called by Xendit when a QR code is paid, authenticated with the x-callback-token header
+// @Tags callbacks
+// @Accept json
+// @Produce json
+// @Param x-callback-token header string true "Xendit callback verification token"
+// @Param entity.QRCodeCallback body entity.QRCodeCallback true "QR code payment callback"
+// @Success 200 {object} entity.Response
+// @Router /callbacks/xendit/qris [post]
+func (h *callbackHandler) XenditQRCodeCallback(c echo.Context) error {
+	if !verifyCallbackToken(c) {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "Invalid callback token",
+		})
+	}
+
+	callback := new(entity.QRCodeCallback)
+	if err := c.Bind(callback); err != nil || callback.Data.QRID == "" {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Bad Request, Invalid request body",
+		})
+	}
+
+	return h.settlePayment(c, callback.Data.QRID, "")
+}
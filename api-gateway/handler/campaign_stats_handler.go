@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+type CampaignStatsHandler interface {
+	GetCampaignStats(c echo.Context) error
+}
+
+type campaignStatsHandler struct {
+	statsRepo repository.CampaignStatsRepository
+}
+
+func NewCampaignStatsHandler(statsRepo repository.CampaignStatsRepository) CampaignStatsHandler {
+	return &campaignStatsHandler{statsRepo: statsRepo}
+}
+
+func campaignStatsEntity(stats *model.CampaignStats, series *model.DonationTimeSeries) entity.CampaignStats {
+	response := entity.CampaignStats{
+		CampaignID:      stats.CampaignID,
+		TotalRaised:     stats.TotalRaised,
+		DonationCount:   stats.DonationCount,
+		DonorCount:      stats.DonorCount,
+		AverageDonation: stats.AverageDonation,
+		MedianDonation:  stats.MedianDonation,
+		RepeatDonorRate: stats.RepeatDonorRate,
+		TimeSeries: entity.DonationTimeSeries{
+			Interval: series.Interval,
+			Timezone: series.Timezone,
+			Buckets:  make([]entity.TimeSeriesBucket, 0, len(series.Buckets)),
+		},
+	}
+	for _, bucket := range series.Buckets {
+		response.TimeSeries.Buckets = append(response.TimeSeries.Buckets, entity.TimeSeriesBucket{
+			Start:         bucket.Start,
+			TotalAmount:   bucket.TotalAmount,
+			DonationCount: bucket.DonationCount,
+		})
+	}
+	return response
+}
+
+// GetCampaignStats godoc
+// @Summary Get the stats of a campaign
+// @Description Get the total raised, donor count, average and median gift, repeat donor rate and the donations per day or week of a campaign. Only its owner and admins can see them.
+// @Tags campaigns
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param id path int true "Campaign ID"
+// @Param interval query string false "Buckets of the time series: day (default) or week, weeks start on Monday"
+// @Param timezone query string false "IANA timezone the buckets start at midnight in, UTC by default"
+// @Param from query string false "First day of the time series, YYYY-MM-DD, 30 days or 12 weeks before to by default"
+// @Param to query string false "Last day of the time series, YYYY-MM-DD, today by default"
+// @Param If-None-Match header string false "ETag of the copy held by the client"
+// @Success 200 {object} entity.Response{data=entity.CampaignStats}
+// @Header 200 {string} ETag "Hash of the response"
+// @Success 304 "The copy held by the client is current"
+// @Failure default {object} entity.Problem
+// @Router /campaigns/{id}/stats [get]
+func (h *campaignStatsHandler) GetCampaignStats(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+	isAdmin, _ := c.Get("is_admin").(bool)
+
+	campaignID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || campaignID <= 0 {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid campaign ID",
+		})
+	}
+
+	query := repository.TimeSeriesQuery{
+		Interval: c.QueryParam("interval"),
+		Timezone: c.QueryParam("timezone"),
+		From:     c.QueryParam("from"),
+		To:       c.QueryParam("to"),
+	}
+	switch query.Interval {
+	case "", "day", "week":
+	default:
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid interval, use day or week",
+		})
+	}
+
+	ctx := c.Request().Context()
+	stats, err := h.statsRepo.GetCampaignStats(ctx, campaignID, int(userID), isAdmin)
+	if err != nil {
+		return respondError(c, err)
+	}
+	series, err := h.statsRepo.GetDonationTimeSeries(ctx, campaignID, int(userID), isAdmin, query)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	setPrivateCache(c)
+	return respondCacheable(c, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    campaignStatsEntity(stats, series),
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -11,11 +12,21 @@ import (
 	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
 )
 
+// ReasonEmailNotVerified is the error reason of a claim by a user who did not
+// verify their email.
+const ReasonEmailNotVerified = "EMAIL_NOT_VERIFIED"
+
 type DonationHandler interface {
 	GetAllDonations(c echo.Context) error
 	CreateDonation(c echo.Context) error
 	GetDonationByID(c echo.Context) error
 	UpdateDonation(c echo.Context) error
+	PatchDonation(c echo.Context) error
+	CreateGuestDonation(c echo.Context) error
+	ClaimGuestDonations(c echo.Context) error
+	GetDonationReceipt(c echo.Context) error
+	GetGuestDonationReceipt(c echo.Context) error
+	GetAnnualReceipt(c echo.Context) error
 }
 
 type donationHandler struct {
@@ -33,7 +44,11 @@ func NewDonationHandler(donationRepo repository.DonationRepository) DonationHand
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
+// @Param If-None-Match header string false "ETag of the copy held by the client"
 // @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Hash of the response"
+// @Success 304 "The copy held by the client is current"
+// @Failure default {object} entity.Problem
 // @Router /donations [get]
 func (h *donationHandler) GetAllDonations(c echo.Context) error {
 	//get user id from context
@@ -45,14 +60,20 @@ func (h *donationHandler) GetAllDonations(c echo.Context) error {
 		})
 	}
 
-	donations, err := h.donationRepo.GetAllDonations()
+	donations, err := h.donationRepo.GetAllDonations(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "Internal Server Error",
-		})
+		return respondError(c, err)
 	}
-	return c.JSON(200, entity.Response{
+
+	// hide donor identity on donations made by someone else
+	userIdFloat, _ := userID.(float64)
+	for i := range *donations {
+		if (*donations)[i].UserID != int(userIdFloat) {
+			(*donations)[i].MaskDonor()
+		}
+	}
+	setPrivateCache(c)
+	return respondCacheable(c, entity.Response{
 		Status:  200,
 		Message: "Success",
 		Data:    donations,
@@ -68,6 +89,8 @@ func (h *donationHandler) GetAllDonations(c echo.Context) error {
 // @Param Authorization header string true "Bearer <access_token>"
 // @Param entity.DonationRequest body entity.DonationRequest true "Donation object" // Updated to use the correct package
 // @Success 201 {object} entity.Response
+// @Failure 400 {object} entity.Problem
+// @Failure default {object} entity.Problem
 // @Router /donations [post] // Updated the router path to use POST method
 func (h *donationHandler) CreateDonation(c echo.Context) error {
 	//
@@ -96,36 +119,21 @@ func (h *donationHandler) CreateDonation(c echo.Context) error {
 		})
 	}
 
-	donation := new(model.Donation)
-	if err := c.Bind(donation); err != nil {
-		return c.JSON(400, entity.Response{
-			Status:  400,
-			Message: "Bad Request, Invalid request body" + err.Error(),
-		})
-	}
-
-	// Validate donation data
-	if donation.Amount <= 0 {
-		return c.JSON(400, entity.Response{
-			Status:  400,
-			Message: "Invalid donation amount",
-		})
+	request := new(entity.DonationRequest)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
 	}
 
-	// Validate campaign ID
-	if donation.CampaignID <= 0 {
-		return c.JSON(400, entity.Response{
-			Status:  400,
-			Message: "Invalid campaign ID",
-		})
-	}
-
-	donation, err := h.donationRepo.CreateDonation(donation)
+	// the donor is the logged in user, whatever the body says
+	donation, err := h.donationRepo.CreateDonation(c.Request().Context(), &model.Donation{
+		UserID:      userIdInt,
+		CampaignID:  request.CampaignID,
+		Amount:      request.Amount,
+		Message:     request.Message,
+		IsAnonymous: request.IsAnonymous,
+	})
 	if err != nil {
-		return c.JSON(500, entity.Response{
-			Status:  500,
-			Message: "Internal Server Error, " + err.Error(),
-		})
+		return respondError(c, err)
 	}
 
 	// return response
@@ -138,19 +146,25 @@ func (h *donationHandler) CreateDonation(c echo.Context) error {
 
 // UpdateDonation godoc
 // @Summary Update a donation based on the invoice status
-// @Description Get details of a specific donation by its ID
+// @Description Change the fields set in the body. A paid donation answers 409, and so does a new amount once the donation has a payment under way or was abandoned
 // @Tags donations
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
 // @Param id path int true "Donation ID"
-// @Param entity.DonationRequest body entity.DonationRequest true "Donation object"
+// @Param If-Match header string true "ETag of the donation"
+// @Param entity.DonationUpdate body entity.DonationUpdate true "Donation object"
 // @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Version of the donation"
+// @Failure 400 {object} entity.Problem
+// @Failure 412 {object} entity.Problem
+// @Failure 428 {object} entity.Problem
+// @Failure default {object} entity.Problem
 // @Router /donations/{id} [put] // Updated the router path to use PUT method
 func (h *donationHandler) UpdateDonation(c echo.Context) error {
 	//get user id from context
-	userID := c.Get("user_id")
-	if userID == nil {
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
 		return c.JSON(http.StatusUnauthorized, entity.Response{
 			Status:  http.StatusUnauthorized,
 			Message: "User not authenticated",
@@ -167,24 +181,30 @@ func (h *donationHandler) UpdateDonation(c echo.Context) error {
 		})
 	}
 
-	// get stored donation
-	donation := new(model.Donation)
-	if err := c.Bind(donation); err != nil {
-		return c.JSON(400, entity.Response{
-			Status:  400,
-			Message: "Bad Request, Invalid request body" + err.Error(),
-		})
+	version, problem := ifMatchVersion(c)
+	if problem != nil {
+		return respondProblem(c, *problem)
 	}
 
-	donation.ID = donationIdInt
-	donation, err = h.donationRepo.UpdateDonation(donation)
+	request := new(entity.DonationUpdate)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	donation, err := h.donationRepo.UpdateDonation(c.Request().Context(), &model.Donation{
+		ID:          donationIdInt,
+		UserID:      int(userID),
+		Amount:      request.Amount,
+		Message:     request.Message,
+		Status:      model.DonationStatus(request.Status),
+		IsAnonymous: request.IsAnonymous,
+		Version:     version,
+	}, nil)
 	if err != nil {
-		return c.JSON(http.StatusNotFound, entity.Response{
-			Status:  http.StatusNotFound,
-			Message: "Donation not found",
-		})
+		return respondError(c, err)
 	}
 
+	setETag(c, donation.Version)
 	// return updated donation
 	return c.JSON(200, entity.Response{
 		Status:  200,
@@ -193,6 +213,80 @@ func (h *donationHandler) UpdateDonation(c echo.Context) error {
 	})
 }
 
+// PatchDonation godoc
+// @Summary Partially update a donation
+// @Description Change only the fields set in the body, zero values included, the others are kept. A paid donation answers 409, and so does a new amount once the donation has a payment under way or was abandoned
+// @Tags donations
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param id path int true "Donation ID"
+// @Param If-Match header string true "ETag of the donation"
+// @Param donation body entity.DonationPatch true "Fields to change"
+// @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Version of the donation"
+// @Failure 400 {object} entity.Problem
+// @Failure 412 {object} entity.Problem
+// @Failure 428 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /donations/{id} [patch]
+func (h *donationHandler) PatchDonation(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	//get donation id from param
+	donationIdInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid donation ID",
+		})
+	}
+
+	version, problem := ifMatchVersion(c)
+	if problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	request := new(entity.DonationPatch)
+	paths, problem := bindPatch(c, request)
+	if problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	donation := &model.Donation{ID: donationIdInt, UserID: int(userID), Version: version}
+	if request.Amount != nil {
+		donation.Amount = *request.Amount
+	}
+	if request.Message != nil {
+		donation.Message = *request.Message
+	}
+	if request.Status != nil {
+		donation.Status = model.DonationStatus(*request.Status)
+	}
+	if request.IsAnonymous != nil {
+		donation.IsAnonymous = *request.IsAnonymous
+	}
+
+	updated, err := h.donationRepo.UpdateDonation(c.Request().Context(), donation, paths)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	setETag(c, updated.Version)
+	return c.JSON(200, entity.Response{
+		Status:  200,
+		Message: "Success",
+		Data:    updated,
+	})
+}
+
 // GetDonationByID godoc
 // @Summary Get Donation details by Donation ID
 // @Description Get details of a specific donation by its ID
@@ -201,7 +295,11 @@ func (h *donationHandler) UpdateDonation(c echo.Context) error {
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
 // @Param id path int true "Donation ID"
+// @Param If-None-Match header string false "ETag of the copy held by the client"
 // @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Version of the donation"
+// @Success 304 "The copy held by the client is current"
+// @Failure default {object} entity.Problem
 // @Router /donations/{id} [get] // Updated the router path to include donation ID
 func (h *donationHandler) GetDonationByID(c echo.Context) error {
 	//get user id from context
@@ -232,18 +330,249 @@ func (h *donationHandler) GetDonationByID(c echo.Context) error {
 
 	// get stored donation data
 	donation := new(model.Donation)
-	result, err := h.donationRepo.GetDonationByID(donationIDInt)
+	result, err := h.donationRepo.GetDonationByID(c.Request().Context(), donationIDInt)
 	if err != nil {
-		return c.JSON(500, entity.Response{
-			Status:  500,
-			Message: "Internal Server Error, Error when getting donation",
-		})
+		return respondError(c, err)
 	}
 	donation = result
 
+	// hide donor identity when the donation belongs to someone else
+	userIdFloat, _ := userID.(float64)
+	if donation.UserID != int(userIdFloat) {
+		donation.MaskDonor()
+	}
+
+	setETag(c, donation.Version)
+	setPrivateCache(c)
+	if notModified(c) {
+		return c.NoContent(http.StatusNotModified)
+	}
 	return c.JSON(200, entity.Response{
 		Status:  200,
 		Message: "Success",
 		Data:    donation,
 	})
 }
+
+// CreateGuestDonation godoc
+// @Summary Create a donation as a guest
+// @Description Create a donation and its payment without an account. payment_method is INVOICE (default, sent to the guest email), VIRTUAL_ACCOUNT with a bank as payment_channel, EWALLET with OVO, DANA or SHOPEEPAY as payment_channel, or QRIS. The transaction carries the payment instructions
+// @Tags donations
+// @Accept json
+// @Produce json
+// @Param entity.GuestDonationRequest body entity.GuestDonationRequest true "Guest donation object"
+// @Success 201 {object} entity.Response
+// @Failure 400 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /donations/guest [post]
+func (h *donationHandler) CreateGuestDonation(c echo.Context) error {
+	request := new(entity.GuestDonationRequest)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	donation := &model.Donation{
+		CampaignID:  request.CampaignID,
+		Amount:      request.Amount,
+		Message:     request.Message,
+		IsAnonymous: request.IsAnonymous,
+		GuestEmail:  request.Email,
+	}
+
+	option := model.PaymentOption{
+		Method:       request.PaymentMethod,
+		Channel:      request.PaymentChannel,
+		MobileNumber: request.MobileNumber,
+	}
+
+	donation, transaction, err := h.donationRepo.CreateGuestDonation(c.Request().Context(), donation, option)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	// return response
+	return c.JSON(201, entity.Response{
+		Status:  201,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"donation":    donation,
+			"transaction": transaction,
+		},
+	})
+}
+
+// ClaimGuestDonations godoc
+// @Summary Claim guest donations
+// @Description Move guest donations made with the email of the logged in user to the user account. The email must be verified, refresh the tokens after verifying it.
+// @Tags donations
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Success 200 {object} entity.Response
+// @Failure default {object} entity.Problem
+// @Router /donations/claim [post]
+func (h *donationHandler) ClaimGuestDonations(c echo.Context) error {
+	//get user id from context
+	userID := c.Get("user_id")
+	if userID == nil {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	userIdFloat, ok := userID.(float64)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user ID",
+		})
+	}
+
+	email, ok := c.Get("email").(string)
+	if !ok || email == "" {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid user email",
+		})
+	}
+
+	// anyone can sign up with the email of a guest donor, only a verified
+	// email proves it is theirs; donation-service checks it again
+	if verified, _ := c.Get("email_verified").(bool); !verified {
+		return respondProblem(c, entity.Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusForbidden),
+			Status: http.StatusForbidden,
+			Detail: "verify the email of the account before claiming guest donations",
+			Reason: ReasonEmailNotVerified,
+		})
+	}
+
+	claimed, err := h.donationRepo.ClaimGuestDonations(c.Request().Context(), int(userIdFloat), email)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(200, entity.Response{
+		Status:  200,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"claimed_count": claimed,
+		},
+	})
+}
+
+// GetDonationReceipt godoc
+// @Summary Download the receipt of a donation
+// @Description Download the PDF receipt of a paid donation of the current user
+// @Tags donations
+// @Produce application/pdf
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param id path int true "Donation ID"
+// @Success 200 {file} file
+// @Failure default {object} entity.Problem
+// @Router /donations/{id}/receipt [get]
+func (h *donationHandler) GetDonationReceipt(c echo.Context) error {
+	//get user id from context
+	userID := c.Get("user_id")
+	if userID == nil {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+	userIdFloat, _ := userID.(float64)
+
+	donationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  400,
+			Message: "Invalid donation ID",
+		})
+	}
+
+	// donation-service reports donations of other donors as missing
+	receipt, err := h.donationRepo.GetDonationReceipt(c.Request().Context(), donationID, int(userIdFloat))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return sendPDF(c, receipt)
+}
+
+// GetGuestDonationReceipt godoc
+// @Summary Download the receipt of a guest donation
+// @Description Download the PDF receipt of a paid guest donation, with the email it was made with and the invoice ID given at checkout. Any other donation is reported as missing
+// @Tags donations
+// @Accept json
+// @Produce application/pdf
+// @Param id path int true "Donation ID"
+// @Param entity.GuestReceiptRequest body entity.GuestReceiptRequest true "Guest email and invoice ID"
+// @Success 200 {file} file
+// @Failure default {object} entity.Problem
+// @Router /donations/{id}/receipt/guest [post]
+func (h *donationHandler) GetGuestDonationReceipt(c echo.Context) error {
+	donationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  400,
+			Message: "Invalid donation ID",
+		})
+	}
+
+	request := new(entity.GuestReceiptRequest)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	receipt, err := h.donationRepo.GetGuestDonationReceipt(c.Request().Context(), donationID, request.Email, request.InvoiceID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return sendPDF(c, receipt)
+}
+
+// GetAnnualReceipt godoc
+// @Summary Download the annual donation summary
+// @Description Download the PDF summary of every receipt issued to the current user in a year
+// @Tags donations
+// @Produce application/pdf
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param year path int true "Year"
+// @Success 200 {file} file
+// @Failure default {object} entity.Problem
+// @Router /donations/receipts/{year} [get]
+func (h *donationHandler) GetAnnualReceipt(c echo.Context) error {
+	//get user id from context
+	userID := c.Get("user_id")
+	if userID == nil {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+	userIdFloat, _ := userID.(float64)
+
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  400,
+			Message: "Invalid year",
+		})
+	}
+
+	receipt, err := h.donationRepo.GetAnnualReceipt(c.Request().Context(), int(userIdFloat), year)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return sendPDF(c, receipt)
+}
+
+func sendPDF(c echo.Context, receipt *model.ReceiptFile) error {
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", receipt.FileName))
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+	return c.Blob(http.StatusOK, "application/pdf", receipt.PDF)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+)
+
+// ReasonPreconditionRequired is the error reason of a write to a versioned
+// resource sent without If-Match.
+const ReasonPreconditionRequired = "PRECONDITION_REQUIRED"
+
+// setETag sets the ETag of a versioned resource, its version.
+func setETag(c echo.Context, version int) {
+	c.Response().Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion returns the version named by the If-Match header of a write,
+// 0 for "*". It returns the problem to answer with when the header is missing,
+// or when it is not an ETag the gateway sent and so cannot match.
+func ifMatchVersion(c echo.Context) (int, *entity.Problem) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if ifMatch == "" {
+		return 0, &entity.Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusPreconditionRequired),
+			Status: http.StatusPreconditionRequired,
+			Detail: "If-Match with the ETag of the resource is required",
+			Reason: ReasonPreconditionRequired,
+		}
+	}
+	if ifMatch == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(ifMatch)
+	version, convErr := strconv.Atoi(unquoted)
+	if err != nil || convErr != nil || version <= 0 {
+		return 0, &entity.Problem{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusPreconditionFailed),
+			Status: http.StatusPreconditionFailed,
+			Detail: "If-Match does not match the ETag of the resource",
+			Reason: optimistic.ReasonVersionMismatch,
+		}
+	}
+	return version, nil
+}
+
+// setPrivateCache lets only the browser of the signed in user keep a response,
+// and makes it revalidate with the ETag before every use.
+func setPrivateCache(c echo.Context) {
+	c.Response().Header().Set("Cache-Control", "private, no-cache")
+	c.Response().Header().Add("Vary", echo.HeaderAuthorization)
+}
+
+// notModified tells whether the If-None-Match of the request names the ETag
+// set on the response, the client holds the current representation then.
+func notModified(c echo.Context) bool {
+	etag := c.Response().Header().Get("ETag")
+	ifNoneMatch := c.Request().Header.Get("If-None-Match")
+	if etag == "" || ifNoneMatch == "" {
+		return false
+	}
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// respondCacheable answers a GET with body, tagged with a hash of its content
+// so clients can revalidate it, or with 304 when If-None-Match names the tag.
+// Set the Cache-Control of the response before.
+func respondCacheable(c echo.Context, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	c.Response().Header().Set("ETag", strconv.Quote(hex.EncodeToString(sum[:16])))
+	if notModified(c) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSONBlob(http.StatusOK, data)
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/health"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+)
+
+// readyTimeout bounds each backend check of /readyz, probes time out soon.
+const readyTimeout = 2 * time.Second
+
+type HealthHandler interface {
+	Healthz(c echo.Context) error
+	Readyz(c echo.Context) error
+}
+
+type healthHandler struct {
+	backends map[string]health.Check
+}
+
+// NewHealthHandler returns the probes of the gateway, backends are checked
+// by name for readiness.
+func NewHealthHandler(backends map[string]health.Check) HealthHandler {
+	return &healthHandler{backends: backends}
+}
+
+// Healthz is the liveness probe, the gateway answers as long as it runs.
+func (h *healthHandler) Healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, entity.Health{Status: "ok"})
+}
+
+// Readyz is the readiness probe, the gateway is ready when every backend
+// reports SERVING on grpc.health.v1. It answers 503 otherwise, so no traffic
+// is routed to a gateway that could only fail it.
+func (h *healthHandler) Readyz(c echo.Context) error {
+	results := health.RunAll(c.Request().Context(), h.backends, readyTimeout)
+
+	status := http.StatusOK
+	body := entity.Health{Status: "ok", Checks: map[string]string{}}
+	for name, err := range results {
+		if err != nil {
+			status = http.StatusServiceUnavailable
+			body.Status = "unavailable"
+			body.Checks[name] = err.Error()
+			continue
+		}
+		body.Checks[name] = "ok"
+	}
+	return c.JSON(status, body)
+}
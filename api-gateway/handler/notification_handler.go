@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+type NotificationHandler interface {
+	GetPreference(c echo.Context) error
+	UpdatePreference(c echo.Context) error
+}
+
+type notificationHandler struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationHandler(notificationRepo repository.NotificationRepository) NotificationHandler {
+	return &notificationHandler{notificationRepo: notificationRepo}
+}
+
+func notificationPreferenceEntity(preference *model.NotificationPreference) entity.NotificationPreference {
+	mutedEvents := preference.MutedEventList()
+	if mutedEvents == nil {
+		mutedEvents = []string{}
+	}
+	return entity.NotificationPreference{
+		Locale:       preference.Locale,
+		EmailEnabled: preference.EmailEnabled,
+		WebhookURL:   preference.WebhookURL,
+		MutedEvents:  mutedEvents,
+	}
+}
+
+// GetPreference godoc
+// @Summary Get notification preferences
+// @Description Get how the current user is notified about donation events
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Success 200 {object} entity.Response{data=entity.NotificationPreference}
+// @Failure default {object} entity.Problem
+// @Router /users/me/notification-preferences [get]
+func (h *notificationHandler) GetPreference(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	preference, err := h.notificationRepo.GetPreference(c.Request().Context(), int(userID))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    notificationPreferenceEntity(preference),
+	})
+}
+
+// UpdatePreference godoc
+// @Summary Update notification preferences
+// @Description Replace how the current user is notified. Locale is id or en, webhook_url must be https.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param entity.NotificationPreference body entity.NotificationPreference true "Notification preferences"
+// @Success 200 {object} entity.Response{data=entity.NotificationPreference}
+// @Failure default {object} entity.Problem
+// @Router /users/me/notification-preferences [put]
+func (h *notificationHandler) UpdatePreference(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	request := new(entity.NotificationPreference)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Bad Request, Invalid request body",
+		})
+	}
+
+	preference, err := h.notificationRepo.UpdatePreference(c.Request().Context(), &model.NotificationPreference{
+		UserID:       int(userID),
+		Locale:       request.Locale,
+		EmailEnabled: request.EmailEnabled,
+		WebhookURL:   request.WebhookURL,
+		MutedEvents:  strings.Join(request.MutedEvents, ","),
+	})
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    notificationPreferenceEntity(preference),
+	})
+}
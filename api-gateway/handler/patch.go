@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+)
+
+// bindPatch binds a PATCH body into req, a struct of pointer fields, and
+// checks it. It returns the JSON names of the fields the body sets, used as
+// the update mask, or the problem to answer with.
+func bindPatch(c echo.Context, req interface{}) ([]string, *entity.Problem) {
+	if problem := bindRequest(c, req); problem != nil {
+		return nil, problem
+	}
+
+	var paths []string
+	v := reflect.ValueOf(req).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Kind() == reflect.Ptr && !v.Field(i).IsNil() {
+			paths = append(paths, strings.SplitN(v.Type().Field(i).Tag.Get("json"), ",", 2)[0])
+		}
+	}
+	if len(paths) == 0 {
+		return nil, badRequestProblem("request sets no field to change")
+	}
+	return paths, nil
+}
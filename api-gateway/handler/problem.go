@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+)
+
+// MIMEApplicationProblemJSON is the content type of problem details bodies.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// grpcToHTTP maps the gRPC codes the services return to HTTP statuses. Other
+// codes are 500.
+var grpcToHTTP = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.Canceled:           499,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+}
+
+// HTTPStatus returns the HTTP status of an error returned by a service. A
+// write made against an outdated version is 412, as for a stale If-Match.
+func HTTPStatus(err error) int {
+	if apperror.Reason(err) == optimistic.ReasonVersionMismatch {
+		return http.StatusPreconditionFailed
+	}
+	if code, ok := grpcToHTTP[status.Code(err)]; ok {
+		return code
+	}
+	return http.StatusInternalServerError
+}
+
+// NewProblem translates an error returned by a service to problem details.
+// Server errors that do not come from the services, such as a failed dial,
+// get a generic detail so addresses and internals are not revealed.
+func NewProblem(err error) entity.Problem {
+	code := HTTPStatus(err)
+	problem := entity.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(code),
+		Status: code,
+		Detail: status.Convert(err).Message(),
+		Reason: apperror.Reason(err),
+	}
+	if code == 499 {
+		problem.Title = "Client Closed Request"
+	}
+	if code >= http.StatusInternalServerError && problem.Reason == "" {
+		problem.Detail = "The request could not be handled, try again later"
+	}
+	for _, v := range apperror.FieldViolations(err) {
+		problem.Errors = append(problem.Errors, entity.FieldError{Field: v.Field, Message: v.Description})
+	}
+	return problem
+}
+
+// respondProblem sends problem as application/problem+json.
+func respondProblem(c echo.Context, problem entity.Problem) error {
+	problem.Instance = c.Request().URL.Path
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	return c.JSON(problem.Status, problem)
+}
+
+// respondError answers with the problem details of an error returned by a
+// repository.
+func respondError(c echo.Context, err error) error {
+	problem := NewProblem(err)
+	if problem.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(c.Request().Context(), "request failed", "method", c.Request().Method, "path", c.Request().URL.Path, "error", err)
+	}
+	return respondProblem(c, problem)
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+type PublicDonationHandler interface {
+	GetCampaignDonations(c echo.Context) error
+	GetCampaignTopDonors(c echo.Context) error
+	GetLeaderboard(c echo.Context) error
+	StreamCampaignProgress(c echo.Context) error
+}
+
+// sseHeartbeatInterval keeps idle progress streams open through proxies.
+const sseHeartbeatInterval = 15 * time.Second
+
+type publicDonationHandler struct {
+	publicRepo repository.PublicDonationRepository
+}
+
+func NewPublicDonationHandler(publicRepo repository.PublicDonationRepository) PublicDonationHandler {
+	return &publicDonationHandler{publicRepo: publicRepo}
+}
+
+// queryInt reads an optional integer query parameter.
+func queryInt(c echo.Context, name string, def int) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// setPublicCache lets browsers and CDNs keep public listings as long as the
+// gateway does.
+func (h *publicDonationHandler) setPublicCache(c echo.Context) {
+	seconds := int(h.publicRepo.CacheTTL(c.Request().Context()).Seconds())
+	c.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", seconds))
+}
+
+// GetCampaignDonations godoc
+// @Summary Get the donor wall of a campaign
+// @Description Get the completed donations of a campaign, anonymous donors are masked
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param page query int false "Page number, starts at 1"
+// @Param page_size query int false "Page size, at most 100"
+// @Param If-None-Match header string false "ETag of the copy held by the client"
+// @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Hash of the response"
+// @Success 304 "The copy held by the client is current"
+// @Failure default {object} entity.Problem
+// @Router /campaigns/{id}/donations [get]
+func (h *publicDonationHandler) GetCampaignDonations(c echo.Context) error {
+	campaignID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || campaignID <= 0 {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid campaign ID",
+		})
+	}
+
+	page, err := queryInt(c, "page", 1)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid page",
+		})
+	}
+	pageSize, err := queryInt(c, "page_size", 20)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid page size",
+		})
+	}
+
+	donations, err := h.publicRepo.GetCampaignDonations(c.Request().Context(), campaignID, page, pageSize)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	h.setPublicCache(c)
+	return respondCacheable(c, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    donations,
+	})
+}
+
+// GetCampaignTopDonors godoc
+// @Summary Get the top donors of a campaign
+// @Description Get the donors that gave the most to a campaign, anonymous donors are masked
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param limit query int false "Number of donors, at most 50"
+// @Param If-None-Match header string false "ETag of the copy held by the client"
+// @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Hash of the response"
+// @Success 304 "The copy held by the client is current"
+// @Failure default {object} entity.Problem
+// @Router /campaigns/{id}/top-donors [get]
+func (h *publicDonationHandler) GetCampaignTopDonors(c echo.Context) error {
+	campaignID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || campaignID <= 0 {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid campaign ID",
+		})
+	}
+
+	limit, err := queryInt(c, "limit", 10)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid limit",
+		})
+	}
+
+	donors, err := h.publicRepo.GetCampaignTopDonors(c.Request().Context(), campaignID, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	h.setPublicCache(c)
+	return respondCacheable(c, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    donors,
+	})
+}
+
+// GetLeaderboard godoc
+// @Summary Get the global leaderboard
+// @Description Get the top donors and top campaigns for a time window
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param window query string false "Time window: day, week, month or all"
+// @Param limit query int false "Number of entries, at most 50"
+// @Param If-None-Match header string false "ETag of the copy held by the client"
+// @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Hash of the response"
+// @Success 304 "The copy held by the client is current"
+// @Failure default {object} entity.Problem
+// @Router /leaderboard [get]
+func (h *publicDonationHandler) GetLeaderboard(c echo.Context) error {
+	window := c.QueryParam("window")
+	if window == "" {
+		window = "all"
+	}
+	switch window {
+	case "day", "week", "month", "all":
+	default:
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid window, use day, week, month or all",
+		})
+	}
+
+	limit, err := queryInt(c, "limit", 10)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid limit",
+		})
+	}
+
+	leaderboard, err := h.publicRepo.GetLeaderboard(c.Request().Context(), window, limit)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	h.setPublicCache(c)
+	return respondCacheable(c, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    leaderboard,
+	})
+}
+
+// StreamCampaignProgress godoc
+// @Summary Stream the progress of a campaign
+// @Description Server-Sent Events stream with the campaign totals every time a donation settles. Send Last-Event-ID (or last_event_id) to resume.
+// @Tags campaigns
+// @Produce text/event-stream
+// @Param id path int true "Campaign ID"
+// @Param Last-Event-ID header int false "ID of the last event received"
+// @Param last_event_id query int false "ID of the last event received, for clients that cannot set headers"
+// @Success 200 {string} string "event stream"
+// @Router /campaigns/{id}/progress/stream [get]
+func (h *publicDonationHandler) StreamCampaignProgress(c echo.Context) error {
+	campaignID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || campaignID <= 0 {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid campaign ID",
+		})
+	}
+
+	lastEventIDParam := c.Request().Header.Get("Last-Event-ID")
+	if lastEventIDParam == "" {
+		lastEventIDParam = c.QueryParam("last_event_id")
+	}
+	var lastEventID int64
+	if lastEventIDParam != "" {
+		lastEventID, err = strconv.ParseInt(lastEventIDParam, 10, 64)
+		if err != nil || lastEventID < 0 {
+			return c.JSON(http.StatusBadRequest, entity.Response{
+				Status:  http.StatusBadRequest,
+				Message: "Invalid Last-Event-ID",
+			})
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	// ask EventSource to reconnect after 3 seconds when the stream drops
+	fmt.Fprint(res, "retry: 3000\n\n")
+	res.Flush()
+
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	events := make(chan model.CampaignProgress)
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- h.publicRepo.WatchCampaignProgress(ctx, campaignID, lastEventID, func(progress model.CampaignProgress) error {
+			select {
+			case events <- progress:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case progress := <-events:
+			data, err := json.Marshal(progress)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(res, "id: %d\nevent: progress\ndata: %s\n\n", progress.EventID, data)
+			res.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(res, ": heartbeat\n\n")
+			res.Flush()
+		case err := <-streamErr:
+			// the client reconnects with Last-Event-ID and resumes
+			if err != nil && ctx.Err() == nil {
+				slog.WarnContext(ctx, "campaign progress stream ended", "campaign_id", campaignID, "error", err)
+				fmt.Fprint(res, "event: error\ndata: {\"message\":\"progress stream interrupted\"}\n\n")
+				res.Flush()
+			}
+			return nil
+		}
+	}
+}
@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -18,6 +18,8 @@ type TransactionHandler interface {
 	GetTransactionByID(c echo.Context) error
 	UpdateTransaction(c echo.Context) error
 	SyncTransaction(c echo.Context) error
+	ReissueInvoice(c echo.Context) error
+	ReissueGuestInvoice(c echo.Context) error
 }
 
 type transactionHandler struct {
@@ -36,6 +38,7 @@ func NewTransactionHandler(transactionRepo repository.TransactionRepository) Tra
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
 // @Success 200 {object} entity.Response
+// @Failure default {object} entity.Problem
 // @Router /transactions [get]
 func (h *transactionHandler) GetAllTransaction(c echo.Context) error {
 	//get user id from context
@@ -55,14 +58,12 @@ func (h *transactionHandler) GetAllTransaction(c echo.Context) error {
 		})
 	}
 	userIdInt := int(userIdFloat)
-	log.Println("userIdInt", userIdInt)
+	slog.DebugContext(c.Request().Context(), "listing transactions", "user_id", userIdInt)
 
-	transactions, err := h.transactionRepo.GetAllTransaction()
+	// donation-service only lists the transactions of the user's donations
+	transactions, err := h.transactionRepo.GetAllTransaction(c.Request().Context(), userIdInt)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "Internal Server Error",
-		})
+		return respondError(c, err)
 	}
 	return c.JSON(200, entity.Response{
 		Status:  200,
@@ -72,15 +73,18 @@ func (h *transactionHandler) GetAllTransaction(c echo.Context) error {
 }
 
 // CreateTransaction godoc
-// @Summary Create a new transaction
-// @Description Create a new transaction for a user
+// @Summary Create a new transaction for a donation
+// @Description Open a payment for a donation of the current user. payment_method is INVOICE (default), VIRTUAL_ACCOUNT with a bank as payment_channel, EWALLET with OVO, DANA or SHOPEEPAY as payment_channel, or QRIS. The amount is the donation amount and may be left out. A paid donation, or one with a payment under way, answers 409. The transaction carries the payment instructions
 // @Tags transactions
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
 // @Param entity.TransactionRequest body entity.TransactionRequest true "Transaction object"
-// @Success 201 {object} entity.Response
-// @Router /transactions [post] // Updated the router path to use POST method
+// @Success 201 {object} entity.Response{data=entity.Transaction}
+// @Failure 400 {object} entity.Problem
+// @Failure 404 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /transactions [post]
 func (h *transactionHandler) CreateTransaction(c echo.Context) error {
 	//
 	userID := c.Get("user_id")
@@ -108,7 +112,25 @@ func (h *transactionHandler) CreateTransaction(c echo.Context) error {
 		})
 	}
 
-	transaction := new(model.Transaction)
+	request := new(entity.TransactionRequest)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	option := model.PaymentOption{
+		Method:       request.PaymentMethod,
+		Channel:      request.PaymentChannel,
+		MobileNumber: request.MobileNumber,
+	}
+
+	// donations of other users are reported as missing by donation-service
+	transaction, err := h.transactionRepo.CreateTransaction(c.Request().Context(), &model.Transaction{
+		DonationID: request.DonationID,
+		Amount:     request.Amount,
+	}, option, userIdInt)
+	if err != nil {
+		return respondError(c, err)
+	}
 
 	// return response
 	return c.JSON(201, entity.Response{
@@ -119,16 +141,24 @@ func (h *transactionHandler) CreateTransaction(c echo.Context) error {
 }
 
 // UpdateTransaction godoc
-// @Summary Update a transaction based on the invoice status
-// @Description Get details of a specific transaction by its ID
+// @Summary Update the status of a transaction
+// @Description Move a transaction of the current user to another status, such as FAILED to abandon it. PAID and SETTLED are only set when the payment settles
 // @Tags transactions
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
 // @Param id path int true "Transaction ID"
-// @Param entity.TransactionRequest body entity.TransactionRequest true "Transaction object"
-// @Success 200 {object} entity.Response
-// @Router /transactions/{id} [put] // Updated the router path to use PUT method
+// @Param If-Match header string true "ETag of the transaction"
+// @Param entity.TransactionUpdate body entity.TransactionUpdate true "Transaction status"
+// @Success 200 {object} entity.Response{data=entity.Transaction}
+// @Header 200 {string} ETag "Version of the transaction"
+// @Failure 400 {object} entity.Problem
+// @Failure 404 {object} entity.Problem
+// @Failure 409 {object} entity.Problem
+// @Failure 412 {object} entity.Problem
+// @Failure 428 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /transactions/{id} [put]
 func (h *transactionHandler) UpdateTransaction(c echo.Context) error {
 	//get user id from context
 	userID := c.Get("user_id")
@@ -147,7 +177,6 @@ func (h *transactionHandler) UpdateTransaction(c echo.Context) error {
 		})
 	}
 	userIdInt := int(userIdFloat)
-	log.Println("userIdInt", userIdInt)
 
 	//get transaction id from param
 	transactionID := c.Param("id")
@@ -159,37 +188,71 @@ func (h *transactionHandler) UpdateTransaction(c echo.Context) error {
 		})
 	}
 
-	// get stored transaction
-	transaction := new(entity.Transaction)
-	if err := c.Bind(transaction); err != nil {
-		return c.JSON(400, entity.Response{
-			Status:  400,
-			Message: "Bad Request, Invalid request body" + err.Error(),
-		})
+	version, problem := ifMatchVersion(c)
+	if problem != nil {
+		return respondProblem(c, *problem)
 	}
-	transaction.ID = transactionIdInt
 
-	// return response
-	return c.JSON(201, entity.Response{
-		Status:  201,
+	request := new(entity.TransactionUpdate)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	// transactions of other users are reported as missing by donation-service
+	transaction, err := h.transactionRepo.UpdateTransaction(c.Request().Context(), &model.Transaction{
+		ID:      transactionIdInt,
+		Status:  model.TransactionStatus(request.Status),
+		Version: version,
+	}, userIdInt)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	setETag(c, transaction.Version)
+	// return updated transaction
+	return c.JSON(200, entity.Response{
+		Status:  200,
 		Message: "Success",
 		Data:    transaction,
 	})
 }
 
 // CheckUpdateTransaction godoc
-// @Summary Check and update a transaction based on the invoice status
-// @Description Get details of a specific transaction by its ID and check its status
+// @Summary Check and update a transaction based on the payment status
+// @Description Fetch the payment of a pending transaction of the current user from the provider again and settle it when it was paid
 // @Tags transactions
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
 // @Param id path int true "Transaction ID"
-// @Success 200 {object} entity.Response
-// @Router /transactions/sync-transaction/{id} [put] // Updated the router path to use PUT method
+// @Success 200 {object} entity.Response{data=entity.Transaction}
+// @Failure 404 {object} entity.Problem
+// @Failure 503 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /transactions/sync-transaction/{id} [put]
 func (h *transactionHandler) SyncTransaction(c echo.Context) error {
 	//get user id from context
-	transaction := new(entity.Transaction)
+	userIdFloat, ok := c.Get("user_id").(float64)
+	if !ok || userIdFloat == 0 {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || transactionID <= 0 {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid transaction ID",
+		})
+	}
+
+	transaction, err := h.transactionRepo.SyncTransaction(c.Request().Context(), transactionID, int(userIdFloat))
+	if err != nil {
+		return respondError(c, err)
+	}
+
 	// return updated transaction
 	return c.JSON(200, entity.Response{
 		Status:  200,
@@ -200,14 +263,19 @@ func (h *transactionHandler) SyncTransaction(c echo.Context) error {
 
 // GetTransactionByID godoc
 // @Summary Get Transaction details by Transaction ID
-// @Description Get details of a specific transaction by its ID
+// @Description Get details of a transaction of the current user by its ID
 // @Tags transactions
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
 // @Param id path int true "Transaction ID"
-// @Success 200 {object} entity.Response
-// @Router /transactions/{id} [get] // Updated the router path to include transaction ID
+// @Param If-None-Match header string false "ETag of the copy held by the client"
+// @Success 200 {object} entity.Response{data=entity.Transaction}
+// @Header 200 {string} ETag "Version of the transaction"
+// @Success 304 "The copy held by the client is current"
+// @Failure 404 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /transactions/{id} [get]
 func (h *transactionHandler) GetTransactionByID(c echo.Context) error {
 	//get user id from context
 	userID := c.Get("user_id")
@@ -226,7 +294,6 @@ func (h *transactionHandler) GetTransactionByID(c echo.Context) error {
 		})
 	}
 	userIdInt := int(userIdFloat)
-	log.Println("userIdInt", userIdInt)
 
 	// get transaction id from url param
 	transactionID := c.Param("id")
@@ -245,13 +312,123 @@ func (h *transactionHandler) GetTransactionByID(c echo.Context) error {
 		})
 	}
 
-	// get stored transaction data
-	transaction := new(entity.Transaction)
-	transaction.ID = transactionIDInt
+	// transactions of other users are reported as missing by donation-service
+	transaction, err := h.transactionRepo.GetTransactionByID(c.Request().Context(), transactionIDInt, userIdInt)
+	if err != nil {
+		return respondError(c, err)
+	}
 
+	setETag(c, transaction.Version)
+	setPrivateCache(c)
+	if notModified(c) {
+		return c.NoContent(http.StatusNotModified)
+	}
 	return c.JSON(200, entity.Response{
 		Status:  200,
 		Message: "Success",
 		Data:    transaction,
 	})
 }
+
+// ReissueInvoice godoc
+// @Summary Reissue the invoice of an expired transaction
+// @Description Create a fresh payment for the donation of an expired or failed transaction, linked to it. The donation is pending again. Without a payment method the previous one is used
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param id path int true "Transaction ID"
+// @Param entity.ReissueInvoiceRequest body entity.ReissueInvoiceRequest false "Payment method of the new invoice"
+// @Success 201 {object} entity.Response
+// @Failure 404 {object} entity.Problem
+// @Failure 409 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /transactions/{id}/reissue [post]
+func (h *transactionHandler) ReissueInvoice(c echo.Context) error {
+	userIdFloat, ok := c.Get("user_id").(float64)
+	if !ok || userIdFloat == 0 {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || transactionID <= 0 {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid transaction ID",
+		})
+	}
+
+	request := new(entity.ReissueInvoiceRequest)
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(request); err != nil {
+			return c.JSON(http.StatusBadRequest, entity.Response{
+				Status:  http.StatusBadRequest,
+				Message: "Bad Request, Invalid request body",
+			})
+		}
+	}
+	option := model.PaymentOption{
+		Method:       request.PaymentMethod,
+		Channel:      request.PaymentChannel,
+		MobileNumber: request.MobileNumber,
+	}
+
+	transaction, err := h.transactionRepo.ReissueInvoice(c.Request().Context(), transactionID, int(userIdFloat), option)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, entity.Response{
+		Status:  http.StatusCreated,
+		Message: "Success",
+		Data:    transaction,
+	})
+}
+
+// ReissueGuestInvoice godoc
+// @Summary Reissue the invoice of an expired guest transaction
+// @Description Create a fresh payment for the guest donation of an expired or failed transaction, with the email it was made with and the invoice ID of the transaction. Any other transaction is reported as missing. Without a payment method the previous one is used
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param entity.GuestReissueRequest body entity.GuestReissueRequest true "Guest email, invoice ID and payment method of the new invoice"
+// @Success 201 {object} entity.Response
+// @Failure 404 {object} entity.Problem
+// @Failure 409 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /transactions/{id}/reissue/guest [post]
+func (h *transactionHandler) ReissueGuestInvoice(c echo.Context) error {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || transactionID <= 0 {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid transaction ID",
+		})
+	}
+
+	request := new(entity.GuestReissueRequest)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
+	}
+	option := model.PaymentOption{
+		Method:       request.PaymentMethod,
+		Channel:      request.PaymentChannel,
+		MobileNumber: request.MobileNumber,
+	}
+
+	// donation-service reports transactions of other donors as missing
+	transaction, err := h.transactionRepo.ReissueGuestInvoice(c.Request().Context(), transactionID, request.Email, request.InvoiceID, option)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, entity.Response{
+		Status:  http.StatusCreated,
+		Message: "Success",
+		Data:    transaction,
+	})
+}
@@ -1,9 +1,8 @@
 package handler
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/rayhanadri/crowdfunding/user-service/model"
 
+	"github.com/rayhanadri/crowdfunding/api-gateway/config"
 	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
 	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
 )
@@ -20,8 +20,12 @@ type UserHandler interface {
 	GetUserByID(c echo.Context) error
 	CreateUser(c echo.Context) error
 	UpdateUser(c echo.Context) error
+	PatchUser(c echo.Context) error
+	ChangePassword(c echo.Context) error
 	LoginUser(c echo.Context) error
 	RefreshToken(c echo.Context) error
+	SendEmailVerification(c echo.Context) error
+	VerifyEmail(c echo.Context) error
 }
 
 type userHandler struct {
@@ -39,7 +43,11 @@ func NewUserHandler(userRepo repository.UserRepository) UserHandler {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
+// @Param If-None-Match header string false "ETag of the copy held by the client"
 // @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Version of the user"
+// @Success 304 "The copy held by the client is current"
+// @Failure default {object} entity.Problem
 // @Router /users/me [get]
 func (h *userHandler) GetUserByID(c echo.Context) error {
 	// fmt.Println("GetUserByID called")
@@ -65,15 +73,17 @@ func (h *userHandler) GetUserByID(c echo.Context) error {
 	// fmt.Println("User ID from context:", idInt)
 	// fmt.Println("User ID from JWT:", userID)
 
-	user, err := h.userRepo.GetUserByID(idInt)
+	user, err := h.userRepo.GetUserByID(c.Request().Context(), idInt)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "Failed to retrieve user",
-		})
+		return respondError(c, err)
 	}
 
 	user.Password = "" // Clear the password before sending the response
+	setETag(c, user.Version)
+	setPrivateCache(c)
+	if notModified(c) {
+		return c.NoContent(http.StatusNotModified)
+	}
 	return c.JSON(http.StatusOK, entity.Response{
 		Status:  http.StatusOK,
 		Message: "Success",
@@ -89,31 +99,22 @@ func (h *userHandler) GetUserByID(c echo.Context) error {
 // @Produce json
 // @Param user body entity.UserRegister true "User object"
 // @Success 201 {object} entity.Response
+// @Failure 400 {object} entity.Problem
+// @Failure default {object} entity.Problem
 // @Router /users/register [post]
 func (h *userHandler) CreateUser(c echo.Context) error {
-
-	user := new(model.User)
-	if err := c.Bind(user); err != nil {
-		return c.JSON(http.StatusBadRequest, entity.Response{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-		})
+	request := new(entity.UserRegister)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
 	}
 
-	err := user.Validate()
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, entity.Response{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-		})
-	}
-
-	user, err = h.userRepo.CreateUser(user)
+	user, err := h.userRepo.CreateUser(c.Request().Context(), &model.User{
+		Name:     request.Name,
+		Email:    request.Email,
+		Password: request.Password,
+	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "Failed to create user, maybe email already exists",
-		})
+		return respondError(c, err)
 	}
 
 	user.Password = "" // Clear the password before sending the response
@@ -132,27 +133,22 @@ func (h *userHandler) CreateUser(c echo.Context) error {
 // @Produce json
 // @Param user body entity.UserLogin true "User object"
 // @Success 200 {object} entity.Response
+// @Failure 400 {object} entity.Problem
+// @Failure default {object} entity.Problem
 // @Router /users/login [post]
 func (h *userHandler) LoginUser(c echo.Context) error {
-	user := new(model.User)
-	if err := c.Bind(user); err != nil {
-		return c.JSON(http.StatusBadRequest, entity.Response{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
-		})
+	request := new(entity.UserLogin)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
 	}
 
 	// fmt.Println("User login request:", user)
 
-	user, err := h.userRepo.LoginUser(user)
+	user, err := h.userRepo.LoginUser(c.Request().Context(), &model.User{Email: request.Email, Password: request.Password})
 	// fmt.Println("User after login:", user)
 
 	if err != nil {
-		// fmt.Println("Password mismatch:", err)
-		return c.JSON(http.StatusInternalServerError, entity.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "Invalid email or password",
-		})
+		return respondError(c, err)
 	}
 
 	accessToken, refreshToken, err := GenerateTokens(user)
@@ -166,7 +162,7 @@ func (h *userHandler) LoginUser(c echo.Context) error {
 	tokenString := accessToken
 	// Parse the token to extract claims
 	token, err := jwt.ParseWithClaims(tokenString, &entity.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("JWT_ACCESS_KEY")), nil
+		return []byte(config.App.JWT.AccessKey), nil
 	})
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, entity.Response{
@@ -208,6 +204,7 @@ func (h *userHandler) LoginUser(c echo.Context) error {
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
 // @Success 200 {object} entity.Response
+// @Failure default {object} entity.Problem
 // @Router /users/refresh-token [post] // Updated the router path to use POST method
 func (h *userHandler) RefreshToken(c echo.Context) error {
 	// Get the refresh token from the request header
@@ -222,7 +219,7 @@ func (h *userHandler) RefreshToken(c echo.Context) error {
 	// Parse the refresh token to extract claims
 	refreshToken = strings.TrimPrefix(refreshToken, "Bearer ")
 	token, err := jwt.ParseWithClaims(refreshToken, &entity.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("JWT_REFRESH_KEY")), nil
+		return []byte(config.App.JWT.RefreshKey), nil
 	})
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, entity.Response{
@@ -241,12 +238,9 @@ func (h *userHandler) RefreshToken(c echo.Context) error {
 
 	userID := claims.UserID
 
-	user, err := h.userRepo.GetUserByID(userID)
+	user, err := h.userRepo.GetUserByID(c.Request().Context(), userID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.Response{
-			Status:  http.StatusInternalServerError,
-			Message: "Failed to retrieve user",
-		})
+		return respondError(c, err)
 	}
 
 	newAccessToken, newRefreshToken, err := GenerateTokens(user)
@@ -274,24 +268,25 @@ func (h *userHandler) RefreshToken(c echo.Context) error {
 // Create Refresh Token
 func GenerateTokens(user *model.User) (string, string, error) {
 	accessClaims := entity.Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Exp:    float64(time.Now().Add(time.Hour * 24).Unix()), // Token expires in 24 hours
+		UserID:        user.ID,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified(),
+		Exp:           float64(time.Now().Add(config.App.JWT.AccessTTL).Unix()),
 	}
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString([]byte(os.Getenv("JWT_ACCESS_KEY")))
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString([]byte(config.App.JWT.AccessKey))
 	if err != nil {
-		log.Println("Error generating access token:", err.Error())
+		slog.Error("failed to sign the access token", "error", err)
 		return "", "", err
 	}
 
-	// Refresh Token (7 days)
 	refreshClaims := entity.Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Exp:    float64(time.Now().Add(time.Hour * 24).Unix()),
+		UserID:        user.ID,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified(),
+		Exp:           float64(time.Now().Add(config.App.JWT.RefreshTTL).Unix()),
 	}
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString([]byte(os.Getenv("JWT_REFRESH_KEY")))
+	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims).SignedString([]byte(config.App.JWT.RefreshKey))
 	if err != nil {
 		return "", "", err
 	}
@@ -301,13 +296,19 @@ func GenerateTokens(user *model.User) (string, string, error) {
 
 // UpdateUser godoc
 // @Summary Update user details
-// @Description Update user information
+// @Description Replace the name and the email of the current user, the password is changed with /users/me/password
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer <access_token>"
-// @Param user body entity.UserRegister true "User object"
+// @Param If-Match header string true "ETag of the user"
+// @Param user body entity.UserUpdate true "User object"
 // @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Version of the user"
+// @Failure 400 {object} entity.Problem
+// @Failure 412 {object} entity.Problem
+// @Failure 428 {object} entity.Problem
+// @Failure default {object} entity.Problem
 // @Router /users/me [put] // Updated the router path to include user ID
 func (h *userHandler) UpdateUser(c echo.Context) error {
 	userID := c.Get("user_id")
@@ -329,21 +330,88 @@ func (h *userHandler) UpdateUser(c echo.Context) error {
 
 	idInt := userIdInt
 
-	user := new(model.User)
-	if err := c.Bind(user); err != nil {
-		return c.JSON(http.StatusBadRequest, entity.Response{
-			Status:  http.StatusBadRequest,
-			Message: err.Error(),
+	version, problem := ifMatchVersion(c)
+	if problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	request := new(entity.UserUpdate)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	user := &model.User{
+		ID:      idInt,
+		Name:    request.Name,
+		Email:   request.Email,
+		Version: version,
+	}
+	updatedUser, err := h.userRepo.UpdateUser(c.Request().Context(), user, nil)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	updatedUser.Password = "" // Clear the password before sending the response
+	setETag(c, updatedUser.Version)
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "User updated successfully",
+		Data: map[string]interface{}{
+			"user": updatedUser,
+		},
+	})
+}
+
+// PatchUser godoc
+// @Summary Partially update user details
+// @Description Change only the fields set in the body, the others are kept
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param If-Match header string true "ETag of the user"
+// @Param user body entity.UserPatch true "Fields to change"
+// @Success 200 {object} entity.Response
+// @Header 200 {string} ETag "Version of the user"
+// @Failure 400 {object} entity.Problem
+// @Failure 412 {object} entity.Problem
+// @Failure 428 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /users/me [patch]
+func (h *userHandler) PatchUser(c echo.Context) error {
+	userIdFloat, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
 		})
 	}
-	user.ID = idInt
-	user.UpdatedAt = time.Now()
-	updatedUser, err := h.userRepo.UpdateUser(user)
+
+	version, problem := ifMatchVersion(c)
+	if problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	request := new(entity.UserPatch)
+	paths, problem := bindPatch(c, request)
+	if problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	user := &model.User{ID: int(userIdFloat), Version: version}
+	if request.Name != nil {
+		user.Name = *request.Name
+	}
+	if request.Email != nil {
+		user.Email = *request.Email
+	}
+	updatedUser, err := h.userRepo.UpdateUser(c.Request().Context(), user, paths)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, err.Error())
+		return respondError(c, err)
 	}
 
 	updatedUser.Password = "" // Clear the password before sending the response
+	setETag(c, updatedUser.Version)
 	return c.JSON(http.StatusOK, entity.Response{
 		Status:  http.StatusOK,
 		Message: "User updated successfully",
@@ -352,3 +420,97 @@ func (h *userHandler) UpdateUser(c echo.Context) error {
 		},
 	})
 }
+
+// ChangePassword godoc
+// @Summary Change password
+// @Description Replace the password of the current user, the current password is required
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param password body entity.PasswordChange true "Current and new password"
+// @Success 200 {object} entity.Response
+// @Failure 400 {object} entity.Problem
+// @Failure default {object} entity.Problem
+// @Router /users/me/password [put]
+func (h *userHandler) ChangePassword(c echo.Context) error {
+	userIdFloat, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	request := new(entity.PasswordChange)
+	if problem := bindRequest(c, request); problem != nil {
+		return respondProblem(c, *problem)
+	}
+
+	if err := h.userRepo.ChangePassword(c.Request().Context(), int(userIdFloat), request.CurrentPassword, request.NewPassword); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Password changed successfully",
+	})
+}
+
+// SendEmailVerification godoc
+// @Summary Send the email verification link again
+// @Description Mail the current user a new link verifying their email, earlier links stop working
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Success 202 {object} entity.Response
+// @Failure default {object} entity.Problem
+// @Router /users/me/verification-email [post]
+func (h *userHandler) SendEmailVerification(c echo.Context) error {
+	userIdFloat, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	if err := h.userRepo.SendEmailVerification(c.Request().Context(), int(userIdFloat)); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, entity.Response{
+		Status:  http.StatusAccepted,
+		Message: "Verification email sent",
+	})
+}
+
+// VerifyEmail godoc
+// @Summary Verify the email of a user
+// @Description Verify the email a verification link was sent to. Refresh the tokens afterwards, they tell whether the email is verified.
+// @Tags users
+// @Produce json
+// @Param token query string true "Token of the verification link"
+// @Success 200 {object} entity.Response
+// @Failure default {object} entity.Problem
+// @Router /users/verify-email [get]
+func (h *userHandler) VerifyEmail(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Missing verification token",
+		})
+	}
+
+	user, err := h.userRepo.VerifyEmail(c.Request().Context(), token)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Email verified successfully",
+		Data:    user,
+	})
+}
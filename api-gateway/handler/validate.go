@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/validation"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+)
+
+// rules are the validate tags backed by the validation package, so the
+// gateway and the services apply the same rules.
+var rules = map[string]func(v reflect.Value) error{
+	"name":     func(v reflect.Value) error { return validation.Name(v.String()) },
+	"email":    func(v reflect.Value) error { return validation.Email(v.String()) },
+	"password": func(v reflect.Value) error { return validation.Password(v.String()) },
+	"amount":   func(v reflect.Value) error { return validation.Amount(v.Float()) },
+	"message":  func(v reflect.Value) error { return validation.Message(v.String()) },
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// report fields by their JSON name
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	for tag, rule := range rules {
+		v.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+			return rule(fl.Field()) == nil
+		})
+	}
+	return v
+}
+
+// fieldErrorMessage describes a failed validate tag.
+func fieldErrorMessage(fe validator.FieldError) string {
+	if rule, ok := rules[fe.Tag()]; ok {
+		if err := rule(reflect.ValueOf(fe.Value())); err != nil {
+			return err.Error()
+		}
+	}
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", fe.Field())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", fe.Field(), fe.Param())
+	case "max":
+		if fe.Kind() == reflect.String {
+			return fmt.Sprintf("%s must be at most %s characters long", fe.Field(), fe.Param())
+		}
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", fe.Field(), strings.ReplaceAll(fe.Param(), " ", ", "))
+	}
+	return fmt.Sprintf("%s is invalid", fe.Field())
+}
+
+// validateRequest checks the validate tags of req. It returns the problem to
+// answer with when req is invalid.
+func validateRequest(req interface{}) *entity.Problem {
+	err := validate.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		panic(err)
+	}
+
+	problem := badRequestProblem("request has invalid fields")
+	for _, fe := range fieldErrors {
+		problem.Errors = append(problem.Errors, entity.FieldError{Field: fe.Field(), Message: fieldErrorMessage(fe)})
+	}
+	return problem
+}
+
+// bindRequest binds the request body into req and checks it. It returns the
+// problem to answer with when the body is malformed or invalid.
+func bindRequest(c echo.Context, req interface{}) *entity.Problem {
+	if err := c.Bind(req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			problem := PayloadTooLargeProblem(tooLarge.Limit)
+			return &problem
+		}
+		return badRequestProblem("request body is malformed")
+	}
+	return validateRequest(req)
+}
+
+func badRequestProblem(detail string) *entity.Problem {
+	return &entity.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusBadRequest),
+		Status: http.StatusBadRequest,
+		Detail: detail,
+		Reason: apperror.ReasonInvalidArgument,
+	}
+}
+
+// ReasonPayloadTooLarge is the problem reason of request bodies over the body
+// limit of their route.
+const ReasonPayloadTooLarge = "PAYLOAD_TOO_LARGE"
+
+// PayloadTooLargeProblem refuses a request body over limit bytes.
+func PayloadTooLargeProblem(limit int64) entity.Problem {
+	return entity.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(http.StatusRequestEntityTooLarge),
+		Status: http.StatusRequestEntityTooLarge,
+		Detail: fmt.Sprintf("The request body exceeds %d bytes", limit),
+		Reason: ReasonPayloadTooLarge,
+	}
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+type WebhookHandler interface {
+	CreateSubscription(c echo.Context) error
+	GetSubscriptions(c echo.Context) error
+	DeleteSubscription(c echo.Context) error
+	GetDeliveries(c echo.Context) error
+	Redeliver(c echo.Context) error
+}
+
+type webhookHandler struct {
+	webhookRepo repository.WebhookRepository
+}
+
+func NewWebhookHandler(webhookRepo repository.WebhookRepository) WebhookHandler {
+	return &webhookHandler{webhookRepo: webhookRepo}
+}
+
+func webhookSubscriptionEntity(subscription *model.WebhookSubscription) entity.WebhookSubscription {
+	return entity.WebhookSubscription{
+		ID:         subscription.ID,
+		CampaignID: subscription.CampaignID,
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventTypes: subscription.EventTypeList(),
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+// CreateSubscription godoc
+// @Summary Subscribe a webhook to campaign events
+// @Description Subscribe a partner URL to events of a campaign owned by the current user. Deliveries are signed with HMAC-SHA256 over "<X-Webhook-Timestamp>.<body>" in X-Webhook-Signature. The secret is only returned here.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param entity.WebhookSubscriptionRequest body entity.WebhookSubscriptionRequest true "Webhook subscription"
+// @Success 201 {object} entity.Response{data=entity.WebhookSubscription}
+// @Failure default {object} entity.Problem
+// @Router /webhooks [post]
+func (h *webhookHandler) CreateSubscription(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	request := new(entity.WebhookSubscriptionRequest)
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Bad Request, Invalid request body",
+		})
+	}
+
+	subscription, err := h.webhookRepo.CreateSubscription(c.Request().Context(), &model.WebhookSubscription{
+		UserID:     int(userID),
+		CampaignID: request.CampaignID,
+		URL:        request.URL,
+		EventTypes: strings.Join(request.EventTypes, ","),
+	})
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, entity.Response{
+		Status:  http.StatusCreated,
+		Message: "Success, store the secret, it is not shown again",
+		Data:    webhookSubscriptionEntity(subscription),
+	})
+}
+
+// GetSubscriptions godoc
+// @Summary Get webhook subscriptions
+// @Description Get the active webhook subscriptions of the current user
+// @Tags webhooks
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Success 200 {object} entity.Response{data=[]entity.WebhookSubscription}
+// @Failure default {object} entity.Problem
+// @Router /webhooks [get]
+func (h *webhookHandler) GetSubscriptions(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	subscriptions, err := h.webhookRepo.GetSubscriptions(c.Request().Context(), int(userID))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	data := make([]entity.WebhookSubscription, 0, len(*subscriptions))
+	for i := range *subscriptions {
+		data = append(data, webhookSubscriptionEntity(&(*subscriptions)[i]))
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    data,
+	})
+}
+
+// DeleteSubscription godoc
+// @Summary Delete a webhook subscription
+// @Description Stop deliveries to a webhook of the current user, its delivery log is kept
+// @Tags webhooks
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param id path int true "Webhook subscription ID"
+// @Success 200 {object} entity.Response
+// @Failure default {object} entity.Problem
+// @Router /webhooks/{id} [delete]
+func (h *webhookHandler) DeleteSubscription(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	subscriptionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid webhook subscription ID",
+		})
+	}
+
+	if err := h.webhookRepo.DeleteSubscription(c.Request().Context(), int(userID), subscriptionID); err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+	})
+}
+
+// GetDeliveries godoc
+// @Summary Get the delivery log of a webhook
+// @Description Get the deliveries of a webhook of the current user, newest first, with their attempts and last error
+// @Tags webhooks
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param id path int true "Webhook subscription ID"
+// @Param page query int false "Page number, starts at 1"
+// @Param page_size query int false "Deliveries per page, at most 100"
+// @Success 200 {object} entity.Response
+// @Failure default {object} entity.Problem
+// @Router /webhooks/{id}/deliveries [get]
+func (h *webhookHandler) GetDeliveries(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	subscriptionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid webhook subscription ID",
+		})
+	}
+	page, err := queryInt(c, "page", 1)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid page",
+		})
+	}
+	pageSize, err := queryInt(c, "page_size", 20)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid page size",
+		})
+	}
+
+	deliveries, err := h.webhookRepo.GetDeliveries(c.Request().Context(), int(userID), subscriptionID, page, pageSize)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    deliveries,
+	})
+}
+
+// Redeliver godoc
+// @Summary Redeliver a webhook delivery
+// @Description Send a delivery again right away, e.g. a dead one after the endpoint was fixed. The payload and its ID stay the same.
+// @Tags webhooks
+// @Produce json
+// @Param Authorization header string true "Bearer <access_token>"
+// @Param id path int true "Webhook subscription ID"
+// @Param delivery_id path int true "Delivery ID"
+// @Success 200 {object} entity.Response
+// @Failure default {object} entity.Problem
+// @Router /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *webhookHandler) Redeliver(c echo.Context) error {
+	//get user id from context
+	userID, ok := c.Get("user_id").(float64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, entity.Response{
+			Status:  http.StatusUnauthorized,
+			Message: "User not authenticated",
+		})
+	}
+
+	subscriptionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid webhook subscription ID",
+		})
+	}
+	deliveryID, err := strconv.Atoi(c.Param("delivery_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, entity.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Invalid delivery ID",
+		})
+	}
+
+	delivery, err := h.webhookRepo.Redeliver(c.Request().Context(), int(userID), subscriptionID, deliveryID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, entity.Response{
+		Status:  http.StatusOK,
+		Message: "Success",
+		Data:    delivery,
+	})
+}
@@ -1,22 +1,66 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/logging"
+	"github.com/rayhanadri/crowdfunding/common/tracing"
+
 	"github.com/rayhanadri/crowdfunding/api-gateway/config" // Import the config package
 	"github.com/rayhanadri/crowdfunding/api-gateway/route"  // Import the route package
 )
 
-func init() {
-	// Load environment variables and connect to the database
-	config.LoadEnv()
-	// Initialize the database connection
-	// config.Connect()
-}
-
 func main() {
 	// @title Crowdfunding API
 	// @version 1.0
 	// @description This is a sample server for a crowdfunding API.
 	// @host localhost:8080
 	// @BasePath /api/v1/
-	route.ExecRouter()
+	printConfig := flag.Bool("print-config", false, "print the configuration, secrets redacted, and exit")
+	flag.Parse()
+
+	// Load the configuration from the environment and the optional .env file
+	err := config.Load()
+	if *printConfig {
+		config.Print(os.Stdout)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	if *printConfig {
+		return
+	}
+
+	// Log structured and redacted records from here on
+	logging.Setup("api-gateway", config.App.Log)
+
+	// Stop on SIGTERM from Cloud Run or on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Start a trace for every request and pass it on to the services, pending
+	// spans are flushed on the way out
+	shutdownTracing, err := tracing.Setup(ctx, "api-gateway", config.App.Tracing)
+	if err != nil {
+		logging.Fatal("failed to set up tracing", "error", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Warn("failed to flush traces", "error", err)
+		}
+	}()
+
+	if err := route.ExecRouter(ctx); err != nil {
+		logging.Fatal("failed to serve", "error", err)
+	}
+	slog.Info("server stopped")
 }
@@ -1,10 +1,10 @@
 package mw
 
 import (
-	"os"
-
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/config"
 )
 
 func CheckAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
@@ -16,7 +16,7 @@ func CheckAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 
 		tokenString := authHeader[len("Bearer "):]
 		userToken, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_ACCESS_KEY")), nil
+			return []byte(config.App.JWT.AccessKey), nil
 		})
 
 		if err != nil || !userToken.Valid {
@@ -37,7 +37,11 @@ func CheckAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		// Set the user ID in the context for further use
 		c.Set("user_id", user_id) // Changed from "id" to "user_id"
 		c.Set("email", email)
+		// tokens issued before emails were verified have no claim, they are unverified
+		emailVerified, _ := claims["email_verified"].(bool)
+		c.Set("email_verified", emailVerified)
 		c.Set("exp", exp)
+		c.Set("is_admin", config.App.IsAdmin(int(user_id)))
 
 		// fmt.Printf("User ID: %v, Email: %s, Expiration: %v\n", user_id, email, exp)
 
@@ -0,0 +1,33 @@
+package mw
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+)
+
+// BodyLimitMiddleware caps request bodies at the limit of their route in
+// routes, or at fallback. A body announced over the limit is refused with 413
+// before it is read, a longer chunked body fails to bind with the same 413.
+func BodyLimitMiddleware(fallback int64, routes map[string]int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			limit, ok := routes[c.Path()]
+			if !ok {
+				limit = fallback
+			}
+
+			req := c.Request()
+			if req.ContentLength > limit {
+				problem := handler.PayloadTooLargeProblem(limit)
+				problem.Instance = req.URL.Path
+				c.Response().Header().Set(echo.HeaderContentType, handler.MIMEApplicationProblemJSON)
+				return c.JSON(http.StatusRequestEntityTooLarge, problem)
+			}
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, limit)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package mw
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/logging"
+)
+
+// requestIDPattern is what the gateway accepts as a request ID from clients,
+// anything else is replaced.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestIDMiddleware gives every request an ID, the X-Request-ID of the
+// client or a new one. It is returned in the response, logged with every
+// record of the request and passed on to the services in the gRPC metadata.
+func RequestIDMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Request().Header.Get(echo.HeaderXRequestID)
+		if !requestIDPattern.MatchString(id) {
+			id = logging.NewRequestID()
+		}
+		c.Response().Header().Set(echo.HeaderXRequestID, id)
+		c.SetRequest(c.Request().WithContext(logging.WithRequestID(c.Request().Context(), id)))
+		return next(c)
+	}
+}
+
+// LoggerMiddleware logs every request with its route, status and duration.
+// The query string is left out, it may hold tokens.
+func LoggerMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		status := c.Response().Status
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			// the error handler writes the response after the middleware
+			status = httpErr.Code
+		} else if err != nil {
+			status = http.StatusInternalServerError
+		}
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(c.Request().Context(), level, "request",
+			"method", c.Request().Method,
+			"route", c.Path(),
+			"path", c.Request().URL.Path,
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
+		return err
+	}
+}
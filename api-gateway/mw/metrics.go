@@ -0,0 +1,49 @@
+package mw
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rayhanadri/crowdfunding/common/metrics"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests served by the gateway, by route and status.",
+	}, []string{"method", "route", "status"})
+	httpLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time the gateway took to answer HTTP requests.",
+		Buckets: metrics.LatencyBuckets,
+	}, []string{"method", "route"})
+)
+
+// MetricsMiddleware counts and times requests by route template, e.g.
+// /api/v1/donations/:id, so IDs do not explode the number of series.
+// Requests matching no route are counted under the empty route.
+func MetricsMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		status := c.Response().Status
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			// the error handler writes the response after the middleware
+			status = httpErr.Code
+		} else if err != nil {
+			status = http.StatusInternalServerError
+		}
+
+		route := c.Path()
+		httpRequests.WithLabelValues(c.Request().Method, route, strconv.Itoa(status)).Inc()
+		httpLatency.WithLabelValues(c.Request().Method, route).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
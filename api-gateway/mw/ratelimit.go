@@ -0,0 +1,92 @@
+package mw
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/ratelimit"
+)
+
+// ReasonRateLimited is the problem reason of requests over a rate limit.
+const ReasonRateLimited = "RATE_LIMITED"
+
+// Headers of the IETF RateLimit header fields draft.
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+	HeaderRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// KeyFunc returns who a request is counted against.
+type KeyFunc func(c echo.Context) string
+
+// ByIP counts requests against the client IP, see echo.Echo.IPExtractor.
+func ByIP(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// ByUser counts requests against the user of the access token, and against
+// the client IP without one. Use it after CheckAuthMiddleware.
+func ByUser(c echo.Context) string {
+	if userID := c.Get("user_id"); userID != nil {
+		return fmt.Sprintf("user:%v", userID)
+	}
+	return ByIP(c)
+}
+
+// RateLimitMiddleware lets the requests of a key through at rate, counted in
+// store under name, and answers 429 beyond it. Every response carries the
+// RateLimit-* headers of the most restrictive limit applied. Requests are let
+// through when the store fails, a broken store does not take the API down.
+func RateLimitMiddleware(store ratelimit.Store, name string, rate ratelimit.Rate, key KeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			result, err := store.Take(c.Request().Context(), name+":"+key(c), rate)
+			if err != nil {
+				slog.WarnContext(c.Request().Context(), "rate limit store failed, letting the request through", "limit", name, "error", err)
+				return next(c)
+			}
+			setRateLimitHeaders(c, rate, result)
+			if result.Allowed {
+				return next(c)
+			}
+
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.Response().Header().Set(echo.HeaderContentType, handler.MIMEApplicationProblemJSON)
+			return c.JSON(http.StatusTooManyRequests, entity.Problem{
+				Type:     "about:blank",
+				Title:    http.StatusText(http.StatusTooManyRequests),
+				Status:   http.StatusTooManyRequests,
+				Detail:   fmt.Sprintf("Too many requests, try again in %d seconds", ceilSeconds(result.RetryAfter)),
+				Instance: c.Request().URL.Path,
+				Reason:   ReasonRateLimited,
+			})
+		}
+	}
+}
+
+// setRateLimitHeaders describes result, unless a limit applied before has
+// fewer requests remaining.
+func setRateLimitHeaders(c echo.Context, rate ratelimit.Rate, result ratelimit.Result) {
+	header := c.Response().Header()
+	if remaining, err := strconv.Atoi(header.Get(HeaderRateLimitRemaining)); err == nil && remaining < result.Remaining {
+		return
+	}
+	header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+	header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+	header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.Reset)))
+	header.Set(HeaderRateLimitPolicy, fmt.Sprintf("%d;w=%d", rate.Limit, ceilSeconds(rate.Period)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
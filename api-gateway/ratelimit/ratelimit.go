@@ -0,0 +1,144 @@
+// Package ratelimit limits requests with token buckets. A bucket holds up to
+// Rate.Limit tokens and refills at Limit per Period, a request takes one
+// token. Buckets live in a Store, in memory for a single gateway instance or
+// in a shared store, such as Redis, when the gateway runs on several.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate allows Limit requests per Period, in bursts of up to Limit.
+type Rate struct {
+	Limit  int
+	Period time.Duration
+}
+
+// UnmarshalText parses a rate written as limit/period, e.g. 10/1m.
+func (r *Rate) UnmarshalText(b []byte) error {
+	text := string(b)
+	limit, period, ok := strings.Cut(text, "/")
+	n, err := strconv.Atoi(limit)
+	if !ok || err != nil || n < 1 {
+		return fmt.Errorf("invalid rate %q, want limit/period such as 10/1m", text)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid rate %q, want limit/period such as 10/1m", text)
+	}
+	*r = Rate{Limit: n, Period: d}
+	return nil
+}
+
+// String writes the rate as UnmarshalText reads it, with whole hours,
+// minutes or seconds in short, 10/1m rather than 10/1m0s.
+func (r Rate) String() string {
+	period := r.Period.String()
+	for _, unit := range []struct {
+		d      time.Duration
+		suffix string
+	}{{time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}} {
+		if r.Period > 0 && r.Period%unit.d == 0 {
+			period = fmt.Sprintf("%d%s", r.Period/unit.d, unit.suffix)
+			break
+		}
+	}
+	return fmt.Sprintf("%d/%s", r.Limit, period)
+}
+
+// perSecond is the refill rate in tokens per second.
+func (r Rate) perSecond() float64 {
+	return float64(r.Limit) / r.Period.Seconds()
+}
+
+// Result is the state of a bucket after a request.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, set when not Allowed.
+	RetryAfter time.Duration
+}
+
+// Store holds the buckets. Take takes a token from the bucket of key, created
+// full when missing. Implementations must be safe for concurrent use.
+type Store interface {
+	Take(ctx context.Context, key string, rate Rate) (Result, error)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	period time.Duration
+}
+
+// MemoryStore keeps the buckets of one gateway instance in memory. Buckets
+// that refilled are dropped, a missing bucket is a full one.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+
+	// Now is the clock of the store, time.Now by default.
+	Now func() time.Time
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*bucket{}, Now: time.Now}
+}
+
+// sweepInterval is the time between two sweeps of refilled buckets.
+const sweepInterval = time.Minute
+
+func (s *MemoryStore) Take(ctx context.Context, key string, rate Rate) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Now()
+	if now.Sub(s.swept) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rate.Limit), last: now}
+		s.buckets[key] = b
+	}
+	b.period = rate.Period
+	b.tokens = math.Min(float64(rate.Limit), b.tokens+now.Sub(b.last).Seconds()*rate.perSecond())
+	b.last = now
+
+	result := Result{Limit: rate.Limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / rate.perSecond())
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((float64(rate.Limit) - b.tokens) / rate.perSecond())
+	return result, nil
+}
+
+// sweep drops the buckets that refilled since their last request.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.Sub(b.last) >= b.period {
+			delete(s.buckets, key)
+		}
+	}
+	s.swept = now
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+// TimeSeriesQuery selects the buckets of a donation time series, empty fields
+// take the defaults of donation-service.
+type TimeSeriesQuery struct {
+	Interval string
+	Timezone string
+	From     string
+	To       string
+}
+
+// CampaignStatsRepository reads the stats of a campaign for its owner, or for
+// any campaign when isAdmin. They are not cached, owners watch them move.
+type CampaignStatsRepository interface {
+	GetCampaignStats(ctx context.Context, campaignID int, userID int, isAdmin bool) (*model.CampaignStats, error)
+	GetDonationTimeSeries(ctx context.Context, campaignID int, userID int, isAdmin bool, query TimeSeriesQuery) (*model.DonationTimeSeries, error)
+}
+
+type campaignStatsRepository struct {
+	address string
+}
+
+func NewCampaignStatsRepository(address string) CampaignStatsRepository {
+	return &campaignStatsRepository{address: address}
+}
+
+func (r *campaignStatsRepository) dial() (pb.DonationServiceClient, func(), error) {
+	conn, err := dial(r.address)
+	if err != nil {
+		slog.Error("failed to connect", "address", r.address, "error", err)
+		return nil, nil, err
+	}
+	return pb.NewDonationServiceClient(conn), func() { conn.Close() }, nil
+}
+
+func (r *campaignStatsRepository) GetCampaignStats(ctx context.Context, campaignID int, userID int, isAdmin bool) (*model.CampaignStats, error) {
+	client, closeConn, err := r.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := client.GetCampaignStats(ctx, &pb.CampaignStatsRequest{
+		CampaignId: int32(campaignID),
+		UserId:     int32(userID),
+		IsAdmin:    isAdmin,
+	})
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetCampaignStats", "error", err)
+		return nil, err
+	}
+
+	return &model.CampaignStats{
+		CampaignID:      int(res.GetCampaignId()),
+		TotalRaised:     res.GetTotalRaised(),
+		DonationCount:   int(res.GetDonationCount()),
+		DonorCount:      int(res.GetDonorCount()),
+		AverageDonation: res.GetAverageDonation(),
+		MedianDonation:  res.GetMedianDonation(),
+		RepeatDonorRate: res.GetRepeatDonorRate(),
+	}, nil
+}
+
+func (r *campaignStatsRepository) GetDonationTimeSeries(ctx context.Context, campaignID int, userID int, isAdmin bool, query TimeSeriesQuery) (*model.DonationTimeSeries, error) {
+	client, closeConn, err := r.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := client.GetDonationTimeSeries(ctx, &pb.DonationTimeSeriesRequest{
+		CampaignId: int32(campaignID),
+		UserId:     int32(userID),
+		IsAdmin:    isAdmin,
+		Interval:   query.Interval,
+		Timezone:   query.Timezone,
+		From:       query.From,
+		To:         query.To,
+	})
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetDonationTimeSeries", "error", err)
+		return nil, err
+	}
+
+	series := &model.DonationTimeSeries{
+		CampaignID: int(res.GetCampaignId()),
+		Interval:   res.GetInterval(),
+		Timezone:   res.GetTimezone(),
+		Buckets:    make([]model.TimeSeriesBucket, 0, len(res.GetBuckets())),
+	}
+	for _, b := range res.GetBuckets() {
+		// the offset of the timezone is kept, buckets start at local midnight
+		start, err := time.Parse(time.RFC3339, b.GetStart())
+		if err != nil {
+			return nil, fmt.Errorf("invalid start value: %v", err)
+		}
+		series.Buckets = append(series.Buckets, model.TimeSeriesBucket{
+			Start:         start,
+			TotalAmount:   b.GetTotalAmount(),
+			DonationCount: int(b.GetDonationCount()),
+		})
+	}
+	return series, nil
+}
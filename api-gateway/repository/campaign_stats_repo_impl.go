@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCampaignStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockCampaignStatsRepository) GetCampaignStats(ctx context.Context, campaignID int, userID int, isAdmin bool) (*model.CampaignStats, error) {
+	args := m.Called(campaignID, userID, isAdmin)
+	if stats := args.Get(0); stats != nil {
+		return stats.(*model.CampaignStats), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCampaignStatsRepository) GetDonationTimeSeries(ctx context.Context, campaignID int, userID int, isAdmin bool, query TimeSeriesQuery) (*model.DonationTimeSeries, error) {
+	args := m.Called(campaignID, userID, isAdmin, query)
+	if series := args.Get(0); series != nil {
+		return series.(*model.DonationTimeSeries), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/rayhanadri/crowdfunding/common/logging"
+	"github.com/rayhanadri/crowdfunding/common/metrics"
+	"github.com/rayhanadri/crowdfunding/common/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// dial connects to another service over TLS, its RPCs are counted in the
+// client metrics and carry the trace and the request ID of their context.
+func dial(address string) (*grpc.ClientConn, error) {
+	return grpc.Dial(
+		address,
+		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
+		grpc.WithChainUnaryInterceptor(logging.UnaryClientInterceptor(), metrics.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(logging.StreamClientInterceptor()),
+		tracing.DialOption(),
+	)
+}
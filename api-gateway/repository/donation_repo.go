@@ -4,38 +4,51 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/rayhanadri/crowdfunding/donation-service/model"
 	"github.com/rayhanadri/crowdfunding/donation-service/pb"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/cache"
 )
 
 type DonationRepository interface {
-	GetAllDonations() (*[]model.Donation, error)
-	CreateDonation(donation *model.Donation) (*model.Donation, error)
-	GetDonationByID(donationID int) (*model.Donation, error)
-	UpdateDonation(donation *model.Donation) (*model.Donation, error)
+	GetAllDonations(ctx context.Context) (*[]model.Donation, error)
+	CreateDonation(ctx context.Context, donation *model.Donation) (*model.Donation, error)
+	GetDonationByID(ctx context.Context, donationID int) (*model.Donation, error)
+	UpdateDonation(ctx context.Context, donation *model.Donation, paths []string) (*model.Donation, error)
+	CreateGuestDonation(ctx context.Context, donation *model.Donation, option model.PaymentOption) (*model.Donation, *model.Transaction, error)
+	ClaimGuestDonations(ctx context.Context, userID int, email string) (int, error)
+	GetDonationReceipt(ctx context.Context, donationID int, userID int) (*model.ReceiptFile, error)
+	GetGuestDonationReceipt(ctx context.Context, donationID int, email string, invoiceID string) (*model.ReceiptFile, error)
+	GetAnnualReceipt(ctx context.Context, userID int, year int) (*model.ReceiptFile, error)
 }
 
 type donationRepository struct {
 	address string
+	cache   cache.Cache
+	ttl     time.Duration
 }
 
-func NewDonationRepository(address string) DonationRepository {
-	return &donationRepository{address: address}
+// NewDonationRepository serves donations from c for ttl before donation-service
+// is asked again. Writes through the repository drop what they change.
+func NewDonationRepository(address string, c cache.Cache, ttl time.Duration) DonationRepository {
+	return &donationRepository{address: address, cache: c, ttl: ttl}
 }
 
-func (r *donationRepository) GetAllDonations() (*[]model.Donation, error) {
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+func (r *donationRepository) GetAllDonations(ctx context.Context) (*[]model.Donation, error) {
+	cacheKey := cache.Key("donations", "all")
+	var cached []model.Donation
+	if cache.GetJSON(ctx, r.cache, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -44,7 +57,7 @@ func (r *donationRepository) GetAllDonations() (*[]model.Donation, error) {
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
@@ -52,7 +65,7 @@ func (r *donationRepository) GetAllDonations() (*[]model.Donation, error) {
 	// Call the GetDonations method
 	res, err := client.GetAllDonations(ctx, req)
 	if err != nil {
-		log.Printf("Error calling GetAllDonations: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "GetAllDonations", "error", err)
 		return nil, err
 	}
 
@@ -71,27 +84,42 @@ func (r *donationRepository) GetAllDonations() (*[]model.Donation, error) {
 		donation.UserID = int(d.UserId)
 		donation.CampaignID = int(d.CampaignId)
 		donation.Amount = float64(d.GetAmount())
-		donation.MessageText = d.GetMessage()
-		donation.Status = d.GetStatus()
+		donation.Message = d.GetMessage()
+		donation.Status = model.DonationStatusFromPb(d.GetStatus())
+		donation.IsAnonymous = d.GetIsAnonymous()
+		donation.GuestEmail = d.GetGuestEmail()
 		donation.CreatedAt = GetCreatedAtTime
 		donation.UpdatedAt = GetUpdatedAtTime
+		donation.Version = int(d.GetVersion())
+		if donor := d.GetDonor(); donor != nil {
+			donation.Donor = &model.DonorSummary{
+				ID:          int(donor.GetId()),
+				Name:        donor.GetName(),
+				AvatarURL:   donor.GetAvatarUrl(),
+				MaskedEmail: donor.GetMaskedEmail(),
+			}
+		}
 		donations = append(donations, donation)
 	}
 	if len(donations) == 0 {
 		return nil, errors.New("no donations found")
 	}
 
+	cache.SetJSON(ctx, r.cache, cacheKey, donations, r.ttl)
 	return &donations, nil
 }
 
-func (r *donationRepository) GetDonationByID(donationID int) (*model.Donation, error) {
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+func (r *donationRepository) GetDonationByID(ctx context.Context, donationID int) (*model.Donation, error) {
+	cacheKey := cache.Key("donation", donationID, "detail")
+	var cached model.Donation
+	if cache.GetJSON(ctx, r.cache, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -100,7 +128,7 @@ func (r *donationRepository) GetDonationByID(donationID int) (*model.Donation, e
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
@@ -108,7 +136,7 @@ func (r *donationRepository) GetDonationByID(donationID int) (*model.Donation, e
 	// Call the GetDonationByID method
 	res, err := client.GetDonationByID(ctx, req)
 	if err != nil {
-		log.Printf("Error calling GetDonationByID: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "GetDonationByID", "error", err)
 		return nil, err
 	}
 
@@ -126,27 +154,28 @@ func (r *donationRepository) GetDonationByID(donationID int) (*model.Donation, e
 	donation.UserID = int(res.UserId)
 	donation.CampaignID = int(res.CampaignId)
 	donation.Amount = float64(res.GetAmount())
-	donation.MessageText = res.GetMessageText()
-	donation.Status = res.GetStatus()
+	donation.Message = res.GetMessageText()
+	donation.Status = model.DonationStatusFromPb(res.GetStatus())
+	donation.IsAnonymous = res.GetIsAnonymous()
+	donation.GuestEmail = res.GetGuestEmail()
 	donation.CreatedAt = GetCreatedAtTime
 	donation.UpdatedAt = GetUpdatedAtTime
+	donation.Version = int(res.GetVersion())
 
 	if donationID == 0 {
 		return nil, fmt.Errorf("donation with id %d not found", donationID)
 	}
 
+	cache.SetJSON(ctx, r.cache, cacheKey, donation, r.ttl)
 	return &donation, nil
 }
 
-func (r *donationRepository) CreateDonation(donation *model.Donation) (*model.Donation, error) {
+func (r *donationRepository) CreateDonation(ctx context.Context, donation *model.Donation) (*model.Donation, error) {
 	// call grpc
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -155,17 +184,19 @@ func (r *donationRepository) CreateDonation(donation *model.Donation) (*model.Do
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
-	req := &pb.DonationRequest{Id: int32(donation.ID), UserId: int32(donation.UserID), CampaignId: int32(donation.CampaignID), Amount: float32(donation.Amount), Message: donation.MessageText, Status: donation.Status} // Use the provided donation parameter
+	req := &pb.DonationRequest{Id: int32(donation.ID), UserId: int32(donation.UserID), CampaignId: int32(donation.CampaignID), Amount: float32(donation.Amount), Message: donation.Message, Status: donation.Status.ToPb(), IsAnonymous: donation.IsAnonymous} // Use the provided donation parameter
 	// Call the CreateDonation method
 	res, err := client.CreateDonation(ctx, req) // Update to call CreateDonation instead of GetDonationByID
 	if err != nil {
-		log.Printf("Error calling CreateDonation: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "CreateDonation", "error", err)
 		return nil, err
 	}
+	// the new donation shows in the listings
+	cache.Delete(ctx, r.cache, cache.Prefix("donations"))
 
 	GetCreatedAtTime, err := time.Parse(time.RFC3339, res.GetCreatedAt())
 	if err != nil {
@@ -180,23 +211,25 @@ func (r *donationRepository) CreateDonation(donation *model.Donation) (*model.Do
 	donation.UserID = int(res.UserId)
 	donation.CampaignID = int(res.CampaignId)
 	donation.Amount = float64(res.GetAmount())
-	donation.MessageText = res.GetMessageText()
-	donation.Status = res.GetStatus()
+	donation.Message = res.GetMessageText()
+	donation.Status = model.DonationStatusFromPb(res.GetStatus())
+	donation.IsAnonymous = res.GetIsAnonymous()
+	donation.GuestEmail = res.GetGuestEmail()
 	donation.CreatedAt = GetCreatedAtTime
 	donation.UpdatedAt = GetUpdatedAtTime
+	donation.Version = int(res.GetVersion())
 
 	return donation, nil
 }
 
-func (r *donationRepository) UpdateDonation(donation *model.Donation) (*model.Donation, error) {
+// UpdateDonation changes the fields of donation named in paths. When paths is
+// nil zero values keep the current ones.
+func (r *donationRepository) UpdateDonation(ctx context.Context, donation *model.Donation, paths []string) (*model.Donation, error) {
 	// validate user id
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -205,17 +238,23 @@ func (r *donationRepository) UpdateDonation(donation *model.Donation) (*model.Do
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
-	req := &pb.DonationRequest{Id: int32(donation.ID), UserId: int32(donation.UserID), CampaignId: int32(donation.CampaignID), Amount: float32(donation.Amount), Message: donation.MessageText, Status: donation.Status} // Use the provided donationID parameter
+	req := &pb.DonationRequest{Id: int32(donation.ID), UserId: int32(donation.UserID), CampaignId: int32(donation.CampaignID), Amount: float32(donation.Amount), Message: donation.Message, Status: donation.Status.ToPb(), IsAnonymous: donation.IsAnonymous, Version: int32(donation.Version)} // Use the provided donationID parameter
+	if paths != nil {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+	}
 	// Call the CreateDonation method
 	res, err := client.UpdateDonation(ctx, req) // Update to call CreateDonation instead of GetDonationByID
 	if err != nil {
-		log.Printf("Error calling UpdateDonation: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "UpdateDonation", "error", err)
 		return nil, err
 	}
+	// the message and anonymity show on the donor wall of the campaign
+	cache.Delete(ctx, r.cache, cache.Prefix("donations"), cache.Prefix("donation", res.GetId()),
+		cache.Prefix("campaign", res.GetCampaignId()))
 
 	GetCreatedAtTime, err := time.Parse(time.RFC3339, res.GetCreatedAt())
 	if err != nil {
@@ -230,10 +269,200 @@ func (r *donationRepository) UpdateDonation(donation *model.Donation) (*model.Do
 	donation.UserID = int(res.UserId)
 	donation.CampaignID = int(res.CampaignId)
 	donation.Amount = float64(res.GetAmount())
-	donation.MessageText = res.GetMessageText()
-	donation.Status = res.GetStatus()
+	donation.Message = res.GetMessageText()
+	donation.Status = model.DonationStatusFromPb(res.GetStatus())
+	donation.IsAnonymous = res.GetIsAnonymous()
+	donation.GuestEmail = res.GetGuestEmail()
 	donation.CreatedAt = GetCreatedAtTime
 	donation.UpdatedAt = GetUpdatedAtTime
+	donation.Version = int(res.GetVersion())
 
 	return donation, nil
 }
+
+func (r *donationRepository) CreateGuestDonation(ctx context.Context, donation *model.Donation, option model.PaymentOption) (*model.Donation, *model.Transaction, error) {
+	// call grpc
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request, the invoice is created in the same call
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.GuestDonationRequest{Email: donation.GuestEmail, CampaignId: int32(donation.CampaignID), Amount: float32(donation.Amount), Message: donation.Message, IsAnonymous: donation.IsAnonymous, PaymentMethod: option.Method, PaymentChannel: option.Channel, MobileNumber: option.MobileNumber}
+	// Call the CreateGuestDonation method
+	res, err := client.CreateGuestDonation(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "CreateGuestDonation", "error", err)
+		return nil, nil, err
+	}
+	cache.Delete(ctx, r.cache, cache.Prefix("donations"))
+
+	d := res.GetDonation()
+	GetCreatedAtTime, err := time.Parse(time.RFC3339, d.GetCreatedAt())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid created_at value: %v", err)
+	}
+	GetUpdatedAtTime, err := time.Parse(time.RFC3339, d.GetUpdatedAt())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid updated_at value: %v", err)
+	}
+
+	donation.ID = int(d.Id)
+	donation.CampaignID = int(d.CampaignId)
+	donation.Amount = float64(d.GetAmount())
+	donation.Message = d.GetMessage()
+	donation.Status = model.DonationStatusFromPb(d.GetStatus())
+	donation.IsAnonymous = d.GetIsAnonymous()
+	donation.GuestEmail = d.GetGuestEmail()
+	donation.CreatedAt = GetCreatedAtTime
+	donation.UpdatedAt = GetUpdatedAtTime
+	donation.Version = int(d.GetVersion())
+
+	t := res.GetTransaction()
+	GetTransactionCreatedAtTime, err := time.Parse(time.RFC3339, t.GetCreatedAt())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid created_at value: %v", err)
+	}
+	GetTransactionUpdatedAtTime, err := time.Parse(time.RFC3339, t.GetUpdatedAt())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid updated_at value: %v", err)
+	}
+
+	transaction := &model.Transaction{
+		ID:                 int(t.GetId()),
+		DonationID:         int(t.GetDonationId()),
+		InvoiceID:          t.GetInvoiceId(),
+		InvoiceURL:         t.GetInvoiceUrl(),
+		InvoiceDescription: t.GetInvoiceDescription(),
+		PaymentMethod:      t.GetPaymentMethod(),
+		Amount:             float64(t.GetAmount()),
+		Status:             model.TransactionStatusFromPb(t.GetStatus()),
+		CreatedAt:          GetTransactionCreatedAtTime,
+		UpdatedAt:          GetTransactionUpdatedAtTime,
+		Version:            int(t.GetVersion()),
+	}
+	applyPaymentInstructions(transaction, t.GetPaymentChannel(), t.GetInstructions())
+
+	return donation, transaction, nil
+}
+
+func (r *donationRepository) ClaimGuestDonations(ctx context.Context, userID int, email string) (int, error) {
+	// call grpc
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return 0, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.ClaimGuestDonationsRequest{UserId: int32(userID), Email: email}
+	// Call the ClaimGuestDonations method
+	res, err := client.ClaimGuestDonations(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "ClaimGuestDonations", "error", err)
+		return 0, err
+	}
+	// the claimed donations changed owner
+	cache.Delete(ctx, r.cache, cache.Prefix("donations"), cache.Prefix("donation"))
+
+	return int(res.GetClaimedCount()), nil
+}
+
+func (r *donationRepository) GetDonationReceipt(ctx context.Context, donationID int, userID int) (*model.ReceiptFile, error) {
+	return r.donationReceipt(ctx, &pb.DonationReceiptRequest{DonationId: int32(donationID), UserId: int32(userID)})
+}
+
+func (r *donationRepository) GetGuestDonationReceipt(ctx context.Context, donationID int, email string, invoiceID string) (*model.ReceiptFile, error) {
+	return r.donationReceipt(ctx, &pb.DonationReceiptRequest{DonationId: int32(donationID), GuestEmail: email, InvoiceId: invoiceID})
+}
+
+func (r *donationRepository) donationReceipt(ctx context.Context, req *pb.DonationReceiptRequest) (*model.ReceiptFile, error) {
+	// call grpc
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Call the GetDonationReceipt method
+	res, err := client.GetDonationReceipt(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetDonationReceipt", "error", err)
+		return nil, err
+	}
+
+	return receiptFileFromPb(res)
+}
+
+func (r *donationRepository) GetAnnualReceipt(ctx context.Context, userID int, year int) (*model.ReceiptFile, error) {
+	// call grpc
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.AnnualReceiptRequest{UserId: int32(userID), Year: int32(year)}
+	// Call the GetAnnualReceipt method
+	res, err := client.GetAnnualReceipt(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetAnnualReceipt", "error", err)
+		return nil, err
+	}
+
+	return receiptFileFromPb(res)
+}
+
+func receiptFileFromPb(res *pb.ReceiptResponse) (*model.ReceiptFile, error) {
+	issuedAt, err := time.Parse(time.RFC3339, res.GetIssuedAt())
+	if err != nil {
+		return nil, fmt.Errorf("invalid issued_at value: %v", err)
+	}
+
+	return &model.ReceiptFile{
+		Number:     res.GetReceiptNumber(),
+		DonationID: int(res.GetDonationId()),
+		UserID:     int(res.GetUserId()),
+		FileName:   res.GetFileName(),
+		PDF:        res.GetPdf(),
+		IssuedAt:   issuedAt,
+	}, nil
+}
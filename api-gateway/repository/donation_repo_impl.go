@@ -1,22 +1,38 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/rayhanadri/crowdfunding/donation-service/model"
 	"github.com/stretchr/testify/mock"
 )
 
 type MockUserDonationInterface interface {
-	GetAllDonation(user_id int) (*[]model.Donation, error)
-	CreateDonation(user_id int, donation *model.Donation) (*model.Donation, error)
-	GetDonationByID(user_id int, donationID int) (*model.Donation, error)
-	UpdateDonation(user_id int, donation *model.Donation) (*model.Donation, error)
+	GetAllDonation(ctx context.Context, user_id int) (*[]model.Donation, error)
+	CreateDonation(ctx context.Context, user_id int, donation *model.Donation) (*model.Donation, error)
+	GetDonationByID(ctx context.Context, user_id int, donationID int) (*model.Donation, error)
+	UpdateDonation(ctx context.Context, user_id int, donation *model.Donation) (*model.Donation, error)
+	CreateGuestDonation(ctx context.Context, donation *model.Donation, option model.PaymentOption) (*model.Donation, *model.Transaction, error)
+	ClaimGuestDonations(ctx context.Context, userID int, email string) (int, error)
+	GetDonationReceipt(ctx context.Context, donationID int, userID int) (*model.ReceiptFile, error)
+	GetGuestDonationReceipt(ctx context.Context, donationID int, email string, invoiceID string) (*model.ReceiptFile, error)
+	GetAnnualReceipt(ctx context.Context, userID int, year int) (*model.ReceiptFile, error)
 }
 
 type MockDonationRepository struct {
 	mock.Mock
 }
 
-func (m *MockDonationRepository) GetAllDonation() (*[]model.Donation, error) {
+func (m *MockDonationRepository) GetAllDonation(ctx context.Context) (*[]model.Donation, error) {
+	args := m.Called()
+	if donations := args.Get(0); donations != nil {
+		return donations.(*[]model.Donation), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetAllDonations satisfies DonationRepository, so the mock can back a handler.
+func (m *MockDonationRepository) GetAllDonations(ctx context.Context) (*[]model.Donation, error) {
 	args := m.Called()
 	if donations := args.Get(0); donations != nil {
 		return donations.(*[]model.Donation), args.Error(1)
@@ -24,7 +40,7 @@ func (m *MockDonationRepository) GetAllDonation() (*[]model.Donation, error) {
 	return nil, args.Error(1)
 }
 
-func (m *MockDonationRepository) CreateDonation(donation *model.Donation) (*model.Donation, error) {
+func (m *MockDonationRepository) CreateDonation(ctx context.Context, donation *model.Donation) (*model.Donation, error) {
 	args := m.Called(donation)
 	if donation := args.Get(0); donation != nil {
 		return donation.(*model.Donation), args.Error(1)
@@ -32,7 +48,7 @@ func (m *MockDonationRepository) CreateDonation(donation *model.Donation) (*mode
 	return nil, args.Error(1)
 }
 
-func (m *MockDonationRepository) GetDonationByID(donationID int) (*model.Donation, error) {
+func (m *MockDonationRepository) GetDonationByID(ctx context.Context, donationID int) (*model.Donation, error) {
 	args := m.Called(donationID)
 	if donation := args.Get(0); donation != nil {
 		return donation.(*model.Donation), args.Error(1)
@@ -40,10 +56,51 @@ func (m *MockDonationRepository) GetDonationByID(donationID int) (*model.Donatio
 	return nil, args.Error(1)
 }
 
-func (m *MockDonationRepository) UpdateDonation(donation *model.Donation) (*model.Donation, error) {
-	args := m.Called(donation)
+func (m *MockDonationRepository) UpdateDonation(ctx context.Context, donation *model.Donation, paths []string) (*model.Donation, error) {
+	args := m.Called(donation, paths)
 	if donation := args.Get(0); donation != nil {
 		return donation.(*model.Donation), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
+
+func (m *MockDonationRepository) CreateGuestDonation(ctx context.Context, donation *model.Donation, option model.PaymentOption) (*model.Donation, *model.Transaction, error) {
+	args := m.Called(donation, option)
+	var transaction *model.Transaction
+	if t := args.Get(1); t != nil {
+		transaction = t.(*model.Transaction)
+	}
+	if donation := args.Get(0); donation != nil {
+		return donation.(*model.Donation), transaction, args.Error(2)
+	}
+	return nil, transaction, args.Error(2)
+}
+
+func (m *MockDonationRepository) ClaimGuestDonations(ctx context.Context, userID int, email string) (int, error) {
+	args := m.Called(userID, email)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockDonationRepository) GetDonationReceipt(ctx context.Context, donationID int, userID int) (*model.ReceiptFile, error) {
+	args := m.Called(donationID, userID)
+	if receipt := args.Get(0); receipt != nil {
+		return receipt.(*model.ReceiptFile), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDonationRepository) GetGuestDonationReceipt(ctx context.Context, donationID int, email string, invoiceID string) (*model.ReceiptFile, error) {
+	args := m.Called(donationID, email, invoiceID)
+	if receipt := args.Get(0); receipt != nil {
+		return receipt.(*model.ReceiptFile), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockDonationRepository) GetAnnualReceipt(ctx context.Context, userID int, year int) (*model.ReceiptFile, error) {
+	args := m.Called(userID, year)
+	if receipt := args.Get(0); receipt != nil {
+		return receipt.(*model.ReceiptFile), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
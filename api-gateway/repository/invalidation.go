@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/cache"
+)
+
+// invalidationRetryDelay is the wait before watching the donation events
+// again after the stream broke.
+const invalidationRetryDelay = 5 * time.Second
+
+// InvalidateOnSettlement watches the donation events until ctx is done, and
+// drops what c holds about a donation when it settles: the donation, the
+// donation listing, the donor wall and top donors of its campaign, and the
+// leaderboards. Events published while the stream was down are not known, so
+// c is cleared every time the stream is watched again.
+func InvalidateOnSettlement(ctx context.Context, repo PublicDonationRepository, c cache.Cache) {
+	for {
+		err := repo.WatchDonationEvents(ctx, 0, func(e model.DonationEvent) error {
+			if e.Type == event.DonationSettled {
+				cache.Delete(ctx, c,
+					cache.Prefix("donation", e.DonationID),
+					cache.Prefix("donations"),
+					cache.Prefix("campaign", e.CampaignID),
+					cache.Prefix("leaderboard"))
+			}
+			return nil
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		slog.WarnContext(ctx, "donation event stream ended, clearing the cache and watching again", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(invalidationRetryDelay):
+		}
+		cache.Delete(ctx, c, "")
+	}
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+type NotificationRepository interface {
+	GetPreference(ctx context.Context, userID int) (*model.NotificationPreference, error)
+	UpdatePreference(ctx context.Context, preference *model.NotificationPreference) (*model.NotificationPreference, error)
+}
+
+type notificationRepository struct {
+	address string
+}
+
+func NewNotificationRepository(address string) NotificationRepository {
+	return &notificationRepository{address: address}
+}
+
+func notificationPreferenceFromPb(res *pb.NotificationPreferenceResponse) *model.NotificationPreference {
+	return &model.NotificationPreference{
+		UserID:       int(res.GetUserId()),
+		Locale:       res.GetLocale(),
+		EmailEnabled: res.GetEmailEnabled(),
+		WebhookURL:   res.GetWebhookUrl(),
+		MutedEvents:  strings.Join(res.GetMutedEvents(), ","),
+	}
+}
+
+func (r *notificationRepository) GetPreference(ctx context.Context, userID int) (*model.NotificationPreference, error) {
+	// call grpc
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.NotificationPreferenceRequest{UserId: int32(userID)}
+	// Call the GetNotificationPreferences method
+	res, err := client.GetNotificationPreferences(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetNotificationPreferences", "error", err)
+		return nil, err
+	}
+
+	return notificationPreferenceFromPb(res), nil
+}
+
+func (r *notificationRepository) UpdatePreference(ctx context.Context, preference *model.NotificationPreference) (*model.NotificationPreference, error) {
+	// call grpc
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.UpdateNotificationPreferenceRequest{
+		UserId:       int32(preference.UserID),
+		Locale:       preference.Locale,
+		EmailEnabled: preference.EmailEnabled,
+		WebhookUrl:   preference.WebhookURL,
+		MutedEvents:  preference.MutedEventList(),
+	}
+	// Call the UpdateNotificationPreferences method
+	res, err := client.UpdateNotificationPreferences(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "UpdateNotificationPreferences", "error", err)
+		return nil, err
+	}
+
+	return notificationPreferenceFromPb(res), nil
+}
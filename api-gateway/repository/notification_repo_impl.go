@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) GetPreference(ctx context.Context, userID int) (*model.NotificationPreference, error) {
+	args := m.Called(userID)
+	if preference := args.Get(0); preference != nil {
+		return preference.(*model.NotificationPreference), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockNotificationRepository) UpdatePreference(ctx context.Context, preference *model.NotificationPreference) (*model.NotificationPreference, error) {
+	args := m.Called(preference)
+	if preference := args.Get(0); preference != nil {
+		return preference.(*model.NotificationPreference), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/cache"
+)
+
+type PublicDonationRepository interface {
+	GetCampaignDonations(ctx context.Context, campaignID int, page int, pageSize int) (*model.PublicDonationPage, error)
+	GetCampaignTopDonors(ctx context.Context, campaignID int, limit int) (*[]model.DonorTotal, error)
+	GetLeaderboard(ctx context.Context, window string, limit int) (*model.Leaderboard, error)
+	WatchCampaignProgress(ctx context.Context, campaignID int, lastEventID int64, onEvent func(model.CampaignProgress) error) error
+	WatchDonationEvents(ctx context.Context, lastEventID int64, onEvent func(model.DonationEvent) error) error
+	CacheTTL(ctx context.Context) time.Duration
+}
+
+type publicDonationRepository struct {
+	address string
+	cache   cache.Cache
+	ttl     time.Duration
+}
+
+// NewPublicDonationRepository serves public listings from c for ttl before
+// donation-service is asked again.
+func NewPublicDonationRepository(address string, c cache.Cache, ttl time.Duration) PublicDonationRepository {
+	return &publicDonationRepository{address: address, cache: c, ttl: ttl}
+}
+
+func (r *publicDonationRepository) CacheTTL(ctx context.Context) time.Duration {
+	return r.ttl
+}
+
+func donorTotalsFromPb(donors []*pb.DonorTotal) []model.DonorTotal {
+	totals := make([]model.DonorTotal, 0, len(donors))
+	for _, d := range donors {
+		totals = append(totals, model.DonorTotal{
+			UserID:        int(d.GetUserId()),
+			DonorName:     d.GetDonorName(),
+			IsAnonymous:   d.GetIsAnonymous(),
+			TotalAmount:   float64(d.GetTotalAmount()),
+			DonationCount: int(d.GetDonationCount()),
+		})
+	}
+	return totals
+}
+
+func (r *publicDonationRepository) GetCampaignDonations(ctx context.Context, campaignID int, page int, pageSize int) (*model.PublicDonationPage, error) {
+	cacheKey := cache.Key("campaign", campaignID, "donations", page, pageSize)
+	var cached model.PublicDonationPage
+	if cache.GetJSON(ctx, r.cache, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.CampaignDonationsRequest{CampaignId: int32(campaignID), Page: int32(page), PageSize: int32(pageSize)}
+	// Call the GetCampaignDonations method
+	res, err := client.GetCampaignDonations(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetCampaignDonations", "error", err)
+		return nil, err
+	}
+
+	result := &model.PublicDonationPage{
+		Donations: make([]model.PublicDonation, 0, len(res.GetDonations())),
+		Page:      int(res.GetPage()),
+		PageSize:  int(res.GetPageSize()),
+		Total:     res.GetTotal(),
+	}
+	for _, d := range res.GetDonations() {
+		GetCreatedAtTime, err := time.Parse(time.RFC3339, d.GetCreatedAt())
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_at value: %v", err)
+		}
+		result.Donations = append(result.Donations, model.PublicDonation{
+			ID:          int(d.GetId()),
+			CampaignID:  int(d.GetCampaignId()),
+			UserID:      int(d.GetUserId()),
+			DonorName:   d.GetDonorName(),
+			Amount:      float64(d.GetAmount()),
+			Message:     d.GetMessage(),
+			IsAnonymous: d.GetIsAnonymous(),
+			CreatedAt:   GetCreatedAtTime,
+		})
+	}
+
+	cache.SetJSON(ctx, r.cache, cacheKey, result, r.ttl)
+	return result, nil
+}
+
+func (r *publicDonationRepository) GetCampaignTopDonors(ctx context.Context, campaignID int, limit int) (*[]model.DonorTotal, error) {
+	cacheKey := cache.Key("campaign", campaignID, "top-donors", limit)
+	var cached []model.DonorTotal
+	if cache.GetJSON(ctx, r.cache, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.CampaignTopDonorsRequest{CampaignId: int32(campaignID), Limit: int32(limit)}
+	// Call the GetCampaignTopDonors method
+	res, err := client.GetCampaignTopDonors(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetCampaignTopDonors", "error", err)
+		return nil, err
+	}
+
+	donors := donorTotalsFromPb(res.GetDonors())
+
+	cache.SetJSON(ctx, r.cache, cacheKey, donors, r.ttl)
+	return &donors, nil
+}
+
+func (r *publicDonationRepository) GetLeaderboard(ctx context.Context, window string, limit int) (*model.Leaderboard, error) {
+	cacheKey := cache.Key("leaderboard", window, limit)
+	var cached model.Leaderboard
+	if cache.GetJSON(ctx, r.cache, cacheKey, &cached) {
+		return &cached, nil
+	}
+
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.LeaderboardRequest{Window: window, Limit: int32(limit)}
+	// Call the GetLeaderboard method
+	res, err := client.GetLeaderboard(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetLeaderboard", "error", err)
+		return nil, err
+	}
+
+	leaderboard := &model.Leaderboard{
+		Window:       res.GetWindow(),
+		TopDonors:    donorTotalsFromPb(res.GetTopDonors()),
+		TopCampaigns: make([]model.CampaignTotal, 0, len(res.GetTopCampaigns())),
+	}
+	for _, c := range res.GetTopCampaigns() {
+		leaderboard.TopCampaigns = append(leaderboard.TopCampaigns, model.CampaignTotal{
+			CampaignID:    int(c.GetCampaignId()),
+			TotalAmount:   float64(c.GetTotalAmount()),
+			DonationCount: int(c.GetDonationCount()),
+			DonorCount:    int(c.GetDonorCount()),
+		})
+	}
+
+	cache.SetJSON(ctx, r.cache, cacheKey, leaderboard, r.ttl)
+	return leaderboard, nil
+}
+
+// WatchCampaignProgress calls onEvent for every progress event of the
+// campaign until the context is done, the stream ends or onEvent fails.
+func (r *publicDonationRepository) WatchCampaignProgress(ctx context.Context, campaignID int, lastEventID int64, onEvent func(model.CampaignProgress) error) error {
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+
+	// Create a request, the stream lives as long as the caller context
+	req := &pb.WatchCampaignProgressRequest{CampaignId: int32(campaignID), LastEventId: lastEventID}
+	// Call the WatchCampaignProgress method
+	stream, err := client.WatchCampaignProgress(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "WatchCampaignProgress", "error", err)
+		return err
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		OccurredAtTime, err := time.Parse(time.RFC3339, res.GetOccurredAt())
+		if err != nil {
+			return fmt.Errorf("invalid occurred_at value: %v", err)
+		}
+
+		progress := model.CampaignProgress{
+			EventID:       res.GetEventId(),
+			CampaignID:    int(res.GetCampaignId()),
+			DonationID:    int(res.GetDonationId()),
+			Amount:        float64(res.GetAmount()),
+			TotalRaised:   float64(res.GetTotalRaised()),
+			DonationCount: int(res.GetDonationCount()),
+			DonorCount:    int(res.GetDonorCount()),
+			Source:        res.GetSource(),
+			OccurredAt:    OccurredAtTime,
+		}
+		if err := onEvent(progress); err != nil {
+			return err
+		}
+	}
+}
+
+// WatchDonationEvents calls onEvent for every donation event published after
+// lastEventID, or from now on for 0, until the context is done, the stream
+// ends or onEvent fails.
+func (r *publicDonationRepository) WatchDonationEvents(ctx context.Context, lastEventID int64, onEvent func(model.DonationEvent) error) error {
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+
+	// Create a request, the stream lives as long as the caller context
+	req := &pb.WatchDonationEventsRequest{LastEventId: lastEventID}
+	// Call the WatchDonationEvents method
+	stream, err := client.WatchDonationEvents(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "WatchDonationEvents", "error", err)
+		return err
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		OccurredAtTime, err := time.Parse(time.RFC3339, res.GetOccurredAt())
+		if err != nil {
+			return fmt.Errorf("invalid occurred_at value: %v", err)
+		}
+
+		e := model.DonationEvent{
+			EventID:    res.GetEventId(),
+			Type:       res.GetType(),
+			CampaignID: int(res.GetCampaignId()),
+			DonationID: int(res.GetDonationId()),
+			UserID:     int(res.GetUserId()),
+			OccurredAt: OccurredAtTime,
+		}
+		if err := onEvent(e); err != nil {
+			return err
+		}
+	}
+}
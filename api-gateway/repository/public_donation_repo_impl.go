@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPublicDonationRepository struct {
+	mock.Mock
+}
+
+func (m *MockPublicDonationRepository) GetCampaignDonations(ctx context.Context, campaignID int, page int, pageSize int) (*model.PublicDonationPage, error) {
+	args := m.Called(campaignID, page, pageSize)
+	if donations := args.Get(0); donations != nil {
+		return donations.(*model.PublicDonationPage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPublicDonationRepository) GetCampaignTopDonors(ctx context.Context, campaignID int, limit int) (*[]model.DonorTotal, error) {
+	args := m.Called(campaignID, limit)
+	if donors := args.Get(0); donors != nil {
+		return donors.(*[]model.DonorTotal), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPublicDonationRepository) GetLeaderboard(ctx context.Context, window string, limit int) (*model.Leaderboard, error) {
+	args := m.Called(window, limit)
+	if leaderboard := args.Get(0); leaderboard != nil {
+		return leaderboard.(*model.Leaderboard), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockPublicDonationRepository) CacheTTL(ctx context.Context) time.Duration {
+	return 0
+}
+
+func (m *MockPublicDonationRepository) WatchCampaignProgress(ctx context.Context, campaignID int, lastEventID int64, onEvent func(model.CampaignProgress) error) error {
+	args := m.Called(ctx, campaignID, lastEventID, onEvent)
+	if events := args.Get(0); events != nil {
+		for _, event := range events.([]model.CampaignProgress) {
+			if err := onEvent(event); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockPublicDonationRepository) WatchDonationEvents(ctx context.Context, lastEventID int64, onEvent func(model.DonationEvent) error) error {
+	args := m.Called(ctx, lastEventID, onEvent)
+	if events := args.Get(0); events != nil {
+		for _, event := range events.([]model.DonationEvent) {
+			if err := onEvent(event); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
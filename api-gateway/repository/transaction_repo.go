@@ -4,21 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/rayhanadri/crowdfunding/donation-service/model"
 	"github.com/rayhanadri/crowdfunding/donation-service/pb"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 )
 
 type TransactionRepository interface {
-	GetAllTransaction() (*[]model.Transaction, error)
-	CreateTransaction(transaction *model.Transaction) (*model.Transaction, error)
-	GetTransactionByID(transactionID int) (*model.Transaction, error)
-	UpdateTransaction(transaction *model.Transaction) (*model.Transaction, error)
-	SyncTransaction(transactionID int) (*model.Transaction, error)
+	GetAllTransaction(ctx context.Context, userID int) (*[]model.Transaction, error)
+	CreateTransaction(ctx context.Context, transaction *model.Transaction, option model.PaymentOption, userID int) (*model.Transaction, error)
+	GetTransactionByID(ctx context.Context, transactionID int, userID int) (*model.Transaction, error)
+	UpdateTransaction(ctx context.Context, transaction *model.Transaction, userID int) (*model.Transaction, error)
+	SyncTransaction(ctx context.Context, transactionID int, userID int) (*model.Transaction, error)
+	HandleInvoiceCallback(ctx context.Context, reference string, paymentID string) (*model.Transaction, error)
+	ReissueInvoice(ctx context.Context, transactionID int, userID int, option model.PaymentOption) (*model.Transaction, error)
+	ReissueGuestInvoice(ctx context.Context, transactionID int, email string, invoiceID string, option model.PaymentOption) (*model.Transaction, error)
 }
 
 type transactionRepository struct {
@@ -29,14 +30,34 @@ func NewTransactionRepository(address string) TransactionRepository {
 	return &transactionRepository{address: address}
 }
 
-func (r *transactionRepository) GetAllTransaction() (*[]model.Transaction, error) {
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+// applyPaymentInstructions copies how to pay a transaction from its response.
+// The checkout URL is the invoice URL.
+func applyPaymentInstructions(transaction *model.Transaction, channel string, instructions *pb.PaymentInstructions) {
+	transaction.PaymentChannel = channel
+	if instructions == nil {
+		return
+	}
+	transaction.VANumber = instructions.GetVaNumber()
+	transaction.QRString = instructions.GetQrString()
+	transaction.DeeplinkURL = instructions.GetDeeplinkUrl()
+	if expiresAt, err := time.Parse(time.RFC3339, instructions.GetExpiresAt()); err == nil {
+		transaction.ExpiresAt = &expiresAt
+	}
+}
+
+func previousTransactionIDFromPb(id int32) *int {
+	if id == 0 {
+		return nil
+	}
+	previousID := int(id)
+	return &previousID
+}
+
+func (r *transactionRepository) GetAllTransaction(ctx context.Context, userID int) (*[]model.Transaction, error) {
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -45,15 +66,15 @@ func (r *transactionRepository) GetAllTransaction() (*[]model.Transaction, error
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
-	req := &pb.GetTransactionsRequest{} // Use the provided donationID parameter
+	req := &pb.GetTransactionsRequest{UserId: int32(userID)}
 	// Call the GetDonations method
 	res, err := client.GetAllTransactions(ctx, req)
 	if err != nil {
-		log.Printf("Error calling GetAllTransactions: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "GetAllTransactions", "error", err)
 		return nil, err
 	}
 
@@ -74,16 +95,19 @@ func (r *transactionRepository) GetAllTransaction() (*[]model.Transaction, error
 		transaction.InvoiceURL = d.GetInvoiceUrl()
 		transaction.InvoiceDescription = d.GetInvoiceDescription()
 		transaction.PaymentMethod = d.GetPaymentMethod()
+		applyPaymentInstructions(&transaction, d.GetPaymentChannel(), d.GetInstructions())
+		transaction.PreviousTransactionID = previousTransactionIDFromPb(d.GetPreviousTransactionId())
 		transaction.Amount = float64(d.GetAmount())
-		transaction.Status = d.GetStatus()
+		transaction.Status = model.TransactionStatusFromPb(d.GetStatus())
 		transaction.CreatedAt = GetCreatedAtTime
 		transaction.UpdatedAt = GetUpdatedAtTime
+		transaction.Version = int(d.GetVersion())
 
 		// get donation from grpc
 		donationReq := &pb.DonationIdRequest{Id: int32(transaction.DonationID)}
 		donationRes, err := client.GetDonationByID(ctx, donationReq)
 		if err != nil {
-			log.Printf("Error calling GetDonation: %v", err)
+			slog.DebugContext(ctx, "rpc failed", "method", "GetDonation", "error", err)
 			return nil, err
 		}
 
@@ -97,13 +121,14 @@ func (r *transactionRepository) GetAllTransaction() (*[]model.Transaction, error
 		}
 
 		transaction.Donation = model.Donation{
-			ID:          int(donationRes.GetId()),
-			CampaignID:  int(donationRes.GetCampaignId()),
-			Amount:      float64(donationRes.GetAmount()),
-			MessageText: donationRes.GetMessageText(),
-			Status:      donationRes.GetStatus(),
-			CreatedAt:   GetDonationCreatedAtTime,
-			UpdatedAt:   GetDonationUpdatedAtTime,
+			ID:         int(donationRes.GetId()),
+			CampaignID: int(donationRes.GetCampaignId()),
+			Amount:     float64(donationRes.GetAmount()),
+			Message:    donationRes.GetMessageText(),
+			Status:     model.DonationStatusFromPb(donationRes.GetStatus()),
+			CreatedAt:  GetDonationCreatedAtTime,
+			UpdatedAt:  GetDonationUpdatedAtTime,
+			Version:    int(donationRes.GetVersion()),
 		}
 
 		// push to arrays
@@ -116,15 +141,12 @@ func (r *transactionRepository) GetAllTransaction() (*[]model.Transaction, error
 	return &transactions, nil
 }
 
-func (r *transactionRepository) CreateTransaction(transaction *model.Transaction) (*model.Transaction, error) {
+func (r *transactionRepository) CreateTransaction(ctx context.Context, transaction *model.Transaction, option model.PaymentOption, userID int) (*model.Transaction, error) {
 	// call grpc
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -133,15 +155,15 @@ func (r *transactionRepository) CreateTransaction(transaction *model.Transaction
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
-	req := &pb.TransactionRequest{Id: 0, DonationId: int32(transaction.DonationID), InvoiceId: "", InvoiceUrl: "", InvoiceDescription: "", PaymentMethod: "", Amount: float32(transaction.Amount), Status: "PENDING"}
+	req := &pb.TransactionRequest{Id: 0, DonationId: int32(transaction.DonationID), InvoiceId: "", InvoiceUrl: "", InvoiceDescription: "", PaymentMethod: option.Method, PaymentChannel: option.Channel, MobileNumber: option.MobileNumber, Amount: float32(transaction.Amount), Status: model.TransactionPending.ToPb(), UserId: int32(userID)}
 	// Call the CreateTransaction method
 	res, err := client.CreateTransaction(ctx, req) // Update to call CreateDonation instead of GetDonationByID
 	if err != nil {
-		log.Printf("Error calling CreateTransaction: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "CreateTransaction", "error", err)
 		return nil, err
 	}
 
@@ -160,23 +182,23 @@ func (r *transactionRepository) CreateTransaction(transaction *model.Transaction
 	transaction.InvoiceURL = res.GetInvoiceUrl()
 	transaction.InvoiceDescription = res.GetInvoiceDescription()
 	transaction.PaymentMethod = res.GetPaymentMethod()
+	applyPaymentInstructions(transaction, res.GetPaymentChannel(), res.GetInstructions())
+	transaction.PreviousTransactionID = previousTransactionIDFromPb(res.GetPreviousTransactionId())
 	transaction.Amount = float64(res.GetAmount())
-	transaction.Status = res.GetStatus()
+	transaction.Status = model.TransactionStatusFromPb(res.GetStatus())
 	transaction.CreatedAt = GetCreatedAtTime
 	transaction.UpdatedAt = GetUpdatedAtTime
+	transaction.Version = int(res.GetVersion())
 
 	return transaction, nil
 }
 
-func (r *transactionRepository) UpdateTransaction(transaction *model.Transaction) (*model.Transaction, error) {
+func (r *transactionRepository) UpdateTransaction(ctx context.Context, transaction *model.Transaction, userID int) (*model.Transaction, error) {
 	// call grpc
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -185,15 +207,15 @@ func (r *transactionRepository) UpdateTransaction(transaction *model.Transaction
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
-	req := &pb.TransactionRequest{Id: int32(transaction.ID), DonationId: int32(transaction.DonationID), InvoiceId: "", InvoiceUrl: "", InvoiceDescription: "", PaymentMethod: "", Amount: float32(transaction.Amount), Status: "PENDING"}
+	req := &pb.TransactionRequest{Id: int32(transaction.ID), DonationId: int32(transaction.DonationID), InvoiceId: "", InvoiceUrl: "", InvoiceDescription: "", PaymentMethod: "", Amount: float32(transaction.Amount), Status: transaction.Status.ToPb(), UserId: int32(userID), Version: int32(transaction.Version)}
 	// Call the UpdateTransaction method
 	res, err := client.UpdateTransaction(ctx, req) // Update to call CreateDonation instead of GetDonationByID
 	if err != nil {
-		log.Printf("Error calling UpdateTransaction: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "UpdateTransaction", "error", err)
 		return nil, err
 	}
 
@@ -212,23 +234,23 @@ func (r *transactionRepository) UpdateTransaction(transaction *model.Transaction
 	transaction.InvoiceURL = res.GetInvoiceUrl()
 	transaction.InvoiceDescription = res.GetInvoiceDescription()
 	transaction.PaymentMethod = res.GetPaymentMethod()
+	applyPaymentInstructions(transaction, res.GetPaymentChannel(), res.GetInstructions())
+	transaction.PreviousTransactionID = previousTransactionIDFromPb(res.GetPreviousTransactionId())
 	transaction.Amount = float64(res.GetAmount())
-	transaction.Status = res.GetStatus()
+	transaction.Status = model.TransactionStatusFromPb(res.GetStatus())
 	transaction.CreatedAt = GetCreatedAtTime
 	transaction.UpdatedAt = GetUpdatedAtTime
+	transaction.Version = int(res.GetVersion())
 
 	return transaction, nil
 }
 
-func (r *transactionRepository) GetTransactionByID(transactionID int) (*model.Transaction, error) {
+func (r *transactionRepository) GetTransactionByID(ctx context.Context, transactionID int, userID int) (*model.Transaction, error) {
 	// call grpc
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -237,15 +259,15 @@ func (r *transactionRepository) GetTransactionByID(transactionID int) (*model.Tr
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
-	req := &pb.TransactionIdRequest{Id: int32(transactionID)} // Use the provided transactionID parameter
+	req := &pb.TransactionIdRequest{Id: int32(transactionID), UserId: int32(userID)} // Use the provided transactionID parameter
 	// Call the GetTransactionByID method
 	res, err := client.GetTransactionByID(ctx, req)
 	if err != nil {
-		log.Printf("Error calling GetTransactionByID: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "GetTransactionByID", "error", err)
 		return nil, err
 	}
 
@@ -265,23 +287,23 @@ func (r *transactionRepository) GetTransactionByID(transactionID int) (*model.Tr
 	transaction.InvoiceURL = res.GetInvoiceUrl()
 	transaction.InvoiceDescription = res.GetInvoiceDescription()
 	transaction.PaymentMethod = res.GetPaymentMethod()
+	applyPaymentInstructions(&transaction, res.GetPaymentChannel(), res.GetInstructions())
+	transaction.PreviousTransactionID = previousTransactionIDFromPb(res.GetPreviousTransactionId())
 	transaction.Amount = float64(res.GetAmount())
-	transaction.Status = res.GetStatus()
+	transaction.Status = model.TransactionStatusFromPb(res.GetStatus())
 	transaction.CreatedAt = GetCreatedAtTime
 	transaction.UpdatedAt = GetUpdatedAtTime
+	transaction.Version = int(res.GetVersion())
 
 	return &transaction, nil
 }
 
-func (r *transactionRepository) SyncTransaction(transactionID int) (*model.Transaction, error) {
+func (r *transactionRepository) SyncTransaction(ctx context.Context, transactionID int, userID int) (*model.Transaction, error) {
 	// call grpc
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -290,15 +312,15 @@ func (r *transactionRepository) SyncTransaction(transactionID int) (*model.Trans
 	// Create a new client
 	client := pb.NewDonationServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
-	req := &pb.TransactionIdRequest{Id: int32(transactionID)} // Use the provided transactionID parameter
+	req := &pb.TransactionIdRequest{Id: int32(transactionID), UserId: int32(userID)} // Use the provided transactionID parameter
 	// Call the GetTransactionByID method
 	res, err := client.SyncTransaction(ctx, req)
 	if err != nil {
-		log.Printf("Error calling GetTransactionByID: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "SyncTransaction", "error", err)
 		return nil, err
 	}
 
@@ -318,10 +340,110 @@ func (r *transactionRepository) SyncTransaction(transactionID int) (*model.Trans
 	transaction.InvoiceURL = res.GetInvoiceUrl()
 	transaction.InvoiceDescription = res.GetInvoiceDescription()
 	transaction.PaymentMethod = res.GetPaymentMethod()
+	applyPaymentInstructions(&transaction, res.GetPaymentChannel(), res.GetInstructions())
+	transaction.PreviousTransactionID = previousTransactionIDFromPb(res.GetPreviousTransactionId())
 	transaction.Amount = float64(res.GetAmount())
-	transaction.Status = res.GetStatus()
+	transaction.Status = model.TransactionStatusFromPb(res.GetStatus())
 	transaction.CreatedAt = GetCreatedAtTime
 	transaction.UpdatedAt = GetUpdatedAtTime
+	transaction.Version = int(res.GetVersion())
 
 	return &transaction, nil
 }
+
+func (r *transactionRepository) HandleInvoiceCallback(ctx context.Context, reference string, paymentID string) (*model.Transaction, error) {
+	// call grpc
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.InvoiceCallbackRequest{InvoiceId: reference, PaymentId: paymentID}
+	// Call the HandleInvoiceCallback method
+	res, err := client.HandleInvoiceCallback(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "HandleInvoiceCallback", "error", err)
+		return nil, err
+	}
+
+	transaction := &model.Transaction{
+		ID:         int(res.GetId()),
+		DonationID: int(res.GetDonationId()),
+		InvoiceID:  res.GetInvoiceId(),
+		Amount:     float64(res.GetAmount()),
+		Status:     model.TransactionStatusFromPb(res.GetStatus()),
+	}
+
+	return transaction, nil
+}
+
+func (r *transactionRepository) ReissueInvoice(ctx context.Context, transactionID int, userID int, option model.PaymentOption) (*model.Transaction, error) {
+	return r.reissueInvoice(ctx, &pb.ReissueInvoiceRequest{TransactionId: int32(transactionID), UserId: int32(userID), PaymentMethod: option.Method, PaymentChannel: option.Channel, MobileNumber: option.MobileNumber})
+}
+
+func (r *transactionRepository) ReissueGuestInvoice(ctx context.Context, transactionID int, email string, invoiceID string, option model.PaymentOption) (*model.Transaction, error) {
+	return r.reissueInvoice(ctx, &pb.ReissueInvoiceRequest{TransactionId: int32(transactionID), GuestEmail: email, InvoiceId: invoiceID, PaymentMethod: option.Method, PaymentChannel: option.Channel, MobileNumber: option.MobileNumber})
+}
+
+func (r *transactionRepository) reissueInvoice(ctx context.Context, req *pb.ReissueInvoiceRequest) (*model.Transaction, error) {
+	// call grpc
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewDonationServiceClient(conn)
+	// Set a timeout for the request, creating the payment calls the provider
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Call the ReissueInvoice method
+	res, err := client.ReissueInvoice(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "ReissueInvoice", "error", err)
+		return nil, err
+	}
+
+	GetCreatedAtTime, err := time.Parse(time.RFC3339, res.GetCreatedAt())
+	if err != nil {
+		return nil, fmt.Errorf("invalid created_at value: %v", err)
+	}
+	GetUpdatedAtTime, err := time.Parse(time.RFC3339, res.GetUpdatedAt())
+	if err != nil {
+		return nil, fmt.Errorf("invalid updated_at value: %v", err)
+	}
+
+	transaction := &model.Transaction{
+		ID:                    int(res.GetId()),
+		DonationID:            int(res.GetDonationId()),
+		PreviousTransactionID: previousTransactionIDFromPb(res.GetPreviousTransactionId()),
+		InvoiceID:             res.GetInvoiceId(),
+		InvoiceURL:            res.GetInvoiceUrl(),
+		InvoiceDescription:    res.GetInvoiceDescription(),
+		PaymentMethod:         res.GetPaymentMethod(),
+		Amount:                float64(res.GetAmount()),
+		Status:                model.TransactionStatusFromPb(res.GetStatus()),
+		CreatedAt:             GetCreatedAtTime,
+		UpdatedAt:             GetUpdatedAtTime,
+		Version:               int(res.GetVersion()),
+	}
+	applyPaymentInstructions(transaction, res.GetPaymentChannel(), res.GetInstructions())
+
+	return transaction, nil
+}
@@ -1,56 +1,85 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/rayhanadri/crowdfunding/donation-service/model"
 	"github.com/stretchr/testify/mock"
 )
 
 type MockUserTransactionInterface interface {
-	GetAllTransaction() (*[]model.Transaction, error)
-	CreateTransaction(transaction *model.Transaction) (*model.Transaction, error)
-	GetTransactionByID(transactionID int) (*model.Transaction, error)
-	UpdateTransaction(transaction *model.Transaction) (*model.Transaction, error)
-	SyncTransaction(transactionID int) (*model.Transaction, error)
+	GetAllTransaction(ctx context.Context, userID int) (*[]model.Transaction, error)
+	CreateTransaction(ctx context.Context, transaction *model.Transaction, option model.PaymentOption, userID int) (*model.Transaction, error)
+	GetTransactionByID(ctx context.Context, transactionID int, userID int) (*model.Transaction, error)
+	UpdateTransaction(ctx context.Context, transaction *model.Transaction, userID int) (*model.Transaction, error)
+	SyncTransaction(ctx context.Context, transactionID int, userID int) (*model.Transaction, error)
+	HandleInvoiceCallback(ctx context.Context, reference string, paymentID string) (*model.Transaction, error)
+	ReissueInvoice(ctx context.Context, transactionID int, userID int, option model.PaymentOption) (*model.Transaction, error)
+	ReissueGuestInvoice(ctx context.Context, transactionID int, email string, invoiceID string, option model.PaymentOption) (*model.Transaction, error)
 }
 
 type MockTransactionRepository struct {
 	mock.Mock
 }
 
-func (m *MockTransactionRepository) GetAllTransaction() (*[]model.Transaction, error) {
-	args := m.Called()
+func (m *MockTransactionRepository) GetAllTransaction(ctx context.Context, userID int) (*[]model.Transaction, error) {
+	args := m.Called(userID)
 	if transactions := args.Get(0); transactions != nil {
 		return transactions.(*[]model.Transaction), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockTransactionRepository) CreateTransaction(transaction *model.Transaction) (*model.Transaction, error) {
-	args := m.Called(transaction)
+func (m *MockTransactionRepository) CreateTransaction(ctx context.Context, transaction *model.Transaction, option model.PaymentOption, userID int) (*model.Transaction, error) {
+	args := m.Called(transaction, option, userID)
+	if transaction := args.Get(0); transaction != nil {
+		return transaction.(*model.Transaction), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetTransactionByID(ctx context.Context, transactionID int, userID int) (*model.Transaction, error) {
+	args := m.Called(transactionID, userID)
+	if transaction := args.Get(0); transaction != nil {
+		return transaction.(*model.Transaction), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTransactionRepository) UpdateTransaction(ctx context.Context, transaction *model.Transaction, userID int) (*model.Transaction, error) {
+	args := m.Called(transaction, userID)
+	if transaction := args.Get(0); transaction != nil {
+		return transaction.(*model.Transaction), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTransactionRepository) SyncTransaction(ctx context.Context, transactionID int, userID int) (*model.Transaction, error) {
+	args := m.Called(transactionID, userID)
 	if transaction := args.Get(0); transaction != nil {
 		return transaction.(*model.Transaction), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockTransactionRepository) GetTransactionByID(transactionID int) (*model.Transaction, error) {
-	args := m.Called(transactionID)
+func (m *MockTransactionRepository) HandleInvoiceCallback(ctx context.Context, reference string, paymentID string) (*model.Transaction, error) {
+	args := m.Called(reference, paymentID)
 	if transaction := args.Get(0); transaction != nil {
 		return transaction.(*model.Transaction), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockTransactionRepository) UpdateTransaction(transaction *model.Transaction) (*model.Transaction, error) {
-	args := m.Called(transaction)
+func (m *MockTransactionRepository) ReissueInvoice(ctx context.Context, transactionID int, userID int, option model.PaymentOption) (*model.Transaction, error) {
+	args := m.Called(transactionID, userID, option)
 	if transaction := args.Get(0); transaction != nil {
 		return transaction.(*model.Transaction), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockTransactionRepository) SyncTransaction(transactionID int) (*model.Transaction, error) {
-	args := m.Called(transactionID)
+func (m *MockTransactionRepository) ReissueGuestInvoice(ctx context.Context, transactionID int, email string, invoiceID string, option model.PaymentOption) (*model.Transaction, error) {
+	args := m.Called(transactionID, email, invoiceID, option)
 	if transaction := args.Get(0); transaction != nil {
 		return transaction.(*model.Transaction), args.Error(1)
 	}
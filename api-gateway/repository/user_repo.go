@@ -4,22 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	// "crowdfund/model"
 
 	"github.com/rayhanadri/crowdfunding/user-service/model"
 	"github.com/rayhanadri/crowdfunding/user-service/pb"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type UserRepository interface {
-	GetUserByID(id int) (*model.User, error)
-	CreateUser(user *model.User) (*model.User, error)
-	UpdateUser(user *model.User) (*model.User, error)
-	LoginUser(user *model.User) (*model.User, error)
+	GetUserByID(ctx context.Context, id int) (*model.User, error)
+	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
+	UpdateUser(ctx context.Context, user *model.User, paths []string) (*model.User, error)
+	ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) error
+	LoginUser(ctx context.Context, user *model.User) (*model.User, error)
+	SendEmailVerification(ctx context.Context, userID int) error
+	VerifyEmail(ctx context.Context, token string) (*model.User, error)
 }
 
 type userRepository struct {
@@ -30,14 +32,24 @@ func NewUserRepository(address string) UserRepository {
 	return &userRepository{address: address}
 }
 
-func (r *userRepository) GetUserByID(id int) (*model.User, error) {
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+// emailVerifiedAt parses when the user verified their email, nil when they
+// did not.
+func emailVerifiedAt(res *pb.UserResponse) (*time.Time, error) {
+	if res.GetEmailVerifiedAt() == "" {
+		return nil, nil
+	}
+	verifiedAt, err := time.Parse(time.RFC3339, res.GetEmailVerifiedAt())
+	if err != nil {
+		return nil, fmt.Errorf("invalid email_verified_at value: %v", err)
+	}
+	return &verifiedAt, nil
+}
+
+func (r *userRepository) GetUserByID(ctx context.Context, id int) (*model.User, error) {
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -46,7 +58,7 @@ func (r *userRepository) GetUserByID(id int) (*model.User, error) {
 	// Create a new client
 	client := pb.NewUserServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
@@ -54,7 +66,7 @@ func (r *userRepository) GetUserByID(id int) (*model.User, error) {
 	// Call the GetUserByID method
 	res, err := client.GetUserByID(ctx, req)
 	if err != nil {
-		log.Printf("Error calling GetUserByID: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "GetUserByID", "error", err)
 		return nil, err
 	}
 
@@ -74,6 +86,10 @@ func (r *userRepository) GetUserByID(id int) (*model.User, error) {
 	user.Password = res.Password
 	user.CreatedAt = GetCreatedAtTime
 	user.UpdatedAt = GetUpdatedAtTime
+	user.Version = int(res.GetVersion())
+	if user.EmailVerifiedAt, err = emailVerifiedAt(res); err != nil {
+		return nil, err
+	}
 	if user.ID == 0 {
 		return nil, fmt.Errorf("user with id %d not found", id)
 	}
@@ -81,7 +97,7 @@ func (r *userRepository) GetUserByID(id int) (*model.User, error) {
 	return &user, nil
 }
 
-func (r *userRepository) CreateUser(user *model.User) (*model.User, error) {
+func (r *userRepository) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
 	//validate user data
 	if user.Name == "" || user.Email == "" || user.Password == "" {
 		return nil, errors.New("name, email, and password are required")
@@ -90,13 +106,10 @@ func (r *userRepository) CreateUser(user *model.User) (*model.User, error) {
 		return nil, errors.New("password must be at least 6 characters long")
 	}
 
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -105,7 +118,7 @@ func (r *userRepository) CreateUser(user *model.User) (*model.User, error) {
 	// Create a new client
 	client := pb.NewUserServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
@@ -113,7 +126,7 @@ func (r *userRepository) CreateUser(user *model.User) (*model.User, error) {
 	// Call the CreateUser method
 	res, err := client.CreateUser(ctx, req)
 	if err != nil {
-		log.Printf("Error calling CreateUser: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "CreateUser", "error", err)
 		return nil, err
 	}
 
@@ -132,26 +145,21 @@ func (r *userRepository) CreateUser(user *model.User) (*model.User, error) {
 	user.Password = res.Password
 	user.CreatedAt = GetCreatedAtTime
 	user.UpdatedAt = GetUpdatedAtTime
+	user.Version = int(res.GetVersion())
+	if user.EmailVerifiedAt, err = emailVerifiedAt(res); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
 
-func (r *userRepository) UpdateUser(user *model.User) (*model.User, error) {
-	//validate user data
-	if user.Name == "" || user.Email == "" || user.Password == "" {
-		return nil, errors.New("name, email, and password are required")
-	}
-	if len(user.Password) < 6 {
-		return nil, errors.New("password must be at least 6 characters long")
-	}
-
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+// UpdateUser changes the fields of user named in paths, the name and the
+// email when paths is nil.
+func (r *userRepository) UpdateUser(ctx context.Context, user *model.User, paths []string) (*model.User, error) {
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -160,15 +168,18 @@ func (r *userRepository) UpdateUser(user *model.User) (*model.User, error) {
 	// Create a new client
 	client := pb.NewUserServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
-	req := &pb.UserRequest{Id: 0, Name: user.Name, Email: user.Email, Password: user.Password}
-	// Call the CreateUser method
+	req := &pb.UserRequest{Id: int32(user.ID), Name: user.Name, Email: user.Email, Version: int32(user.Version)}
+	if paths != nil {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+	}
+	// Call the UpdateUser method
 	res, err := client.UpdateUser(ctx, req)
 	if err != nil {
-		log.Printf("Error calling UpdateUser: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "UpdateUser", "error", err)
 		return nil, err
 	}
 
@@ -187,18 +198,46 @@ func (r *userRepository) UpdateUser(user *model.User) (*model.User, error) {
 	user.Password = res.Password
 	user.CreatedAt = GetCreatedAtTime
 	user.UpdatedAt = GetUpdatedAtTime
+	user.Version = int(res.GetVersion())
+	if user.EmailVerifiedAt, err = emailVerifiedAt(res); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 }
 
-func (r *userRepository) LoginUser(user *model.User) (*model.User, error) {
-	conn, err := grpc.Dial(
-		r.address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+func (r *userRepository) ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) error {
+	conn, err := dial(r.address)
 
 	if err != nil {
-		log.Printf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewUserServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Create a request
+	req := &pb.ChangePasswordRequest{Id: int32(userID), CurrentPassword: currentPassword, NewPassword: newPassword}
+	// Call the ChangePassword method
+	if _, err := client.ChangePassword(ctx, req); err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "ChangePassword", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepository) LoginUser(ctx context.Context, user *model.User) (*model.User, error) {
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -207,7 +246,7 @@ func (r *userRepository) LoginUser(user *model.User) (*model.User, error) {
 	// Create a new client
 	client := pb.NewUserServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	// Create a request
@@ -215,7 +254,7 @@ func (r *userRepository) LoginUser(user *model.User) (*model.User, error) {
 	// Call the LoginUser method
 	res, err := client.LoginUser(ctx, req)
 	if err != nil {
-		log.Printf("Error calling LoginUser: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "LoginUser", "error", err)
 		return nil, err
 	}
 
@@ -234,7 +273,73 @@ func (r *userRepository) LoginUser(user *model.User) (*model.User, error) {
 	user.Password = ""
 	user.CreatedAt = GetCreatedAtTime
 	user.UpdatedAt = GetUpdatedAtTime
+	user.Version = int(res.GetVersion())
+	if user.EmailVerifiedAt, err = emailVerifiedAt(res); err != nil {
+		return nil, err
+	}
 
 	return user, nil
 
 }
+
+// SendEmailVerification mails the user a new link verifying their email.
+func (r *userRepository) SendEmailVerification(ctx context.Context, userID int) error {
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewUserServiceClient(conn)
+	// Set a timeout for the request, the email is sent before the answer
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	// Call the SendEmailVerification method
+	if _, err := client.SendEmailVerification(ctx, &pb.UserIdRequest{Id: int32(userID)}); err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "SendEmailVerification", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// VerifyEmail verifies the email a verification link was sent to.
+func (r *userRepository) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	conn, err := dial(r.address)
+
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Create a new client
+	client := pb.NewUserServiceClient(conn)
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Call the VerifyEmail method
+	res, err := client.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: token})
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "VerifyEmail", "error", err)
+		return nil, err
+	}
+
+	var user model.User
+	user.ID = int(res.GetId())
+	user.Name = res.GetName()
+	user.Email = res.GetEmail()
+	user.Version = int(res.GetVersion())
+	if user.EmailVerifiedAt, err = emailVerifiedAt(res); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
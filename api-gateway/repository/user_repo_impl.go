@@ -1,22 +1,27 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/rayhanadri/crowdfunding/user-service/model"
 	"github.com/stretchr/testify/mock"
 )
 
 type MockUserRepositoryInterface interface {
-	GetUserByID(id int) (*model.User, error)
-	CreateUser(user *model.User) (*model.User, error)
-	UpdateUser(user *model.User) (*model.User, error)
-	LoginUser(user *model.User) (*model.User, error)
+	GetUserByID(ctx context.Context, id int) (*model.User, error)
+	CreateUser(ctx context.Context, user *model.User) (*model.User, error)
+	UpdateUser(ctx context.Context, user *model.User, paths []string) (*model.User, error)
+	ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) error
+	LoginUser(ctx context.Context, user *model.User) (*model.User, error)
+	SendEmailVerification(ctx context.Context, userID int) error
+	VerifyEmail(ctx context.Context, token string) (*model.User, error)
 }
 
 type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) GetUserByID(id int) (*model.User, error) {
+func (m *MockUserRepository) GetUserByID(ctx context.Context, id int) (*model.User, error) {
 	args := m.Called(id)
 	if user := args.Get(0); user != nil {
 		return user.(*model.User), args.Error(1)
@@ -24,7 +29,7 @@ func (m *MockUserRepository) GetUserByID(id int) (*model.User, error) {
 	return nil, args.Error(1)
 }
 
-func (m *MockUserRepository) CreateUser(user *model.User) (*model.User, error) {
+func (m *MockUserRepository) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
 	args := m.Called(user)
 	if user := args.Get(0); user != nil {
 		return user.(*model.User), args.Error(1)
@@ -32,18 +37,36 @@ func (m *MockUserRepository) CreateUser(user *model.User) (*model.User, error) {
 	return nil, args.Error(1)
 }
 
-func (m *MockUserRepository) UpdateUser(user *model.User) (*model.User, error) {
-	args := m.Called(user)
+func (m *MockUserRepository) UpdateUser(ctx context.Context, user *model.User, paths []string) (*model.User, error) {
+	args := m.Called(user, paths)
 	if user := args.Get(0); user != nil {
 		return user.(*model.User), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockUserRepository) LoginUser(user *model.User) (*model.User, error) {
+func (m *MockUserRepository) ChangePassword(ctx context.Context, userID int, currentPassword string, newPassword string) error {
+	args := m.Called(userID, currentPassword, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) LoginUser(ctx context.Context, user *model.User) (*model.User, error) {
 	args := m.Called(user)
 	if user := args.Get(0); user != nil {
 		return user.(*model.User), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
+
+func (m *MockUserRepository) SendEmailVerification(ctx context.Context, userID int) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	args := m.Called(token)
+	if user := args.Get(0); user != nil {
+		return user.(*model.User), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
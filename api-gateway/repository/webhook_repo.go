@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error)
+	GetSubscriptions(ctx context.Context, userID int) (*[]model.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, userID int, subscriptionID int) error
+	GetDeliveries(ctx context.Context, userID int, subscriptionID int, page int, pageSize int) (*model.WebhookDeliveryPage, error)
+	Redeliver(ctx context.Context, userID int, subscriptionID int, deliveryID int) (*model.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	address string
+}
+
+func NewWebhookRepository(address string) WebhookRepository {
+	return &webhookRepository{address: address}
+}
+
+func (r *webhookRepository) dial() (pb.DonationServiceClient, func(), error) {
+	conn, err := dial(r.address)
+	if err != nil {
+		slog.Error("failed to connect", "address", r.address, "error", err)
+		return nil, nil, err
+	}
+	return pb.NewDonationServiceClient(conn), func() { conn.Close() }, nil
+}
+
+func webhookSubscriptionFromPb(s *pb.WebhookSubscription) (*model.WebhookSubscription, error) {
+	createdAt, err := time.Parse(time.RFC3339, s.GetCreatedAt())
+	if err != nil {
+		return nil, fmt.Errorf("invalid created_at value: %v", err)
+	}
+	return &model.WebhookSubscription{
+		ID:         int(s.GetId()),
+		UserID:     int(s.GetUserId()),
+		CampaignID: int(s.GetCampaignId()),
+		URL:        s.GetUrl(),
+		Secret:     s.GetSecret(),
+		EventTypes: strings.Join(s.GetEventTypes(), ","),
+		Active:     s.GetActive(),
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+func webhookDeliveryFromPb(d *pb.WebhookDelivery) (*model.WebhookDelivery, error) {
+	createdAt, err := time.Parse(time.RFC3339, d.GetCreatedAt())
+	if err != nil {
+		return nil, fmt.Errorf("invalid created_at value: %v", err)
+	}
+	delivery := &model.WebhookDelivery{
+		ID:             int(d.GetId()),
+		SubscriptionID: int(d.GetSubscriptionId()),
+		EventType:      d.GetEventType(),
+		Payload:        d.GetPayload(),
+		Status:         d.GetStatus(),
+		Attempts:       int(d.GetAttempts()),
+		ResponseStatus: int(d.GetResponseStatus()),
+		LastError:      d.GetLastError(),
+		CreatedAt:      createdAt,
+	}
+	if d.GetNextAttemptAt() != "" {
+		if delivery.NextAttemptAt, err = time.Parse(time.RFC3339, d.GetNextAttemptAt()); err != nil {
+			return nil, fmt.Errorf("invalid next_attempt_at value: %v", err)
+		}
+	}
+	if d.GetDeliveredAt() != "" {
+		deliveredAt, err := time.Parse(time.RFC3339, d.GetDeliveredAt())
+		if err != nil {
+			return nil, fmt.Errorf("invalid delivered_at value: %v", err)
+		}
+		delivery.DeliveredAt = &deliveredAt
+	}
+	return delivery, nil
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	client, closeConn, err := r.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req := &pb.CreateWebhookSubscriptionRequest{
+		UserId:     int32(subscription.UserID),
+		CampaignId: int32(subscription.CampaignID),
+		Url:        subscription.URL,
+		EventTypes: subscription.EventTypeList(),
+	}
+	res, err := client.CreateWebhookSubscription(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "CreateWebhookSubscription", "error", err)
+		return nil, err
+	}
+
+	return webhookSubscriptionFromPb(res.GetSubscription())
+}
+
+func (r *webhookRepository) GetSubscriptions(ctx context.Context, userID int) (*[]model.WebhookSubscription, error) {
+	client, closeConn, err := r.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := client.GetWebhookSubscriptions(ctx, &pb.WebhookSubscriptionsRequest{UserId: int32(userID)})
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetWebhookSubscriptions", "error", err)
+		return nil, err
+	}
+
+	subscriptions := make([]model.WebhookSubscription, 0, len(res.GetSubscriptions()))
+	for _, s := range res.GetSubscriptions() {
+		subscription, err := webhookSubscriptionFromPb(s)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	return &subscriptions, nil
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, userID int, subscriptionID int) error {
+	client, closeConn, err := r.dial()
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err = client.DeleteWebhookSubscription(ctx, &pb.WebhookSubscriptionIdRequest{Id: int32(subscriptionID), UserId: int32(userID)})
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "DeleteWebhookSubscription", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetDeliveries(ctx context.Context, userID int, subscriptionID int, page int, pageSize int) (*model.WebhookDeliveryPage, error) {
+	client, closeConn, err := r.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	// Set a timeout for the request
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req := &pb.WebhookDeliveriesRequest{
+		SubscriptionId: int32(subscriptionID),
+		UserId:         int32(userID),
+		Page:           int32(page),
+		PageSize:       int32(pageSize),
+	}
+	res, err := client.GetWebhookDeliveries(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "GetWebhookDeliveries", "error", err)
+		return nil, err
+	}
+
+	deliveryPage := &model.WebhookDeliveryPage{
+		Deliveries: make([]model.WebhookDelivery, 0, len(res.GetDeliveries())),
+		Page:       int(res.GetPage()),
+		PageSize:   int(res.GetPageSize()),
+		Total:      res.GetTotal(),
+	}
+	for _, d := range res.GetDeliveries() {
+		delivery, err := webhookDeliveryFromPb(d)
+		if err != nil {
+			return nil, err
+		}
+		deliveryPage.Deliveries = append(deliveryPage.Deliveries, *delivery)
+	}
+
+	return deliveryPage, nil
+}
+
+func (r *webhookRepository) Redeliver(ctx context.Context, userID int, subscriptionID int, deliveryID int) (*model.WebhookDelivery, error) {
+	client, closeConn, err := r.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	// the partner gets up to 10 seconds to answer
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	req := &pb.RedeliverWebhookRequest{
+		SubscriptionId: int32(subscriptionID),
+		DeliveryId:     int32(deliveryID),
+		UserId:         int32(userID),
+	}
+	res, err := client.RedeliverWebhook(ctx, req)
+	if err != nil {
+		slog.DebugContext(ctx, "rpc failed", "method", "RedeliverWebhook", "error", err)
+		return nil, err
+	}
+
+	return webhookDeliveryFromPb(res.GetDelivery())
+}
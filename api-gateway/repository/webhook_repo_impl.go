@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) CreateSubscription(ctx context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	args := m.Called(subscription)
+	if subscription := args.Get(0); subscription != nil {
+		return subscription.(*model.WebhookSubscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetSubscriptions(ctx context.Context, userID int) (*[]model.WebhookSubscription, error) {
+	args := m.Called(userID)
+	if subscriptions := args.Get(0); subscriptions != nil {
+		return subscriptions.(*[]model.WebhookSubscription), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) DeleteSubscription(ctx context.Context, userID int, subscriptionID int) error {
+	args := m.Called(userID, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetDeliveries(ctx context.Context, userID int, subscriptionID int, page int, pageSize int) (*model.WebhookDeliveryPage, error) {
+	args := m.Called(userID, subscriptionID, page, pageSize)
+	if deliveries := args.Get(0); deliveries != nil {
+		return deliveries.(*model.WebhookDeliveryPage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockWebhookRepository) Redeliver(ctx context.Context, userID int, subscriptionID int, deliveryID int) (*model.WebhookDelivery, error) {
+	args := m.Called(userID, subscriptionID, deliveryID)
+	if delivery := args.Get(0); delivery != nil {
+		return delivery.(*model.WebhookDelivery), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
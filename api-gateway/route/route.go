@@ -1,51 +1,174 @@
 package route
 
 import (
-	"os"
-	// Import the config package
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/rayhanadri/crowdfunding/common/health"
+	"github.com/rayhanadri/crowdfunding/common/metrics"
 	echoSwagger "github.com/swaggo/echo-swagger" // echo-swagger middleware
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
+	"github.com/rayhanadri/crowdfunding/api-gateway/cache"      // Import the cache package
+	"github.com/rayhanadri/crowdfunding/api-gateway/config"     // Import the config package
 	_ "github.com/rayhanadri/crowdfunding/api-gateway/docs"     // docs is generated by Swag CLI, you have to import it.
 	"github.com/rayhanadri/crowdfunding/api-gateway/entity"     // Import the model package
 	"github.com/rayhanadri/crowdfunding/api-gateway/handler"    // Import the handler package
 	"github.com/rayhanadri/crowdfunding/api-gateway/mw"         // Import the middleware package
+	"github.com/rayhanadri/crowdfunding/api-gateway/ratelimit"  // Import the ratelimit package
 	"github.com/rayhanadri/crowdfunding/api-gateway/repository" // Import the repository package
 )
 
-func ExecRouter() {
+// ExecRouter serves the API until ctx is done, then drains in-flight requests.
+func ExecRouter(ctx context.Context) error {
 	e := echo.New()
 
+	// Initialize the cache, shared by the repositories
+	responseCache := cache.None
+	if config.App.Cache.Enabled {
+		responseCache = cache.NewLRU(config.App.Cache.Size)
+	}
+
 	// Initialize the repository
-	userRepo := repository.NewUserRepository("user-service-273575294549.asia-southeast2.run.app:443")
-	donationRepo := repository.NewDonationRepository("donation-service-273575294549.asia-southeast2.run.app:443")
-	transRepo := repository.NewTransactionRepository("donation-service-273575294549.asia-southeast2.run.app:443")
+	userRepo := repository.NewUserRepository(config.App.UserServiceAddr)
+	donationRepo := repository.NewDonationRepository(config.App.DonationServiceAddr, responseCache, config.App.Cache.PrivateTTL)
+	transRepo := repository.NewTransactionRepository(config.App.DonationServiceAddr)
+	publicRepo := repository.NewPublicDonationRepository(config.App.DonationServiceAddr, responseCache, config.App.Cache.PublicTTL)
+	notificationRepo := repository.NewNotificationRepository(config.App.DonationServiceAddr)
+	webhookRepo := repository.NewWebhookRepository(config.App.DonationServiceAddr)
+	statsRepo := repository.NewCampaignStatsRepository(config.App.DonationServiceAddr)
+	if config.App.Cache.Enabled {
+		go repository.InvalidateOnSettlement(ctx, publicRepo, responseCache)
+	}
 
 	// Initialize the handlers
 	userHandler := handler.NewUserHandler(userRepo)
 	transHandler := handler.NewTransactionHandler(transRepo)
 	donationHandler := handler.NewDonationHandler(donationRepo)
+	publicHandler := handler.NewPublicDonationHandler(publicRepo)
+	callbackHandler := handler.NewCallbackHandler(transRepo)
+	notificationHandler := handler.NewNotificationHandler(notificationRepo)
+	webhookHandler := handler.NewWebhookHandler(webhookRepo)
+	statsHandler := handler.NewCampaignStatsHandler(statsRepo)
+	tls := grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, ""))
+	healthHandler := handler.NewHealthHandler(map[string]health.Check{
+		"user-service":     health.GRPC(config.App.UserServiceAddr, tls),
+		"donation-service": health.GRPC(config.App.DonationServiceAddr, tls),
+	})
+
+	// Find the client IP in X-Forwarded-For, trusting only private and
+	// configured proxies, so clients cannot pick the IP they are limited by
+	trusted, err := config.App.RateLimit.TrustedRanges()
+	if err != nil {
+		return err
+	}
+	trustOptions := make([]echo.TrustOption, 0, len(trusted))
+	for _, ipNet := range trusted {
+		trustOptions = append(trustOptions, echo.TrustIPRange(ipNet))
+	}
+	e.IPExtractor = echo.ExtractIPFromXFFHeader(trustOptions...)
+
+	// Rate limits, per client IP on the whole API and tighter on the routes
+	// that can be abused: sign in, and those opening Xendit payments
+	limits := config.App.RateLimit
+	store := ratelimit.NewMemoryStore()
+	limit := func(name string, rate ratelimit.Rate, key mw.KeyFunc) echo.MiddlewareFunc {
+		if !limits.Enabled {
+			return func(next echo.HandlerFunc) echo.HandlerFunc { return next }
+		}
+		return mw.RateLimitMiddleware(store, name, rate, key)
+	}
+	ipLimit := limit("ip", limits.IP, mw.ByIP)
+	authLimit := limit("auth", limits.Auth, mw.ByIP)
+	userLimit := limit("user", limits.User, mw.ByUser)
+	paymentLimit := limit("payments", limits.Payments, mw.ByUser)
+	// authed routes need an access token and are limited per user
+	authed := []echo.MiddlewareFunc{mw.CheckAuthMiddleware, userLimit}
+	authedPayment := []echo.MiddlewareFunc{mw.CheckAuthMiddleware, userLimit, paymentLimit}
 
 	// Middleware
-	e.Use(middleware.Logger())
+	e.Use(otelecho.Middleware("api-gateway", otelecho.WithSkipper(isProbe))) // outermost, so the span covers every request
+	e.Use(mw.RequestIDMiddleware)
+	e.Use(mw.LoggerMiddleware)
+	e.Use(mw.MetricsMiddleware) // outside Recover, so panics count as 500
 	e.Use(middleware.Recover())
+	// Browser apps on the configured origins, they read the IDs, versions
+	// and limits the API answers with
+	if cors := config.App.HTTP.CORS; len(cors.AllowOrigins) > 0 {
+		e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins:     cors.AllowOrigins,
+			AllowCredentials: cors.AllowCredentials,
+			AllowHeaders: []string{
+				echo.HeaderOrigin, echo.HeaderAccept, echo.HeaderContentType, echo.HeaderAuthorization,
+				"If-Match", "If-None-Match", echo.HeaderXRequestID, "traceparent", "tracestate",
+			},
+			ExposeHeaders: []string{
+				echo.HeaderXRequestID, "ETag", echo.HeaderContentDisposition, echo.HeaderRetryAfter,
+				mw.HeaderRateLimitLimit, mw.HeaderRateLimitRemaining, mw.HeaderRateLimitReset, mw.HeaderRateLimitPolicy,
+			},
+			MaxAge: int(cors.MaxAge.Seconds()),
+		}))
+	}
+	// Security headers, HSTS is only sent on HTTPS requests
+	security := config.App.HTTP.Security
+	e.Use(middleware.SecureWithConfig(middleware.SecureConfig{
+		ContentTypeNosniff:    "nosniff",
+		XFrameOptions:         security.FrameOptions,
+		HSTSMaxAge:            int(security.HSTSMaxAge.Seconds()),
+		HSTSExcludeSubdomains: !security.HSTSIncludeSubdomains,
+		ReferrerPolicy:        "no-referrer",
+	}))
+	// Body limits per route, and a deadline for the service calls of a request
+	bodyLimit, routeBodyLimits, err := config.App.HTTP.Limits()
+	if err != nil {
+		return err
+	}
+	e.Use(mw.BodyLimitMiddleware(bodyLimit, routeBodyLimits))
+	e.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+		Skipper: isStream,
+		Timeout: config.App.HTTP.RequestTimeout,
+	}))
 
 	e.GET("/", rootShow) // Root route
 
+	// Probes, outside the API
+	e.GET("/healthz", healthHandler.Healthz) // Liveness
+	e.GET("/readyz", healthHandler.Readyz)   // Readiness, every backend is serving
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+
+	// Payment provider callbacks, authenticated by their token and not limited,
+	// Xendit retries refused callbacks
+	callbacks := e.Group("/api/v1/callbacks")
+	callbacks.POST("/xendit/invoice", callbackHandler.XenditInvoiceCallback)                // Xendit invoice status callback
+	callbacks.POST("/xendit/virtual-account", callbackHandler.XenditVirtualAccountCallback) // Xendit virtual account payment callback
+	callbacks.POST("/xendit/ewallet", callbackHandler.XenditEWalletCallback)                // Xendit e-wallet charge callback
+	callbacks.POST("/xendit/qris", callbackHandler.XenditQRCodeCallback)                    // Xendit QR code payment callback
+
 	// Routes
-	g := e.Group("/api/v1")
+	g := e.Group("/api/v1", ipLimit)
 
 	//
 	g.GET("/swagger/*", echoSwagger.WrapHandler) // Swagger documentation route
 
 	// Users routes
-	g.POST("/users/register", userHandler.CreateUser)                   // Create a new user
-	g.POST("/users/login", userHandler.LoginUser)                       // login
-	g.GET("/users/me", userHandler.GetUserByID, mw.CheckAuthMiddleware) // Get current user
-	g.PUT("/users/me", userHandler.UpdateUser, mw.CheckAuthMiddleware)  // Update current user
-	g.GET("/users/refresh-token", userHandler.RefreshToken)             // Get user by ID
+	g.POST("/users/register", userHandler.CreateUser, authLimit)                                 // Create a new user
+	g.POST("/users/login", userHandler.LoginUser, authLimit)                                     // login
+	g.GET("/users/me", userHandler.GetUserByID, authed...)                                       // Get current user
+	g.PUT("/users/me", userHandler.UpdateUser, authed...)                                        // Update current user
+	g.PATCH("/users/me", userHandler.PatchUser, authed...)                                       // Partially update current user
+	g.PUT("/users/me/password", userHandler.ChangePassword, authed...)                           // Change password of current user
+	g.GET("/users/refresh-token", userHandler.RefreshToken, authLimit)                           // Get user by ID
+	g.GET("/users/verify-email", userHandler.VerifyEmail, authLimit)                             // Verify the email of a user with the emailed link
+	g.POST("/users/me/verification-email", userHandler.SendEmailVerification, authed...)         // Send the email verification link again
+	g.GET("/users/me/notification-preferences", notificationHandler.GetPreference, authed...)    // Get notification preferences
+	g.PUT("/users/me/notification-preferences", notificationHandler.UpdatePreference, authed...) // Update notification preferences
 
 	// Campaign routes
 	// g.GET("/campaign", campaignHandler.GetAllCampaign)      // Get all campaigns
@@ -53,6 +176,22 @@ func ExecRouter() {
 	// g.POST("/campaign/:id", campaignHandler.CreateCampaign) // Create campaign
 	// g.PUT("/campaign/:id", campaignHandler.UpdateCampaign)  // Update campaign by ID
 
+	// Public campaign routes, no authentication
+	g.GET("/campaigns/:id/donations", publicHandler.GetCampaignDonations)         // Donor wall of a campaign
+	g.GET("/campaigns/:id/top-donors", publicHandler.GetCampaignTopDonors)        // Top donors of a campaign
+	g.GET("/campaigns/:id/progress/stream", publicHandler.StreamCampaignProgress) // Live campaign progress as Server-Sent Events
+	g.GET("/leaderboard", publicHandler.GetLeaderboard)                           // Top donors and campaigns per time window
+
+	// Campaign stats, for the campaign owner and admins
+	g.GET("/campaigns/:id/stats", statsHandler.GetCampaignStats, authed...) // Totals and donations per day or week of a campaign
+
+	// Partner webhook routes, for campaign owners
+	g.POST("/webhooks", webhookHandler.CreateSubscription, authed...)                              // Subscribe a webhook to campaign events
+	g.GET("/webhooks", webhookHandler.GetSubscriptions, authed...)                                 // Get webhook subscriptions
+	g.DELETE("/webhooks/:id", webhookHandler.DeleteSubscription, authed...)                        // Delete a webhook subscription
+	g.GET("/webhooks/:id/deliveries", webhookHandler.GetDeliveries, authed...)                     // Delivery log of a webhook
+	g.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver, authed...) // Send a delivery again
+
 	// Blog routes
 	// g.GET("/blogs", blogHandler.GetAllBlog)      //
 	// g.GET("/blogs/:id", blogHandler.GetBlogById) //
@@ -60,28 +199,64 @@ func ExecRouter() {
 	// g.PUT("/blogs/:id", blogHandler.UpdateBlog)  //
 
 	// Donation routes
-	g.GET("/donations", donationHandler.GetAllDonations, mw.CheckAuthMiddleware)     // Get all donations
-	g.GET("/donations/:id", donationHandler.GetDonationByID, mw.CheckAuthMiddleware) // Get donation by ID
-	g.POST("/donations", donationHandler.CreateDonation, mw.CheckAuthMiddleware)     // Create donation
-	g.PUT("/donations/:id", donationHandler.UpdateDonation, mw.CheckAuthMiddleware)  // Update donation by ID
+	g.GET("/donations", donationHandler.GetAllDonations, authed...)                            // Get all donations
+	g.GET("/donations/:id", donationHandler.GetDonationByID, authed...)                        // Get donation by ID
+	g.POST("/donations", donationHandler.CreateDonation, authedPayment...)                     // Create donation
+	g.PUT("/donations/:id", donationHandler.UpdateDonation, authed...)                         // Update donation by ID
+	g.PATCH("/donations/:id", donationHandler.PatchDonation, authed...)                        // Partially update donation by ID
+	g.POST("/donations/guest", donationHandler.CreateGuestDonation, paymentLimit)              // Create donation without an account
+	g.POST("/donations/claim", donationHandler.ClaimGuestDonations, authed...)                 // Claim guest donations made with the user email
+	g.GET("/donations/:id/receipt", donationHandler.GetDonationReceipt, authed...)             // Download the PDF receipt of a paid donation
+	g.POST("/donations/:id/receipt/guest", donationHandler.GetGuestDonationReceipt, authLimit) // Download the receipt of a guest donation
+	g.GET("/donations/receipts/:year", donationHandler.GetAnnualReceipt, authed...)            // Download the annual donation summary
 
 	// Transaction routes
-	g.GET("/transactions", transHandler.GetAllTransaction, mw.CheckAuthMiddleware)                    // Get all transactions for a user
-	g.GET("/transactions/:id", transHandler.GetTransactionByID, mw.CheckAuthMiddleware)               // Get transaction by ID for a user
-	g.POST("/transactions", transHandler.CreateTransaction, mw.CheckAuthMiddleware)                   // Create a new transaction
-	g.PUT("/transactions/:id", transHandler.UpdateTransaction, mw.CheckAuthMiddleware)                // Update transaction by ID, confirm to complete the transaction
-	g.PUT("/transactions/sync-transaction/:id", transHandler.SyncTransaction, mw.CheckAuthMiddleware) // Check and update transaction by ID
+	g.GET("/transactions", transHandler.GetAllTransaction, authed...)                                    // Get all transactions for a user
+	g.GET("/transactions/:id", transHandler.GetTransactionByID, authed...)                               // Get transaction by ID for a user
+	g.POST("/transactions", transHandler.CreateTransaction, authedPayment...)                            // Create a new transaction
+	g.PUT("/transactions/:id", transHandler.UpdateTransaction, authed...)                                // Update transaction by ID, confirm to complete the transaction
+	g.PUT("/transactions/sync-transaction/:id", transHandler.SyncTransaction, authedPayment...)          // Check and update transaction by ID
+	g.POST("/transactions/:id/reissue", transHandler.ReissueInvoice, authedPayment...)                   // Reissue the invoice of an expired transaction
+	g.POST("/transactions/:id/reissue/guest", transHandler.ReissueGuestInvoice, authLimit, paymentLimit) // Reissue the invoice of an expired guest transaction
 
 	// Scheduler routes
 	// g.GET("/scheduler/update-transaction-status", schedulerHandler.updatePendingTransaction)   // update transaction status on pending transaction
 	// g.GET("/scheduler/update-campaign-status", schedulerHandler.updateCampaignStatus) // update campaign to completed if the campaign deadline is reached
 
 	// Start server
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080" // Default port if not set in environment variables
+	e.Server.ReadHeaderTimeout = config.App.HTTP.ReadHeaderTimeout
+	e.Server.ReadTimeout = config.App.HTTP.ReadTimeout
+	e.Server.IdleTimeout = config.App.HTTP.IdleTimeout
+	errs := make(chan error, 1)
+	go func() {
+		errs <- e.Start(fmt.Sprintf(":%d", config.App.Port))
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down, draining in-flight requests")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.App.ShutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		return err
 	}
-	e.Logger.Fatal(e.Start(":" + port))
+	if err := <-errs; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// isProbe tells the probe and scrape requests apart, they are not traced.
+func isProbe(c echo.Context) bool {
+	switch c.Path() {
+	case "/healthz", "/readyz", "/metrics":
+		return true
+	}
+	return false
 }
 
 func rootShow(c echo.Context) error {
@@ -104,3 +279,9 @@ func rootShow(c echo.Context) error {
 
 	return c.JSON(200, response)
 }
+
+// isStream tells the Server-Sent Events streams apart, they stay open past the
+// request timeout.
+func isStream(c echo.Context) bool {
+	return c.Path() == "/api/v1/campaigns/:id/progress/stream"
+}
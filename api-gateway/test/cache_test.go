@@ -0,0 +1,136 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/cache"
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func TestLRU_Expiry(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(10)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Now = func() time.Time { return now }
+
+	assert.NoError(t, c.Set(ctx, "leaderboard:all:10", []byte("cached"), 30*time.Second))
+	value, ok, err := c.Get(ctx, "leaderboard:all:10")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("cached"), value)
+
+	// Check if the entry is gone after the time to live
+	now = now.Add(30 * time.Second)
+	_, ok, _ = c.Get(ctx, "leaderboard:all:10")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewLRU(2)
+
+	// Representing a full cache whose oldest entry was just read
+	c.Set(ctx, "campaign:1:donations:1:20", []byte("1"), time.Minute)
+	c.Set(ctx, "campaign:2:donations:1:20", []byte("2"), time.Minute)
+	c.Get(ctx, "campaign:1:donations:1:20")
+	c.Set(ctx, "campaign:3:donations:1:20", []byte("3"), time.Minute)
+
+	// Check if the entry not read is the one evicted
+	_, ok, _ := c.Get(ctx, "campaign:1:donations:1:20")
+	assert.True(t, ok)
+	_, ok, _ = c.Get(ctx, "campaign:2:donations:1:20")
+	assert.False(t, ok)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestInvalidateOnSettlement(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := cache.NewLRU(10)
+	for _, key := range []string{
+		cache.Key("campaign", 1, "donations", 1, 20),
+		cache.Key("campaign", 1, "top-donors", 10),
+		cache.Key("campaign", 10, "donations", 1, 20),
+		cache.Key("leaderboard", "week", 10),
+		cache.Key("donation", 7, "detail"),
+		cache.Key("donation", 70, "detail"),
+		cache.Key("donations", "all"),
+	} {
+		c.Set(ctx, key, []byte("{}"), time.Minute)
+	}
+
+	// Representing a donation created, then settled, on campaign 1
+	mockRepo := new(repository.MockPublicDonationRepository)
+	mockRepo.On("WatchDonationEvents", mock.Anything, int64(0), mock.Anything).
+		Run(func(args mock.Arguments) { cancel() }).
+		Return([]model.DonationEvent{
+			{EventID: 1, Type: event.DonationCreated, CampaignID: 10, DonationID: 70},
+			{EventID: 2, Type: event.DonationSettled, CampaignID: 1, DonationID: 7},
+		}, errors.New("stream closed"))
+	repository.InvalidateOnSettlement(ctx, mockRepo, c)
+
+	// Check if only what the settlement changed is dropped
+	for key, kept := range map[string]bool{
+		cache.Key("campaign", 1, "donations", 1, 20):  false,
+		cache.Key("campaign", 1, "top-donors", 10):    false,
+		cache.Key("leaderboard", "week", 10):          false,
+		cache.Key("donation", 7, "detail"):            false,
+		cache.Key("donations", "all"):                 false,
+		cache.Key("campaign", 10, "donations", 1, 20): true,
+		cache.Key("donation", 70, "detail"):           true,
+	} {
+		_, ok, _ := c.Get(ctx, key)
+		assert.Equal(t, kept, ok, key)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetCampaignDonations_NotModified(t *testing.T) {
+	mockRepo := new(repository.MockPublicDonationRepository)
+	mockRepo.On("GetCampaignDonations", 1, 1, 20).Return(&model.PublicDonationPage{
+		Donations: []model.PublicDonation{{ID: 1, CampaignID: 1, DonorName: "Andi Wijaya", Amount: 50000}},
+		Page:      1,
+		PageSize:  20,
+		Total:     1,
+	}, nil)
+	h := handler.NewPublicDonationHandler(mockRepo)
+	e := echo.New()
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/campaigns/1/donations", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		assert.NoError(t, h.GetCampaignDonations(c))
+		return rec
+	}
+
+	// Representing a browser revalidating the donor wall it holds
+	first := get("")
+	etag := first.Header().Get("ETag")
+	rec := get(etag)
+
+	// Check if the wall is tagged, and not sent again while unchanged
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.NotEmpty(t, etag)
+	assert.Contains(t, first.Header().Get("Cache-Control"), "public")
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, http.StatusOK, get(`"stale"`).Code)
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func TestGetCampaignStats_Success(t *testing.T) {
+	mockRepo := new(repository.MockCampaignStatsRepository)
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	// Representing a campaign with a donor who gave twice, over two weeks
+	mockStats := &model.CampaignStats{
+		CampaignID: 1, TotalRaised: 350000, DonationCount: 3, DonorCount: 2,
+		AverageDonation: 116666.67, MedianDonation: 100000, RepeatDonorRate: 0.5,
+	}
+	mockSeries := &model.DonationTimeSeries{
+		CampaignID: 1, Interval: "week", Timezone: "Asia/Jakarta",
+		Buckets: []model.TimeSeriesBucket{
+			{Start: time.Date(2025, 6, 2, 0, 0, 0, 0, jakarta), TotalAmount: 150000, DonationCount: 2},
+			{Start: time.Date(2025, 6, 9, 0, 0, 0, 0, jakarta)},
+		},
+	}
+	query := repository.TimeSeriesQuery{Interval: "week", Timezone: "Asia/Jakarta", From: "2025-06-02", To: "2025-06-15"}
+	mockRepo.On("GetCampaignStats", 1, 1, false).Return(mockStats, nil)
+	mockRepo.On("GetDonationTimeSeries", 1, 1, false, query).Return(mockSeries, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/campaigns/1/stats?interval=week&timezone=Asia/Jakarta&from=2025-06-02&to=2025-06-15", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(1))
+	c.Set("is_admin", false)
+
+	err := handler.NewCampaignStatsHandler(mockRepo).GetCampaignStats(c)
+
+	// Check if the stats and the buckets, at local midnight, are returned privately
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"repeat_donor_rate":0.5`)
+	assert.Contains(t, rec.Body.String(), `"start":"2025-06-09T00:00:00+07:00"`)
+	assert.Equal(t, "private, no-cache", rec.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetCampaignStats_NotOwner(t *testing.T) {
+	mockRepo := new(repository.MockCampaignStatsRepository)
+
+	// donation-service refuses users who do not own the campaign
+	mockRepo.On("GetCampaignStats", 1, 2, false).Return(nil, status.Error(codes.PermissionDenied, "campaign is not owned by the user"))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/campaigns/1/stats", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(2))
+	c.Set("is_admin", false)
+
+	err := handler.NewCampaignStatsHandler(mockRepo).GetCampaignStats(c)
+
+	// Check if the stats are forbidden and the time series is not asked for
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockRepo.AssertNotCalled(t, "GetDonationTimeSeries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetCampaignStats_Admin(t *testing.T) {
+	mockRepo := new(repository.MockCampaignStatsRepository)
+
+	// Representing an admin looking at a campaign of another user
+	mockRepo.On("GetCampaignStats", 1, 9, true).Return(&model.CampaignStats{CampaignID: 1}, nil)
+	mockRepo.On("GetDonationTimeSeries", 1, 9, true, repository.TimeSeriesQuery{}).Return(&model.DonationTimeSeries{CampaignID: 1, Interval: "day", Timezone: "UTC"}, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/campaigns/1/stats", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(9))
+	c.Set("is_admin", true)
+
+	err := handler.NewCampaignStatsHandler(mockRepo).GetCampaignStats(c)
+
+	// Check if the admin flag reaches donation-service
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetCampaignStats_InvalidInterval(t *testing.T) {
+	mockRepo := new(repository.MockCampaignStatsRepository)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/campaigns/1/stats?interval=month", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(1))
+
+	err := handler.NewCampaignStatsHandler(mockRepo).GetCampaignStats(c)
+
+	// Check if the interval is refused before donation-service is called
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestConfig_IsAdmin(t *testing.T) {
+	cfg := validHTTPConfig()
+	cfg.AdminUserIDs = []string{"3", " 7"}
+	assert.NoError(t, cfg.Validate())
+
+	// Check if only the listed users are admins
+	assert.True(t, cfg.IsAdmin(3))
+	assert.True(t, cfg.IsAdmin(7))
+	assert.False(t, cfg.IsAdmin(37))
+
+	// Check if an ID that is not a user ID is refused
+	cfg.AdminUserIDs = []string{"admin"}
+	assert.Error(t, cfg.Validate())
+}
@@ -1,12 +1,20 @@
 package test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/rayhanadri/crowdfunding/donation-service/model"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
 	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
 )
 
@@ -14,20 +22,20 @@ func TestGetDonationByID_Success(t *testing.T) {
 	mockRepo := new(repository.MockDonationRepository)
 
 	mockDonation := model.Donation{
-		ID:          1,
-		UserID:      1,
-		CampaignID:  1,
-		Amount:      50000,
-		MessageText: "Donation for a cause",
-		Status:      "PENDING",
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:         1,
+		UserID:     1,
+		CampaignID: 1,
+		Amount:     50000,
+		Message:    "Donation for a cause",
+		Status:     "PENDING",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 	mockDonationPtr := &mockDonation
 
 	// Representing retrieving a donation by ID from the database
 	mockRepo.On("GetDonationByID", 1).Return(mockDonationPtr, nil)
-	donationPtr, err := mockRepo.GetDonationByID(1)
+	donationPtr, err := mockRepo.GetDonationByID(context.Background(), 1)
 
 	// Check if the donation is retrieved successfully
 	assert.NoError(t, err)
@@ -41,7 +49,7 @@ func TestGetDonationByID_Failed(t *testing.T) {
 
 	// Representing retrieving a donation by ID from the database
 	mockRepo.On("GetDonationByID", 1).Return(nil, assert.AnError)
-	donationPtr, err := mockRepo.GetDonationByID(1)
+	donationPtr, err := mockRepo.GetDonationByID(context.Background(), 1)
 
 	// Check if the donation retrieval failed as expected
 	assert.Error(t, err)
@@ -56,24 +64,24 @@ func TestGetAllDonations_Success(t *testing.T) {
 	// Representing donations retrieved from the database
 	mockDonations := []model.Donation{
 		{
-			ID:          1,
-			UserID:      1,
-			CampaignID:  1,
-			Amount:      50000,
-			MessageText: "Donation for a cause",
-			Status:      "PENDING",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+			ID:         1,
+			UserID:     1,
+			CampaignID: 1,
+			Amount:     50000,
+			Message:    "Donation for a cause",
+			Status:     "PENDING",
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
 		},
 		{
-			ID:          2,
-			UserID:      2,
-			CampaignID:  2,
-			Amount:      50000,
-			MessageText: "Donation for a cause",
-			Status:      "PENDING",
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
+			ID:         2,
+			UserID:     2,
+			CampaignID: 2,
+			Amount:     50000,
+			Message:    "Donation for a cause",
+			Status:     "PENDING",
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
 		},
 	}
 
@@ -81,7 +89,7 @@ func TestGetAllDonations_Success(t *testing.T) {
 
 	// Representing retrieving all donations from the database
 	mockRepo.On("GetAllDonation").Return(mockDonationsPtr, nil)
-	donations, err := mockRepo.GetAllDonation()
+	donations, err := mockRepo.GetAllDonation(context.Background())
 
 	// Check if the donations are retrieved successfully
 	assert.NoError(t, err)
@@ -95,7 +103,7 @@ func TestGetAllDonations_Failed(t *testing.T) {
 
 	// Representing retrieving all donations from the database
 	mockRepo.On("GetAllDonation").Return(nil, assert.AnError)
-	donations, err := mockRepo.GetAllDonation()
+	donations, err := mockRepo.GetAllDonation(context.Background())
 
 	// Check if the donations retrieval failed as expected
 	assert.Error(t, err)
@@ -109,20 +117,20 @@ func TestCreateDonation_Success(t *testing.T) {
 
 	// Representing a donation created and retrieved from the database
 	mockDonation := model.Donation{
-		ID:          1,
-		UserID:      1,
-		CampaignID:  1,
-		Amount:      50000,
-		MessageText: "Donation for a cause",
-		Status:      "PENDING",
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:         1,
+		UserID:     1,
+		CampaignID: 1,
+		Amount:     50000,
+		Message:    "Donation for a cause",
+		Status:     "PENDING",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 	mockDonationPtr := &mockDonation
 
 	// Representing creating a donation in the database
 	mockRepo.On("CreateDonation", mockDonationPtr).Return(mockDonationPtr, nil)
-	donationPtr, err := mockRepo.CreateDonation(mockDonationPtr)
+	donationPtr, err := mockRepo.CreateDonation(context.Background(), mockDonationPtr)
 
 	// Check if the donation is created successfully
 	assert.NoError(t, err)
@@ -136,19 +144,19 @@ func TestCreateDonation_Failed(t *testing.T) {
 
 	// Representing creating a donation in the database
 	mockDonation := model.Donation{
-		ID:          1,
-		UserID:      1,
-		CampaignID:  1,
-		Amount:      50000,
-		MessageText: "Donation for a cause",
-		Status:      "PENDING",
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:         1,
+		UserID:     1,
+		CampaignID: 1,
+		Amount:     50000,
+		Message:    "Donation for a cause",
+		Status:     "PENDING",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 	mockDonationPtr := &mockDonation
 
 	mockRepo.On("CreateDonation", mockDonationPtr).Return(nil, assert.AnError)
-	donationPtr, err := mockRepo.CreateDonation(mockDonationPtr)
+	donationPtr, err := mockRepo.CreateDonation(context.Background(), mockDonationPtr)
 
 	// Check if the donation creation failed as expected
 	assert.Error(t, err)
@@ -162,20 +170,20 @@ func TestUpdateDonation_Success(t *testing.T) {
 
 	// Representing a donation created and retrieved from the database
 	mockDonation := model.Donation{
-		ID:          1,
-		UserID:      1,
-		CampaignID:  1,
-		Amount:      50000,
-		MessageText: "Donation for a cause",
-		Status:      "PENDING",
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:         1,
+		UserID:     1,
+		CampaignID: 1,
+		Amount:     50000,
+		Message:    "Donation for a cause",
+		Status:     "PENDING",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 	mockDonationPtr := &mockDonation
 
 	// Representing updating a donation in the database
-	mockRepo.On("UpdateDonation", mockDonationPtr).Return(mockDonationPtr, nil)
-	donationPtr, err := mockRepo.UpdateDonation(mockDonationPtr)
+	mockRepo.On("UpdateDonation", mockDonationPtr, []string(nil)).Return(mockDonationPtr, nil)
+	donationPtr, err := mockRepo.UpdateDonation(context.Background(), mockDonationPtr, nil)
 
 	// Check if the donation is updated successfully
 	assert.NoError(t, err)
@@ -189,20 +197,20 @@ func TestUpdateDonation_Failed(t *testing.T) {
 
 	// Representing a donation created and retrieved from the database
 	mockDonation := model.Donation{
-		ID:          1,
-		UserID:      1,
-		CampaignID:  1,
-		Amount:      50000,
-		MessageText: "Donation for a cause",
-		Status:      "PENDING",
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:         1,
+		UserID:     1,
+		CampaignID: 1,
+		Amount:     50000,
+		Message:    "Donation for a cause",
+		Status:     "PENDING",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
 	}
 	mockDonationPtr := &mockDonation
 
 	// Representing updating a donation in the database
-	mockRepo.On("UpdateDonation", mockDonationPtr).Return(nil, assert.AnError)
-	donationPtr, err := mockRepo.UpdateDonation(mockDonationPtr)
+	mockRepo.On("UpdateDonation", mockDonationPtr, []string(nil)).Return(nil, assert.AnError)
+	donationPtr, err := mockRepo.UpdateDonation(context.Background(), mockDonationPtr, nil)
 
 	// Check if the donation update failed as expected
 	assert.Error(t, err)
@@ -210,3 +218,246 @@ func TestUpdateDonation_Failed(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUpdateDonation_SettlementOnlyStatus(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing a donor trying to complete a donation without paying
+	mockDonation := &model.Donation{ID: 1, UserID: 1, Status: model.DonationCompleted}
+	mockRepo.On("UpdateDonation", mockDonation, []string(nil)).
+		Return(nil, status.Error(codes.InvalidArgument, "donation status COMPLETED is only set when its payment settles"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/donations/1", strings.NewReader(`{"status":"COMPLETED"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", "*")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(1))
+
+	err := handler.NewDonationHandler(mockRepo).UpdateDonation(c)
+
+	// Check if the status change is rejected
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "only set when its payment settles")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDonationStatus_Transitions(t *testing.T) {
+	// Check the donation and transaction status transitions
+	assert.True(t, model.DonationPending.CanTransitionTo(model.DonationAbandoned))
+	assert.True(t, model.DonationAbandoned.CanTransitionTo(model.DonationPending))
+	assert.False(t, model.DonationCompleted.CanTransitionTo(model.DonationPending))
+	assert.True(t, model.DonationCompleted.SettlementOnly())
+	assert.True(t, model.DonationAbandoned.ReissueOnly(model.DonationPending))
+	assert.False(t, model.DonationPending.ReissueOnly(model.DonationAbandoned))
+	assert.True(t, model.TransactionPending.CanTransitionTo(model.TransactionExpired))
+	assert.False(t, model.TransactionExpired.CanTransitionTo(model.TransactionPaid))
+	assert.True(t, model.TransactionSettled.SettlementOnly())
+	assert.False(t, model.TransactionStatus("UNKNOWN").Valid())
+
+	// Check the statuses round trip through the proto enums
+	assert.Equal(t, model.DonationAbandoned, model.DonationStatusFromPb(model.DonationAbandoned.ToPb()))
+	assert.Equal(t, model.TransactionSettled, model.TransactionStatusFromPb(model.TransactionSettled.ToPb()))
+	assert.Equal(t, model.TransactionStatus(""), model.TransactionStatusFromPb(model.TransactionStatus("").ToPb()))
+}
+
+func TestCreateGuestDonation_Success(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing a guest donation, there is no user ID
+	mockDonation := model.Donation{
+		CampaignID:  1,
+		Amount:      50000,
+		Message:     "Donation for a cause",
+		IsAnonymous: true,
+		GuestEmail:  "guest@example.com",
+	}
+	mockDonationPtr := &mockDonation
+	mockTransaction := &model.Transaction{
+		ID:         1,
+		DonationID: 1,
+		InvoiceID:  "INV-1",
+		Amount:     50000,
+		Status:     "PENDING",
+	}
+
+	// Representing creating the donation and its invoice
+	mockOption := model.PaymentOption{Method: "INVOICE"}
+	mockRepo.On("CreateGuestDonation", mockDonationPtr, mockOption).Return(mockDonationPtr, mockTransaction, nil)
+	donationPtr, transactionPtr, err := mockRepo.CreateGuestDonation(context.Background(), mockDonationPtr, mockOption)
+
+	// Check if the donation and transaction are created successfully
+	assert.NoError(t, err)
+	assert.NotNil(t, donationPtr)
+	assert.NotNil(t, transactionPtr)
+	assert.True(t, donationPtr.IsGuest())
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestClaimGuestDonations_Success(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing claiming two guest donations
+	mockRepo.On("ClaimGuestDonations", 1, "guest@example.com").Return(2, nil)
+	claimed, err := mockRepo.ClaimGuestDonations(context.Background(), 1, "guest@example.com")
+
+	// Check if the donations are claimed
+	assert.NoError(t, err)
+	assert.Equal(t, 2, claimed)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestClaimGuestDonations_UnverifiedEmail(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing a user who registered with the email of a guest donor
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/v1/donations/claim", nil), rec)
+	c.Set("user_id", float64(2))
+	c.Set("email", "guest@example.com")
+	c.Set("email_verified", false)
+
+	err := handler.NewDonationHandler(mockRepo).ClaimGuestDonations(c)
+
+	// Check if the claim is refused before donation-service is called
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), handler.ReasonEmailNotVerified)
+	mockRepo.AssertNotCalled(t, "ClaimGuestDonations", 2, "guest@example.com")
+}
+
+func TestClaimGuestDonations_VerifiedEmail(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing a user who verified the email of their guest donations
+	mockRepo.On("ClaimGuestDonations", 1, "guest@example.com").Return(2, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/v1/donations/claim", nil), rec)
+	c.Set("user_id", float64(1))
+	c.Set("email", "guest@example.com")
+	c.Set("email_verified", true)
+
+	err := handler.NewDonationHandler(mockRepo).ClaimGuestDonations(c)
+
+	// Check if the donations are claimed
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"claimed_count":2`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMaskDonor_Anonymous(t *testing.T) {
+	donation := model.Donation{
+		ID:          1,
+		UserID:      1,
+		CampaignID:  1,
+		Amount:      50000,
+		IsAnonymous: true,
+		GuestEmail:  "guest@example.com",
+		Donor:       &model.DonorSummary{ID: 1, Name: "Andi Wijaya", MaskedEmail: "a***@example.com"},
+	}
+
+	donation.MaskDonor()
+
+	// Check if the donor identity is hidden
+	assert.Equal(t, 0, donation.UserID)
+	assert.Empty(t, donation.GuestEmail)
+	assert.Nil(t, donation.Donor)
+	assert.Equal(t, 50000.0, donation.Amount)
+}
+
+func TestGetDonationReceipt_Success(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing the receipt of a paid donation of user 1
+	mockReceipt := &model.ReceiptFile{
+		Number:     "RCP-2025-000001",
+		DonationID: 1,
+		UserID:     1,
+		FileName:   "receipt-RCP-2025-000001.pdf",
+		PDF:        []byte("%PDF-1.4"),
+		IssuedAt:   time.Now(),
+	}
+
+	mockRepo.On("GetDonationReceipt", 1, 1).Return(mockReceipt, nil)
+	mockRepo.On("GetDonationReceipt", 1, 2).Return(nil, status.Error(codes.NotFound, "donation not found"))
+
+	e := echo.New()
+	for _, tc := range []struct {
+		userID float64
+		status int
+	}{
+		{userID: 1, status: http.StatusOK},
+		{userID: 2, status: http.StatusNotFound},
+	} {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/donations/1/receipt", nil), rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		c.Set("user_id", tc.userID)
+
+		err := handler.NewDonationHandler(mockRepo).GetDonationReceipt(c)
+
+		// Check if only the owner of the donation gets the PDF
+		assert.NoError(t, err)
+		assert.Equal(t, tc.status, rec.Code)
+		if tc.status == http.StatusOK {
+			assert.Equal(t, "application/pdf", rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, "%PDF-1.4", rec.Body.String())
+		}
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetGuestDonationReceipt(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing the receipt of a paid guest donation
+	mockReceipt := &model.ReceiptFile{
+		Number:     "RCP-2025-000002",
+		DonationID: 2,
+		FileName:   "receipt-RCP-2025-000002.pdf",
+		PDF:        []byte("%PDF-1.4"),
+		IssuedAt:   time.Now(),
+	}
+
+	mockRepo.On("GetGuestDonationReceipt", 2, "guest@example.com", "inv-1").Return(mockReceipt, nil)
+	mockRepo.On("GetGuestDonationReceipt", 2, "guest@example.com", "inv-2").Return(nil, status.Error(codes.NotFound, "donation not found"))
+
+	e := echo.New()
+	for _, tc := range []struct {
+		body   string
+		status int
+	}{
+		{body: `{"email":"guest@example.com","invoice_id":"inv-1"}`, status: http.StatusOK},
+		{body: `{"email":"guest@example.com","invoice_id":"inv-2"}`, status: http.StatusNotFound},
+		{body: `{"email":"guest@example.com"}`, status: http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/donations/2/receipt/guest", strings.NewReader(tc.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("2")
+
+		err := handler.NewDonationHandler(mockRepo).GetGuestDonationReceipt(c)
+
+		// Check if only the guest with the invoice ID gets the PDF
+		assert.NoError(t, err)
+		assert.Equal(t, tc.status, rec.Code)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func TestGetTransactionByID_SetsETag(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a transaction updated twice since it was created
+	mockRepo.On("GetTransactionByID", 3, 7).Return(&model.Transaction{ID: 3, DonationID: 1, Version: 3}, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/transactions/3", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).GetTransactionByID(c)
+
+	// Check if the version is the ETag
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateDonation_RequiresIfMatch(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/donations/1", strings.NewReader(`{"message":"Get well soon"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(1))
+
+	err := handler.NewDonationHandler(mockRepo).UpdateDonation(c)
+
+	// Check if a write without If-Match is refused before reaching donation-service
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+
+	var problem entity.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, handler.ReasonPreconditionRequired, problem.Reason)
+
+	mockRepo.AssertNotCalled(t, "UpdateDonation", mock.Anything, mock.Anything)
+}
+
+func TestPatchUser_WeakETag(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(`{"name":"Jane Doe"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `W/"4"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewUserHandler(mockRepo).PatchUser(c)
+
+	// Check if an ETag the gateway never sends cannot match
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransaction_VersionMismatch(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a payment callback settling the transaction in the meantime
+	mockRepo.On("UpdateTransaction", &model.Transaction{ID: 3, Status: model.TransactionFailed, Version: 2}, 7).
+		Return(nil, optimistic.Mismatch("transaction"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/transactions/3", strings.NewReader(`{"status":"FAILED"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).UpdateTransaction(c)
+
+	// Check if the lost update is a 412
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	var problem entity.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, optimistic.ReasonVersionMismatch, problem.Reason)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/health"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+)
+
+func healthy(ctx context.Context) error { return nil }
+
+func TestReadyz_AllServing(t *testing.T) {
+	h := handler.NewHealthHandler(map[string]health.Check{
+		"user-service":     healthy,
+		"donation-service": healthy,
+	})
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), rec)
+
+	err := h.Readyz(c)
+
+	// Check if the gateway is ready when every backend is serving
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body entity.Health
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "ok", body.Status)
+	assert.Equal(t, map[string]string{"user-service": "ok", "donation-service": "ok"}, body.Checks)
+}
+
+func TestReadyz_BackendDown(t *testing.T) {
+	// Representing donation-service reporting NOT_SERVING while its database is down
+	h := handler.NewHealthHandler(map[string]health.Check{
+		"user-service": healthy,
+		"donation-service": func(ctx context.Context) error {
+			return errors.New("status NOT_SERVING")
+		},
+	})
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), rec)
+
+	err := h.Readyz(c)
+
+	// Check if no traffic is routed to a gateway whose backend is down
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var body entity.Health
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "unavailable", body.Status)
+	assert.Equal(t, "ok", body.Checks["user-service"])
+	assert.Equal(t, "status NOT_SERVING", body.Checks["donation-service"])
+}
+
+func TestHealthz_IgnoresBackends(t *testing.T) {
+	h := handler.NewHealthHandler(map[string]health.Check{
+		"donation-service": func(ctx context.Context) error {
+			return errors.New("unreachable")
+		},
+	})
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/healthz", nil), rec)
+
+	err := h.Healthz(c)
+
+	// Check if liveness does not restart the gateway for a backend outage
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
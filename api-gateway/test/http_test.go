@@ -0,0 +1,120 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/logging"
+	"github.com/rayhanadri/crowdfunding/common/tracing"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/config"
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/mw"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func bodyLimitedServer() *echo.Echo {
+	e := echo.New()
+	e.Use(mw.BodyLimitMiddleware(64, map[string]int64{"/api/v1/callbacks/xendit/invoice": 1024}))
+	e.POST("/api/v1/donations/guest", handler.NewDonationHandler(new(repository.MockDonationRepository)).CreateGuestDonation)
+	e.POST("/api/v1/callbacks/xendit/invoice", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	return e
+}
+
+func TestBodyLimit_RefusesLargeBodies(t *testing.T) {
+	e := bodyLimitedServer()
+	body := `{"campaign_id":1,"amount":50000,"message":"` + strings.Repeat("a", 100) + `"}`
+
+	// Representing a body announced over the default limit, and the same
+	// body on a route with a higher limit
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/donations/guest", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	callback := httptest.NewRequest(http.MethodPost, "/api/v1/callbacks/xendit/invoice", strings.NewReader(body))
+	callbackRec := httptest.NewRecorder()
+	e.ServeHTTP(callbackRec, callback)
+
+	// Check if only the first is refused, as a problem
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, handler.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	var problem entity.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, handler.ReasonPayloadTooLarge, problem.Reason)
+	assert.Equal(t, "/api/v1/donations/guest", problem.Instance)
+
+	assert.Equal(t, http.StatusNoContent, callbackRec.Code)
+}
+
+func TestBodyLimit_RefusesLargeChunkedBodies(t *testing.T) {
+	e := bodyLimitedServer()
+
+	// Representing a body over the limit sent without a Content-Length
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/donations/guest",
+		strings.NewReader(`{"campaign_id":1,"amount":50000,"message":"`+strings.Repeat("a", 100)+`"}`))
+	req.ContentLength = -1
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// Check if reading the body stops at the limit, with the same problem
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	var problem entity.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, handler.ReasonPayloadTooLarge, problem.Reason)
+}
+
+func validHTTPConfig() config.Config {
+	return config.Config{
+		Port:            8080,
+		ShutdownTimeout: 1,
+		JWT:             config.JWT{AccessKey: "access", RefreshKey: "refresh", AccessTTL: 1, RefreshTTL: 1},
+		HTTP: config.HTTP{
+			Security:          config.Security{FrameOptions: "DENY"},
+			BodyLimit:         "64K",
+			BodyLimits:        []string{"/api/v1/callbacks/xendit/invoice=256K"},
+			RequestTimeout:    1,
+			ReadHeaderTimeout: 1,
+			ReadTimeout:       1,
+			IdleTimeout:       1,
+		},
+		Log:     logging.Config{Level: "info", Format: logging.FormatJSON},
+		Tracing: tracing.Config{Exporter: tracing.ExporterNone, SampleRatio: 1},
+	}
+}
+
+func TestHTTPConfig_Validate(t *testing.T) {
+	cfg := validHTTPConfig()
+	assert.NoError(t, cfg.Validate())
+	limit, routes, err := cfg.HTTP.Limits()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(64000), limit)
+	assert.Equal(t, map[string]int64{"/api/v1/callbacks/xendit/invoice": 256000}, routes)
+
+	// Representing configurations a browser or the router would misread
+	for name, change := range map[string]func(c *config.Config){
+		"any origin with credentials": func(c *config.Config) {
+			c.HTTP.CORS = config.CORS{AllowOrigins: []string{"*"}, AllowCredentials: true}
+		},
+		"origin without scheme": func(c *config.Config) { c.HTTP.CORS.AllowOrigins = []string{"app.example.com"} },
+		"frame options":         func(c *config.Config) { c.HTTP.Security.FrameOptions = "ALLOW-FROM https://example.com" },
+		"body limit":            func(c *config.Config) { c.HTTP.BodyLimit = "lots" },
+		"route body limit":      func(c *config.Config) { c.HTTP.BodyLimits = []string{"/api/v1/donations"} },
+		"request timeout":       func(c *config.Config) { c.HTTP.RequestTimeout = 0 },
+	} {
+		cfg := validHTTPConfig()
+		change(&cfg)
+
+		// Check if each is refused
+		assert.Error(t, cfg.Validate(), name)
+	}
+}
@@ -0,0 +1,113 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/logging"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/mw"
+)
+
+// captureLogs makes the default logger write to the returned buffer for the
+// duration of the test.
+func captureLogs(t *testing.T, cfg logging.Config) *bytes.Buffer {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buf, "api-gateway", cfg))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestRequestID_LoggedAndRedacted(t *testing.T) {
+	logs := captureLogs(t, logging.Config{Level: "info", Format: logging.FormatJSON})
+
+	e := echo.New()
+	e.Use(mw.RequestIDMiddleware)
+	e.Use(mw.LoggerMiddleware)
+	e.POST("/api/v1/users/login", func(c echo.Context) error {
+		slog.InfoContext(c.Request().Context(), "login of jane.doe@example.com", "password", "hunter2",
+			"invoice_url", "https://checkout.xendit.co/web/123")
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	// Representing a client sending its own request ID
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/login?token=secret-token", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-123")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// Check if the ID is returned and logged, and nothing personal is logged
+	assert.Equal(t, "req-123", rec.Header().Get(echo.HeaderXRequestID))
+	assert.Contains(t, logs.String(), `"msg":"login of j***@example.com"`)
+	assert.Contains(t, logs.String(), `"msg":"request"`)
+	assert.Contains(t, logs.String(), `"route":"/api/v1/users/login"`)
+	assert.Equal(t, 2, bytes.Count(logs.Bytes(), []byte(`"request_id":"req-123"`)))
+	assert.NotContains(t, logs.String(), "jane.doe")
+	assert.NotContains(t, logs.String(), "hunter2")
+	assert.NotContains(t, logs.String(), "checkout.xendit.co")
+	assert.NotContains(t, logs.String(), "secret-token")
+}
+
+func TestRequestID_ReplacesInvalidID(t *testing.T) {
+	captureLogs(t, logging.Config{Level: "info", Format: logging.FormatJSON})
+
+	e := echo.New()
+	e.Use(mw.RequestIDMiddleware)
+	e.GET("/", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	// Representing a client sending an ID with a line break
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "forged\nlevel=ERROR")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// Check if a new ID is generated instead
+	assert.Regexp(t, `^[0-9a-f]{32}$`, rec.Header().Get(echo.HeaderXRequestID))
+}
+
+func TestRequestID_SentInGRPCMetadata(t *testing.T) {
+	ctx := logging.WithRequestID(context.Background(), "req-123")
+
+	// Representing a call to a service, the invoker sees the outgoing metadata
+	var sent []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(logging.MetadataKey)
+		return nil
+	}
+	err := logging.UnaryClientInterceptor()(ctx, "/donation.DonationService/GetDonationByID", nil, nil, nil, invoker)
+
+	// Check if the request ID travels with the call
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"req-123"}, sent)
+}
+
+func TestLogLevels_PerPackage(t *testing.T) {
+	logs := captureLogs(t, logging.Config{Level: "warn", Levels: []string{"api-gateway/test=debug"}, Format: logging.FormatJSON})
+
+	e := echo.New()
+	e.Use(mw.LoggerMiddleware)
+	e.GET("/", func(c echo.Context) error {
+		slog.DebugContext(c.Request().Context(), "handled")
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	// Representing a debug record of this package and an info record of the
+	// mw package
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Check if only the package with its own level logs below warn
+	assert.Contains(t, logs.String(), `"msg":"handled"`)
+	assert.NotContains(t, logs.String(), `"msg":"request"`)
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/metrics"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/mw"
+)
+
+func TestMetricsMiddleware_LabelsByRoute(t *testing.T) {
+	e := echo.New()
+	e.Use(mw.MetricsMiddleware)
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+	e.GET("/api/v1/campaigns/:id/donors", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.GET("/api/v1/campaigns/:id/leaderboard", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, "campaign not found")
+	})
+
+	// Representing requests for two campaigns and one for a campaign that does not exist
+	for _, path := range []string{"/api/v1/campaigns/1/donors", "/api/v1/campaigns/2/donors", "/api/v1/campaigns/3/leaderboard"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// Check if requests are counted by route template, errors with their status
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `http_requests_total{method="GET",route="/api/v1/campaigns/:id/donors",status="204"} 2`)
+	assert.Contains(t, rec.Body.String(), `http_requests_total{method="GET",route="/api/v1/campaigns/:id/leaderboard",status="404"} 1`)
+	assert.Contains(t, rec.Body.String(), `http_request_duration_seconds_count{method="GET",route="/api/v1/campaigns/:id/donors"} 2`)
+	assert.NotContains(t, rec.Body.String(), `/api/v1/campaigns/1/donors`)
+}
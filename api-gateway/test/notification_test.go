@@ -0,0 +1,68 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func TestGetNotificationPreference_Success(t *testing.T) {
+	mockRepo := new(repository.MockNotificationRepository)
+
+	// Representing a user without a stored preference
+	mockPreference := &model.NotificationPreference{UserID: 1, Locale: "id", EmailEnabled: true}
+
+	mockRepo.On("GetPreference", 1).Return(mockPreference, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/users/me/notification-preferences", nil), rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewNotificationHandler(mockRepo).GetPreference(c)
+
+	// Check if the defaults are returned with an empty muted list
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"locale":"id"`)
+	assert.Contains(t, rec.Body.String(), `"muted_events":[]`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateNotificationPreference_Success(t *testing.T) {
+	mockRepo := new(repository.MockNotificationRepository)
+
+	// Representing an English speaking user who mutes donation created emails
+	mockPreference := &model.NotificationPreference{UserID: 1, Locale: "en", EmailEnabled: true, MutedEvents: "donation.created"}
+
+	mockRepo.On("UpdatePreference", mock.MatchedBy(func(p *model.NotificationPreference) bool {
+		return p.UserID == 1 && p.Locale == "en" && p.MutedEvents == "donation.created"
+	})).Return(mockPreference, nil)
+
+	e := echo.New()
+	body := `{"locale":"en","email_enabled":true,"muted_events":["donation.created"]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/notification-preferences", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewNotificationHandler(mockRepo).UpdatePreference(c)
+
+	// Check if the preference is stored for the current user
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"muted_events":["donation.created"]`)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func TestCreateDonation_FieldViolations(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing donation-service rejecting the donation fields
+	mockRepo.On("CreateDonation", mock.Anything).Return(nil, apperror.InvalidArgument("invalid donation",
+		apperror.FieldViolation{Field: "campaign_id", Description: "campaign is not active"},
+	))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/donations", strings.NewReader(`{"campaign_id":1,"amount":50000}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewDonationHandler(mockRepo).CreateDonation(c)
+
+	// Check if the invalid field is reported as problem details
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handler.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var problem entity.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, apperror.ReasonInvalidArgument, problem.Reason)
+	assert.Equal(t, "/api/v1/donations", problem.Instance)
+	assert.Equal(t, []entity.FieldError{{Field: "campaign_id", Message: "campaign is not active"}}, problem.Errors)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetDonationByID_NotFoundProblem(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing a donation missing in donation-service
+	mockRepo.On("GetDonationByID", 9).Return(nil, apperror.NotFound("DONATION_NOT_FOUND", "donation not found"))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/donations/9", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("9")
+	c.Set("user_id", float64(1))
+
+	err := handler.NewDonationHandler(mockRepo).GetDonationByID(c)
+
+	// Check if the missing donation is a 404 with its reason
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var problem entity.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "DONATION_NOT_FOUND", problem.Reason)
+	assert.Equal(t, "donation not found", problem.Detail)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNewProblem_HidesUnknownErrors(t *testing.T) {
+	// Representing a failure that does not come from a service, such as a failed dial
+	problem := handler.NewProblem(errors.New("dial tcp 10.0.0.1:443: connection refused"))
+
+	// Check if the cause is not revealed
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.NotContains(t, problem.Detail, "10.0.0.1")
+}
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func TestGetCampaignDonations_Success(t *testing.T) {
+	mockRepo := new(repository.MockPublicDonationRepository)
+
+	// Representing the donor wall of a campaign, the second donor is anonymous
+	mockPage := &model.PublicDonationPage{
+		Donations: []model.PublicDonation{
+			{ID: 1, CampaignID: 1, UserID: 1, DonorName: "Andi Wijaya", Amount: 50000, CreatedAt: time.Now()},
+			{ID: 2, CampaignID: 1, DonorName: model.AnonymousDonorName, Amount: 25000, IsAnonymous: true, CreatedAt: time.Now()},
+		},
+		Page:     1,
+		PageSize: 20,
+		Total:    2,
+	}
+
+	mockRepo.On("GetCampaignDonations", 1, 1, 20).Return(mockPage, nil)
+	page, err := mockRepo.GetCampaignDonations(context.Background(), 1, 1, 20)
+
+	// Check if the donor wall is retrieved successfully
+	assert.NoError(t, err)
+	assert.Len(t, page.Donations, 2)
+	assert.Equal(t, 0, page.Donations[1].UserID)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetLeaderboard_Failed(t *testing.T) {
+	mockRepo := new(repository.MockPublicDonationRepository)
+
+	mockRepo.On("GetLeaderboard", "week", 10).Return(nil, assert.AnError)
+	leaderboard, err := mockRepo.GetLeaderboard(context.Background(), "week", 10)
+
+	// Check if the leaderboard retrieval failed as expected
+	assert.Error(t, err)
+	assert.Nil(t, leaderboard)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStreamCampaignProgress_Success(t *testing.T) {
+	mockRepo := new(repository.MockPublicDonationRepository)
+
+	// Representing a snapshot followed by one settled donation
+	mockEvents := []model.CampaignProgress{
+		{EventID: 41, CampaignID: 1, TotalRaised: 50000, DonationCount: 1, DonorCount: 1},
+		{EventID: 42, CampaignID: 1, DonationID: 7, Amount: 25000, TotalRaised: 75000, DonationCount: 2, DonorCount: 2, Source: "webhook"},
+	}
+
+	mockRepo.On("WatchCampaignProgress", mock.Anything, 1, int64(40), mock.Anything).Return(mockEvents, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/campaigns/1/progress/stream", nil)
+	req.Header.Set("Last-Event-ID", "40")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	err := handler.NewPublicDonationHandler(mockRepo).StreamCampaignProgress(c)
+
+	// Check if both events are written to the stream in order
+	assert.NoError(t, err)
+	assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+	body := rec.Body.String()
+	assert.Contains(t, body, "id: 41\nevent: progress\n")
+	assert.Contains(t, body, "id: 42\nevent: progress\n")
+	assert.Less(t, strings.Index(body, "id: 41"), strings.Index(body, "id: 42"))
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/mw"
+	"github.com/rayhanadri/crowdfunding/api-gateway/ratelimit"
+)
+
+func rateLimitedServer(store ratelimit.Store, rate ratelimit.Rate, key mw.KeyFunc) *echo.Echo {
+	e := echo.New()
+	e.POST("/api/v1/users/login", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// representing CheckAuthMiddleware for requests with a user
+			if userID := c.Request().Header.Get("X-Test-User"); userID != "" {
+				c.Set("user_id", userID)
+			}
+			return next(c)
+		}
+	}, mw.RateLimitMiddleware(store, "auth", rate, key))
+	return e
+}
+
+func login(e *echo.Echo, ip string, userID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/login", nil)
+	req.RemoteAddr = ip + ":1234"
+	if userID != "" {
+		req.Header.Set("X-Test-User", userID)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_TooManyRequests(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.Now = func() time.Time { return now }
+	e := rateLimitedServer(store, ratelimit.Rate{Limit: 2, Period: time.Minute}, mw.ByIP)
+
+	// Representing a client logging in three times in a row
+	first := login(e, "203.0.113.7", "")
+	login(e, "203.0.113.7", "")
+	rec := login(e, "203.0.113.7", "")
+
+	// Check if the third attempt is refused with the standard headers
+	assert.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, "2", first.Header().Get(mw.HeaderRateLimitLimit))
+	assert.Equal(t, "1", first.Header().Get(mw.HeaderRateLimitRemaining))
+	assert.Equal(t, "30", first.Header().Get(mw.HeaderRateLimitReset))
+	assert.Equal(t, "2;w=60", first.Header().Get(mw.HeaderRateLimitPolicy))
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get(mw.HeaderRateLimitRemaining))
+	assert.Equal(t, "30", rec.Header().Get(echo.HeaderRetryAfter))
+	var problem entity.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, mw.ReasonRateLimited, problem.Reason)
+
+	// Check if another client is not affected, and the bucket refills
+	assert.Equal(t, http.StatusNoContent, login(e, "198.51.100.1", "").Code)
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, http.StatusNoContent, login(e, "203.0.113.7", "").Code)
+}
+
+func TestRateLimit_ByUser(t *testing.T) {
+	e := rateLimitedServer(ratelimit.NewMemoryStore(), ratelimit.Rate{Limit: 1, Period: time.Minute}, mw.ByUser)
+
+	// Representing two users behind the same IP
+	assert.Equal(t, http.StatusNoContent, login(e, "203.0.113.7", "1").Code)
+	assert.Equal(t, http.StatusNoContent, login(e, "203.0.113.7", "2").Code)
+
+	// Check if each user has their own bucket
+	assert.Equal(t, http.StatusTooManyRequests, login(e, "203.0.113.7", "1").Code)
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, rate ratelimit.Rate) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func TestRateLimit_StoreFailureLetsRequestsThrough(t *testing.T) {
+	e := rateLimitedServer(failingStore{}, ratelimit.Rate{Limit: 1, Period: time.Minute}, mw.ByIP)
+
+	// Representing a distributed store that cannot be reached
+	login(e, "203.0.113.7", "")
+	rec := login(e, "203.0.113.7", "")
+
+	// Check if the API keeps answering without the limit
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get(mw.HeaderRateLimitLimit))
+}
+
+func TestRate_UnmarshalText(t *testing.T) {
+	var rate ratelimit.Rate
+	assert.NoError(t, rate.UnmarshalText([]byte("10/1m")))
+	assert.Equal(t, ratelimit.Rate{Limit: 10, Period: time.Minute}, rate)
+	assert.Equal(t, "10/1m", rate.String())
+	assert.Equal(t, "5/90s", ratelimit.Rate{Limit: 5, Period: 90 * time.Second}.String())
+
+	for _, invalid := range []string{"", "10", "0/1m", "ten/1m", "10/soon", "10/-1m"} {
+		assert.Error(t, rate.UnmarshalText([]byte(invalid)), invalid)
+	}
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_ContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	_, err := tracing.Setup(context.Background(), "api-gateway", tracing.Config{Exporter: tracing.ExporterNone, SampleRatio: 1})
+	assert.NoError(t, err)
+
+	// Representing a backend the gateway calls while handling the request
+	var forwarded string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = r.Header.Get("traceparent")
+	}))
+	defer backend.Close()
+	client := &http.Client{Transport: tracing.Transport(nil)}
+
+	e := echo.New()
+	e.Use(otelecho.Middleware("api-gateway"))
+	e.GET("/api/v1/donations/:id", func(c echo.Context) error {
+		req, _ := http.NewRequestWithContext(c.Request().Context(), http.MethodGet, backend.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/donations/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// Check if the request joins the trace of the caller and passes it on
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, forwarded, "4bf92f3577b34da6a3ce929d0e0e4736")
+	var server sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "GET /api/v1/donations/:id" {
+			server = span
+		}
+	}
+	if assert.NotNil(t, server) {
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	}
+}
@@ -1,12 +1,21 @@
 package test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/rayhanadri/crowdfunding/donation-service/model"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/rayhanadri/crowdfunding/api-gateway/config"
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
 	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
 )
 
@@ -31,8 +40,8 @@ func TestGetTransactionByID_Success(t *testing.T) {
 	mockTransactionPtr := &mockTransaction
 
 	// Representing retrieving a transaction by ID from the database
-	mockRepo.On("GetTransactionByID", 1).Return(mockTransactionPtr, nil)
-	transactionPtr, err := mockRepo.GetTransactionByID(1)
+	mockRepo.On("GetTransactionByID", 1, 1).Return(mockTransactionPtr, nil)
+	transactionPtr, err := mockRepo.GetTransactionByID(context.Background(), 1, 1)
 
 	// Check if the transaction is retrieved successfully
 	assert.NoError(t, err)
@@ -45,8 +54,8 @@ func TestGetTransactionByID_Failed(t *testing.T) {
 	mockRepo := new(repository.MockTransactionRepository)
 
 	// Representing retrieving a transaction by ID from the database
-	mockRepo.On("GetTransactionByID", 1).Return(nil, assert.AnError)
-	transactionPtr, err := mockRepo.GetTransactionByID(1)
+	mockRepo.On("GetTransactionByID", 1, 1).Return(nil, assert.AnError)
+	transactionPtr, err := mockRepo.GetTransactionByID(context.Background(), 1, 1)
 
 	// Check if the transaction retrieval failed as expected
 	assert.Error(t, err)
@@ -90,8 +99,8 @@ func TestGetAllTransaction_Success(t *testing.T) {
 	mockTransactionsPtr := &mockTransactions
 
 	// Representing retrieving all transactions from the database
-	mockRepo.On("GetAllTransaction").Return(mockTransactionsPtr, nil)
-	transactions, err := mockRepo.GetAllTransaction()
+	mockRepo.On("GetAllTransaction", 1).Return(mockTransactionsPtr, nil)
+	transactions, err := mockRepo.GetAllTransaction(context.Background(), 1)
 
 	// Check if the transaction is retrieved successfully
 	assert.NoError(t, err)
@@ -104,8 +113,8 @@ func TestGetAllTransaction_Failed(t *testing.T) {
 	mockRepo := new(repository.MockTransactionRepository)
 
 	// Representing retrieving all transactions from the database
-	mockRepo.On("GetAllTransaction").Return(nil, assert.AnError)
-	transactions, err := mockRepo.GetAllTransaction()
+	mockRepo.On("GetAllTransaction", 1).Return(nil, assert.AnError)
+	transactions, err := mockRepo.GetAllTransaction(context.Background(), 1)
 
 	// Check if the transaction retrieval failed as expected
 	assert.Error(t, err)
@@ -134,8 +143,9 @@ func TestCreateTransaction_Success(t *testing.T) {
 	mockTransactionPtr := &mockTransaction
 
 	// Representing creating a transaction in the database
-	mockRepo.On("CreateTransaction", mockTransactionPtr).Return(mockTransactionPtr, nil)
-	transactionPtr, err := mockRepo.CreateTransaction(mockTransactionPtr)
+	mockOption := model.PaymentOption{Method: "EWALLET", Channel: "DANA"}
+	mockRepo.On("CreateTransaction", mockTransactionPtr, mockOption, 1).Return(mockTransactionPtr, nil)
+	transactionPtr, err := mockRepo.CreateTransaction(context.Background(), mockTransactionPtr, mockOption, 1)
 
 	// Check if the transaction is created successfully
 	assert.NoError(t, err)
@@ -163,8 +173,9 @@ func TestCreateTransaction_Failed(t *testing.T) {
 	}
 	mockTransactionPtr := &mockTransaction
 
-	mockRepo.On("CreateTransaction", mockTransactionPtr).Return(nil, assert.AnError)
-	transactionPtr, err := mockRepo.CreateTransaction(mockTransactionPtr)
+	mockOption := model.PaymentOption{Method: "EWALLET", Channel: "DANA"}
+	mockRepo.On("CreateTransaction", mockTransactionPtr, mockOption, 1).Return(nil, assert.AnError)
+	transactionPtr, err := mockRepo.CreateTransaction(context.Background(), mockTransactionPtr, mockOption, 1)
 
 	// Check if the transaction creation failed as expected
 	assert.Error(t, err)
@@ -193,8 +204,8 @@ func TestUpdateTransaction_Success(t *testing.T) {
 	mockTransactionPtr := &mockTransaction
 
 	// Representing updating a transaction in the database
-	mockRepo.On("UpdateTransaction", mockTransactionPtr).Return(mockTransactionPtr, nil)
-	transactionPtr, err := mockRepo.UpdateTransaction(mockTransactionPtr)
+	mockRepo.On("UpdateTransaction", mockTransactionPtr, 1).Return(mockTransactionPtr, nil)
+	transactionPtr, err := mockRepo.UpdateTransaction(context.Background(), mockTransactionPtr, 1)
 
 	// Check if the transaction is updated successfully
 	assert.NoError(t, err)
@@ -223,8 +234,8 @@ func TestUpdateTransaction_Failed(t *testing.T) {
 	mockTransactionPtr := &mockTransaction
 
 	// Representing updating a transaction in the database
-	mockRepo.On("UpdateTransaction", mockTransactionPtr).Return(nil, assert.AnError)
-	transactionPtr, err := mockRepo.UpdateTransaction(mockTransactionPtr)
+	mockRepo.On("UpdateTransaction", mockTransactionPtr, 1).Return(nil, assert.AnError)
+	transactionPtr, err := mockRepo.UpdateTransaction(context.Background(), mockTransactionPtr, 1)
 
 	// Check if the transaction update failed as expected
 	assert.Error(t, err)
@@ -253,8 +264,8 @@ func TestSyncTransaction_Success(t *testing.T) {
 	mockTransactionPtr := &mockTransaction
 
 	// Representing syncing a transaction in the database
-	mockRepo.On("SyncTransaction", mockTransaction.ID).Return(mockTransactionPtr, nil)
-	transactionPtr, err := mockRepo.SyncTransaction(mockTransaction.ID)
+	mockRepo.On("SyncTransaction", mockTransaction.ID, 1).Return(mockTransactionPtr, nil)
+	transactionPtr, err := mockRepo.SyncTransaction(context.Background(), mockTransaction.ID, 1)
 
 	// Check if the transaction is synced successfully
 	assert.NoError(t, err)
@@ -283,8 +294,8 @@ func TestCheckUpdateTransaction_Failed(t *testing.T) {
 	// mockTransactionPtr := &mockTransaction
 
 	// Representing syncing a transaction in the database
-	mockRepo.On("SyncTransaction", mockTransaction.ID).Return(nil, assert.AnError)
-	transactionPtr, err := mockRepo.SyncTransaction(mockTransaction.ID)
+	mockRepo.On("SyncTransaction", mockTransaction.ID, 1).Return(nil, assert.AnError)
+	transactionPtr, err := mockRepo.SyncTransaction(context.Background(), mockTransaction.ID, 1)
 
 	// Check if the transaction update failed as expected
 	assert.Error(t, err)
@@ -292,3 +303,416 @@ func TestCheckUpdateTransaction_Failed(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestHandleInvoiceCallback_Success(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing the transaction settled by the callback
+	mockTransaction := &model.Transaction{
+		ID:         1,
+		DonationID: 1,
+		InvoiceID:  "inv-123",
+		Amount:     50000,
+		Status:     "PAID",
+	}
+
+	mockRepo.On("HandleInvoiceCallback", "inv-123", "").Return(mockTransaction, nil)
+	transaction, err := mockRepo.HandleInvoiceCallback(context.Background(), "inv-123", "")
+
+	// Check if the transaction is settled
+	assert.NoError(t, err)
+	assert.Equal(t, model.TransactionPaid, transaction.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestXenditVirtualAccountCallback_Success(t *testing.T) {
+	config.App.XenditCallbackToken = "callback-token"
+	t.Cleanup(func() { config.App.XenditCallbackToken = "" })
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a virtual account transaction settled by its payment
+	mockTransaction := &model.Transaction{
+		ID:             1,
+		DonationID:     1,
+		InvoiceID:      "va-123",
+		PaymentMethod:  "VIRTUAL_ACCOUNT",
+		PaymentChannel: "BCA",
+		Amount:         50000,
+		Status:         "PAID",
+	}
+
+	mockRepo.On("HandleInvoiceCallback", "va-123", "pay-456").Return(mockTransaction, nil)
+
+	e := echo.New()
+	body := `{"payment_id":"pay-456","callback_virtual_account_id":"va-123","external_id":"donation-1","amount":50000}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/callbacks/xendit/virtual-account", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("x-callback-token", "callback-token")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.NewCallbackHandler(mockRepo).XenditVirtualAccountCallback(c)
+
+	// Check if the account and the payment are passed on to be verified
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"payment_channel":"BCA"`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReissueInvoice_Success(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing the new attempt of an expired transaction, paid by QRIS this time
+	previousID := 1
+	mockTransaction := &model.Transaction{
+		ID:                    2,
+		DonationID:            1,
+		PreviousTransactionID: &previousID,
+		InvoiceID:             "qr-123",
+		PaymentMethod:         "QRIS",
+		QRString:              "00020101021226",
+		Amount:                50000,
+		Status:                "PENDING",
+	}
+
+	mockRepo.On("ReissueInvoice", 1, 7, model.PaymentOption{Method: "QRIS"}).Return(mockTransaction, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/1/reissue", strings.NewReader(`{"payment_method":"QRIS"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).ReissueInvoice(c)
+
+	// Check if the new transaction links back to the expired one
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"previous_transaction_id":1`)
+	assert.Contains(t, rec.Body.String(), `"qr_string":"00020101021226"`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReissueInvoice_AlreadyReissued(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a transaction that was reissued before
+	mockRepo.On("ReissueInvoice", 1, 7, model.PaymentOption{}).
+		Return(nil, status.Error(codes.FailedPrecondition, "donation already has a newer transaction 2"))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/v1/transactions/1/reissue", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).ReissueInvoice(c)
+
+	// Check if the conflict is reported
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "newer transaction 2")
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestReissueGuestInvoice(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing the new attempt of an expired guest transaction
+	previousID := 1
+	mockTransaction := &model.Transaction{
+		ID:                    2,
+		DonationID:            1,
+		PreviousTransactionID: &previousID,
+		InvoiceID:             "inv-2",
+		InvoiceURL:            "https://checkout.xendit.co/inv-2",
+		Amount:                50000,
+		Status:                model.TransactionPending,
+	}
+
+	mockRepo.On("ReissueGuestInvoice", 1, "guest@example.com", "inv-1", model.PaymentOption{}).Return(mockTransaction, nil)
+	mockRepo.On("ReissueGuestInvoice", 1, "guest@example.com", "inv-9", model.PaymentOption{}).Return(nil, status.Error(codes.NotFound, "transaction not found"))
+
+	e := echo.New()
+	for _, tc := range []struct {
+		body   string
+		status int
+	}{
+		{body: `{"email":"guest@example.com","invoice_id":"inv-1"}`, status: http.StatusCreated},
+		{body: `{"email":"guest@example.com","invoice_id":"inv-9"}`, status: http.StatusNotFound},
+		{body: `{"email":"guest@example.com"}`, status: http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/1/reissue/guest", strings.NewReader(tc.body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		err := handler.NewTransactionHandler(mockRepo).ReissueGuestInvoice(c)
+
+		// Check if only the guest with the invoice ID gets a new payment
+		assert.NoError(t, err)
+		assert.Equal(t, tc.status, rec.Code)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetAllTransactionHandler_OwnTransactions(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing the transactions of the donations of user 7
+	mockRepo.On("GetAllTransaction", 7).Return(&[]model.Transaction{{ID: 3, DonationID: 1, Amount: 50000, Status: model.TransactionPending}}, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/transactions", nil), rec)
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).GetAllTransaction(c)
+
+	// Check if only the transactions of the user are asked for
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":3`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateTransactionHandler_Created(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a virtual account opened for a donation of user 7
+	mockTransaction := &model.Transaction{
+		ID:             3,
+		DonationID:     1,
+		InvoiceID:      "va-123",
+		PaymentMethod:  "VIRTUAL_ACCOUNT",
+		PaymentChannel: "BCA",
+		VANumber:       "8808123456",
+		Amount:         50000,
+		Status:         model.TransactionPending,
+	}
+	mockRepo.On("CreateTransaction",
+		&model.Transaction{DonationID: 1, Amount: 50000},
+		model.PaymentOption{Method: "VIRTUAL_ACCOUNT", Channel: "BCA"},
+		7,
+	).Return(mockTransaction, nil)
+
+	e := echo.New()
+	body := `{"donation_id":1,"amount":50000,"payment_method":"VIRTUAL_ACCOUNT","payment_channel":"BCA"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).CreateTransaction(c)
+
+	// Check if the transaction is created with its payment instructions
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"va_number":"8808123456"`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateTransactionHandler_NotOwner(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// donation-service reports donations of other users as missing
+	mockRepo.On("CreateTransaction", &model.Transaction{DonationID: 1, Amount: 50000}, model.PaymentOption{}, 8).
+		Return(nil, status.Error(codes.NotFound, "donation not found"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions", strings.NewReader(`{"donation_id":1,"amount":50000}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(8))
+
+	err := handler.NewTransactionHandler(mockRepo).CreateTransaction(c)
+
+	// Check if paying for someone else's donation is not found
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateTransactionHandler_PaymentOpen(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a donation whose invoice is still open, the amount is left out
+	mockRepo.On("CreateTransaction", &model.Transaction{DonationID: 1}, model.PaymentOption{}, 7).
+		Return(nil, status.Error(codes.FailedPrecondition, "donation already has a payment, pay or sync it"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions", strings.NewReader(`{"donation_id":1}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).CreateTransaction(c)
+
+	// Check if a second payment for the donation is refused
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTransactionByIDHandler_Success(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a transaction of user 7
+	mockRepo.On("GetTransactionByID", 3, 7).Return(&model.Transaction{ID: 3, DonationID: 1, Amount: 50000, Status: model.TransactionPending}, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/transactions/3", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).GetTransactionByID(c)
+
+	// Check if the stored transaction is returned
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"id":3`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetTransactionByIDHandler_NotOwner(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// donation-service reports transactions of other users as missing
+	mockRepo.On("GetTransactionByID", 3, 8).Return(nil, status.Error(codes.NotFound, "transaction not found"))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/transactions/3", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(8))
+
+	err := handler.NewTransactionHandler(mockRepo).GetTransactionByID(c)
+
+	// Check if the transaction of another user is not found
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateTransactionHandler_Success(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a donor abandoning a pending payment
+	mockRepo.On("UpdateTransaction", &model.Transaction{ID: 3, Status: model.TransactionFailed, Version: 2}, 7).
+		Return(&model.Transaction{ID: 3, DonationID: 1, Amount: 50000, Status: model.TransactionFailed, Version: 3}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/transactions/3", strings.NewReader(`{"status":"FAILED"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"2"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).UpdateTransaction(c)
+
+	// Check if the updated transaction is returned
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"FAILED"`)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateTransactionHandler_SettlementOnlyStatus(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a donor trying to mark a payment as paid
+	mockRepo.On("UpdateTransaction", &model.Transaction{ID: 3, Status: model.TransactionPaid}, 7).
+		Return(nil, status.Error(codes.InvalidArgument, "transaction status PAID is only set when its payment settles"))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/transactions/3", strings.NewReader(`{"status":"PAID"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", "*")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).UpdateTransaction(c)
+
+	// Check if the status change is rejected
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncTransactionHandler_Success(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing a pending transaction the provider reports as paid
+	mockRepo.On("SyncTransaction", 3, 7).Return(&model.Transaction{ID: 3, DonationID: 1, Amount: 50000, Status: model.TransactionPaid}, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPut, "/api/v1/transactions/sync-transaction/3", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).SyncTransaction(c)
+
+	// Check if the settled transaction is returned
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"PAID"`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSyncTransactionHandler_ProviderUnavailable(t *testing.T) {
+	mockRepo := new(repository.MockTransactionRepository)
+
+	// Representing the payment provider being down
+	mockRepo.On("SyncTransaction", 3, 7).Return(nil, status.Error(codes.Unavailable, "payment provider is unavailable, try again later"))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPut, "/api/v1/transactions/sync-transaction/3", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(7))
+
+	err := handler.NewTransactionHandler(mockRepo).SyncTransaction(c)
+
+	// Check if the sync can be retried later
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
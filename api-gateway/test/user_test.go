@@ -1,6 +1,7 @@
 package test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -26,7 +27,7 @@ func TestGetUserByID_Success(t *testing.T) {
 
 	// Representing retrieving a user by ID from the database
 	mockRepo.On("GetUserByID", 1).Return(mockUserPtr, nil)
-	userPtr, err := mockRepo.GetUserByID(1)
+	userPtr, err := mockRepo.GetUserByID(context.Background(), 1)
 
 	// Check if the user is retrieved successfully
 	assert.NoError(t, err)
@@ -40,7 +41,7 @@ func TestGetUserByID_Failed(t *testing.T) {
 
 	// Representing retrieving a user by ID from the database
 	mockRepo.On("GetUserByID", 2).Return(nil, assert.AnError)
-	userPtr, err := mockRepo.GetUserByID(2)
+	userPtr, err := mockRepo.GetUserByID(context.Background(), 2)
 
 	// Check if the user retrieval failed as expected
 	assert.Error(t, err)
@@ -65,7 +66,7 @@ func TestCreateUser_Success(t *testing.T) {
 
 	// Representing creating a user in the database
 	mockRepo.On("CreateUser", mockUserPtr).Return(mockUserPtr, nil)
-	userPtr, err := mockRepo.CreateUser(mockUserPtr)
+	userPtr, err := mockRepo.CreateUser(context.Background(), mockUserPtr)
 
 	// Check if the user is created successfully
 	assert.NoError(t, err)
@@ -90,7 +91,7 @@ func TestCreateUser_Failed(t *testing.T) {
 
 	// Representing creating a user in the database
 	mockRepo.On("CreateUser", mockUserPtr).Return(nil, assert.AnError)
-	userPtr, err := mockRepo.CreateUser(mockUserPtr)
+	userPtr, err := mockRepo.CreateUser(context.Background(), mockUserPtr)
 
 	// Check if the user creation failed as expected
 	assert.Error(t, err)
@@ -114,8 +115,8 @@ func TestUpdateUser_Success(t *testing.T) {
 	mockUserPtr := &mockUser
 
 	// Representing updating a user in the database
-	mockRepo.On("UpdateUser", mockUserPtr).Return(mockUserPtr, nil)
-	userPtr, err := mockRepo.UpdateUser(mockUserPtr)
+	mockRepo.On("UpdateUser", mockUserPtr, []string(nil)).Return(mockUserPtr, nil)
+	userPtr, err := mockRepo.UpdateUser(context.Background(), mockUserPtr, nil)
 
 	// Check if the user is updated successfully
 	assert.NoError(t, err)
@@ -139,8 +140,8 @@ func TestUpdateUser_Failed(t *testing.T) {
 	mockUserPtr := &mockUser
 
 	// Representing updating a user in the database
-	mockRepo.On("UpdateUser", mockUserPtr).Return(nil, assert.AnError)
-	userPtr, err := mockRepo.UpdateUser(mockUserPtr)
+	mockRepo.On("UpdateUser", mockUserPtr, []string(nil)).Return(nil, assert.AnError)
+	userPtr, err := mockRepo.UpdateUser(context.Background(), mockUserPtr, nil)
 
 	// Check if the user update failed as expected
 	assert.Error(t, err)
@@ -161,7 +162,7 @@ func TestLoginUser_Success(t *testing.T) {
 
 	// Representing logging in a user in the database
 	mockRepo.On("LoginUser", mockUserPtr).Return(mockUserPtr, nil)
-	userPtr, err := mockRepo.LoginUser(mockUserPtr)
+	userPtr, err := mockRepo.LoginUser(context.Background(), mockUserPtr)
 
 	// Check if the user login is successful
 	assert.NoError(t, err)
@@ -182,7 +183,7 @@ func TestLoginUser_Failed(t *testing.T) {
 
 	// Representing logging in a user in the database
 	mockRepo.On("LoginUser", mockUserPtr).Return(nil, assert.AnError)
-	userPtr, err := mockRepo.LoginUser(mockUserPtr)
+	userPtr, err := mockRepo.LoginUser(context.Background(), mockUserPtr)
 
 	// Check if the user login failed as expected
 	assert.Error(t, err)
@@ -190,3 +191,17 @@ func TestLoginUser_Failed(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUser_Summary(t *testing.T) {
+	// Representing a user shown as the donor of a donation
+	user := model.User{ID: 1, Name: "John Doe", Email: "John.Doe@Example.com"}
+	same := model.User{Email: " john.doe@example.com"}
+	invalid := model.User{Email: "not-an-email"}
+
+	// Check if the email is masked, and the avatar does not depend on its case
+	assert.Equal(t, "J***@Example.com", user.MaskedEmail())
+	assert.Empty(t, invalid.MaskedEmail())
+	assert.Equal(t, same.AvatarURL(), user.AvatarURL())
+	assert.Regexp(t, `^https://www\.gravatar\.com/avatar/[0-9a-f]{64}\?d=identicon$`, user.AvatarURL())
+	assert.NotContains(t, user.AvatarURL(), "john")
+}
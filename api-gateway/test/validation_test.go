@@ -0,0 +1,248 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	donation_model "github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/user-service/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/entity"
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func fieldErrors(t *testing.T, rec *httptest.ResponseRecorder) map[string]string {
+	var problem entity.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+
+	errors := map[string]string{}
+	for _, fe := range problem.Errors {
+		errors[fe.Field] = fe.Message
+	}
+	return errors
+}
+
+func TestCreateUser_InvalidFields(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+
+	e := echo.New()
+	body := `{"name":"John Doe","email":"John <john@example>","password":"password"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/register", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.NewUserHandler(mockRepo).CreateUser(c)
+
+	// Check if every invalid field is reported and user-service is not called
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, map[string]string{
+		"email":    "email must be a valid email address",
+		"password": "password must contain a letter and a digit",
+	}, fieldErrors(t, rec))
+
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything)
+}
+
+func TestUpdateUser_ReplacesNameAndEmail(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+
+	// Representing a user replacing their details, without an update mask
+	mockRepo.On("UpdateUser", &model.User{ID: 1, Name: "Jane Doe", Email: "jane@example.com", Version: 4}, []string(nil)).
+		Return(&model.User{ID: 1, Name: "Jane Doe", Email: "jane@example.com", Version: 5}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me", strings.NewReader(`{"name":"Jane Doe","email":"jane@example.com"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"4"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewUserHandler(mockRepo).UpdateUser(c)
+
+	// Check if both fields are sent for the current user
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateUser_RequiresEveryField(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me", strings.NewReader(`{"name":"Jane Doe"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"4"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewUserHandler(mockRepo).UpdateUser(c)
+
+	// Check if a replacement without the email is rejected
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, map[string]string{"email": "email is required"}, fieldErrors(t, rec))
+
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestPatchUser_SendsMaskOfSetFields(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+
+	// Representing a user renaming themselves, the email is kept
+	mockRepo.On("UpdateUser", &model.User{ID: 1, Name: "Jane Doe", Version: 4}, []string{"name"}).
+		Return(&model.User{ID: 1, Name: "Jane Doe", Email: "jane@example.com", Version: 5}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(`{"name":"Jane Doe"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"4"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewUserHandler(mockRepo).PatchUser(c)
+
+	// Check if only the name is named in the mask
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPatchUser_InvalidAndEmpty(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+
+	e := echo.New()
+	for body, field := range map[string]string{`{"email":""}`: "email", `{}`: ""} {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"4"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user_id", float64(1))
+
+		err := handler.NewUserHandler(mockRepo).PatchUser(c)
+
+		// Check if a set but empty field and a body setting nothing are rejected
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		if field != "" {
+			assert.Contains(t, fieldErrors(t, rec), field)
+		}
+	}
+
+	mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestChangePassword_WeakPassword(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/password", strings.NewReader(`{"new_password":"abc1"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewUserHandler(mockRepo).ChangePassword(c)
+
+	// Check if the current password and the password policy are required
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	errors := fieldErrors(t, rec)
+	assert.Contains(t, errors, "current_password")
+	assert.Contains(t, errors, "new_password")
+
+	mockRepo.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChangePassword_WrongCurrentPassword(t *testing.T) {
+	mockRepo := new(repository.MockUserRepository)
+
+	// Representing user-service rejecting the current password
+	mockRepo.On("ChangePassword", 1, "wrong-pass1", "new-pass123").Return(apperror.InvalidArgument("invalid password change",
+		apperror.FieldViolation{Field: "current_password", Description: "current password is incorrect"},
+	))
+
+	e := echo.New()
+	body := `{"current_password":"wrong-pass1","new_password":"new-pass123"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/password", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewUserHandler(mockRepo).ChangePassword(c)
+
+	// Check if the rejection is reported on the current password
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, map[string]string{"current_password": "current password is incorrect"}, fieldErrors(t, rec))
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPatchDonation_SendsZeroValues(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	// Representing a donor clearing the message and making the donation public
+	mockRepo.On("UpdateDonation", &donation_model.Donation{ID: 3, UserID: 1, Version: 1}, []string{"message", "is_anonymous"}).
+		Return(&donation_model.Donation{ID: 3, Amount: 50000, Version: 2}, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/donations/3", strings.NewReader(`{"message":"","is_anonymous":false}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"1"`)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("3")
+	c.Set("user_id", float64(1))
+
+	err := handler.NewDonationHandler(mockRepo).PatchDonation(c)
+
+	// Check if the zero values are named in the mask and the amount is not
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateDonation_InvalidFields(t *testing.T) {
+	mockRepo := new(repository.MockDonationRepository)
+
+	e := echo.New()
+	body := `{"campaign_id":0,"amount":500,"message":"` + strings.Repeat("a", 501) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/donations", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", float64(1))
+
+	err := handler.NewDonationHandler(mockRepo).CreateDonation(c)
+
+	// Check if the amount bounds and the message length are enforced
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, handler.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, map[string]string{
+		"campaign_id": "campaign_id must be greater than 0",
+		"amount":      "amount must be between 10000 and 100000000",
+		"message":     "message must be at most 500 characters long",
+	}, fieldErrors(t, rec))
+
+	mockRepo.AssertNotCalled(t, "CreateDonation", mock.Anything)
+}
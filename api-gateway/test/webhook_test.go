@@ -0,0 +1,72 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rayhanadri/crowdfunding/api-gateway/handler"
+	"github.com/rayhanadri/crowdfunding/api-gateway/repository"
+)
+
+func TestGetWebhookDeliveries_Success(t *testing.T) {
+	mockRepo := new(repository.MockWebhookRepository)
+
+	// Representing a dead delivery followed by a delivered one
+	mockPage := &model.WebhookDeliveryPage{
+		Deliveries: []model.WebhookDelivery{
+			{ID: 2, SubscriptionID: 1, EventType: "donation.settled", Status: "DELIVERED", Attempts: 1, ResponseStatus: 200, CreatedAt: time.Now()},
+			{ID: 1, SubscriptionID: 1, EventType: "donation.settled", Status: "DEAD", Attempts: 8, ResponseStatus: 500, LastError: "partner responded with status code 500", CreatedAt: time.Now()},
+		},
+		Page:     1,
+		PageSize: 20,
+		Total:    2,
+	}
+
+	mockRepo.On("GetDeliveries", 1, 1, 1, 20).Return(mockPage, nil)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/1/deliveries", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(1))
+
+	err := handler.NewWebhookHandler(mockRepo).GetDeliveries(c)
+
+	// Check if the delivery log is returned
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"DEAD"`)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetWebhookDeliveries_NotOwner(t *testing.T) {
+	mockRepo := new(repository.MockWebhookRepository)
+
+	// donation-service reports subscriptions of other users as missing
+	mockRepo.On("GetDeliveries", 2, 1, 1, 20).Return(nil, status.Error(codes.NotFound, "webhook subscription not found"))
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/1/deliveries", nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", float64(2))
+
+	err := handler.NewWebhookHandler(mockRepo).GetDeliveries(c)
+
+	// Check if the delivery log of another user is not found
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,151 @@
+// Package apperror builds the gRPC errors the services return. Every error
+// has a status code and an ErrorInfo with a machine readable reason, invalid
+// requests also list the violated fields, so the gateway can answer with the
+// right HTTP status and a problem+json body.
+package apperror
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"gorm.io/gorm"
+)
+
+// Domain is the ErrorInfo domain of every error.
+const Domain = "crowdfunding"
+
+// Reasons shared by the services. Services add their own, such as
+// DONATION_NOT_FOUND, in the same UPPER_SNAKE_CASE form.
+const (
+	ReasonInvalidArgument = "INVALID_ARGUMENT"
+	ReasonInternal        = "INTERNAL"
+	ReasonTimeout         = "TIMEOUT"
+	ReasonConflict        = "CONFLICT"
+)
+
+// FieldViolation names a request field and why it is invalid.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// New returns a status error with the reason and the field violations as
+// details.
+func New(code codes.Code, reason string, message string, violations ...FieldViolation) error {
+	st := status.New(code, message)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: Domain}}
+	if len(violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// NotFound is returned for a missing resource, and for resources of other
+// users so their IDs are not revealed.
+func NotFound(reason string, message string) error {
+	return New(codes.NotFound, reason, message)
+}
+
+// InvalidArgument is returned for a request that is wrong whatever the state
+// of the system.
+func InvalidArgument(message string, violations ...FieldViolation) error {
+	return New(codes.InvalidArgument, ReasonInvalidArgument, message, violations...)
+}
+
+// FailedPrecondition is returned when the state of a resource does not allow
+// the request, such as paying a donation twice.
+func FailedPrecondition(reason string, message string) error {
+	return New(codes.FailedPrecondition, reason, message)
+}
+
+func AlreadyExists(reason string, message string) error {
+	return New(codes.AlreadyExists, reason, message)
+}
+
+func PermissionDenied(reason string, message string) error {
+	return New(codes.PermissionDenied, reason, message)
+}
+
+func Unauthenticated(reason string, message string) error {
+	return New(codes.Unauthenticated, reason, message)
+}
+
+// Unavailable is returned when a dependency such as the payment provider
+// cannot be reached, the request can be retried later.
+func Unavailable(reason string, message string) error {
+	return New(codes.Unavailable, reason, message)
+}
+
+// Aborted is returned when a concurrent change got in the way, the request
+// can be retried.
+func Aborted(message string) error {
+	return New(codes.Aborted, ReasonConflict, message)
+}
+
+// Internal logs err and returns an error that does not reveal it.
+func Internal(err error) error {
+	slog.Error("internal error", "error", err)
+	return New(codes.Internal, ReasonInternal, "internal error")
+}
+
+// FromDB maps a database error. A missing record is NotFound with the reason
+// <RESOURCE>_NOT_FOUND, a unique violation AlreadyExists with the reason
+// <RESOURCE>_ALREADY_EXISTS, status errors are kept and anything else is
+// Internal. Unique violations need gorm.Config.TranslateError.
+func FromDB(err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	prefix := strings.ToUpper(strings.ReplaceAll(resource, " ", "_"))
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFound(prefix+"_NOT_FOUND", resource+" not found")
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return AlreadyExists(prefix+"_ALREADY_EXISTS", resource+" already exists")
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return New(codes.DeadlineExceeded, ReasonTimeout, "request timed out")
+	}
+	return Internal(err)
+}
+
+// Reason returns the ErrorInfo reason of err, empty when it has none.
+func Reason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+// FieldViolations returns the invalid fields of err.
+func FieldViolations(err error) []FieldViolation {
+	var violations []FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range badRequest.GetFieldViolations() {
+				violations = append(violations, FieldViolation{Field: v.GetField(), Description: v.GetDescription()})
+			}
+		}
+	}
+	return violations
+}
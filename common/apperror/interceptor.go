@@ -0,0 +1,27 @@
+package apperror
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor converts the errors a handler returns as is, such as
+// a database error, with FromDB. Handlers should still return their own
+// errors, this only keeps raw errors from reaching clients as Unknown.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, FromDB(err, "record")
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streams.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return FromDB(handler(srv, ss), "record")
+	}
+}
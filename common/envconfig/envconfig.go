@@ -0,0 +1,190 @@
+// Package envconfig loads the typed configuration of a service from the
+// environment and an optional dotenv file. Fields of the configuration struct
+// are tagged with their variable and how to treat it:
+//
+//	Port      int    `env:"PORT" default:"50051"`
+//	Password  string `env:"POSTGRES_PASSWORD" required:"true" secret:"true"`
+//
+// Types implementing encoding.TextUnmarshaler parse their own values. Nested
+// structs group related fields. The environment wins over the file, the file
+// over the default. Secrets are redacted when the configuration is printed.
+package envconfig
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// FileVariable names the dotenv file to read, .env is read when it exists.
+const FileVariable = "CONFIG_FILE"
+
+const defaultFile = ".env"
+
+// Validator is implemented by configurations with rules across fields, it is
+// called once every field is loaded.
+type Validator interface {
+	Validate() error
+}
+
+// Load fills cfg, a pointer to a struct, from the environment and the dotenv
+// file. Every invalid or missing value is reported, not only the first one.
+func Load(cfg any) error {
+	file, err := readFile()
+	if err != nil {
+		return err
+	}
+	lookup := func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := file[name]
+		return value, ok
+	}
+
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("envconfig: %T is not a pointer to a struct", cfg)
+	}
+
+	var errs []error
+	walk(v.Elem(), func(field reflect.StructField, value reflect.Value) {
+		name := field.Tag.Get("env")
+		raw, ok := lookup(name)
+		if !ok || raw == "" {
+			raw, ok = field.Tag.Lookup("default")
+		}
+		if !ok || raw == "" {
+			if field.Tag.Get("required") == "true" {
+				errs = append(errs, fmt.Errorf("%s is required", name))
+			}
+			return
+		}
+		if err := set(value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	})
+	if len(errs) == 0 {
+		if validator, ok := cfg.(Validator); ok {
+			if err := validator.Validate(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// readFile reads the file named by CONFIG_FILE, which must exist, or .env
+// when it exists.
+func readFile() (map[string]string, error) {
+	path, named := os.LookupEnv(FileVariable)
+	if !named {
+		path = defaultFile
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+	}
+	values, err := godotenv.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("envconfig: failed to read %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// walk calls fn for every field tagged env, descending into nested structs.
+func walk(v reflect.Value, fn func(reflect.StructField, reflect.Value)) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value := v.Field(i)
+		if _, ok := field.Tag.Lookup("env"); ok {
+			fn(field, value)
+		} else if value.Kind() == reflect.Struct && field.IsExported() {
+			walk(value, fn)
+		}
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func set(value reflect.Value, raw string) error {
+	if u, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+	if value.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		value.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		value.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		value.SetBool(b)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", value.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// Print writes every variable of cfg with its value, secrets are redacted.
+// It is the output of the --print-config flag.
+func Print(w io.Writer, cfg any) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	walk(reflect.Indirect(reflect.ValueOf(cfg)), func(field reflect.StructField, value reflect.Value) {
+		fmt.Fprintf(tw, "%s\t%s\n", field.Tag.Get("env"), format(field, value))
+	})
+	return tw.Flush()
+}
+
+func format(field reflect.StructField, value reflect.Value) string {
+	if value.IsZero() {
+		return "(unset)"
+	}
+	if field.Tag.Get("secret") == "true" {
+		return "(redacted)"
+	}
+	if value.Kind() == reflect.Slice {
+		return strings.Join(value.Interface().([]string), ",")
+	}
+	return fmt.Sprint(value.Interface())
+}
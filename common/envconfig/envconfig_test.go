@@ -0,0 +1,210 @@
+package envconfig
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type level string
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch s := strings.ToLower(string(text)); s {
+	case "debug", "info":
+		*l = level(s)
+		return nil
+	}
+	return errors.New("unknown level")
+}
+
+type database struct {
+	Host     string `env:"TEST_DB_HOST" required:"true"`
+	Password string `env:"TEST_DB_PASSWORD" secret:"true"`
+}
+
+type testConfig struct {
+	Port     int           `env:"TEST_PORT" default:"50051"`
+	Timeout  time.Duration `env:"TEST_TIMEOUT" default:"5s"`
+	Ratio    float64       `env:"TEST_RATIO"`
+	Enabled  bool          `env:"TEST_ENABLED"`
+	Origins  []string      `env:"TEST_ORIGINS"`
+	LogLevel level         `env:"TEST_LOG_LEVEL" default:"info"`
+	Database database
+}
+
+type validatedConfig struct {
+	Min int `env:"TEST_MIN" default:"1"`
+	Max int `env:"TEST_MAX" default:"10"`
+}
+
+func (c *validatedConfig) Validate() error {
+	if c.Min > c.Max {
+		return errors.New("TEST_MIN must not be above TEST_MAX")
+	}
+	return nil
+}
+
+// useFile points CONFIG_FILE at a dotenv file holding content, so a .env of
+// the working directory is never read.
+func useFile(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(FileVariable, path)
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		file    string
+		want    testConfig
+		wantErr []string
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{"TEST_DB_HOST": "db"},
+			want: testConfig{Port: 50051, Timeout: 5 * time.Second, LogLevel: "info", Database: database{Host: "db"}},
+		},
+		{
+			name: "every type",
+			env: map[string]string{
+				"TEST_DB_HOST":   "db",
+				"TEST_PORT":      "8080",
+				"TEST_TIMEOUT":   "1m30s",
+				"TEST_RATIO":     "0.25",
+				"TEST_ENABLED":   "true",
+				"TEST_ORIGINS":   " https://a.example.com, ,https://b.example.com",
+				"TEST_LOG_LEVEL": "DEBUG",
+			},
+			want: testConfig{
+				Port:     8080,
+				Timeout:  90 * time.Second,
+				Ratio:    0.25,
+				Enabled:  true,
+				Origins:  []string{"https://a.example.com", "https://b.example.com"},
+				LogLevel: "debug",
+				Database: database{Host: "db"},
+			},
+		},
+		{
+			name: "file under the environment",
+			env:  map[string]string{"TEST_PORT": "8080"},
+			file: "TEST_PORT=9090\nTEST_DB_HOST=file-db\nTEST_DB_PASSWORD=secret\n",
+			want: testConfig{Port: 8080, Timeout: 5 * time.Second, LogLevel: "info", Database: database{Host: "file-db", Password: "secret"}},
+		},
+		{
+			name: "empty value takes the default",
+			env:  map[string]string{"TEST_DB_HOST": "db", "TEST_PORT": ""},
+			want: testConfig{Port: 50051, Timeout: 5 * time.Second, LogLevel: "info", Database: database{Host: "db"}},
+		},
+		{
+			name: "every error reported",
+			env: map[string]string{
+				"TEST_PORT":      "http",
+				"TEST_TIMEOUT":   "5",
+				"TEST_ENABLED":   "maybe",
+				"TEST_LOG_LEVEL": "loud",
+			},
+			wantErr: []string{
+				`TEST_PORT: invalid integer "http"`,
+				`TEST_TIMEOUT: invalid duration "5"`,
+				`TEST_ENABLED: invalid boolean "maybe"`,
+				"TEST_LOG_LEVEL: unknown level",
+				"TEST_DB_HOST is required",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFile(t, tt.file)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			var cfg testConfig
+			err := Load(&cfg)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatal("Load() error = nil")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("Load() error = %q, want it to contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg, tt.want) {
+				t.Errorf("Load() = %+v, want %+v", cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadValidates(t *testing.T) {
+	useFile(t, "")
+	t.Setenv("TEST_MIN", "20")
+
+	var cfg validatedConfig
+	if err := Load(&cfg); err == nil || !strings.Contains(err.Error(), "TEST_MIN must not be above TEST_MAX") {
+		t.Errorf("Load() error = %v, want the Validate error", err)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Setenv(FileVariable, filepath.Join(t.TempDir(), "missing.env"))
+
+	var cfg validatedConfig
+	if err := Load(&cfg); err == nil {
+		t.Error("Load() error = nil, want the named file to be required")
+	}
+}
+
+func TestLoadNotAStruct(t *testing.T) {
+	useFile(t, "")
+	var port int
+	if err := Load(&port); err == nil {
+		t.Error("Load() error = nil for a pointer to an int")
+	}
+}
+
+func TestPrint(t *testing.T) {
+	cfg := testConfig{
+		Port:     8080,
+		Origins:  []string{"https://a.example.com", "https://b.example.com"},
+		Database: database{Host: "db", Password: "secret"},
+	}
+	var out strings.Builder
+	if err := Print(&out, &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		fields := strings.Fields(line)
+		lines[fields[0]] = strings.Join(fields[1:], " ")
+	}
+	want := map[string]string{
+		"TEST_PORT":        "8080",
+		"TEST_TIMEOUT":     "(unset)",
+		"TEST_RATIO":       "(unset)",
+		"TEST_ENABLED":     "(unset)",
+		"TEST_ORIGINS":     "https://a.example.com,https://b.example.com",
+		"TEST_LOG_LEVEL":   "(unset)",
+		"TEST_DB_HOST":     "db",
+		"TEST_DB_PASSWORD": "(redacted)",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("Print() = %v, want %v", lines, want)
+	}
+}
@@ -0,0 +1,55 @@
+// Package fieldmask applies the update masks of partial updates, only the
+// fields a mask names are changed, zero values included.
+package fieldmask
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+)
+
+// Columns returns the columns the paths of mask update, allowed maps each
+// path that can be updated to its column. It returns nil for a nil mask, an
+// empty mask or an unknown path is InvalidArgument.
+func Columns(mask *fieldmaskpb.FieldMask, allowed map[string]string) ([]string, error) {
+	if mask == nil {
+		return nil, nil
+	}
+
+	var columns []string
+	var violations []apperror.FieldViolation
+	seen := map[string]bool{}
+	for _, path := range mask.GetPaths() {
+		column, ok := allowed[path]
+		if !ok {
+			violations = append(violations, apperror.FieldViolation{Field: "update_mask", Description: fmt.Sprintf("%q cannot be updated", path)})
+			continue
+		}
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 && len(violations) == 0 {
+		violations = append(violations, apperror.FieldViolation{Field: "update_mask", Description: "update_mask names no field"})
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("invalid update mask", violations...)
+	}
+	return columns, nil
+}
+
+// Contains reports whether mask names path. A nil mask names every path.
+func Contains(mask *fieldmaskpb.FieldMask, path string) bool {
+	if mask == nil {
+		return true
+	}
+	for _, p := range mask.GetPaths() {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
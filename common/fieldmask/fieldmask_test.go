@@ -0,0 +1,68 @@
+package fieldmask
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var allowed = map[string]string{
+	"name":      "name",
+	"full_name": "name",
+	"email":     "email",
+}
+
+func TestColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		mask    *fieldmaskpb.FieldMask
+		want    []string
+		wantErr bool
+	}{
+		{"no mask", nil, nil, false},
+		{"one path", &fieldmaskpb.FieldMask{Paths: []string{"email"}}, []string{"email"}, false},
+		{"in mask order", &fieldmaskpb.FieldMask{Paths: []string{"email", "name"}}, []string{"email", "name"}, false},
+		{"paths of one column", &fieldmaskpb.FieldMask{Paths: []string{"name", "full_name"}}, []string{"name"}, false},
+		{"empty mask", &fieldmaskpb.FieldMask{}, nil, true},
+		{"unknown path", &fieldmaskpb.FieldMask{Paths: []string{"name", "password"}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Columns(tt.mask, allowed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Columns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && status.Code(err) != codes.InvalidArgument {
+				t.Errorf("Columns() code = %v, want InvalidArgument", status.Code(err))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Columns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	mask := &fieldmaskpb.FieldMask{Paths: []string{"name"}}
+	tests := []struct {
+		name string
+		mask *fieldmaskpb.FieldMask
+		path string
+		want bool
+	}{
+		{"no mask names every path", nil, "email", true},
+		{"named", mask, "name", true},
+		{"not named", mask, "email", false},
+		{"empty mask", &fieldmaskpb.FieldMask{}, "name", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Contains(tt.mask, tt.path); got != tt.want {
+				t.Errorf("Contains(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
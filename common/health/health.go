@@ -0,0 +1,166 @@
+// Package health reports whether a server can do its work. A Monitor runs
+// checks of the database and of the services a server calls, and publishes
+// the result on the standard grpc.health.v1 service, so load balancers and
+// the gateway stop routing to a server whose database is down.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check returns nil while the thing it checks is healthy.
+type Check func(ctx context.Context) error
+
+// DB checks the database answers.
+func DB(db *sql.DB) Check {
+	return db.PingContext
+}
+
+// GRPC checks the server at address reports the overall status SERVING on
+// grpc.health.v1.
+func GRPC(address string, opts ...grpc.DialOption) Check {
+	return func(ctx context.Context) error {
+		conn, err := grpc.NewClient(address, opts...)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		if res.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("status %s", res.GetStatus())
+		}
+		return nil
+	}
+}
+
+// RunAll runs checks concurrently, each within timeout, and returns their
+// errors by name. Healthy checks have a nil error.
+func RunAll(ctx context.Context, checks map[string]Check, timeout time.Duration) map[string]error {
+	results := make(map[string]error, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			err := check(ctx)
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Monitor publishes the result of its checks on a health server. Required
+// checks make up the overall status of the server, reported for "" and the
+// services given to NewMonitor. Every check is also reported under its own
+// name, so an observed dependency shows up without failing the server.
+type Monitor struct {
+	server   *grpchealth.Server
+	services []string
+	required map[string]Check
+	observed map[string]Check
+	// healthy is the last result of every check, only changes are logged
+	healthy map[string]bool
+
+	// Interval is the time between two rounds of checks, Timeout bounds each
+	// check.
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// NewMonitor returns a Monitor publishing on server the overall status of
+// services, "" included.
+func NewMonitor(server *grpchealth.Server, services ...string) *Monitor {
+	return &Monitor{
+		server:   server,
+		services: append([]string{""}, services...),
+		required: map[string]Check{},
+		observed: map[string]Check{},
+		healthy:  map[string]bool{},
+		Interval: 10 * time.Second,
+		Timeout:  2 * time.Second,
+	}
+}
+
+// Require adds a check the server cannot serve without.
+func (m *Monitor) Require(name string, check Check) {
+	m.required[name] = check
+}
+
+// Observe adds a check of a dependency the server partly works without.
+func (m *Monitor) Observe(name string, check Check) {
+	m.observed[name] = check
+}
+
+// Run checks once before returning, then keeps checking every Interval until
+// ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	m.check(ctx)
+	go func() {
+		ticker := time.NewTicker(m.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check(ctx)
+			}
+		}
+	}()
+}
+
+func (m *Monitor) check(ctx context.Context) {
+	overall := healthpb.HealthCheckResponse_SERVING
+	for name, err := range RunAll(ctx, m.required, m.Timeout) {
+		if err != nil {
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		m.publish(name, err)
+	}
+	for name, err := range RunAll(ctx, m.observed, m.Timeout) {
+		m.publish(name, err)
+	}
+	if ctx.Err() != nil {
+		// shutting down, Shutdown of the server reports NOT_SERVING itself
+		return
+	}
+	for _, service := range m.services {
+		m.server.SetServingStatus(service, overall)
+	}
+}
+
+func (m *Monitor) publish(name string, err error) {
+	healthy, known := m.healthy[name]
+	switch {
+	case err != nil && (healthy || !known):
+		slog.Warn("dependency is unhealthy", "check", name, "error", err)
+	case err == nil && !healthy && known:
+		slog.Info("dependency is healthy again", "check", name)
+	}
+	m.healthy[name] = err == nil
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if err != nil {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	m.server.SetServingStatus(name, status)
+}
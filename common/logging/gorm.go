@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// SlowQuery is the duration from which queries are logged as slow.
+const SlowQuery = 200 * time.Millisecond
+
+// GORM returns the logger of GORM. It logs failed and slow queries with
+// their placeholders, the values, which may be personal data, are left out.
+func GORM() gormlogger.Interface {
+	return gormLogger{}
+}
+
+type gormLogger struct{}
+
+func (l gormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+func (gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	slog.InfoContext(ctx, fmt.Sprintf(msg, data...))
+}
+
+func (gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	slog.WarnContext(ctx, fmt.Sprintf(msg, data...))
+}
+
+func (gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	slog.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+}
+
+func (gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, context.Canceled):
+		sql, rows := fc()
+		slog.ErrorContext(ctx, "query failed", "sql", sql, "rows", rows, "duration_ms", elapsed.Milliseconds(), "error", err)
+	case elapsed >= SlowQuery:
+		sql, rows := fc()
+		slog.WarnContext(ctx, "slow query", "sql", sql, "rows", rows, "duration_ms", elapsed.Milliseconds())
+	}
+}
+
+// ParamsFilter keeps the values out of the logged SQL.
+func (gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey carries the request ID in the gRPC metadata.
+const MetadataKey = "x-request-id"
+
+// fromIncoming returns ctx with the request ID sent by the caller.
+func fromIncoming(ctx context.Context) context.Context {
+	if ids := metadata.ValueFromIncomingContext(ctx, MetadataKey); len(ids) > 0 && ids[0] != "" {
+		return WithRequestID(ctx, ids[0])
+	}
+	return ctx
+}
+
+// toOutgoing returns ctx sending its request ID to the server.
+func toOutgoing(ctx context.Context) context.Context {
+	if id := RequestID(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+	}
+	return ctx
+}
+
+// UnaryServerInterceptor puts the request ID of the caller in the context of
+// the RPC and logs the RPC. Chain it first, so the other interceptors log with
+// the request ID and it sees the status codes clients get.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = fromIncoming(ctx)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logRPC(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming RPCs.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := fromIncoming(ss.Context())
+		start := time.Now()
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		logRPC(ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// logRPC logs an RPC at the debug level, failures of the server at the error
+// level.
+func logRPC(ctx context.Context, method string, start time.Time, err error) {
+	level := slog.LevelDebug
+	code := status.Code(err)
+	if code == codes.Internal || code == codes.Unknown {
+		level = slog.LevelError
+	}
+	slog.Log(ctx, level, "rpc", "method", method, "code", code.String(), "duration_ms", time.Since(start).Milliseconds())
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor passes the request ID of the context on to the
+// server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(toOutgoing(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor passes the request ID of the context on to the
+// server of a stream.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(toOutgoing(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,202 @@
+// Package logging sets up the structured logger of the gateway and the
+// services. Records are written with log/slog, carry the request ID the
+// gateway gave the request and the trace ID, and are redacted before they are
+// written: emails are masked, tokens, passwords and payment URLs removed.
+// Levels can be set per package, e.g. LOG_LEVELS=notification=debug.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Formats of Config.Format.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config is the logging part of the service configurations.
+type Config struct {
+	// Level is the minimum level of packages without their own, one of
+	// debug, info, warn and error.
+	Level string `env:"LOG_LEVEL" default:"info"`
+	// Levels overrides the level of packages, as package=level pairs. A
+	// package is its import path or its last element, e.g. notification.
+	Levels []string `env:"LOG_LEVELS"`
+	// Format is json, for the log collector, or text, for local runs.
+	Format string `env:"LOG_FORMAT" default:"json"`
+}
+
+// Validate checks the levels and the format.
+func (c Config) Validate() error {
+	if _, err := c.levels(); err != nil {
+		return err
+	}
+	if c.Format != FormatJSON && c.Format != FormatText {
+		return fmt.Errorf("LOG_FORMAT must be json or text")
+	}
+	return nil
+}
+
+func (c Config) levels() (*levels, error) {
+	l := &levels{byPackage: map[string]slog.Level{}}
+	if err := l.fallback.UnmarshalText([]byte(c.Level)); err != nil {
+		return nil, fmt.Errorf("LOG_LEVEL must be debug, info, warn or error")
+	}
+	l.min = l.fallback
+	for _, pair := range c.Levels {
+		pkg, name, ok := strings.Cut(pair, "=")
+		var level slog.Level
+		if !ok || pkg == "" || level.UnmarshalText([]byte(name)) != nil {
+			return nil, fmt.Errorf("LOG_LEVELS must be package=level pairs, got %q", pair)
+		}
+		l.byPackage[pkg] = level
+		l.min = min(l.min, level)
+	}
+	return l, nil
+}
+
+// Setup makes the logger of service, configured by cfg, the default of slog
+// and of the log package, so the remaining log.Printf calls are structured
+// and redacted too. cfg must be valid.
+func Setup(service string, cfg Config) {
+	// the log package passes its caller on, for the level of its package
+	log.SetFlags(log.Lshortfile)
+	slog.SetDefault(New(os.Stderr, service, cfg))
+}
+
+// New returns the logger of service writing to w.
+func New(w io.Writer, service string, cfg Config) *slog.Logger {
+	levels, err := cfg.levels()
+	if err != nil {
+		panic(err)
+	}
+	var next slog.Handler
+	options := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if cfg.Format == FormatText {
+		next = slog.NewTextHandler(w, options)
+	} else {
+		next = slog.NewJSONHandler(w, options)
+	}
+	return slog.New(&handler{next: next, levels: levels}).With("service", service)
+}
+
+// Fatal logs msg at the error level and exits, for the failures a server
+// cannot start with.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns ctx carrying the request ID id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID of ctx, "" outside of a request.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewRequestID returns a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// levels decides the minimum level of a record by the package logging it.
+type levels struct {
+	fallback  slog.Level
+	min       slog.Level
+	byPackage map[string]slog.Level
+	// cache holds the level by program counter
+	cache sync.Map
+}
+
+func (l *levels) of(pc uintptr) slog.Level {
+	if pc == 0 || len(l.byPackage) == 0 {
+		return l.fallback
+	}
+	if level, ok := l.cache.Load(pc); ok {
+		return level.(slog.Level)
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	pkg := packageOf(frame.Function)
+	level := l.fallback
+	for name, override := range l.byPackage {
+		if pkg == name || strings.HasSuffix(pkg, "/"+name) {
+			level = override
+			break
+		}
+	}
+	l.cache.Store(pc, level)
+	return level
+}
+
+// packageOf returns the import path of the package of function, e.g.
+// github.com/a/b/service of github.com/a/b/service.(*T).Method.
+func packageOf(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
+
+// handler filters records by the level of their package, adds the request
+// and trace IDs of the context and redacts every record before passing it
+// on.
+type handler struct {
+	next   slog.Handler
+	levels *levels
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.levels.min
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < h.levels.of(r.PC) {
+		return nil
+	}
+	out := slog.NewRecord(r.Time, r.Level, Redact(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(redactAttr(a))
+		return true
+	})
+	if id := RequestID(ctx); id != "" {
+		out.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		out.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+	return h.next.Handle(ctx, out)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return &handler{next: h.next.WithAttrs(redacted), levels: h.levels}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name), levels: h.levels}
+}
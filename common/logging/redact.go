@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are attributes whose values are never logged.
+var sensitiveKeys = map[string]bool{
+	"password":       true,
+	"token":          true,
+	"access_token":   true,
+	"refresh_token":  true,
+	"authorization":  true,
+	"secret":         true,
+	"api_key":        true,
+	"callback_token": true,
+	"invoice_url":    true,
+	"checkout_url":   true,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+	jwtPattern   = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	bearer       = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+	secretPair   = regexp.MustCompile(`(?i)\b(password|token|secret|api_?key)("?\s*[:=]\s*"?)[^\s"&,}]+`)
+	// paymentURL matches the invoice and checkout pages of Xendit, anyone
+	// holding one can see the invoice of the donor
+	paymentURL = regexp.MustCompile(`https?://[^\s"]*(xendit\.co|/invoices?/|/checkout)[^\s"]*`)
+)
+
+// Redact masks the emails in s, j***@example.com, and removes tokens,
+// passwords and payment URLs.
+func Redact(s string) string {
+	s = paymentURL.ReplaceAllString(s, redacted)
+	s = jwtPattern.ReplaceAllString(s, redacted)
+	s = bearer.ReplaceAllString(s, "$1 "+redacted)
+	s = secretPair.ReplaceAllString(s, "$1$2"+redacted)
+	return emailPattern.ReplaceAllString(s, "$1***@$2")
+}
+
+func redactAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if sensitiveKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(Redact(a.Value.String()))
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, attr := range group {
+			attrs[i] = redactAttr(attr)
+		}
+		a.Value = slog.GroupValue(attrs...)
+	case slog.KindAny:
+		// errors and structs may hold anything, they are logged as redacted
+		// text
+		a.Value = slog.StringValue(Redact(fmt.Sprint(a.Value.Any())))
+	}
+	return a
+}
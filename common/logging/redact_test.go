@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "donation 12 settled", "donation 12 settled"},
+		{"email", "sent to jane.doe@example.com", "sent to j***@example.com"},
+		{"emails", "a@x.co and bob@mail.example.org", "a***@x.co and b***@mail.example.org"},
+		{"jwt", "token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.abc-_123 expired", "token [REDACTED] expired"},
+		{"bearer", "Authorization: Bearer abc.def-123", "Authorization: Bearer [REDACTED]"},
+		{"basic", "basic dXNlcjpwYXNz", "basic [REDACTED]"},
+		{"password pair", "password=hunter2&next=1", "password=[REDACTED]&next=1"},
+		{"json secret", `{"api_key": "xnd_123", "id": 4}`, `{"api_key": "[REDACTED]", "id": 4}`},
+		{"xendit invoice", "pay at https://checkout.xendit.co/web/abc123 now", "pay at [REDACTED] now"},
+		{"checkout path", "redirect https://pay.example.com/checkout?id=9", "redirect [REDACTED]"},
+		{"other url", "see https://example.com/campaigns/3", "see https://example.com/campaigns/3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.in); got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactAttr(t *testing.T) {
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want slog.Value
+	}{
+		{"sensitive key", slog.String("password", "hunter2"), slog.StringValue(redacted)},
+		{"sensitive key any case", slog.String("Authorization", "Bearer x"), slog.StringValue(redacted)},
+		{"sensitive key of another kind", slog.Int("token", 42), slog.StringValue(redacted)},
+		{"string value", slog.String("to", "jane@example.com"), slog.StringValue("j***@example.com")},
+		{"number", slog.Int("donation_id", 7), slog.IntValue(7)},
+		{"error", slog.Any("error", errors.New("user jane@example.com not found")), slog.StringValue("user j***@example.com not found")},
+		{"group", slog.Group("user", slog.String("email", "jane@example.com"), slog.String("secret", "s")),
+			slog.GroupValue(slog.String("email", "j***@example.com"), slog.String("secret", redacted))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactAttr(tt.attr)
+			if got.Key != tt.attr.Key {
+				t.Errorf("key = %q, want %q", got.Key, tt.attr.Key)
+			}
+			if !got.Value.Equal(tt.want) {
+				t.Errorf("value = %v, want %v", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggerRedacts(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(&out, "donation-service", Config{Level: "info", Format: FormatJSON}).
+		With("refresh_token", "r1")
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	logger.InfoContext(ctx, "invoice sent to jane@example.com", "invoice_url", "https://checkout.xendit.co/web/1")
+	logger.DebugContext(ctx, "dropped")
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("want one JSON record, got %q: %v", out.String(), err)
+	}
+	want := map[string]any{
+		"msg":           "invoice sent to j***@example.com",
+		"service":       "donation-service",
+		"refresh_token": redacted,
+		"invoice_url":   redacted,
+		"request_id":    "req-1",
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v", key, record[key], value)
+		}
+	}
+}
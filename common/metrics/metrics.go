@@ -0,0 +1,121 @@
+// Package metrics exposes Prometheus metrics of the services: gRPC server
+// and client calls, database pool stats, and whatever counters a service
+// registers itself with promauto. The gRPC services serve them on their own
+// port, Serve, the gateway adds Handler to its router.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// LatencyBuckets are the histogram buckets of request latencies, in seconds.
+var LatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+var (
+	serverHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "RPCs completed by the server, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+	serverLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Time the server took to handle RPCs.",
+		Buckets: LatencyBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+
+	clientHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "RPCs completed by clients of other services, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+	clientLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Time other services took to answer RPCs.",
+		Buckets: LatencyBuckets,
+	}, []string{"grpc_service", "grpc_method"})
+)
+
+// splitMethod splits "/package.Service/Method" into service and method.
+func splitMethod(fullMethod string) (string, string) {
+	for i := len(fullMethod) - 1; i > 0; i-- {
+		if fullMethod[i] == '/' {
+			return fullMethod[1:i], fullMethod[i+1:]
+		}
+	}
+	return "unknown", fullMethod
+}
+
+func observe(handled *prometheus.CounterVec, latency *prometheus.HistogramVec, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	handled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+	latency.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+}
+
+// UnaryServerInterceptor counts and times unary RPCs. Chain it before the
+// apperror interceptor, so it sees the status codes clients get.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		observe(serverHandled, serverLatency, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor counts and times streaming RPCs, from start to end
+// of the stream.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		observe(serverHandled, serverLatency, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor counts and times the RPCs made to other services.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		observe(clientHandled, clientLatency, method, start, err)
+		return err
+	}
+}
+
+// RegisterDB exports the connection pool stats of db, labeled with its name.
+func RegisterDB(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve serves /metrics on address until ctx is done.
+func Serve(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	server := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("failed to serve metrics", "address", address, "error", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+}
@@ -0,0 +1,96 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Usage describes the migrate subcommand.
+const Usage = `usage: migrate <command>
+  up            apply every pending migration
+  down [steps]  revert the last applied migration, or the last steps ones
+  to <version>  apply or revert migrations until version is the last applied, 0 reverts all
+  status        list the migrations and when they were applied`
+
+// Run runs the migrate subcommand given by args, printing the status to out.
+func Run(ctx context.Context, m *Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", Usage)
+	}
+
+	switch command, rest := args[0], args[1:]; {
+	case command == "up" && len(rest) == 0:
+		return m.Up(ctx)
+	case command == "down" && len(rest) <= 1:
+		steps := 1
+		if len(rest) == 1 {
+			n, err := strconv.Atoi(rest[0])
+			if err != nil {
+				return fmt.Errorf("invalid number of steps %q", rest[0])
+			}
+			steps = n
+		}
+		return m.Down(ctx, steps)
+	case command == "to" && len(rest) == 1:
+		version, err := strconv.Atoi(rest[0])
+		if err != nil {
+			return fmt.Errorf("invalid version %q", rest[0])
+		}
+		return m.To(ctx, version)
+	case command == "status" && len(rest) == 0:
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printStatus(out, statuses)
+	}
+	return fmt.Errorf("invalid command %q\n%s", args, Usage)
+}
+
+func printStatus(out io.Writer, statuses []Status) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tNOTE")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+		note := ""
+		switch {
+		case s.Missing:
+			note = "not in this build"
+		case s.Modified:
+			note = "modified after it was applied"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, appliedAt, note)
+	}
+	return w.Flush()
+}
+
+// Seed runs script, sample data for development, in a transaction. The schema
+// must be up to date, so the script matches it.
+func (m *Migrator) Seed(ctx context.Context, script string) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			return fmt.Errorf("migration %d_%s is pending, run migrate up first", s.Version, s.Name)
+		}
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("seed failed: %w", err)
+	}
+	return tx.Commit()
+}
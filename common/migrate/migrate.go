@@ -0,0 +1,361 @@
+// Package migrate applies the versioned SQL migrations a service embeds. Each
+// service owns a PostgreSQL schema and records the migrations applied to it in
+// <schema>.schema_migrations. Migrations are files named
+// <version>_<name>.up.sql and <version>_<name>.down.sql, every migration runs
+// in its own transaction together with its record, and a session advisory
+// lock keeps replicas that start at the same time from migrating twice.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Migration is one schema change and the change that reverts it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum identifies the up script, so an applied migration that was edited
+// afterwards is noticed.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Load reads the migrations in the root of fsys, ordered by version. Every
+// migration needs an up and a down script.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(match[1])
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// ErrLocked is returned when another migration holds the lock for longer than
+// Migrator.LockTimeout.
+var ErrLocked = errors.New("another migration is running")
+
+// Migrator applies the migrations of one schema.
+type Migrator struct {
+	db         *sql.DB
+	schema     string
+	migrations []Migration
+
+	// LockTimeout bounds the wait for a migration running elsewhere.
+	LockTimeout time.Duration
+}
+
+// New returns a Migrator for the migrations of schema.
+func New(db *sql.DB, schema string, migrations []Migration) *Migrator {
+	return &Migrator{db: db, schema: schema, migrations: migrations, LockTimeout: time.Minute}
+}
+
+// Status is the state of a migration in the database.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+	// Modified is set when the applied script differs from the embedded one.
+	Modified bool
+	// Missing is set for an applied migration the service no longer ships.
+	Missing bool
+}
+
+type record struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+func (m *Migrator) table() string {
+	return m.schema + ".schema_migrations"
+}
+
+// lockKey is the advisory lock of the schema, the same on every replica.
+func (m *Migrator) lockKey() int64 {
+	h := fnv.New64a()
+	h.Write([]byte("migrate:" + m.schema))
+	return int64(h.Sum64())
+}
+
+// session runs fn on a connection holding the migration lock of the schema,
+// after creating the schema and its schema_migrations table when needed.
+func (m *Migrator) session(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(m.LockTimeout)
+	for {
+		var locked bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", m.lockKey()).Scan(&locked); err != nil {
+			return fmt.Errorf("failed to take the migration lock: %w", err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			return ErrLocked
+		}
+		slog.InfoContext(ctx, "waiting for the migration lock", "schema", m.schema)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	// a fresh context, the lock is released even when ctx was canceled
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", m.lockKey())
+
+	bootstrap := fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s;
+CREATE TABLE IF NOT EXISTS %s (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`, m.schema, m.table())
+	if _, err := conn.ExecContext(ctx, bootstrap); err != nil {
+		return fmt.Errorf("failed to create %s: %w", m.table(), err)
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]record, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM "+m.table())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]record{}
+	for rows.Next() {
+		var version int
+		var r record
+		if err := rows.Scan(&version, &r.name, &r.checksum, &r.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = r
+	}
+	return applied, rows.Err()
+}
+
+// check refuses to migrate a schema whose history differs from the embedded
+// migrations, an edited or unknown migration needs a person to look at it.
+func (m *Migrator) check(applied map[int]record) error {
+	known := map[int]Migration{}
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+	for version, r := range applied {
+		migration, ok := known[version]
+		if !ok {
+			return fmt.Errorf("migration %d_%s is applied but unknown to this build", version, r.name)
+		}
+		if migration.Checksum() != r.checksum {
+			return fmt.Errorf("migration %d_%s was modified after it was applied", version, migration.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	script := migration.Down
+	if up {
+		script = migration.Up
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, "INSERT INTO "+m.table()+" (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum())
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM "+m.table()+" WHERE version = $1", migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+	slog.InfoContext(ctx, "migrated", "schema", m.schema, "version", migration.Version, "name", migration.Name, "direction", direction)
+	return nil
+}
+
+// migrate brings the schema to version, applying the pending migrations up to
+// it or reverting the applied ones above it. A negative version is the latest.
+func (m *Migrator) migrate(ctx context.Context, version int) error {
+	return m.session(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.check(applied); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || (version >= 0 && migration.Version > version) {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok || version < 0 || migration.Version <= version {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, false); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.migrate(ctx, -1)
+}
+
+// To applies or reverts migrations until version is the last one applied, 0
+// reverts them all.
+func (m *Migrator) To(ctx context.Context, version int) error {
+	if version < 0 {
+		return fmt.Errorf("invalid version %d", version)
+	}
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	return m.migrate(ctx, version)
+}
+
+// Down reverts the last steps applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("invalid number of steps %d", steps)
+	}
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var applied []int
+	for _, s := range statuses {
+		if s.AppliedAt != nil {
+			applied = append(applied, s.Version)
+		}
+	}
+	target := 0
+	if steps < len(applied) {
+		target = applied[len(applied)-steps-1]
+	}
+	return m.migrate(ctx, target)
+}
+
+func (m *Migrator) known(version int) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Status lists the embedded migrations and whether they are applied, followed
+// by applied migrations the service no longer ships.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.session(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			status := Status{Migration: migration}
+			if r, ok := applied[migration.Version]; ok {
+				appliedAt := r.appliedAt
+				status.AppliedAt = &appliedAt
+				status.Modified = r.checksum != migration.Checksum()
+				delete(applied, migration.Version)
+			}
+			statuses = append(statuses, status)
+		}
+		for version, r := range applied {
+			appliedAt := r.appliedAt
+			statuses = append(statuses, Status{Migration: Migration{Version: version, Name: r.name}, AppliedAt: &appliedAt, Missing: true})
+		}
+		return nil
+	})
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
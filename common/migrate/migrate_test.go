@@ -0,0 +1,126 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    fstest.MapFS
+		versions []int
+		wantErr  string
+	}{
+		{
+			name: "ordered by version",
+			files: fstest.MapFS{
+				"0010_add_index.up.sql":      {Data: []byte("CREATE INDEX")},
+				"0010_add_index.down.sql":    {Data: []byte("DROP INDEX")},
+				"0002_add_column.up.sql":     {Data: []byte("ALTER TABLE")},
+				"0002_add_column.down.sql":   {Data: []byte("ALTER TABLE")},
+				"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE")},
+				"0001_create_table.down.sql": {Data: []byte("DROP TABLE")},
+			},
+			versions: []int{1, 2, 10},
+		},
+		{
+			name: "other files skipped",
+			files: fstest.MapFS{
+				"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE")},
+				"0001_create_table.down.sql": {Data: []byte("DROP TABLE")},
+				"seed.sql":                   {Data: []byte("INSERT")},
+				"migrations.go":              {Data: []byte("package migrations")},
+			},
+			versions: []int{1},
+		},
+		{
+			name: "missing down",
+			files: fstest.MapFS{
+				"0001_create_table.up.sql": {Data: []byte("CREATE TABLE")},
+			},
+			wantErr: "needs both an up and a down script",
+		},
+		{
+			name: "names differ",
+			files: fstest.MapFS{
+				"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE")},
+				"0001_create_users.down.sql": {Data: []byte("DROP TABLE")},
+			},
+			wantErr: "is named both",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			var versions []int
+			for _, m := range migrations {
+				versions = append(versions, m.Version)
+			}
+			if len(versions) != len(tt.versions) {
+				t.Fatalf("Load() versions = %v, want %v", versions, tt.versions)
+			}
+			for i := range versions {
+				if versions[i] != tt.versions[i] {
+					t.Fatalf("Load() versions = %v, want %v", versions, tt.versions)
+				}
+			}
+		})
+	}
+}
+
+func TestLockKey(t *testing.T) {
+	donations := New(nil, "donations", nil)
+	users := New(nil, "users", nil)
+
+	// every replica of a service takes the same lock, services never share one
+	if donations.lockKey() != New(nil, "donations", nil).lockKey() {
+		t.Error("lockKey() differs between migrators of one schema")
+	}
+	if donations.lockKey() == users.lockKey() {
+		t.Error("lockKey() is the same for two schemas")
+	}
+	if donations.LockTimeout <= 0 {
+		t.Errorf("LockTimeout = %v, want a wait for the lock", donations.LockTimeout)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	create := Migration{Version: 1, Name: "create_table", Up: "CREATE TABLE", Down: "DROP TABLE"}
+	m := New(nil, "donations", []Migration{create})
+
+	tests := []struct {
+		name    string
+		applied map[int]record
+		wantErr string
+	}{
+		{"nothing applied", map[int]record{}, ""},
+		{"applied as shipped", map[int]record{1: {name: "create_table", checksum: create.Checksum()}}, ""},
+		{"modified after applied", map[int]record{1: {name: "create_table", checksum: "edited"}}, "was modified"},
+		{"unknown to the build", map[int]record{2: {name: "add_column", checksum: "x"}}, "unknown to this build"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.check(tt.applied)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("check() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("check() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Package optimistic implements optimistic concurrency for versioned rows.
+// Every write to such a row increments its version column, and a write made
+// with an expected version is only applied while the row still has it, so
+// two writers cannot silently overwrite each other.
+package optimistic
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+)
+
+// ReasonVersionMismatch is the error reason of a write made with a version
+// the row no longer has.
+const ReasonVersionMismatch = "VERSION_MISMATCH"
+
+// Increment is the value of the version column in map updates.
+var Increment = gorm.Expr("version + 1")
+
+// Mismatch is the error of a write that lost against another one.
+func Mismatch(resource string) error {
+	return apperror.FailedPrecondition(ReasonVersionMismatch, fmt.Sprintf("%s was changed by another request, fetch it again", resource))
+}
+
+// Check returns the version a write to a row at version current is made
+// against. An expected version of 0 takes the current one, other versions
+// must match it.
+func Check(resource string, expected int, current int) (int, error) {
+	if expected != 0 && expected != current {
+		return 0, Mismatch(resource)
+	}
+	return current, nil
+}
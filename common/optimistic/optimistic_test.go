@@ -0,0 +1,49 @@
+package optimistic
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected int
+		current  int
+		want     int
+		wantErr  bool
+	}{
+		{"no expected version", 0, 3, 3, false},
+		{"current version", 3, 3, 3, false},
+		{"older version", 2, 3, 0, true},
+		{"newer version", 4, 3, 0, true},
+		{"first version", 1, 1, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Check("donation", tt.expected, tt.current)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Check() = %d, want %d", got, tt.want)
+			}
+			if err != nil {
+				if status.Code(err) != codes.FailedPrecondition || apperror.Reason(err) != ReasonVersionMismatch {
+					t.Errorf("Check() error = %v, want FailedPrecondition %s", err, ReasonVersionMismatch)
+				}
+			}
+		})
+	}
+}
+
+func TestMismatch(t *testing.T) {
+	err := Mismatch("transaction")
+	if got := status.Convert(err).Message(); got != "transaction was changed by another request, fetch it again" {
+		t.Errorf("Mismatch() message = %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const parentKey = "tracing:parent"
+
+// InstrumentGORM records a span for every query of db made with a context
+// that carries a trace, db.WithContext(ctx). Queries outside of a trace, such
+// as those of background jobs, are not recorded. The span holds the SQL with
+// its placeholders, never the values.
+func InstrumentGORM(db *gorm.DB) error {
+	tracer := otel.Tracer("gorm")
+
+	before := func(operation string) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			parent := tx.Statement.Context
+			if parent == nil || !trace.SpanContextFromContext(parent).IsValid() {
+				return
+			}
+			ctx, _ := tracer.Start(parent, "gorm."+operation, trace.WithSpanKind(trace.SpanKindClient))
+			tx.InstanceSet(parentKey, parent)
+			tx.Statement.Context = ctx
+		}
+	}
+
+	after := func(tx *gorm.DB) {
+		parent, ok := tx.InstanceGet(parentKey)
+		if !ok {
+			return
+		}
+		span := trace.SpanFromContext(tx.Statement.Context)
+		span.SetAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.sql.table", tx.Statement.Table),
+			attribute.String("db.statement", tx.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", tx.Statement.RowsAffected),
+		)
+		if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(tx.Error)
+			span.SetStatus(codes.Error, tx.Error.Error())
+		}
+		span.End()
+		// the next query of the session starts from the caller again
+		tx.Statement.Context = parent.(context.Context)
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", before("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", after),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", before("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", after),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", before("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", after),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", before("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", after),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", before("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", after),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", before("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", after),
+	)
+}
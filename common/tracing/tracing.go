@@ -0,0 +1,113 @@
+// Package tracing sets up OpenTelemetry tracing for the gateway and the
+// services. Spans are recorded for gRPC servers and clients, GORM queries and
+// outgoing HTTP calls, the W3C trace context travels with every call, and
+// spans are exported over OTLP or, for local runs, to stdout.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+)
+
+// Exporters of Config.Exporter.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Config is the tracing part of the service configurations.
+type Config struct {
+	// Exporter is none, stdout or otlp. With none, spans are not recorded but
+	// the trace context is still passed on.
+	Exporter string `env:"TRACING_EXPORTER" default:"none"`
+	// OTLPEndpoint is the collector, e.g. http://otel-collector:4317.
+	OTLPEndpoint string `env:"OTEL_EXPORTER_OTLP_ENDPOINT" default:"http://localhost:4317"`
+	// SampleRatio is the share of traces started here that are recorded,
+	// calls that arrive with a trace follow the decision of the caller.
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" default:"1"`
+}
+
+// Validate checks the exporter and the sample ratio.
+func (c Config) Validate() error {
+	switch c.Exporter {
+	case ExporterNone, ExporterStdout, ExporterOTLP:
+	default:
+		return fmt.Errorf("TRACING_EXPORTER must be none, stdout or otlp")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+	return nil
+}
+
+// Setup installs the tracer provider of service and the W3C propagators.
+// Call the returned function on shutdown, it flushes the pending spans.
+func Setup(ctx context.Context, service string, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		exporter, err = otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(cfg.OTLPEndpoint))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the %s span exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", service)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe the service: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// ServerOption records a span for every RPC the server handles, continuing
+// the trace of the caller.
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
+
+// DialOption records a span for every RPC made on the connection and passes
+// the trace on to the server.
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// Transport records a span for every request made with base and passes the
+// trace on in the traceparent header. A nil base is http.DefaultTransport.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return otelhttp.NewTransport(base)
+}
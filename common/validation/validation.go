@@ -0,0 +1,89 @@
+// Package validation holds the request rules shared by the gateway and the
+// services, so a request the gateway accepts is not rejected further down
+// for a different reason. Every check returns nil or an error describing the
+// violation, used as the field violation description.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MaxNameLength  = 100
+	MaxEmailLength = 254
+
+	// MinPasswordLength and MaxPasswordLength bound passwords, bcrypt ignores
+	// anything after 72 bytes.
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+
+	// MinDonationAmount and MaxDonationAmount bound donations, in rupiah.
+	MinDonationAmount = 10000
+	MaxDonationAmount = 100000000
+
+	MaxMessageLength = 500
+)
+
+// Name checks the display name of a user.
+func Name(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return errors.New("name is required")
+	case utf8.RuneCountInString(name) > MaxNameLength:
+		return fmt.Errorf("name must be at most %d characters long", MaxNameLength)
+	}
+	return nil
+}
+
+// Email checks that email is a bare address, without a display name.
+func Email(email string) error {
+	if strings.TrimSpace(email) == "" {
+		return errors.New("email is required")
+	}
+	if len(email) > MaxEmailLength {
+		return fmt.Errorf("email must be at most %d characters long", MaxEmailLength)
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return errors.New("email must be a valid email address")
+	}
+	return nil
+}
+
+// Password checks the password policy: 8 to 72 bytes with at least one letter
+// and one digit.
+func Password(password string) error {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return fmt.Errorf("password must be %d to %d characters long", MinPasswordLength, MaxPasswordLength)
+	}
+	var letter, digit bool
+	for _, r := range password {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+	if !letter || !digit {
+		return errors.New("password must contain a letter and a digit")
+	}
+	return nil
+}
+
+// Amount checks the amount of a donation or of its payment.
+func Amount(amount float64) error {
+	if amount < MinDonationAmount || amount > MaxDonationAmount {
+		return fmt.Errorf("amount must be between %d and %d", MinDonationAmount, MaxDonationAmount)
+	}
+	return nil
+}
+
+// Message checks the message a donor leaves, it is optional.
+func Message(message string) error {
+	if utf8.RuneCountInString(message) > MaxMessageLength {
+		return fmt.Errorf("message must be at most %d characters long", MaxMessageLength)
+	}
+	return nil
+}
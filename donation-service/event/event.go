@@ -0,0 +1,138 @@
+package event
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Event types published by donation-service.
+const (
+	DonationCreated = "donation.created"
+	InvoiceIssued   = "invoice.issued"
+	DonationSettled = "donation.settled"
+	CampaignFunded  = "campaign.funded"
+
+	// No flow in this service publishes these yet. They are declared so
+	// subscribers and templates are ready when refunds, payouts and campaign
+	// expiry move here.
+	DonationRefunded = "donation.refunded"
+	PayoutSent       = "campaign.payout_sent"
+	CampaignExpired  = "campaign.expired"
+)
+
+// Event is a domain event. ID increases by one for every published event, so
+// subscribers can resume after the last ID they saw. UserID is zero for guest
+// donations, which carry GuestEmail instead.
+type Event struct {
+	ID            int64
+	Type          string
+	CampaignID    int
+	DonationID    int
+	TransactionID int
+	UserID        int
+	GuestEmail    string
+	IsAnonymous   bool
+	Amount        float64
+	InvoiceURL    string
+	// PaymentMethod names how the donor pays, VANumber is the virtual account
+	// to transfer to when they pay by bank transfer.
+	PaymentMethod string
+	VANumber      string
+	Source        string
+	OccurredAt    time.Time
+}
+
+// Bus is an in-memory publish and subscribe hub. It only reaches subscribers
+// in the same process, every instance of the service has its own bus.
+type Bus struct {
+	mu          sync.Mutex
+	lastID      int64
+	history     []Event
+	historySize int
+	subscribers map[chan Event]struct{}
+	dropped     int64
+}
+
+func NewBus(historySize int) *Bus {
+	return &Bus{
+		historySize: historySize,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish assigns the event an ID, keeps it for replay and hands it to every
+// subscriber. Subscribers that are not keeping up miss the event instead of
+// blocking the publisher, the drop is logged and counted. Notifications and
+// webhooks do not depend on the bus, they work from the outbox.
+func (b *Bus) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	e.ID = b.lastID
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+
+	b.history = append(b.history, e)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			b.dropped++
+			slog.Warn("event bus subscriber is behind, dropped event", "event_id", e.ID, "event_type", e.Type)
+		}
+	}
+	return e
+}
+
+// Dropped returns how many deliveries to subscribers were dropped so far.
+func (b *Bus) Dropped() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Subscribe returns a channel receiving every event published from now on,
+// the events kept in history with an ID above afterID, and the ID of the last
+// published event. Call the returned function to unsubscribe.
+func (b *Bus) Subscribe(afterID int64) (<-chan Event, []Event, int64, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []Event
+	for _, e := range b.history {
+		if e.ID > afterID {
+			missed = append(missed, e)
+		}
+	}
+
+	ch := make(chan Event, 64)
+	b.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, missed, b.lastID, unsubscribe
+}
+
+// Default is the bus shared by the donation-service packages.
+var Default = NewBus(1024)
+
+func Publish(e Event) Event {
+	return Default.Publish(e)
+}
+
+func Subscribe(afterID int64) (<-chan Event, []Event, int64, func()) {
+	return Default.Subscribe(afterID)
+}
@@ -0,0 +1,51 @@
+package event
+
+import "testing"
+
+func TestBusCountsDrops(t *testing.T) {
+	tests := []struct {
+		name      string
+		published int
+		dropped   int64
+	}{
+		{"within the buffer", 64, 0},
+		{"one past the buffer", 65, 1},
+		{"far behind", 100, 36},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := NewBus(16)
+			events, _, _, unsubscribe := bus.Subscribe(0)
+			defer unsubscribe()
+
+			for i := 0; i < tt.published; i++ {
+				bus.Publish(Event{Type: DonationCreated})
+			}
+
+			if got := bus.Dropped(); got != tt.dropped {
+				t.Errorf("Dropped() = %d, want %d", got, tt.dropped)
+			}
+			if got := int64(len(events)); got != int64(tt.published)-tt.dropped {
+				t.Errorf("subscriber got %d events, want %d", got, int64(tt.published)-tt.dropped)
+			}
+		})
+	}
+}
+
+func TestSubscribeReplaysHistory(t *testing.T) {
+	bus := NewBus(3)
+	for i := 0; i < 5; i++ {
+		bus.Publish(Event{Type: DonationSettled})
+	}
+
+	_, missed, lastID, unsubscribe := bus.Subscribe(3)
+	defer unsubscribe()
+
+	if lastID != 5 {
+		t.Errorf("lastID = %d, want 5", lastID)
+	}
+	if len(missed) != 2 || missed[0].ID != 4 || missed[1].ID != 5 {
+		t.Errorf("missed = %v, want events 4 and 5", missed)
+	}
+}
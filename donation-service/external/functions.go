@@ -2,13 +2,15 @@ package external
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
 	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
 )
 
 type CreateInvoiceRequest struct {
@@ -38,6 +40,7 @@ type InvoiceResponse struct {
 	ExternalID                string        `json:"external_id"`
 	UserID                    string        `json:"user_id"`
 	PaymentMethod             string        `json:"payment_method"`
+	PaymentChannel            string        `json:"payment_channel"`
 	Status                    string        `json:"status"`
 	MerchantName              string        `json:"merchant_name"`
 	MerchantProfilePictureUrl string        `json:"merchant_profile_picture_url"`
@@ -51,7 +54,7 @@ type InvoiceResponse struct {
 	AvailableBanks            []interface{} `json:"available_banks"`
 }
 
-func CreateInvoice(externalId string, amount int, payerEmail string, description string) (InvoiceResponse, error) {
+func CreateInvoice(ctx context.Context, externalId string, amount int, payerEmail string, description string) (InvoiceResponse, error) {
 	request := CreateInvoiceRequest{
 		ExternalID:  externalId,
 		Amount:      amount,
@@ -60,68 +63,64 @@ func CreateInvoice(externalId string, amount int, payerEmail string, description
 	}
 
 	url := "https://api.xendit.co/v2/invoices"
-	client := &http.Client{}
-
 	reqBody, err := json.Marshal(request)
 	if err != nil {
 		return InvoiceResponse{}, err
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return InvoiceResponse{}, err
 	}
-	apiKey := os.Getenv("XENDIT_API_KEY")
+	apiKey := config.App.Xendit.APIKey
 	if apiKey == "" {
-		log.Printf("XENDIT_API_KEY is not set in the environment")
-		return InvoiceResponse{}, fmt.Errorf("XENDIT_API_KEY is not set in the environment")
+		slog.ErrorContext(ctx, "XENDIT_API_KEY is not configured")
+		return InvoiceResponse{}, fmt.Errorf("XENDIT_API_KEY is not configured")
 	}
 	req.SetBasicAuth(apiKey, "")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+	resp, err := xenditClient.Do(req)
 	if err != nil {
 		return InvoiceResponse{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("failed to create invoice, status code: %d", resp.StatusCode)
+		slog.WarnContext(ctx, "failed to create invoice", "status", resp.StatusCode)
 		return InvoiceResponse{}, fmt.Errorf("failed to create invoice, status code: %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("failed to read response body: %v", err)
+		slog.ErrorContext(ctx, "failed to read the xendit response", "error", err)
 		return InvoiceResponse{}, err
 	}
 
 	var createInvoiceResponse InvoiceResponse
 	if err := json.Unmarshal(body, &createInvoiceResponse); err != nil {
-		log.Printf("failed to unmarshal response body: %v", err)
+		slog.ErrorContext(ctx, "failed to decode the xendit response", "error", err)
 		return InvoiceResponse{}, err
 	}
 
 	return createInvoiceResponse, nil
 }
 
-func GetInvoice(invoiceID string) (InvoiceResponse, error) {
+func GetInvoice(ctx context.Context, invoiceID string) (InvoiceResponse, error) {
 	url := "https://api.xendit.co/v2/invoices/" + invoiceID
-	client := &http.Client{}
-
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return InvoiceResponse{}, err
 	}
-	apiKey := os.Getenv("XENDIT_API_KEY")
+	apiKey := config.App.Xendit.APIKey
 	if apiKey == "" {
-		log.Printf("XENDIT_API_KEY is not set in the environment")
-		return InvoiceResponse{}, fmt.Errorf("XENDIT_API_KEY is not set in the environment")
+		slog.ErrorContext(ctx, "XENDIT_API_KEY is not configured")
+		return InvoiceResponse{}, fmt.Errorf("XENDIT_API_KEY is not configured")
 	}
 	req.SetBasicAuth(apiKey, "")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+	resp, err := xenditClient.Do(req)
 	if err != nil {
 		return InvoiceResponse{}, err
 	}
@@ -129,21 +128,21 @@ func GetInvoice(invoiceID string) (InvoiceResponse, error) {
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("failed to read response body: %v", err)
+		slog.ErrorContext(ctx, "failed to read the xendit response", "error", err)
 		return InvoiceResponse{}, err
 	}
 
 	var getInvoiceResponse InvoiceResponse
 	if err := json.Unmarshal(body, &getInvoiceResponse); err != nil {
-		log.Printf("failed to unmarshal response body: %v", err)
+		slog.ErrorContext(ctx, "failed to decode the xendit response", "error", err)
 		return InvoiceResponse{}, err
 	}
 
 	return getInvoiceResponse, nil
 }
 
-func GetInvoiceStatus(invoiceID string) (string, error) {
-	invoice, err := GetInvoice(invoiceID)
+func GetInvoiceStatus(ctx context.Context, invoiceID string) (string, error) {
+	invoice, err := GetInvoice(ctx, invoiceID)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,285 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/tracing"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+)
+
+const xenditBaseURL = "https://api.xendit.co"
+
+// qrCodeAPIVersion pins the QR code API, its responses changed between
+// versions.
+const qrCodeAPIVersion = "2022-07-31"
+
+// xenditClient traces its requests, a slow payment shows up in the trace of
+// the donation.
+var xenditClient = &http.Client{Timeout: 15 * time.Second, Transport: tracing.Transport(nil)}
+
+// xenditRequest calls the Xendit API and decodes the JSON response into out.
+func xenditRequest(ctx context.Context, method string, path string, body interface{}, headers map[string]string, out interface{}) error {
+	apiKey := config.App.Xendit.APIKey
+	if apiKey == "" {
+		slog.ErrorContext(ctx, "XENDIT_API_KEY is not configured")
+		return fmt.Errorf("XENDIT_API_KEY is not configured")
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, xenditBaseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(apiKey, "")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := xenditClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to read the xendit response", "path", path, "error", err)
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			ErrorCode string `json:"error_code"`
+			Message   string `json:"message"`
+		}
+		json.Unmarshal(respBody, &apiErr)
+		slog.WarnContext(ctx, "xendit request failed", "method", method, "path", path, "status", resp.StatusCode, "error_code", apiErr.ErrorCode)
+		return fmt.Errorf("xendit request failed, status code: %d, error: %s %s", resp.StatusCode, apiErr.ErrorCode, apiErr.Message)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		slog.ErrorContext(ctx, "failed to decode the xendit response", "path", path, "error", err)
+		return err
+	}
+	return nil
+}
+
+type CreateVirtualAccountRequest struct {
+	ExternalID     string    `json:"external_id"`
+	BankCode       string    `json:"bank_code"`
+	Name           string    `json:"name"`
+	ExpectedAmount int       `json:"expected_amount"`
+	IsClosed       bool      `json:"is_closed"`
+	IsSingleUse    bool      `json:"is_single_use"`
+	ExpirationDate time.Time `json:"expiration_date"`
+}
+
+type VirtualAccountResponse struct {
+	ID             string    `json:"id"`
+	ExternalID     string    `json:"external_id"`
+	BankCode       string    `json:"bank_code"`
+	AccountNumber  string    `json:"account_number"`
+	Name           string    `json:"name"`
+	ExpectedAmount int       `json:"expected_amount"`
+	Status         string    `json:"status"`
+	ExpirationDate time.Time `json:"expiration_date"`
+}
+
+type VirtualAccountPaymentResponse struct {
+	ID                       string    `json:"id"`
+	PaymentID                string    `json:"payment_id"`
+	CallbackVirtualAccountID string    `json:"callback_virtual_account_id"`
+	ExternalID               string    `json:"external_id"`
+	BankCode                 string    `json:"bank_code"`
+	AccountNumber            string    `json:"account_number"`
+	Amount                   int       `json:"amount"`
+	TransactionTimestamp     time.Time `json:"transaction_timestamp"`
+}
+
+// CreateVirtualAccount opens a closed, single use virtual account for exactly
+// the expected amount.
+func CreateVirtualAccount(ctx context.Context, request CreateVirtualAccountRequest) (VirtualAccountResponse, error) {
+	var response VirtualAccountResponse
+	err := xenditRequest(ctx, http.MethodPost, "/callback_virtual_accounts", request, nil, &response)
+	return response, err
+}
+
+func GetVirtualAccount(ctx context.Context, id string) (VirtualAccountResponse, error) {
+	var response VirtualAccountResponse
+	err := xenditRequest(ctx, http.MethodGet, "/callback_virtual_accounts/"+id, nil, nil, &response)
+	return response, err
+}
+
+// ExpireVirtualAccount moves the expiration of a virtual account to now, it
+// takes no more payments.
+func ExpireVirtualAccount(ctx context.Context, id string) (VirtualAccountResponse, error) {
+	var response VirtualAccountResponse
+	body := map[string]time.Time{"expiration_date": time.Now().UTC()}
+	err := xenditRequest(ctx, http.MethodPatch, "/callback_virtual_accounts/"+id, body, nil, &response)
+	return response, err
+}
+
+// ExpireInvoice expires an invoice right away, it can no longer be paid.
+func ExpireInvoice(ctx context.Context, id string) (InvoiceResponse, error) {
+	var response InvoiceResponse
+	err := xenditRequest(ctx, http.MethodPost, "/invoices/"+id+"/expire!", nil, nil, &response)
+	return response, err
+}
+
+// GetVirtualAccountPayment returns a payment into a virtual account, by the
+// payment ID sent in the payment callback.
+func GetVirtualAccountPayment(ctx context.Context, paymentID string) (VirtualAccountPaymentResponse, error) {
+	var response VirtualAccountPaymentResponse
+	err := xenditRequest(ctx, http.MethodGet, "/callback_virtual_account_payments/payment_id="+paymentID, nil, nil, &response)
+	return response, err
+}
+
+// Transaction is a money movement on the Xendit account, as listed by the
+// transactions API. ProductID is the ID of what was paid, e.g. the virtual
+// account.
+type Transaction struct {
+	ID              string    `json:"id"`
+	ProductID       string    `json:"product_id"`
+	Type            string    `json:"type"`
+	Status          string    `json:"status"`
+	ChannelCategory string    `json:"channel_category"`
+	ChannelCode     string    `json:"channel_code"`
+	ReferenceID     string    `json:"reference_id"`
+	Amount          float64   `json:"amount"`
+	Created         time.Time `json:"created"`
+}
+
+type TransactionsResponse struct {
+	Data    []Transaction `json:"data"`
+	HasMore bool          `json:"has_more"`
+}
+
+// GetVirtualAccountPayments lists the payments into the virtual accounts
+// opened with an external ID.
+func GetVirtualAccountPayments(ctx context.Context, externalID string) (TransactionsResponse, error) {
+	query := url.Values{
+		"types":              {"PAYMENT"},
+		"channel_categories": {"VIRTUAL_ACCOUNT"},
+		"reference_id":       {externalID},
+	}
+	var response TransactionsResponse
+	err := xenditRequest(ctx, http.MethodGet, "/transactions?"+query.Encode(), nil, nil, &response)
+	return response, err
+}
+
+type EWalletChargeProperties struct {
+	MobileNumber       string `json:"mobile_number,omitempty"`
+	SuccessRedirectURL string `json:"success_redirect_url,omitempty"`
+}
+
+type CreateEWalletChargeRequest struct {
+	ReferenceID       string                  `json:"reference_id"`
+	Currency          string                  `json:"currency"`
+	Amount            int                     `json:"amount"`
+	CheckoutMethod    string                  `json:"checkout_method"`
+	ChannelCode       string                  `json:"channel_code"`
+	ChannelProperties EWalletChargeProperties `json:"channel_properties"`
+}
+
+type EWalletChargeActions struct {
+	DesktopWebCheckoutURL     string `json:"desktop_web_checkout_url"`
+	MobileWebCheckoutURL      string `json:"mobile_web_checkout_url"`
+	MobileDeeplinkCheckoutURL string `json:"mobile_deeplink_checkout_url"`
+	QRCheckoutString          string `json:"qr_checkout_string"`
+}
+
+type EWalletChargeResponse struct {
+	ID           string               `json:"id"`
+	ReferenceID  string               `json:"reference_id"`
+	Status       string               `json:"status"`
+	Currency     string               `json:"currency"`
+	ChargeAmount int                  `json:"charge_amount"`
+	ChannelCode  string               `json:"channel_code"`
+	Actions      EWalletChargeActions `json:"actions"`
+	Created      time.Time            `json:"created"`
+	Updated      time.Time            `json:"updated"`
+}
+
+func CreateEWalletCharge(ctx context.Context, request CreateEWalletChargeRequest) (EWalletChargeResponse, error) {
+	var response EWalletChargeResponse
+	err := xenditRequest(ctx, http.MethodPost, "/ewallets/charges", request, nil, &response)
+	return response, err
+}
+
+func GetEWalletCharge(ctx context.Context, id string) (EWalletChargeResponse, error) {
+	var response EWalletChargeResponse
+	err := xenditRequest(ctx, http.MethodGet, "/ewallets/charges/"+id, nil, nil, &response)
+	return response, err
+}
+
+type CreateQRCodeRequest struct {
+	ReferenceID string    `json:"reference_id"`
+	Type        string    `json:"type"`
+	Currency    string    `json:"currency"`
+	Amount      int       `json:"amount"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type QRCodeResponse struct {
+	ID          string    `json:"id"`
+	ReferenceID string    `json:"reference_id"`
+	Type        string    `json:"type"`
+	Currency    string    `json:"currency"`
+	Amount      int       `json:"amount"`
+	Status      string    `json:"status"`
+	QRString    string    `json:"qr_string"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type QRCodePayment struct {
+	ID      string    `json:"id"`
+	QRID    string    `json:"qr_id"`
+	Status  string    `json:"status"`
+	Amount  int       `json:"amount"`
+	Created time.Time `json:"created"`
+}
+
+type QRCodePaymentsResponse struct {
+	Data []QRCodePayment `json:"data"`
+}
+
+// CreateQRCode creates a dynamic QRIS code for exactly the amount.
+func CreateQRCode(ctx context.Context, request CreateQRCodeRequest) (QRCodeResponse, error) {
+	var response QRCodeResponse
+	headers := map[string]string{"api-version": qrCodeAPIVersion}
+	err := xenditRequest(ctx, http.MethodPost, "/qr_codes", request, headers, &response)
+	return response, err
+}
+
+func GetQRCode(ctx context.Context, id string) (QRCodeResponse, error) {
+	var response QRCodeResponse
+	headers := map[string]string{"api-version": qrCodeAPIVersion}
+	err := xenditRequest(ctx, http.MethodGet, "/qr_codes/"+id, nil, headers, &response)
+	return response, err
+}
+
+func GetQRCodePayments(ctx context.Context, id string) (QRCodePaymentsResponse, error) {
+	var response QRCodePaymentsResponse
+	headers := map[string]string{"api-version": qrCodeAPIVersion}
+	err := xenditRequest(ctx, http.MethodGet, "/qr_codes/"+id+"/payments", nil, headers, &response)
+	return response, err
+}
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS donations.webhook_deliveries;
+DROP TABLE IF EXISTS donations.webhook_subscriptions;
+DROP TABLE IF EXISTS donations.notification_preferences;
+DROP TABLE IF EXISTS donations.notifications;
+DROP TABLE IF EXISTS donations.receipt_sequences;
+DROP TABLE IF EXISTS donations.receipts;
+DROP TABLE IF EXISTS donations.transactions;
+DROP TABLE IF EXISTS donations.donations;
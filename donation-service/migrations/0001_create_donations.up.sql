@@ -0,0 +1,152 @@
+-- Donations and their payments, receipts, notifications and partner webhooks.
+-- Users and campaigns belong to other services, their IDs are not foreign
+-- keys. IF NOT EXISTS adopts databases created by hand before migrations
+-- existed.
+CREATE TABLE IF NOT EXISTS donations.donations (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER,
+    campaign_id INTEGER NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    message VARCHAR(255),
+    status VARCHAR(50) DEFAULT 'PENDING',
+    is_anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    guest_email VARCHAR(150), -- set for guest checkout, user_id stays NULL until claimed
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tables created by hand predate anonymous and guest donations
+ALTER TABLE donations.donations ADD COLUMN IF NOT EXISTS is_anonymous BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE donations.donations ADD COLUMN IF NOT EXISTS guest_email VARCHAR(150);
+
+-- Donor wall and leaderboard read completed donations per campaign, newest first
+CREATE INDEX IF NOT EXISTS idx_donations_campaign_status ON donations.donations (campaign_id, status, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS donations.transactions (
+    id SERIAL PRIMARY KEY,
+    donation_id INTEGER NOT NULL REFERENCES donations.donations(id) ON DELETE CASCADE,
+    previous_transaction_id INTEGER REFERENCES donations.transactions(id),
+    invoice_id VARCHAR(255),
+    invoice_url VARCHAR(255),
+    invoice_description VARCHAR(255),
+    payment_method VARCHAR(50),
+    payment_channel VARCHAR(50),
+    va_number VARCHAR(50),
+    qr_string TEXT,
+    deeplink_url VARCHAR(500),
+    amount NUMERIC(15,2) NOT NULL,
+    status VARCHAR(50) DEFAULT 'PENDING',
+    expires_at TIMESTAMP,
+    paid_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tables created by hand only know invoices, paid once
+ALTER TABLE donations.transactions ADD COLUMN IF NOT EXISTS previous_transaction_id INTEGER REFERENCES donations.transactions(id);
+ALTER TABLE donations.transactions ADD COLUMN IF NOT EXISTS payment_channel VARCHAR(50);
+ALTER TABLE donations.transactions ADD COLUMN IF NOT EXISTS va_number VARCHAR(50);
+ALTER TABLE donations.transactions ADD COLUMN IF NOT EXISTS qr_string TEXT;
+ALTER TABLE donations.transactions ADD COLUMN IF NOT EXISTS deeplink_url VARCHAR(500);
+ALTER TABLE donations.transactions ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+ALTER TABLE donations.transactions ADD COLUMN IF NOT EXISTS paid_at TIMESTAMP;
+
+-- Provider callbacks look transactions up by their payment reference
+CREATE INDEX IF NOT EXISTS idx_transactions_invoice_id ON donations.transactions (invoice_id);
+
+-- A transaction is reissued at most once, the next attempt links back to it
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_previous ON donations.transactions (previous_transaction_id);
+
+-- One receipt per paid donation
+CREATE TABLE IF NOT EXISTS donations.receipts (
+    id SERIAL PRIMARY KEY,
+    number VARCHAR(30) UNIQUE NOT NULL,
+    year INTEGER NOT NULL,
+    sequence INTEGER NOT NULL,
+    donation_id INTEGER UNIQUE NOT NULL REFERENCES donations.donations(id) ON DELETE CASCADE,
+    transaction_id INTEGER NOT NULL,
+    donor_name VARCHAR(100),
+    donor_email VARCHAR(150),
+    campaign_id INTEGER,
+    campaign_title VARCHAR(200),
+    amount NUMERIC(15,2) NOT NULL,
+    payment_method VARCHAR(50),
+    invoice_id VARCHAR(255),
+    paid_at TIMESTAMP NOT NULL,
+    pdf BYTEA NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (year, sequence)
+);
+
+-- Last receipt number per year, locked while a receipt is issued so numbers have no gaps
+CREATE TABLE IF NOT EXISTS donations.receipt_sequences (
+    year INTEGER PRIMARY KEY,
+    last_value INTEGER NOT NULL DEFAULT 0
+);
+
+-- One row per recipient and channel with its delivery state
+CREATE TABLE IF NOT EXISTS donations.notifications (
+    id SERIAL PRIMARY KEY,
+    event_id BIGINT,
+    event_type VARCHAR(50) NOT NULL,
+    user_id INTEGER,
+    email VARCHAR(150),
+    webhook_url VARCHAR(255),
+    channel VARCHAR(20) NOT NULL, -- email, webhook
+    locale VARCHAR(5) NOT NULL, -- id, en
+    subject VARCHAR(255),
+    body TEXT,
+    status VARCHAR(20) NOT NULL, -- PENDING, SENT, RETRYING, FAILED
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Retries pick due notifications by status and time
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON donations.notifications (status, next_attempt_at);
+
+-- Users without a row get email in Bahasa Indonesia
+CREATE TABLE IF NOT EXISTS donations.notification_preferences (
+    user_id INTEGER PRIMARY KEY,
+    locale VARCHAR(5) NOT NULL DEFAULT 'id',
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    webhook_url VARCHAR(255),
+    muted_events VARCHAR(255), -- comma separated event types
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Partner webhooks, owned by the campaign owner
+CREATE TABLE IF NOT EXISTS donations.webhook_subscriptions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    campaign_id INTEGER NOT NULL,
+    url VARCHAR(255) NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    event_types VARCHAR(255) NOT NULL, -- comma separated event types
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_campaign ON donations.webhook_subscriptions (campaign_id) WHERE active;
+
+CREATE TABLE IF NOT EXISTS donations.webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    subscription_id INTEGER NOT NULL REFERENCES donations.webhook_subscriptions(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL, -- PENDING, DELIVERED, RETRYING, DEAD
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON donations.webhook_deliveries (subscription_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON donations.webhook_deliveries (status, next_attempt_at);
@@ -0,0 +1,8 @@
+-- The constraints are dropped, the mapped status values are kept.
+ALTER TABLE donations.transactions
+    DROP CONSTRAINT IF EXISTS transactions_status_check,
+    ALTER COLUMN status DROP NOT NULL;
+
+ALTER TABLE donations.donations
+    DROP CONSTRAINT IF EXISTS donations_status_check,
+    ALTER COLUMN status DROP NOT NULL;
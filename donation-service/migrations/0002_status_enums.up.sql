@@ -0,0 +1,27 @@
+-- Donation and transaction statuses become closed sets, matching the
+-- DonationStatus and TransactionStatus enums of donation.proto. Existing
+-- values are mapped first, then constraints keep new ones in the set.
+
+-- Donations: PENDING, COMPLETED, ABANDONED
+UPDATE donations.donations SET status = UPPER(TRIM(status)) WHERE status IS NOT NULL;
+UPDATE donations.donations SET status = 'COMPLETED' WHERE status IN ('PAID', 'SETTLED', 'SUCCESS', 'COMPLETE');
+UPDATE donations.donations SET status = 'ABANDONED' WHERE status IN ('EXPIRED', 'FAILED', 'CANCELLED');
+UPDATE donations.donations SET status = 'PENDING' WHERE status IS NULL OR status NOT IN ('PENDING', 'COMPLETED', 'ABANDONED');
+
+-- databases created by hand may already have the constraint
+ALTER TABLE donations.donations DROP CONSTRAINT IF EXISTS donations_status_check;
+ALTER TABLE donations.donations
+    ALTER COLUMN status SET NOT NULL,
+    ADD CONSTRAINT donations_status_check CHECK (status IN ('PENDING', 'COMPLETED', 'ABANDONED'));
+
+-- Transactions: PENDING, PAID, SETTLED, EXPIRED, FAILED. Unknown values are
+-- pending again, so the reconciler fetches their real status from the provider.
+UPDATE donations.transactions SET status = UPPER(TRIM(status)) WHERE status IS NOT NULL;
+UPDATE donations.transactions SET status = 'PAID' WHERE status IN ('SUCCEEDED', 'SUCCESS', 'COMPLETED');
+UPDATE donations.transactions SET status = 'FAILED' WHERE status IN ('VOIDED', 'CANCELLED');
+UPDATE donations.transactions SET status = 'PENDING' WHERE status IS NULL OR status NOT IN ('PENDING', 'PAID', 'SETTLED', 'EXPIRED', 'FAILED');
+
+ALTER TABLE donations.transactions DROP CONSTRAINT IF EXISTS transactions_status_check;
+ALTER TABLE donations.transactions
+    ALTER COLUMN status SET NOT NULL,
+    ADD CONSTRAINT transactions_status_check CHECK (status IN ('PENDING', 'PAID', 'SETTLED', 'EXPIRED', 'FAILED'));
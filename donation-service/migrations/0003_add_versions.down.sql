@@ -0,0 +1,2 @@
+ALTER TABLE donations.transactions DROP COLUMN IF EXISTS version;
+ALTER TABLE donations.donations DROP COLUMN IF EXISTS version;
@@ -0,0 +1,4 @@
+-- The version is incremented by every update, writes made with an expected
+-- version are only applied while the row still has it.
+ALTER TABLE donations.donations ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE donations.transactions ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
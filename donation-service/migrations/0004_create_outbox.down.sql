@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS donations.campaign_credits;
+DROP TABLE IF EXISTS donations.outbox_events;
@@ -0,0 +1,27 @@
+-- Events are stored in the same transaction as the change they describe, the
+-- relay publishes them on the in-memory bus once that commits.
+CREATE TABLE IF NOT EXISTS donations.outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL, -- the event as JSON
+    published_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON donations.outbox_events (id) WHERE published_at IS NULL;
+
+-- One row per settled donation, added to the collected amount of its
+-- campaign by campaign-service. Failed calls are retried on their own.
+CREATE TABLE IF NOT EXISTS donations.campaign_credits (
+    donation_id INTEGER PRIMARY KEY REFERENCES donations.donations(id),
+    campaign_id INTEGER NOT NULL,
+    amount NUMERIC(15,2) NOT NULL,
+    source VARCHAR(20), -- the path that settled the donation
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL,
+    credited_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_credits_due ON donations.campaign_credits (next_attempt_at) WHERE credited_at IS NULL;
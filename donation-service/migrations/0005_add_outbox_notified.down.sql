@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS donations.idx_outbox_events_unnotified;
+ALTER TABLE donations.outbox_events DROP COLUMN IF EXISTS notify_attempts;
+ALTER TABLE donations.outbox_events DROP COLUMN IF EXISTS notified_at;
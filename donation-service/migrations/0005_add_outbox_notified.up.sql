@@ -0,0 +1,6 @@
+-- Notifications are created from the outbox, so none is lost when the bus
+-- drops an event or the process dies before sending.
+ALTER TABLE donations.outbox_events ADD COLUMN IF NOT EXISTS notified_at TIMESTAMP;
+ALTER TABLE donations.outbox_events ADD COLUMN IF NOT EXISTS notify_attempts INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unnotified ON donations.outbox_events (id) WHERE notified_at IS NULL;
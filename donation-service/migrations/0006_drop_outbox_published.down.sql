@@ -0,0 +1,2 @@
+ALTER TABLE donations.outbox_events ADD COLUMN IF NOT EXISTS published_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON donations.outbox_events (id) WHERE published_at IS NULL;
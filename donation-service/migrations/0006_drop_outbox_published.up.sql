@@ -0,0 +1,4 @@
+-- Every instance tails the outbox with its own cursor, there is no shared
+-- published state to keep.
+DROP INDEX IF EXISTS donations.idx_outbox_events_unpublished;
+ALTER TABLE donations.outbox_events DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1 @@
+ALTER TABLE donations.outbox_events DROP COLUMN IF EXISTS notify_lease_until;
@@ -0,0 +1,3 @@
+-- An instance claims an event for a while before notifying about it, so the
+-- notifications are sent outside any database transaction.
+ALTER TABLE donations.outbox_events ADD COLUMN IF NOT EXISTS notify_lease_until TIMESTAMP;
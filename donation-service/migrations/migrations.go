@@ -0,0 +1,25 @@
+// Package migrations embeds the schema of donation-service, the donations schema, as
+// versioned migrations and the sample data of the seed command.
+package migrations
+
+import (
+	"embed"
+
+	"github.com/rayhanadri/crowdfunding/common/migrate"
+)
+
+// Schema is the PostgreSQL schema donation-service owns.
+const Schema = "donations"
+
+//go:embed *.up.sql *.down.sql
+var files embed.FS
+
+// Seed is the sample data of the seed command.
+//
+//go:embed seed.sql
+var Seed string
+
+// All returns the migrations of the donations schema.
+func All() ([]migrate.Migration, error) {
+	return migrate.Load(files)
+}
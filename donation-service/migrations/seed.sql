@@ -0,0 +1,26 @@
+-- Sample donations and payments for development, matching the sample users of
+-- user-service. Run with the seed command, it is never applied by migrations.
+-- Rows already present are kept.
+INSERT INTO donations.donations (id, user_id, campaign_id, amount, message, status)
+VALUES
+(1, 2, 1, 50000, 'Semoga lekas sembuh', 'COMPLETED'),
+(2, 3, 2, 100000, 'Semoga mushola cepat direnovasi', 'COMPLETED'),
+(3, 4, 3, 25000, 'Bantu pendidikan generasi penerus', 'PENDING'),
+(4, 5, 4, 75000, 'Bantuan untuk saudara di Kalimantan', 'COMPLETED'),
+(5, 1, 5, 30000, 'Semoga usaha berjalan lancar', 'PENDING')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO donations.transactions (
+    id, donation_id, invoice_id, invoice_url, invoice_description,
+    payment_method, amount, status
+) VALUES
+(1, 1, 'INV-20240520-001', 'https://example.com/invoice/INV-20240520-001', 'Donasi untuk Pengobatan Anak Yatim', 'INVOICE', 50000, 'PAID'),
+(2, 2, 'INV-20240520-002', 'https://example.com/invoice/INV-20240520-002', 'Donasi untuk Renovasi Mushola', 'INVOICE', 100000, 'PAID'),
+(3, 3, 'INV-20240520-003', 'https://example.com/invoice/INV-20240520-003', 'Donasi Pendidikan Anak Kurang Mampu', 'INVOICE', 25000, 'PENDING'),
+(4, 4, 'INV-20240520-004', 'https://example.com/invoice/INV-20240520-004', 'Bantuan Korban Banjir', 'INVOICE', 75000, 'PAID'),
+(5, 5, 'INV-20240520-005', 'https://example.com/invoice/INV-20240520-005', 'Donasi Modal Usaha Ibu Rumah Tangga', 'INVOICE', 30000, 'PENDING')
+ON CONFLICT DO NOTHING;
+
+-- the IDs were given, move the sequences past them
+SELECT setval(pg_get_serial_sequence('donations.donations', 'id'), GREATEST((SELECT MAX(id) FROM donations.donations), 1));
+SELECT setval(pg_get_serial_sequence('donations.transactions', 'id'), GREATEST((SELECT MAX(id) FROM donations.transactions), 1));
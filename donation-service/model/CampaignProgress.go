@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// CampaignProgress is sent to campaign watchers every time a donation to the
+// campaign settles.
+type CampaignProgress struct {
+	EventID       int64     `json:"event_id"`
+	CampaignID    int       `json:"campaign_id"`
+	DonationID    int       `json:"donation_id,omitempty"`
+	Amount        float64   `json:"amount,omitempty"`
+	TotalRaised   float64   `json:"total_raised"`
+	DonationCount int       `json:"donation_count"`
+	DonorCount    int       `json:"donor_count"`
+	Source        string    `json:"source,omitempty"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
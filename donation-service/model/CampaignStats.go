@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// CampaignStats sums up the completed donations of a campaign for its owner.
+type CampaignStats struct {
+	CampaignID      int     `json:"campaign_id"`
+	TotalRaised     float64 `json:"total_raised"`
+	DonationCount   int     `json:"donation_count"`
+	DonorCount      int     `json:"donor_count"`
+	AverageDonation float64 `json:"average_donation"`
+	MedianDonation  float64 `json:"median_donation"`
+	// RepeatDonorRate is the share of donors who gave more than once, from 0
+	// to 1.
+	RepeatDonorRate float64 `json:"repeat_donor_rate"`
+}
+
+// DonationTimeSeries is the completed donations of a campaign per day or per
+// week, in a timezone.
+type DonationTimeSeries struct {
+	CampaignID int                `json:"campaign_id"`
+	Interval   string             `json:"interval"`
+	Timezone   string             `json:"timezone"`
+	Buckets    []TimeSeriesBucket `json:"buckets"`
+}
+
+// TimeSeriesBucket is the donations of a day or a week, Start is midnight at
+// its start in the timezone of the series.
+type TimeSeriesBucket struct {
+	Start         time.Time `json:"start"`
+	TotalAmount   float64   `json:"total_amount"`
+	DonationCount int       `json:"donation_count"`
+}
@@ -11,13 +11,44 @@ type Donation struct {
 	CampaignID int `json:"campaign_id"`
 	// Campaign   Campaign `gorm:"foreignKey:CampaignID" json:"campaign"` // corrected the import path
 
-	Amount    float64   `json:"amount"`
-	Message   string    `json:"message"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Amount      float64        `json:"amount"`
+	Message     string         `json:"message"`
+	Status      DonationStatus `json:"status"`
+	IsAnonymous bool           `gorm:"default:false" json:"is_anonymous"`
+	GuestEmail  string         `gorm:"size:150" json:"guest_email,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	// Version is incremented by every update, see the optimistic package.
+	Version int `gorm:"not null;default:1" json:"version"`
+	// Donor is the user who made the donation, in listings.
+	Donor *DonorSummary `gorm:"-" json:"donor,omitempty"`
+}
+
+// DonorSummary is what may be shown of the user who made a donation, the
+// email is masked.
+type DonorSummary struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	AvatarURL   string `json:"avatar_url"`
+	MaskedEmail string `json:"masked_email"`
 }
 
 func (Donation) TableName() string {
 	return "donations.donations"
 }
+
+// IsGuest reports whether the donation was made without an account.
+func (d *Donation) IsGuest() bool {
+	return d.UserID == 0 && d.GuestEmail != ""
+}
+
+// MaskDonor hides the donor identity for public listings. Anonymous donations
+// lose their user ID, and guest emails are never shown publicly. The stored
+// record is untouched, finance still reads the full donation.
+func (d *Donation) MaskDonor() {
+	if d.IsAnonymous {
+		d.UserID = 0
+		d.Donor = nil
+	}
+	d.GuestEmail = ""
+}
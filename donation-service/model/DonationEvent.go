@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// DonationEvent tells what happened to a donation, see the event package for
+// the types. It carries IDs only, no donor details.
+type DonationEvent struct {
+	EventID    int64     `json:"event_id"`
+	Type       string    `json:"type"`
+	CampaignID int       `json:"campaign_id"`
+	DonationID int       `json:"donation_id"`
+	UserID     int       `json:"user_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Notification is a message rendered for one recipient on one channel. The
+// rendered subject and body are kept so a retry sends exactly the same text.
+type Notification struct {
+	ID            int        `gorm:"primaryKey" json:"id"`
+	EventID       int64      `json:"event_id"`
+	EventType     string     `gorm:"size:50;not null" json:"event_type"`
+	UserID        *int       `json:"user_id,omitempty"`
+	Email         string     `gorm:"size:150" json:"email,omitempty"`
+	WebhookURL    string     `gorm:"size:255" json:"webhook_url,omitempty"`
+	Channel       string     `gorm:"size:20;not null" json:"channel"`
+	Locale        string     `gorm:"size:5;not null" json:"locale"`
+	Subject       string     `gorm:"size:255" json:"subject"`
+	Body          string     `json:"body"`
+	Status        string     `gorm:"size:20;not null;index" json:"status"`
+	Attempts      int        `gorm:"not null" json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `gorm:"index" json:"next_attempt_at"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Notification) TableName() string {
+	return "donations.notifications"
+}
+
+// NotificationPreference is how a user wants to be notified. Users without a
+// stored preference get email in Bahasa Indonesia for every event.
+type NotificationPreference struct {
+	UserID       int       `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	Locale       string    `gorm:"size:5;not null" json:"locale"`
+	EmailEnabled bool      `gorm:"not null" json:"email_enabled"`
+	WebhookURL   string    `gorm:"size:255" json:"webhook_url"`
+	MutedEvents  string    `gorm:"size:255" json:"muted_events"` // comma separated event types
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "donations.notification_preferences"
+}
+
+// Muted reports whether the user turned off notifications for an event type.
+func (p *NotificationPreference) Muted(eventType string) bool {
+	for _, muted := range p.MutedEventList() {
+		if muted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// MutedEventList splits MutedEvents into event types.
+func (p *NotificationPreference) MutedEventList() []string {
+	var events []string
+	for _, muted := range strings.Split(p.MutedEvents, ",") {
+		if muted = strings.TrimSpace(muted); muted != "" {
+			events = append(events, muted)
+		}
+	}
+	return events
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// OutboxEvent is an event stored with the change it describes. Payload is the
+// event as JSON and NotifiedAt is set once its notifications were created.
+// NotifyLeaseUntil keeps other instances off an event while one notifies.
+type OutboxEvent struct {
+	ID             int64      `gorm:"primaryKey" json:"id"`
+	EventType      string     `gorm:"size:50;not null" json:"event_type"`
+	Payload        string     `gorm:"not null" json:"payload"`
+	NotifiedAt     *time.Time `json:"notified_at,omitempty"`
+	NotifyAttempts int        `gorm:"not null" json:"notify_attempts"`
+	// NotifyLeaseUntil is set while an instance notifies about the event.
+	NotifyLeaseUntil *time.Time `json:"-"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "donations.outbox_events"
+}
+
+// CampaignCredit is the amount of a settled donation still to be added to
+// the collected amount of its campaign, or already added once CreditedAt is
+// set. There is one per donation, so it is never added twice.
+type CampaignCredit struct {
+	DonationID    int        `gorm:"primaryKey;autoIncrement:false" json:"donation_id"`
+	CampaignID    int        `gorm:"not null" json:"campaign_id"`
+	Amount        float64    `gorm:"not null" json:"amount"`
+	Source        string     `gorm:"size:20" json:"source"`
+	Attempts      int        `gorm:"not null" json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	NextAttemptAt time.Time  `gorm:"not null" json:"next_attempt_at"`
+	CreditedAt    *time.Time `json:"credited_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (CampaignCredit) TableName() string {
+	return "donations.campaign_credits"
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+const (
+	AnonymousDonorName = "Anonymous"
+	GuestDonorName     = "Guest"
+)
+
+// PublicDonation is a completed donation as shown on a public campaign page.
+type PublicDonation struct {
+	ID          int       `json:"id"`
+	CampaignID  int       `json:"campaign_id"`
+	UserID      int       `json:"user_id,omitempty"`
+	DonorName   string    `json:"donor_name"`
+	Amount      float64   `json:"amount"`
+	Message     string    `json:"message"`
+	IsAnonymous bool      `json:"is_anonymous"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type PublicDonationPage struct {
+	Donations []PublicDonation `json:"donations"`
+	Page      int              `json:"page"`
+	PageSize  int              `json:"page_size"`
+	Total     int64            `json:"total"`
+}
+
+// DonorTotal is the sum of completed donations of one donor.
+type DonorTotal struct {
+	UserID        int     `json:"user_id,omitempty"`
+	GuestEmail    string  `gorm:"column:guest_email" json:"-"`
+	DonorName     string  `gorm:"-" json:"donor_name"`
+	IsAnonymous   bool    `json:"is_anonymous"`
+	TotalAmount   float64 `json:"total_amount"`
+	DonationCount int     `json:"donation_count"`
+}
+
+// CampaignTotal is the sum of completed donations to one campaign.
+type CampaignTotal struct {
+	CampaignID    int     `json:"campaign_id"`
+	TotalAmount   float64 `json:"total_amount"`
+	DonationCount int     `json:"donation_count"`
+	DonorCount    int     `json:"donor_count"`
+}
+
+type Leaderboard struct {
+	Window       string          `json:"window"`
+	TopDonors    []DonorTotal    `json:"top_donors"`
+	TopCampaigns []CampaignTotal `json:"top_campaigns"`
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Receipt is issued once per paid donation. Numbers are sequential per year
+// without gaps, as donors file them for tax and zakat deductions. The donor
+// and campaign details are copied at the time of payment so the receipt never
+// changes afterwards.
+type Receipt struct {
+	ID            int       `gorm:"primaryKey" json:"id"`
+	Number        string    `gorm:"size:30;uniqueIndex" json:"number"`
+	Year          int       `gorm:"not null" json:"year"`
+	Sequence      int       `gorm:"not null" json:"sequence"`
+	DonationID    int       `gorm:"not null;uniqueIndex" json:"donation_id"`
+	TransactionID int       `gorm:"not null" json:"transaction_id"`
+	DonorName     string    `gorm:"size:100" json:"donor_name"`
+	DonorEmail    string    `gorm:"size:150" json:"donor_email"`
+	CampaignID    int       `json:"campaign_id"`
+	CampaignTitle string    `gorm:"size:200" json:"campaign_title"`
+	Amount        float64   `gorm:"not null" json:"amount"`
+	PaymentMethod string    `gorm:"size:50" json:"payment_method"`
+	InvoiceID     string    `gorm:"size:255" json:"invoice_id"`
+	PaidAt        time.Time `json:"paid_at"`
+	PDF           []byte    `json:"-"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (Receipt) TableName() string {
+	return "donations.receipts"
+}
+
+// FileName is the name the PDF is downloaded as.
+func (r *Receipt) FileName() string {
+	return "receipt-" + r.Number + ".pdf"
+}
+
+// ReceiptNumber formats the number of the sequence-th receipt of a year.
+func ReceiptNumber(year int, sequence int) string {
+	return fmt.Sprintf("RCP-%d-%06d", year, sequence)
+}
+
+// ReceiptSequence holds the last receipt number issued in a year. The row is
+// locked while a receipt is created, so numbers are handed out one at a time
+// and a failed insert rolls the counter back.
+type ReceiptSequence struct {
+	Year      int `gorm:"primaryKey;autoIncrement:false"`
+	LastValue int `gorm:"not null"`
+}
+
+func (ReceiptSequence) TableName() string {
+	return "donations.receipt_sequences"
+}
+
+// AnnualReceipt summarizes the receipts issued to a donor in a year.
+type AnnualReceipt struct {
+	UserID     int       `json:"user_id"`
+	DonorName  string    `json:"donor_name"`
+	DonorEmail string    `json:"donor_email"`
+	Year       int       `json:"year"`
+	Receipts   []Receipt `json:"receipts"`
+	Total      float64   `json:"total"`
+}
+
+// FileName is the name the PDF is downloaded as.
+func (a *AnnualReceipt) FileName() string {
+	return fmt.Sprintf("annual-receipt-%d-%d.pdf", a.Year, a.UserID)
+}
+
+// ReceiptFile is a rendered receipt as returned to the API gateway. UserID is
+// the current owner of the donation, not the donor printed on the receipt.
+type ReceiptFile struct {
+	Number     string    `json:"number"`
+	DonationID int       `json:"donation_id"`
+	UserID     int       `json:"user_id"`
+	FileName   string    `json:"file_name"`
+	PDF        []byte    `json:"-"`
+	IssuedAt   time.Time `json:"issued_at"`
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+// DonationStatus is the state of a donation, stored as its name.
+type DonationStatus string
+
+const (
+	DonationPending   DonationStatus = "PENDING"
+	DonationCompleted DonationStatus = "COMPLETED"
+	// DonationAbandoned is a donation whose payment expired. Reissuing the
+	// invoice makes it pending again.
+	DonationAbandoned DonationStatus = "ABANDONED"
+)
+
+var donationTransitions = map[DonationStatus][]DonationStatus{
+	DonationPending:   {DonationCompleted, DonationAbandoned},
+	DonationAbandoned: {DonationPending, DonationCompleted},
+}
+
+// Valid reports whether s is a known donation status.
+func (s DonationStatus) Valid() bool {
+	return s == DonationPending || s == DonationCompleted || s == DonationAbandoned
+}
+
+// CanTransitionTo reports whether a donation in status s may move to next.
+func (s DonationStatus) CanTransitionTo(next DonationStatus) bool {
+	return containsStatus(donationTransitions[s], next)
+}
+
+// SettlementOnly reports whether only a settled payment may set the status.
+func (s DonationStatus) SettlementOnly() bool {
+	return s == DonationCompleted
+}
+
+// ReissueOnly reports whether only reissuing the invoice may move a donation
+// from s to next. An abandoned donation is pending again with a new payment,
+// never without one.
+func (s DonationStatus) ReissueOnly(next DonationStatus) bool {
+	return s == DonationAbandoned && next == DonationPending
+}
+
+func (s DonationStatus) ToPb() pb.DonationStatus {
+	return pb.DonationStatus(pb.DonationStatus_value["DONATION_STATUS_"+string(s)])
+}
+
+// DonationStatusFromPb returns the status of a proto enum, empty when it is
+// unspecified.
+func DonationStatusFromPb(s pb.DonationStatus) DonationStatus {
+	if s == pb.DonationStatus_DONATION_STATUS_UNSPECIFIED {
+		return ""
+	}
+	return DonationStatus(strings.TrimPrefix(s.String(), "DONATION_STATUS_"))
+}
+
+// TransactionStatus is the state of a payment, stored as its name.
+type TransactionStatus string
+
+const (
+	TransactionPending TransactionStatus = "PENDING"
+	TransactionPaid    TransactionStatus = "PAID"
+	TransactionSettled TransactionStatus = "SETTLED"
+	TransactionExpired TransactionStatus = "EXPIRED"
+	TransactionFailed  TransactionStatus = "FAILED"
+)
+
+var transactionTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionPending: {TransactionPaid, TransactionSettled, TransactionExpired, TransactionFailed},
+	TransactionPaid:    {TransactionSettled},
+}
+
+// Valid reports whether s is a known transaction status.
+func (s TransactionStatus) Valid() bool {
+	switch s {
+	case TransactionPending, TransactionPaid, TransactionSettled, TransactionExpired, TransactionFailed:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a transaction in status s may move to next.
+func (s TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	return containsStatus(transactionTransitions[s], next)
+}
+
+// SettlementOnly reports whether only a settled payment may set the status.
+func (s TransactionStatus) SettlementOnly() bool {
+	return s == TransactionPaid || s == TransactionSettled
+}
+
+// Paid reports whether the payment went through.
+func (s TransactionStatus) Paid() bool {
+	return s == TransactionPaid || s == TransactionSettled
+}
+
+func (s TransactionStatus) ToPb() pb.TransactionStatus {
+	return pb.TransactionStatus(pb.TransactionStatus_value["TRANSACTION_STATUS_"+string(s)])
+}
+
+// TransactionStatusFromPb returns the status of a proto enum, empty when it is
+// unspecified.
+func TransactionStatusFromPb(s pb.TransactionStatus) TransactionStatus {
+	if s == pb.TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED {
+		return ""
+	}
+	return TransactionStatus(strings.TrimPrefix(s.String(), "TRANSACTION_STATUS_"))
+}
+
+func containsStatus[S ~string](statuses []S, status S) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
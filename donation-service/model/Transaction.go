@@ -6,17 +6,40 @@ type Transaction struct {
 	ID         int      `gorm:"primaryKey" json:"id"`
 	DonationID int      `gorm:"not null;index" json:"donation_id"`
 	Donation   Donation `gorm:"foreignKey:DonationID" json:"donation"`
+	// PreviousTransactionID links a reissued transaction to the expired or
+	// failed attempt it replaces.
+	PreviousTransactionID *int `json:"previous_transaction_id,omitempty"`
 
-	InvoiceID          string    `gorm:"size:255" json:"invoice_id"`
-	InvoiceURL         string    `gorm:"size:255" json:"invoice_url"`
-	InvoiceDescription string    `gorm:"size:255" json:"invoice_description"`
-	PaymentMethod      string    `gorm:"size:50" json:"payment_method"`
-	Amount             float64   `gorm:"not null" json:"amount"`
-	Status             string    `gorm:"size:50;default:'PENDING'" json:"status"`
-	CreatedAt          time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt          time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	// InvoiceID is the provider reference of the payment, whatever the method,
+	// and InvoiceURL its checkout page when it has one.
+	InvoiceID          string            `gorm:"size:255" json:"invoice_id"`
+	InvoiceURL         string            `gorm:"size:255" json:"invoice_url"`
+	InvoiceDescription string            `gorm:"size:255" json:"invoice_description"`
+	PaymentMethod      string            `gorm:"size:50" json:"payment_method"`
+	PaymentChannel     string            `gorm:"size:50" json:"payment_channel"`
+	VANumber           string            `gorm:"column:va_number;size:50" json:"va_number,omitempty"`
+	QRString           string            `gorm:"type:text" json:"qr_string,omitempty"`
+	DeeplinkURL        string            `gorm:"size:500" json:"deeplink_url,omitempty"`
+	Amount             float64           `gorm:"not null" json:"amount"`
+	Status             TransactionStatus `gorm:"size:50;default:'PENDING'" json:"status"`
+	ExpiresAt          *time.Time        `json:"expires_at,omitempty"`
+	PaidAt             *time.Time        `json:"paid_at,omitempty"`
+	CreatedAt          time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	// Version is incremented by every update, see the optimistic package.
+	Version int `gorm:"not null;default:1" json:"version"`
 }
 
 func (Transaction) TableName() string {
 	return "donations.transactions"
 }
+
+// PaymentOption is the payment method a donor chooses for a new transaction:
+// INVOICE (the default), VIRTUAL_ACCOUNT with the bank as channel, EWALLET
+// with OVO, DANA or SHOPEEPAY as channel, or QRIS. OVO also needs the donor's
+// mobile number.
+type PaymentOption struct {
+	Method       string `json:"payment_method"`
+	Channel      string `json:"payment_channel,omitempty"`
+	MobileNumber string `json:"mobile_number,omitempty"`
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// WebhookSubscription tells a partner about events of one campaign. Only the
+// campaign owner can create one. Secret signs every payload.
+type WebhookSubscription struct {
+	ID         int       `gorm:"primaryKey" json:"id"`
+	UserID     int       `gorm:"not null;index" json:"user_id"`
+	CampaignID int       `gorm:"not null;index" json:"campaign_id"`
+	URL        string    `gorm:"size:255;not null" json:"url"`
+	Secret     string    `gorm:"size:100;not null" json:"-"`
+	EventTypes string    `gorm:"size:255;not null" json:"event_types"` // comma separated event types
+	Active     bool      `gorm:"not null" json:"active"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "donations.webhook_subscriptions"
+}
+
+// EventTypeList splits EventTypes into event types.
+func (s *WebhookSubscription) EventTypeList() []string {
+	var events []string
+	for _, eventType := range strings.Split(s.EventTypes, ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			events = append(events, eventType)
+		}
+	}
+	return events
+}
+
+// Wants reports whether the subscription asked for an event type.
+func (s *WebhookSubscription) Wants(eventType string) bool {
+	for _, wanted := range s.EventTypeList() {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent to one subscription, with the state of
+// its attempts. The payload is stored so every attempt sends the same body.
+type WebhookDelivery struct {
+	ID             int        `gorm:"primaryKey" json:"id"`
+	SubscriptionID int        `gorm:"not null;index" json:"subscription_id"`
+	EventType      string     `gorm:"size:50;not null" json:"event_type"`
+	Payload        string     `gorm:"not null" json:"payload"`
+	Status         string     `gorm:"size:20;not null;index" json:"status"`
+	Attempts       int        `gorm:"not null" json:"attempts"`
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `gorm:"index" json:"next_attempt_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "donations.webhook_deliveries"
+}
+
+// WebhookDeliveryPage is one page of the delivery log, newest first.
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Page       int               `json:"page"`
+	PageSize   int               `json:"page_size"`
+	Total      int64             `json:"total"`
+}
@@ -0,0 +1,182 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/webhook"
+)
+
+// Channel names, as stored on each notification.
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Message is a rendered notification ready to be sent.
+type Message struct {
+	NotificationID int       `json:"notification_id"`
+	EventType      string    `json:"event_type"`
+	Locale         string    `json:"locale"`
+	Email          string    `json:"email,omitempty"`
+	WebhookURL     string    `json:"-"`
+	Subject        string    `json:"subject"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Channel delivers messages. An error marks the delivery for a retry.
+type Channel interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTPChannel sends messages as plain text email.
+type SMTPChannel struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (c *SMTPChannel) Send(ctx context.Context, msg Message) error {
+	if msg.Email == "" {
+		return fmt.Errorf("notification %d has no email address", msg.NotificationID)
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", c.From)
+	fmt.Fprintf(&body, "To: %s\r\n", msg.Email)
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	body.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if c.Username != "" {
+		auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+	// net/smtp has no context support, the send runs to completion
+	return smtp.SendMail(net.JoinHostPort(c.Host, c.Port), auth, c.From, []string{msg.Email}, body.Bytes())
+}
+
+// WebhookChannel posts messages as JSON to the URL the user configured. The
+// URL is user input, NewWebhookChannel keeps it away from private addresses
+// like the partner webhooks.
+type WebhookChannel struct {
+	Client *http.Client
+}
+
+func NewWebhookChannel() *WebhookChannel {
+	return &WebhookChannel{Client: webhook.NewClient()}
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, msg Message) error {
+	if msg.WebhookURL == "" {
+		return fmt.Errorf("notification %d has no webhook URL", msg.NotificationID)
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// MemoryChannel keeps messages in memory, for tests and local development.
+type MemoryChannel struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (c *MemoryChannel) Send(ctx context.Context, msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (c *MemoryChannel) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.messages...)
+}
+
+// FileChannel appends messages to a file as JSON lines, for local development
+// without a mail server.
+type FileChannel struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (c *FileChannel) Send(ctx context.Context, msg Message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	file, err := os.OpenFile(c.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// ChannelsFromConfig builds the channels from the configuration. Email goes
+// over SMTP when SMTP_HOST is set, otherwise to NOTIFICATION_FILE when that is
+// set. Webhooks are always available.
+func ChannelsFromConfig() map[string]Channel {
+	channels := map[string]Channel{
+		ChannelWebhook: NewWebhookChannel(),
+	}
+
+	if smtpConfig := config.App.SMTP; smtpConfig.Host != "" {
+		channels[ChannelEmail] = &SMTPChannel{
+			Host:     smtpConfig.Host,
+			Port:     smtpConfig.Port,
+			Username: smtpConfig.Username,
+			Password: smtpConfig.Password,
+			From:     smtpConfig.From,
+		}
+	} else if path := config.App.NotificationFile; path != "" {
+		channels[ChannelEmail] = &FileChannel{Path: path}
+	}
+
+	return channels
+}
@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookChannelRefusesPrivateAddresses(t *testing.T) {
+	hit := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer server.Close()
+
+	err := NewWebhookChannel().Send(context.Background(), Message{NotificationID: 1, WebhookURL: server.URL})
+	if err == nil {
+		t.Fatal("Send() to a loopback URL succeeded, want an error")
+	}
+	if hit {
+		t.Error("the loopback server was reached")
+	}
+}
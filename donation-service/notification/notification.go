@@ -0,0 +1,377 @@
+// Package notification tells donors and campaign owners about donation
+// events. Every event of the outbox is rendered per recipient in their
+// language, stored, and delivered over the channels they enabled, with
+// retries on failure.
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/outbox"
+)
+
+// Delivery statuses of a notification.
+const (
+	StatusPending  = "PENDING"
+	StatusSent     = "SENT"
+	StatusRetrying = "RETRYING"
+	StatusFailed   = "FAILED"
+)
+
+// Audiences of an event. Each has its own template.
+const (
+	audienceDonor = "donor"
+	audienceOwner = "owner"
+)
+
+// audiences lists who is told about each event type.
+var audiences = map[string][]string{
+	event.DonationCreated:  {audienceDonor},
+	event.InvoiceIssued:    {audienceDonor},
+	event.DonationSettled:  {audienceDonor, audienceOwner},
+	event.DonationRefunded: {audienceDonor},
+	event.PayoutSent:       {audienceOwner},
+	event.CampaignFunded:   {audienceOwner},
+	event.CampaignExpired:  {audienceOwner},
+}
+
+// Recipient is a person a notification is addressed to. Guests have no user
+// ID and no name.
+type Recipient struct {
+	UserID int
+	Name   string
+	Email  string
+}
+
+// Directory looks up recipients in the services that own them.
+type Directory interface {
+	User(ctx context.Context, userID int) (Recipient, error)
+	Campaign(ctx context.Context, campaignID int) (title string, owner Recipient, err error)
+}
+
+// Service turns the events of the outbox into notifications and delivers
+// them.
+type Service struct {
+	directory     Directory
+	channels      map[string]Channel
+	MaxAttempts   int
+	PollInterval  time.Duration
+	RetryInterval time.Duration
+}
+
+func NewService(directory Directory, channels map[string]Channel) *Service {
+	return &Service{
+		directory:     directory,
+		channels:      channels,
+		MaxAttempts:   5,
+		PollInterval:  2 * time.Second,
+		RetryInterval: 30 * time.Second,
+	}
+}
+
+// Start notifies about the stored events and retries failed deliveries until
+// the context is cancelled.
+func (s *Service) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.HandleOutbox(ctx)
+			}
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(s.RetryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.RetryDue(ctx)
+			}
+		}
+	}()
+}
+
+// notifiedEventTypes lists the event types anyone is told about.
+func notifiedEventTypes() []string {
+	eventTypes := make([]string, 0, len(audiences))
+	for eventType := range audiences {
+		eventTypes = append(eventTypes, eventType)
+	}
+	return eventTypes
+}
+
+// HandleOutbox creates the notifications of the outbox events nobody was told
+// about yet, oldest first.
+func (s *Service) HandleOutbox(ctx context.Context) {
+	var ids []int64
+	err := config.DB.WithContext(ctx).Model(&model.OutboxEvent{}).
+		Where("notified_at IS NULL AND notify_attempts < ? AND event_type IN ?", s.MaxAttempts, notifiedEventTypes()).
+		Where("notify_lease_until IS NULL OR notify_lease_until < ?", time.Now()).
+		Order("id").
+		Limit(100).
+		Pluck("id", &ids).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get events to notify", "error", err)
+		return
+	}
+
+	for _, id := range ids {
+		if err := s.handleOutboxEvent(ctx, id); err != nil {
+			slog.ErrorContext(ctx, "failed to update outbox event", "outbox_id", id, "error", err)
+		}
+	}
+}
+
+// handleOutboxEvent notifies about one outbox event. The event is leased in
+// a short update so two instances never notify twice, the lookups and sends
+// run outside any database transaction, and the outcome is stored in a
+// second update. An event whose recipients cannot be looked up is tried
+// again up to MaxAttempts times, an instance that dies while notifying leaves
+// it to the others once the lease ends.
+func (s *Service) handleOutboxEvent(ctx context.Context, id int64) error {
+	now := time.Now()
+	claim := config.DB.WithContext(ctx).Model(&model.OutboxEvent{}).
+		Where("id = ? AND notified_at IS NULL AND (notify_lease_until IS NULL OR notify_lease_until < ?)", id, now).
+		Update("notify_lease_until", now.Add(s.RetryInterval*2))
+	if claim.Error != nil || claim.RowsAffected == 0 {
+		return claim.Error
+	}
+
+	var row model.OutboxEvent
+	if err := config.DB.WithContext(ctx).First(&row, id).Error; err != nil {
+		return err
+	}
+
+	e, err := outbox.Decode(&row)
+	if err == nil {
+		// the outbox ID is stable, unlike the bus ID of the event
+		e.ID = row.ID
+		err = s.Handle(ctx, e)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to notify event", "outbox_id", row.ID, "event_type", row.EventType, "attempts", row.NotifyAttempts+1, "error", err)
+		return config.DB.WithContext(ctx).Model(&row).Updates(map[string]interface{}{
+			"notify_attempts":    gorm.Expr("notify_attempts + 1"),
+			"notify_lease_until": nil,
+		}).Error
+	}
+	return config.DB.WithContext(ctx).Model(&row).Updates(map[string]interface{}{
+		"notified_at":        time.Now(),
+		"notify_lease_until": nil,
+	}).Error
+}
+
+// Handle creates and sends the notifications of an event. It fails when the
+// recipients cannot be looked up, before anything is stored.
+func (s *Service) Handle(ctx context.Context, e event.Event) error {
+	var campaignTitle string
+	var owner Recipient
+	if e.CampaignID != 0 {
+		title, campaignOwner, err := s.directory.Campaign(ctx, e.CampaignID)
+		if err != nil {
+			return fmt.Errorf("failed to get campaign %d: %w", e.CampaignID, err)
+		}
+		campaignTitle, owner = title, campaignOwner
+	}
+
+	donor, err := s.donor(ctx, e)
+	if err != nil {
+		return fmt.Errorf("failed to get donor: %w", err)
+	}
+
+	donorName := donor.Name
+	if e.IsAnonymous {
+		donorName = model.AnonymousDonorName
+	} else if donor.UserID == 0 {
+		donorName = model.GuestDonorName
+	}
+
+	for _, audience := range audiences[e.Type] {
+		recipient := donor
+		if audience == audienceOwner {
+			recipient = owner
+		}
+		if recipient.UserID == 0 && recipient.Email == "" {
+			continue
+		}
+
+		data := templateData{
+			Recipient:     recipient,
+			CampaignTitle: campaignTitle,
+			DonorName:     donorName,
+			DonationID:    e.DonationID,
+			Amount:        e.Amount,
+			InvoiceURL:    e.InvoiceURL,
+			PaymentMethod: e.PaymentMethod,
+			VANumber:      e.VANumber,
+			OccurredAt:    e.OccurredAt,
+		}
+		s.notify(ctx, e, audience, recipient, data)
+	}
+	return nil
+}
+
+func (s *Service) donor(ctx context.Context, e event.Event) (Recipient, error) {
+	if e.UserID != 0 {
+		return s.directory.User(ctx, e.UserID)
+	}
+	return Recipient{Email: e.GuestEmail}, nil
+}
+
+// notify stores one notification per channel the recipient enabled and sends
+// them right away.
+func (s *Service) notify(ctx context.Context, e event.Event, audience string, recipient Recipient, data templateData) {
+	preference, err := LoadPreference(ctx, recipient.UserID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get notification preference", "user_id", recipient.UserID, "error", err)
+		return
+	}
+	if preference.Muted(e.Type) {
+		return
+	}
+
+	subject, body, err := render(preference.Locale, e.Type, audience, data)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to render notification", "event_id", e.ID, "error", err)
+		return
+	}
+
+	var userID *int
+	if recipient.UserID != 0 {
+		userID = &recipient.UserID
+	}
+
+	var pending []*model.Notification
+	if preference.EmailEnabled && recipient.Email != "" {
+		pending = append(pending, &model.Notification{Channel: ChannelEmail, Email: recipient.Email})
+	}
+	if preference.WebhookURL != "" {
+		pending = append(pending, &model.Notification{Channel: ChannelWebhook, WebhookURL: preference.WebhookURL})
+	}
+
+	for _, n := range pending {
+		if _, ok := s.channels[n.Channel]; !ok {
+			continue
+		}
+		n.EventID = e.ID
+		n.EventType = e.Type
+		n.UserID = userID
+		n.Locale = preference.Locale
+		n.Subject = subject
+		n.Body = body
+		n.Status = StatusPending
+		// the retry loop leaves it alone while the first attempt runs
+		n.NextAttemptAt = time.Now().Add(s.RetryInterval * 2)
+		if err := config.DB.WithContext(ctx).Create(n).Error; err != nil {
+			slog.ErrorContext(ctx, "failed to store notification", "event_id", e.ID, "error", err)
+			continue
+		}
+		s.deliver(ctx, n)
+	}
+}
+
+// deliver sends a stored notification and records the outcome. Failures are
+// retried with exponential backoff until MaxAttempts.
+func (s *Service) deliver(ctx context.Context, n *model.Notification) {
+	channel, ok := s.channels[n.Channel]
+	if !ok {
+		return
+	}
+
+	err := channel.Send(ctx, Message{
+		NotificationID: n.ID,
+		EventType:      n.EventType,
+		Locale:         n.Locale,
+		Email:          n.Email,
+		WebhookURL:     n.WebhookURL,
+		Subject:        n.Subject,
+		Body:           n.Body,
+		CreatedAt:      n.CreatedAt,
+	})
+
+	n.Attempts++
+	now := time.Now()
+	switch {
+	case err == nil:
+		n.Status = StatusSent
+		n.SentAt = &now
+		n.LastError = ""
+	case n.Attempts >= s.MaxAttempts:
+		n.Status = StatusFailed
+		n.LastError = err.Error()
+		slog.WarnContext(ctx, "notification failed", "notification_id", n.ID, "attempts", n.Attempts, "error", err)
+	default:
+		n.Status = StatusRetrying
+		n.LastError = err.Error()
+		n.NextAttemptAt = now.Add(backoff(n.Attempts))
+	}
+
+	if err := config.DB.WithContext(ctx).Save(n).Error; err != nil {
+		slog.ErrorContext(ctx, "failed to update notification", "notification_id", n.ID, "error", err)
+	}
+}
+
+// backoff doubles the wait after every failed attempt, from one minute up to
+// an hour.
+func backoff(attempts int) time.Duration {
+	wait := time.Minute << (attempts - 1)
+	if wait > time.Hour || wait <= 0 {
+		return time.Hour
+	}
+	return wait
+}
+
+// RetryDue sends the notifications whose next attempt is due.
+func (s *Service) RetryDue(ctx context.Context) {
+	var due []model.Notification
+	err := config.DB.WithContext(ctx).
+		Where("status IN ? AND next_attempt_at <= ?", []string{StatusPending, StatusRetrying}, time.Now()).
+		Order("next_attempt_at").
+		Limit(100).
+		Find(&due).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get notifications to retry", "error", err)
+		return
+	}
+
+	for i := range due {
+		s.deliver(ctx, &due[i])
+	}
+}
+
+// LoadPreference returns the notification preference of a user, or the
+// defaults when the user never changed it. Guests (user ID 0) always get the
+// defaults.
+func LoadPreference(ctx context.Context, userID int) (*model.NotificationPreference, error) {
+	preference := &model.NotificationPreference{
+		UserID:       userID,
+		Locale:       DefaultLocale,
+		EmailEnabled: true,
+	}
+	if userID == 0 {
+		return preference, nil
+	}
+
+	err := config.DB.WithContext(ctx).First(preference, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return preference, nil
+	}
+	return preference, err
+}
@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/receipt"
+)
+
+// Supported locales. Templates for each live in templates/<locale>.tmpl.
+const (
+	LocaleID = "id"
+	LocaleEN = "en"
+)
+
+// DefaultLocale is used for guests and users without a preference.
+const DefaultLocale = LocaleID
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templates = map[string]*template.Template{
+	LocaleID: parseTemplates(LocaleID, receipt.FormatDate),
+	LocaleEN: parseTemplates(LocaleEN, func(t time.Time) string { return t.Format("2 January 2006") }),
+}
+
+func parseTemplates(locale string, formatDate func(time.Time) string) *template.Template {
+	funcs := template.FuncMap{
+		"rupiah": receipt.FormatRupiah,
+		"date":   formatDate,
+	}
+	return template.Must(template.New(locale).Funcs(funcs).ParseFS(templateFiles, "templates/"+locale+".tmpl"))
+}
+
+// SupportedLocale reports whether there are templates for a locale.
+func SupportedLocale(locale string) bool {
+	_, ok := templates[locale]
+	return ok
+}
+
+// templateData is what the templates can refer to.
+type templateData struct {
+	Recipient     Recipient
+	CampaignTitle string
+	DonorName     string
+	DonationID    int
+	Amount        float64
+	InvoiceURL    string
+	PaymentMethod string
+	VANumber      string
+	OccurredAt    time.Time
+}
+
+// render returns the subject and body of an event for an audience, in the
+// given locale, falling back to the default locale.
+func render(locale string, eventType string, audience string, data templateData) (subject string, body string, err error) {
+	tmpl, ok := templates[locale]
+	if !ok {
+		tmpl = templates[DefaultLocale]
+	}
+
+	name := eventType + "." + audience
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name+".subject", data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+	subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := tmpl.ExecuteTemplate(&buf, name+".body", data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s body: %w", name, err)
+	}
+	return subject, strings.TrimSpace(buf.String()), nil
+}
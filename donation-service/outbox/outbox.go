@@ -0,0 +1,139 @@
+// Package outbox keeps events in the database with the change they describe,
+// so they survive a restart and subscribers that fall behind. Every instance
+// tails the outbox and publishes the events on its own in-memory bus of the
+// event package.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+)
+
+// Record stores e in the outbox with tx, so it is kept exactly when the
+// change it describes commits. The relay publishes it afterwards.
+func Record(tx *gorm.DB, e event.Event) error {
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&model.OutboxEvent{EventType: e.Type, Payload: string(payload)}).Error
+}
+
+// Decode returns the event stored in an outbox row.
+func Decode(row *model.OutboxEvent) (event.Event, error) {
+	var e event.Event
+	err := json.Unmarshal([]byte(row.Payload), &e)
+	return e, err
+}
+
+// gapTimeout is how long a cursor waits for an ID it skipped. A transaction
+// can commit after one that took a later ID, an ID still missing by then was
+// rolled back.
+const gapTimeout = time.Minute
+
+// maxGaps bounds the skipped IDs a cursor waits for.
+const maxGaps = 1000
+
+// Cursor is how far an instance read the outbox. Each instance tails the
+// outbox with its own cursor, so the watchers connected to any replica get
+// every event. The zero value starts after the newest stored event, the ones
+// before it had no one on this bus to watch them.
+type Cursor struct {
+	started bool
+	last    int64
+	// gaps are the IDs below last not read yet, and when they were skipped.
+	gaps map[int64]time.Time
+}
+
+// Relay publishes the events stored after the cursor on the bus, oldest
+// first, and moves the cursor past them. An event whose transaction committed
+// late is published when it shows up, after newer ones.
+func (c *Cursor) Relay(ctx context.Context) {
+	db := config.DB.WithContext(ctx)
+	if !c.started {
+		if err := db.Model(&model.OutboxEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&c.last).Error; err != nil {
+			slog.ErrorContext(ctx, "failed to find the end of the outbox", "error", err)
+			return
+		}
+		c.started = true
+	}
+
+	query := db.Where("id > ?", c.last)
+	if len(c.gaps) > 0 {
+		ids := make([]int64, 0, len(c.gaps))
+		for id := range c.gaps {
+			ids = append(ids, id)
+		}
+		query = query.Or("id IN ?", ids)
+	}
+	var rows []model.OutboxEvent
+	if err := query.Order("id").Limit(100).Find(&rows).Error; err != nil {
+		slog.ErrorContext(ctx, "failed to relay outbox events", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for i := range rows {
+		c.advance(rows[i].ID, now)
+		e, err := Decode(&rows[i])
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to decode outbox event", "outbox_id", rows[i].ID, "error", err)
+			continue
+		}
+		event.Publish(e)
+	}
+	c.expireGaps(now)
+}
+
+// advance records that the event id was read, and the IDs it skipped.
+func (c *Cursor) advance(id int64, now time.Time) {
+	if id <= c.last {
+		delete(c.gaps, id)
+		return
+	}
+	if c.gaps == nil {
+		c.gaps = make(map[int64]time.Time)
+	}
+	for missed := c.last + 1; missed < id && len(c.gaps) < maxGaps; missed++ {
+		c.gaps[missed] = now
+	}
+	c.last = id
+}
+
+// expireGaps stops waiting for the IDs skipped more than gapTimeout ago.
+func (c *Cursor) expireGaps(now time.Time) {
+	for id, skipped := range c.gaps {
+		if now.Sub(skipped) > gapTimeout {
+			delete(c.gaps, id)
+		}
+	}
+}
+
+// StartRelay runs Relay with a new cursor every interval until the context is
+// cancelled.
+func StartRelay(ctx context.Context, interval time.Duration) {
+	var cursor Cursor
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				cursor.Relay(ctx)
+			}
+		}
+	}()
+}
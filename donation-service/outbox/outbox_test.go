@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCursorAdvance(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := Cursor{started: true, last: 10}
+
+	c.advance(11, now)
+	if c.last != 11 || len(c.gaps) != 0 {
+		t.Fatalf("next ID: last %d gaps %v, want 11 and none", c.last, c.gaps)
+	}
+
+	// 12 and 13 were taken by transactions that did not commit yet
+	c.advance(14, now)
+	if c.last != 14 {
+		t.Fatalf("last = %d, want 14", c.last)
+	}
+	if _, ok := c.gaps[12]; !ok {
+		t.Errorf("gaps = %v, want 12 waited for", c.gaps)
+	}
+	if _, ok := c.gaps[13]; !ok {
+		t.Errorf("gaps = %v, want 13 waited for", c.gaps)
+	}
+
+	// 12 commits late
+	c.advance(12, now.Add(time.Second))
+	if c.last != 14 {
+		t.Errorf("late ID moved last to %d, want 14", c.last)
+	}
+	if _, ok := c.gaps[12]; ok {
+		t.Errorf("gaps = %v, want 12 no longer waited for", c.gaps)
+	}
+
+	// 13 was rolled back
+	c.expireGaps(now.Add(gapTimeout))
+	if len(c.gaps) != 1 {
+		t.Errorf("gaps = %v, want 13 still waited for at the timeout", c.gaps)
+	}
+	c.expireGaps(now.Add(gapTimeout + time.Second))
+	if len(c.gaps) != 0 {
+		t.Errorf("gaps = %v, want none after the timeout", c.gaps)
+	}
+}
+
+func TestCursorAdvanceBoundsGaps(t *testing.T) {
+	c := Cursor{started: true}
+	c.advance(maxGaps*3, time.Now())
+	if len(c.gaps) != maxGaps {
+		t.Errorf("gaps = %d, want at most %d", len(c.gaps), maxGaps)
+	}
+	if c.last != maxGaps*3 {
+		t.Errorf("last = %d, want %d", c.last, maxGaps*3)
+	}
+}
@@ -0,0 +1,160 @@
+// Package payment creates and checks donation payments. Every method a donor
+// can pay with goes through a Provider, which returns the instructions of the
+// method and reports the payment status in one normalized form.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Payment methods a donor can choose. An invoice is a hosted page where the
+// donor picks the channel, the other methods are paid directly.
+const (
+	MethodInvoice        = "INVOICE"
+	MethodVirtualAccount = "VIRTUAL_ACCOUNT"
+	MethodEWallet        = "EWALLET"
+	MethodQRIS           = "QRIS"
+)
+
+// Normalized payment statuses. Only PAID and SETTLED settle a donation.
+const (
+	StatusPending = "PENDING"
+	StatusPaid    = "PAID"
+	StatusSettled = "SETTLED"
+	StatusExpired = "EXPIRED"
+	StatusFailed  = "FAILED"
+)
+
+// Banks that issue virtual accounts.
+var Banks = []string{"BCA", "BNI", "BRI", "MANDIRI", "PERMATA", "BSI", "CIMB"}
+
+// E-wallets donors can pay with.
+const (
+	EWalletOVO       = "OVO"
+	EWalletDANA      = "DANA"
+	EWalletShopeePay = "SHOPEEPAY"
+)
+
+var EWallets = []string{EWalletOVO, EWalletDANA, EWalletShopeePay}
+
+// Expiry is how long a virtual account, QR code or e-wallet charge can be paid.
+const Expiry = 24 * time.Hour
+
+// Request describes the payment of a donation.
+type Request struct {
+	ExternalID  string
+	Amount      int
+	Method      string
+	Channel     string
+	PayerName   string
+	PayerEmail  string
+	Description string
+	// MobileNumber is the OVO account to push the payment to.
+	MobileNumber string
+}
+
+// Instructions tell the donor how to pay. Only the fields of the method are
+// set.
+type Instructions struct {
+	CheckoutURL string
+	VANumber    string
+	QRString    string
+	DeeplinkURL string
+}
+
+// Payment is the state of a payment at the provider. Reference is the
+// provider's ID of the invoice, virtual account, charge or QR code.
+type Payment struct {
+	Reference   string
+	Method      string
+	Channel     string
+	Status      string
+	Description string
+	Instructions
+	ExpiresAt time.Time
+	PaidAt    time.Time
+}
+
+// Paid reports whether the payment settles the donation.
+func (p *Payment) Paid() bool {
+	return p.Status == StatusPaid || p.Status == StatusSettled
+}
+
+// Provider creates payments and fetches their state.
+type Provider interface {
+	Create(ctx context.Context, req Request) (*Payment, error)
+	// Get fetches a payment again. paymentID is the provider's ID of a single
+	// payment into the reference, sent with some callbacks, and may be empty.
+	Get(ctx context.Context, method string, reference string, paymentID string) (*Payment, error)
+	// Expire stops a payment from being paid, for a payment created for a
+	// transaction that could not be stored.
+	Expire(ctx context.Context, method string, reference string) error
+}
+
+// Default is the provider used by the donation service.
+var Default Provider = Xendit{}
+
+var mobileNumberPattern = regexp.MustCompile(`^\+62[0-9]{8,13}$`)
+
+// Validate normalizes the method and channel of a request and checks the
+// method has what it needs. No method means an invoice.
+func Validate(req *Request) error {
+	req.Method = strings.ToUpper(strings.TrimSpace(req.Method))
+	req.Channel = strings.ToUpper(strings.TrimSpace(req.Channel))
+	if req.Method == "" {
+		req.Method = MethodInvoice
+	}
+
+	switch req.Method {
+	case MethodInvoice, MethodQRIS:
+		if req.Channel != "" {
+			return fmt.Errorf("payment method %s takes no channel", req.Method)
+		}
+	case MethodVirtualAccount:
+		if !contains(Banks, req.Channel) {
+			return fmt.Errorf("unsupported bank %q, use one of %s", req.Channel, strings.Join(Banks, ", "))
+		}
+	case MethodEWallet:
+		if !contains(EWallets, req.Channel) {
+			return fmt.Errorf("unsupported e-wallet %q, use one of %s", req.Channel, strings.Join(EWallets, ", "))
+		}
+		if req.Channel == EWalletOVO && !mobileNumberPattern.MatchString(req.MobileNumber) {
+			return errors.New("OVO payments need a mobile number in the +62 format")
+		}
+	default:
+		return fmt.Errorf("unsupported payment method %q", req.Method)
+	}
+	return nil
+}
+
+// Label is the name of a method and channel shown to donors, on receipts.
+func Label(method string, channel string) string {
+	switch method {
+	case MethodVirtualAccount:
+		return strings.TrimSpace("Virtual Account " + channel)
+	case MethodEWallet:
+		if channel == EWalletShopeePay {
+			return "ShopeePay"
+		}
+		return channel
+	case MethodQRIS:
+		return "QRIS"
+	case MethodInvoice:
+		return "Invoice"
+	}
+	return strings.TrimSpace(method + " " + channel)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,295 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/external"
+)
+
+// Xendit is the Provider backed by the Xendit API. DANA and ShopeePay send
+// the donor back to PAYMENT_REDIRECT_URL after paying.
+type Xendit struct{}
+
+func (Xendit) Create(ctx context.Context, req Request) (*Payment, error) {
+	switch req.Method {
+	case MethodInvoice:
+		invoice, err := external.CreateInvoice(ctx, req.ExternalID, req.Amount, req.PayerEmail, req.Description)
+		if err != nil {
+			return nil, err
+		}
+		return invoicePayment(invoice), nil
+
+	case MethodVirtualAccount:
+		account, err := external.CreateVirtualAccount(ctx, external.CreateVirtualAccountRequest{
+			ExternalID:     req.ExternalID,
+			BankCode:       req.Channel,
+			Name:           req.PayerName,
+			ExpectedAmount: req.Amount,
+			IsClosed:       true,
+			IsSingleUse:    true,
+			ExpirationDate: time.Now().Add(Expiry).UTC(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &Payment{
+			Reference:    account.ID,
+			Method:       MethodVirtualAccount,
+			Channel:      account.BankCode,
+			Status:       StatusPending,
+			Description:  req.Description,
+			Instructions: Instructions{VANumber: account.AccountNumber},
+			ExpiresAt:    account.ExpirationDate,
+		}, nil
+
+	case MethodEWallet:
+		properties := external.EWalletChargeProperties{}
+		if req.Channel == EWalletOVO {
+			properties.MobileNumber = req.MobileNumber
+		} else {
+			properties.SuccessRedirectURL = config.App.Xendit.PaymentRedirectURL
+			if properties.SuccessRedirectURL == "" {
+				return nil, errors.New("PAYMENT_REDIRECT_URL is not configured")
+			}
+		}
+		charge, err := external.CreateEWalletCharge(ctx, external.CreateEWalletChargeRequest{
+			ReferenceID:       req.ExternalID,
+			Currency:          "IDR",
+			Amount:            req.Amount,
+			CheckoutMethod:    "ONE_TIME_PAYMENT",
+			ChannelCode:       "ID_" + req.Channel,
+			ChannelProperties: properties,
+		})
+		if err != nil {
+			return nil, err
+		}
+		p := chargePayment(charge, time.Now())
+		p.Description = req.Description
+		return p, nil
+
+	case MethodQRIS:
+		code, err := external.CreateQRCode(ctx, external.CreateQRCodeRequest{
+			ReferenceID: req.ExternalID,
+			Type:        "DYNAMIC",
+			Currency:    "IDR",
+			Amount:      req.Amount,
+			ExpiresAt:   time.Now().Add(Expiry).UTC(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &Payment{
+			Reference:    code.ID,
+			Method:       MethodQRIS,
+			Status:       StatusPending,
+			Description:  req.Description,
+			Instructions: Instructions{QRString: code.QRString},
+			ExpiresAt:    code.ExpiresAt,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported payment method %q", req.Method)
+}
+
+func (Xendit) Get(ctx context.Context, method string, reference string, paymentID string) (*Payment, error) {
+	switch method {
+	// transactions from before payment methods existed are invoices
+	case MethodInvoice, "":
+		invoice, err := external.GetInvoice(ctx, reference)
+		if err != nil {
+			return nil, err
+		}
+		return invoicePayment(invoice), nil
+
+	case MethodVirtualAccount:
+		return virtualAccountPayment(ctx, reference, paymentID)
+
+	case MethodEWallet:
+		charge, err := external.GetEWalletCharge(ctx, reference)
+		if err != nil {
+			return nil, err
+		}
+		return chargePayment(charge, time.Now()), nil
+
+	case MethodQRIS:
+		return qrPayment(ctx, reference)
+	}
+	return nil, fmt.Errorf("unsupported payment method %q", method)
+}
+
+// Expire expires invoices and virtual accounts. E-wallet charges and QR codes
+// cannot be cancelled before they are paid, they lapse after Expiry.
+func (Xendit) Expire(ctx context.Context, method string, reference string) error {
+	switch method {
+	case MethodInvoice:
+		_, err := external.ExpireInvoice(ctx, reference)
+		return err
+	case MethodVirtualAccount:
+		_, err := external.ExpireVirtualAccount(ctx, reference)
+		return err
+	}
+	return fmt.Errorf("%s payments cannot be expired, they lapse after %s", method, Expiry)
+}
+
+// invoiceMethods maps the channel groups of a paid invoice to our methods.
+var invoiceMethods = map[string]string{
+	"BANK_TRANSFER": MethodVirtualAccount,
+	"EWALLET":       MethodEWallet,
+	"QR_CODE":       MethodQRIS,
+}
+
+func invoicePayment(invoice external.InvoiceResponse) *Payment {
+	p := &Payment{
+		Reference:    invoice.ID,
+		Method:       MethodInvoice,
+		Status:       invoice.Status,
+		Description:  invoice.Description,
+		Instructions: Instructions{CheckoutURL: invoice.InvoiceURL},
+		PaidAt:       invoice.PaidAt,
+	}
+	if expiresAt, err := time.Parse(time.RFC3339, invoice.ExpiryDate); err == nil {
+		p.ExpiresAt = expiresAt
+	}
+	// the donor picks the channel on the invoice page, keep what was used
+	if invoice.PaymentMethod != "" {
+		p.Method = invoice.PaymentMethod
+		if method, ok := invoiceMethods[invoice.PaymentMethod]; ok {
+			p.Method = method
+		}
+		p.Channel = invoice.PaymentChannel
+	}
+	return p
+}
+
+// chargePayment maps an e-wallet charge. The donor approves a charge in the
+// e-wallet app, one left pending for longer than Expiry is expired like the
+// other methods, so the donation can be paid again another way.
+func chargePayment(charge external.EWalletChargeResponse, now time.Time) *Payment {
+	p := &Payment{
+		Reference: charge.ID,
+		Method:    MethodEWallet,
+		Channel:   strings.TrimPrefix(charge.ChannelCode, "ID_"),
+		Instructions: Instructions{
+			CheckoutURL: charge.Actions.DesktopWebCheckoutURL,
+			DeeplinkURL: charge.Actions.MobileDeeplinkCheckoutURL,
+			QRString:    charge.Actions.QRCheckoutString,
+		},
+	}
+	if p.CheckoutURL == "" {
+		p.CheckoutURL = charge.Actions.MobileWebCheckoutURL
+	}
+	if !charge.Created.IsZero() {
+		p.ExpiresAt = charge.Created.Add(Expiry)
+	}
+
+	switch charge.Status {
+	case "SUCCEEDED":
+		p.Status = StatusPaid
+		p.PaidAt = charge.Updated
+	case "FAILED", "VOIDED":
+		p.Status = StatusFailed
+	default:
+		p.Status = StatusPending
+		if !p.ExpiresAt.IsZero() && now.After(p.ExpiresAt) {
+			p.Status = StatusExpired
+		}
+	}
+	return p
+}
+
+// virtualAccountPayment checks the payments into a virtual account. The
+// payment callback names the payment, which is verified against the account.
+// Without one the payments of the account are listed first, the account is
+// only expired when none of them paid it.
+func virtualAccountPayment(ctx context.Context, reference string, paymentID string) (*Payment, error) {
+	account, err := external.GetVirtualAccount(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	p := &Payment{
+		Reference:    account.ID,
+		Method:       MethodVirtualAccount,
+		Channel:      account.BankCode,
+		Status:       StatusPending,
+		Instructions: Instructions{VANumber: account.AccountNumber},
+		ExpiresAt:    account.ExpirationDate,
+	}
+
+	if paymentID != "" {
+		vaPayment, err := external.GetVirtualAccountPayment(ctx, paymentID)
+		if err != nil {
+			return nil, err
+		}
+		if vaPayment.CallbackVirtualAccountID != account.ID {
+			return nil, fmt.Errorf("payment %s is not a payment into virtual account %s", paymentID, account.ID)
+		}
+		if vaPayment.Amount >= account.ExpectedAmount {
+			p.Status = StatusPaid
+			p.PaidAt = vaPayment.TransactionTimestamp
+		}
+		return p, nil
+	}
+
+	payments, err := external.GetVirtualAccountPayments(ctx, account.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	settleVirtualAccount(p, account, payments.Data, time.Now())
+	return p, nil
+}
+
+// settleVirtualAccount sets the status of p from the payments listed for
+// account: paid by a successful payment of the expected amount into it,
+// expired once it expired without one, pending otherwise. An INACTIVE account
+// proves nothing alone, a single use account turns inactive both when it is
+// paid and when it expires.
+func settleVirtualAccount(p *Payment, account external.VirtualAccountResponse, payments []external.Transaction, now time.Time) {
+	for _, vaPayment := range payments {
+		if vaPayment.ProductID == account.ID && vaPayment.Status == "SUCCESS" && vaPayment.Amount >= float64(account.ExpectedAmount) {
+			p.Status = StatusPaid
+			p.PaidAt = vaPayment.Created
+			return
+		}
+	}
+
+	if !account.ExpirationDate.IsZero() && now.After(account.ExpirationDate) {
+		p.Status = StatusExpired
+		return
+	}
+	p.Status = StatusPending
+}
+
+func qrPayment(ctx context.Context, reference string) (*Payment, error) {
+	code, err := external.GetQRCode(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	p := &Payment{
+		Reference:    code.ID,
+		Method:       MethodQRIS,
+		Status:       StatusPending,
+		Instructions: Instructions{QRString: code.QRString},
+		ExpiresAt:    code.ExpiresAt,
+	}
+
+	payments, err := external.GetQRCodePayments(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	for _, qrPayment := range payments.Data {
+		if qrPayment.Status == "SUCCEEDED" && qrPayment.Amount >= code.Amount {
+			p.Status = StatusPaid
+			p.PaidAt = qrPayment.Created
+			return p, nil
+		}
+	}
+
+	if code.Status == "INACTIVE" || (!code.ExpiresAt.IsZero() && time.Now().After(code.ExpiresAt)) {
+		p.Status = StatusExpired
+	}
+	return p, nil
+}
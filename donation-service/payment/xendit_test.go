@@ -0,0 +1,142 @@
+package payment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/external"
+)
+
+func TestInvoicePayment(t *testing.T) {
+	paidAt := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		invoice     external.InvoiceResponse
+		wantStatus  string
+		wantMethod  string
+		wantChannel string
+		wantExpiry  time.Time
+	}{
+		{
+			name:       "pending",
+			invoice:    external.InvoiceResponse{ID: "inv-1", Status: StatusPending, ExpiryDate: "2025-06-02T03:00:00Z"},
+			wantStatus: StatusPending,
+			wantMethod: MethodInvoice,
+			wantExpiry: time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "paid by bank transfer",
+			invoice:     external.InvoiceResponse{ID: "inv-1", Status: StatusPaid, PaidAt: paidAt, PaymentMethod: "BANK_TRANSFER", PaymentChannel: "BCA"},
+			wantStatus:  StatusPaid,
+			wantMethod:  MethodVirtualAccount,
+			wantChannel: "BCA",
+		},
+		{
+			name:        "unknown channel group kept",
+			invoice:     external.InvoiceResponse{ID: "inv-1", Status: StatusSettled, PaymentMethod: "RETAIL_OUTLET", PaymentChannel: "ALFAMART"},
+			wantStatus:  StatusSettled,
+			wantMethod:  "RETAIL_OUTLET",
+			wantChannel: "ALFAMART",
+		},
+		{
+			name:       "expired",
+			invoice:    external.InvoiceResponse{ID: "inv-1", Status: StatusExpired, ExpiryDate: "not a date"},
+			wantStatus: StatusExpired,
+			wantMethod: MethodInvoice,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := invoicePayment(tt.invoice)
+			if p.Status != tt.wantStatus || p.Method != tt.wantMethod || p.Channel != tt.wantChannel {
+				t.Errorf("invoicePayment() = %s %s %s, want %s %s %s", p.Status, p.Method, p.Channel, tt.wantStatus, tt.wantMethod, tt.wantChannel)
+			}
+			if !p.ExpiresAt.Equal(tt.wantExpiry) {
+				t.Errorf("ExpiresAt = %v, want %v", p.ExpiresAt, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+func TestChargePayment(t *testing.T) {
+	created := time.Date(2025, 6, 1, 3, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		status     string
+		created    time.Time
+		now        time.Time
+		wantStatus string
+	}{
+		{"succeeded", "SUCCEEDED", created, created.Add(time.Minute), StatusPaid},
+		{"succeeded after expiry", "SUCCEEDED", created, created.Add(2 * Expiry), StatusPaid},
+		{"failed", "FAILED", created, created.Add(time.Minute), StatusFailed},
+		{"voided", "VOIDED", created, created.Add(time.Minute), StatusFailed},
+		{"pending", "PENDING", created, created.Add(time.Hour), StatusPending},
+		{"pending at expiry", "PENDING", created, created.Add(Expiry), StatusPending},
+		{"pending past expiry", "PENDING", created, created.Add(Expiry + time.Second), StatusExpired},
+		{"pending without created", "PENDING", time.Time{}, created, StatusPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charge := external.EWalletChargeResponse{
+				ID:          "ewc-1",
+				Status:      tt.status,
+				ChannelCode: "ID_DANA",
+				Created:     tt.created,
+				Updated:     tt.created.Add(time.Minute),
+			}
+			p := chargePayment(charge, tt.now)
+			if p.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", p.Status, tt.wantStatus)
+			}
+			if p.Channel != "DANA" {
+				t.Errorf("Channel = %s, want DANA", p.Channel)
+			}
+			if p.Status == StatusPaid && !p.PaidAt.Equal(charge.Updated) {
+				t.Errorf("PaidAt = %v, want %v", p.PaidAt, charge.Updated)
+			}
+		})
+	}
+}
+
+func TestSettleVirtualAccount(t *testing.T) {
+	expires := time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC)
+	paidAt := expires.Add(-time.Hour)
+	account := external.VirtualAccountResponse{
+		ID:             "va-1",
+		ExternalID:     "donation-1",
+		ExpectedAmount: 50000,
+		Status:         "INACTIVE",
+		ExpirationDate: expires,
+	}
+	paid := external.Transaction{ProductID: "va-1", Status: "SUCCESS", Amount: 50000, Created: paidAt}
+
+	tests := []struct {
+		name       string
+		payments   []external.Transaction
+		now        time.Time
+		wantStatus string
+		wantPaidAt time.Time
+	}{
+		{"inactive without payments", nil, expires.Add(-time.Minute), StatusPending, time.Time{}},
+		{"expired without payments", nil, expires.Add(time.Minute), StatusExpired, time.Time{}},
+		{"paid", []external.Transaction{paid}, expires.Add(-time.Minute), StatusPaid, paidAt},
+		{"paid, checked after expiry", []external.Transaction{paid}, expires.Add(time.Hour), StatusPaid, paidAt},
+		{"payment into another account", []external.Transaction{{ProductID: "va-2", Status: "SUCCESS", Amount: 50000}}, expires.Add(-time.Minute), StatusPending, time.Time{}},
+		{"payment short", []external.Transaction{{ProductID: "va-1", Status: "SUCCESS", Amount: 49999}}, expires.Add(-time.Minute), StatusPending, time.Time{}},
+		{"payment pending", []external.Transaction{{ProductID: "va-1", Status: "PENDING", Amount: 50000}}, expires.Add(-time.Minute), StatusPending, time.Time{}},
+		{"failed then paid", []external.Transaction{{ProductID: "va-1", Status: "FAILED", Amount: 50000}, paid}, expires.Add(-time.Minute), StatusPaid, paidAt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Payment{Status: StatusPending}
+			settleVirtualAccount(p, account, tt.payments, tt.now)
+			if p.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", p.Status, tt.wantStatus)
+			}
+			if !p.PaidAt.Equal(tt.wantPaidAt) {
+				t.Errorf("PaidAt = %v, want %v", p.PaidAt, tt.wantPaidAt)
+			}
+		})
+	}
+}
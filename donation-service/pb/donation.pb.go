@@ -13,6 +13,7 @@ import (
 
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 const (
@@ -22,6 +23,121 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DonationStatus is the state of a donation. A donation is COMPLETED only when
+// its payment settles, and ABANDONED when the payment expired, until the
+// invoice is reissued.
+type DonationStatus int32
+
+const (
+	DonationStatus_DONATION_STATUS_UNSPECIFIED DonationStatus = 0
+	DonationStatus_DONATION_STATUS_PENDING     DonationStatus = 1
+	DonationStatus_DONATION_STATUS_COMPLETED   DonationStatus = 2
+	DonationStatus_DONATION_STATUS_ABANDONED   DonationStatus = 3
+)
+
+// Enum value maps for DonationStatus.
+var (
+	DonationStatus_name = map[int32]string{
+		0: "DONATION_STATUS_UNSPECIFIED",
+		1: "DONATION_STATUS_PENDING",
+		2: "DONATION_STATUS_COMPLETED",
+		3: "DONATION_STATUS_ABANDONED",
+	}
+	DonationStatus_value = map[string]int32{
+		"DONATION_STATUS_UNSPECIFIED": 0,
+		"DONATION_STATUS_PENDING":     1,
+		"DONATION_STATUS_COMPLETED":   2,
+		"DONATION_STATUS_ABANDONED":   3,
+	}
+)
+
+func (x DonationStatus) Enum() *DonationStatus {
+	p := new(DonationStatus)
+	*p = x
+	return p
+}
+
+func (x DonationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DonationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_donation_proto_enumTypes[0].Descriptor()
+}
+
+func (DonationStatus) Type() protoreflect.EnumType {
+	return &file_pb_donation_proto_enumTypes[0]
+}
+
+func (x DonationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DonationStatus.Descriptor instead.
+func (DonationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{0}
+}
+
+// TransactionStatus is the state of a payment. PAID and SETTLED are only set
+// by settlement, after the payment is fetched from the provider.
+type TransactionStatus int32
+
+const (
+	TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED TransactionStatus = 0
+	TransactionStatus_TRANSACTION_STATUS_PENDING     TransactionStatus = 1
+	TransactionStatus_TRANSACTION_STATUS_PAID        TransactionStatus = 2
+	TransactionStatus_TRANSACTION_STATUS_SETTLED     TransactionStatus = 3
+	TransactionStatus_TRANSACTION_STATUS_EXPIRED     TransactionStatus = 4
+	TransactionStatus_TRANSACTION_STATUS_FAILED      TransactionStatus = 5
+)
+
+// Enum value maps for TransactionStatus.
+var (
+	TransactionStatus_name = map[int32]string{
+		0: "TRANSACTION_STATUS_UNSPECIFIED",
+		1: "TRANSACTION_STATUS_PENDING",
+		2: "TRANSACTION_STATUS_PAID",
+		3: "TRANSACTION_STATUS_SETTLED",
+		4: "TRANSACTION_STATUS_EXPIRED",
+		5: "TRANSACTION_STATUS_FAILED",
+	}
+	TransactionStatus_value = map[string]int32{
+		"TRANSACTION_STATUS_UNSPECIFIED": 0,
+		"TRANSACTION_STATUS_PENDING":     1,
+		"TRANSACTION_STATUS_PAID":        2,
+		"TRANSACTION_STATUS_SETTLED":     3,
+		"TRANSACTION_STATUS_EXPIRED":     4,
+		"TRANSACTION_STATUS_FAILED":      5,
+	}
+)
+
+func (x TransactionStatus) Enum() *TransactionStatus {
+	p := new(TransactionStatus)
+	*p = x
+	return p
+}
+
+func (x TransactionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_donation_proto_enumTypes[1].Descriptor()
+}
+
+func (TransactionStatus) Type() protoreflect.EnumType {
+	return &file_pb_donation_proto_enumTypes[1]
+}
+
+func (x TransactionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionStatus.Descriptor instead.
+func (TransactionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{1}
+}
+
 type DonationIdRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -67,13 +183,24 @@ func (x *DonationIdRequest) GetId() int32 {
 }
 
 type DonationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CampaignId    int32                  `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
-	Amount        float32                `protobuf:"fixed32,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// user_id is the donor, UpdateDonation only finds their donations and
+	// changes neither the donor nor the campaign.
+	UserId      int32          `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CampaignId  int32          `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Amount      float32        `protobuf:"fixed32,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Message     string         `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Status      DonationStatus `protobuf:"varint,6,opt,name=status,proto3,enum=donation.DonationStatus" json:"status,omitempty"`
+	IsAnonymous bool           `protobuf:"varint,7,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	// update_mask names the fields UpdateDonation changes: amount, message,
+	// status and is_anonymous. Without it zero values keep the current ones. A
+	// completed donation never changes, and the amount only while the donation
+	// is pending without a payment, both fail with FAILED_PRECONDITION.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// version is the version the donation is expected to have, an update made
+	// against another one fails with VERSION_MISMATCH. 0 skips the check.
+	Version       int32 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -143,11 +270,32 @@ func (x *DonationRequest) GetMessage() string {
 	return ""
 }
 
-func (x *DonationRequest) GetStatus() string {
+func (x *DonationRequest) GetStatus() DonationStatus {
 	if x != nil {
 		return x.Status
 	}
-	return ""
+	return DonationStatus_DONATION_STATUS_UNSPECIFIED
+}
+
+func (x *DonationRequest) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
+func (x *DonationRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *DonationRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
 }
 
 type DonationResponse struct {
@@ -159,9 +307,12 @@ type DonationResponse struct {
 	CampaignId    int32                  `protobuf:"varint,5,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
 	Amount        float32                `protobuf:"fixed32,6,opt,name=amount,proto3" json:"amount,omitempty"`
 	MessageText   string                 `protobuf:"bytes,7,opt,name=message_text,json=messageText,proto3" json:"message_text,omitempty"`
-	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	Status        DonationStatus         `protobuf:"varint,8,opt,name=status,proto3,enum=donation.DonationStatus" json:"status,omitempty"`
 	CreatedAt     string                 `protobuf:"bytes,9,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	IsAnonymous   bool                   `protobuf:"varint,11,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	GuestEmail    string                 `protobuf:"bytes,12,opt,name=guest_email,json=guestEmail,proto3" json:"guest_email,omitempty"`
+	Version       int32                  `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -245,11 +396,11 @@ func (x *DonationResponse) GetMessageText() string {
 	return ""
 }
 
-func (x *DonationResponse) GetStatus() string {
+func (x *DonationResponse) GetStatus() DonationStatus {
 	if x != nil {
 		return x.Status
 	}
-	return ""
+	return DonationStatus_DONATION_STATUS_UNSPECIFIED
 }
 
 func (x *DonationResponse) GetCreatedAt() string {
@@ -266,16 +417,42 @@ func (x *DonationResponse) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *DonationResponse) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
+func (x *DonationResponse) GetGuestEmail() string {
+	if x != nil {
+		return x.GuestEmail
+	}
+	return ""
+}
+
+func (x *DonationResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type Donation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CampaignId    int32                  `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
-	Amount        float32                `protobuf:"fixed32,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
-	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId      int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CampaignId  int32                  `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Amount      float32                `protobuf:"fixed32,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Message     string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Status      DonationStatus         `protobuf:"varint,6,opt,name=status,proto3,enum=donation.DonationStatus" json:"status,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt   string                 `protobuf:"bytes,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	IsAnonymous bool                   `protobuf:"varint,9,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	GuestEmail  string                 `protobuf:"bytes,10,opt,name=guest_email,json=guestEmail,proto3" json:"guest_email,omitempty"`
+	Version     int32                  `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	// donor is unset for guest donations, and when user-service did not answer.
+	Donor         *DonorSummary `protobuf:"bytes,12,opt,name=donor,proto3" json:"donor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -345,11 +522,11 @@ func (x *Donation) GetMessage() string {
 	return ""
 }
 
-func (x *Donation) GetStatus() string {
+func (x *Donation) GetStatus() DonationStatus {
 	if x != nil {
 		return x.Status
 	}
-	return ""
+	return DonationStatus_DONATION_STATUS_UNSPECIFIED
 }
 
 func (x *Donation) GetCreatedAt() string {
@@ -366,26 +543,59 @@ func (x *Donation) GetUpdatedAt() string {
 	return ""
 }
 
-type GetDonationsRequest struct {
+func (x *Donation) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
+func (x *Donation) GetGuestEmail() string {
+	if x != nil {
+		return x.GuestEmail
+	}
+	return ""
+}
+
+func (x *Donation) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Donation) GetDonor() *DonorSummary {
+	if x != nil {
+		return x.Donor
+	}
+	return nil
+}
+
+// DonorSummary is what may be shown of the user who made a donation.
+type DonorSummary struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	MaskedEmail   string                 `protobuf:"bytes,4,opt,name=masked_email,json=maskedEmail,proto3" json:"masked_email,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDonationsRequest) Reset() {
-	*x = GetDonationsRequest{}
+func (x *DonorSummary) Reset() {
+	*x = DonorSummary{}
 	mi := &file_pb_donation_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDonationsRequest) String() string {
+func (x *DonorSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDonationsRequest) ProtoMessage() {}
+func (*DonorSummary) ProtoMessage() {}
 
-func (x *GetDonationsRequest) ProtoReflect() protoreflect.Message {
+func (x *DonorSummary) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_donation_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -397,32 +607,59 @@ func (x *GetDonationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDonationsRequest.ProtoReflect.Descriptor instead.
-func (*GetDonationsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DonorSummary.ProtoReflect.Descriptor instead.
+func (*DonorSummary) Descriptor() ([]byte, []int) {
 	return file_pb_donation_proto_rawDescGZIP(), []int{4}
 }
 
-type GetDonationsResponse struct {
+func (x *DonorSummary) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DonorSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DonorSummary) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *DonorSummary) GetMaskedEmail() string {
+	if x != nil {
+		return x.MaskedEmail
+	}
+	return ""
+}
+
+type GetDonationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Donations     []*Donation            `protobuf:"bytes,1,rep,name=donations,proto3" json:"donations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDonationsResponse) Reset() {
-	*x = GetDonationsResponse{}
+func (x *GetDonationsRequest) Reset() {
+	*x = GetDonationsRequest{}
 	mi := &file_pb_donation_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDonationsResponse) String() string {
+func (x *GetDonationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDonationsResponse) ProtoMessage() {}
+func (*GetDonationsRequest) ProtoMessage() {}
 
-func (x *GetDonationsResponse) ProtoReflect() protoreflect.Message {
+func (x *GetDonationsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_donation_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -434,39 +671,32 @@ func (x *GetDonationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDonationsResponse.ProtoReflect.Descriptor instead.
-func (*GetDonationsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDonationsRequest.ProtoReflect.Descriptor instead.
+func (*GetDonationsRequest) Descriptor() ([]byte, []int) {
 	return file_pb_donation_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetDonationsResponse) GetDonations() []*Donation {
-	if x != nil {
-		return x.Donations
-	}
-	return nil
-}
-
-type TransactionIdRequest struct {
+type GetDonationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Donations     []*Donation            `protobuf:"bytes,1,rep,name=donations,proto3" json:"donations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TransactionIdRequest) Reset() {
-	*x = TransactionIdRequest{}
+func (x *GetDonationsResponse) Reset() {
+	*x = GetDonationsResponse{}
 	mi := &file_pb_donation_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TransactionIdRequest) String() string {
+func (x *GetDonationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransactionIdRequest) ProtoMessage() {}
+func (*GetDonationsResponse) ProtoMessage() {}
 
-func (x *TransactionIdRequest) ProtoReflect() protoreflect.Message {
+func (x *GetDonationsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_donation_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -478,46 +708,48 @@ func (x *TransactionIdRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransactionIdRequest.ProtoReflect.Descriptor instead.
-func (*TransactionIdRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDonationsResponse.ProtoReflect.Descriptor instead.
+func (*GetDonationsResponse) Descriptor() ([]byte, []int) {
 	return file_pb_donation_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *TransactionIdRequest) GetId() int32 {
+func (x *GetDonationsResponse) GetDonations() []*Donation {
 	if x != nil {
-		return x.Id
+		return x.Donations
 	}
-	return 0
+	return nil
 }
 
-type TransactionRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	DonationId         int32                  `protobuf:"varint,2,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
-	InvoiceId          string                 `protobuf:"bytes,3,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
-	InvoiceUrl         string                 `protobuf:"bytes,4,opt,name=invoice_url,json=invoiceUrl,proto3" json:"invoice_url,omitempty"`
-	InvoiceDescription string                 `protobuf:"bytes,5,opt,name=invoice_description,json=invoiceDescription,proto3" json:"invoice_description,omitempty"`
-	PaymentMethod      string                 `protobuf:"bytes,6,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
-	Amount             float32                `protobuf:"fixed32,7,opt,name=amount,proto3" json:"amount,omitempty"`
-	Status             string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// GuestDonationRequest creates a donation without an account, the invoice is
+// sent to the guest email.
+type GuestDonationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	CampaignId     int32                  `protobuf:"varint,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Amount         float32                `protobuf:"fixed32,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Message        string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	IsAnonymous    bool                   `protobuf:"varint,5,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	PaymentMethod  string                 `protobuf:"bytes,6,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	PaymentChannel string                 `protobuf:"bytes,7,opt,name=payment_channel,json=paymentChannel,proto3" json:"payment_channel,omitempty"`
+	MobileNumber   string                 `protobuf:"bytes,8,opt,name=mobile_number,json=mobileNumber,proto3" json:"mobile_number,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *TransactionRequest) Reset() {
-	*x = TransactionRequest{}
+func (x *GuestDonationRequest) Reset() {
+	*x = GuestDonationRequest{}
 	mi := &file_pb_donation_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TransactionRequest) String() string {
+func (x *GuestDonationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransactionRequest) ProtoMessage() {}
+func (*GuestDonationRequest) ProtoMessage() {}
 
-func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
+func (x *GuestDonationRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_donation_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -529,99 +761,91 @@ func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransactionRequest.ProtoReflect.Descriptor instead.
-func (*TransactionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GuestDonationRequest.ProtoReflect.Descriptor instead.
+func (*GuestDonationRequest) Descriptor() ([]byte, []int) {
 	return file_pb_donation_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *TransactionRequest) GetId() int32 {
+func (x *GuestDonationRequest) GetEmail() string {
 	if x != nil {
-		return x.Id
+		return x.Email
 	}
-	return 0
+	return ""
 }
 
-func (x *TransactionRequest) GetDonationId() int32 {
+func (x *GuestDonationRequest) GetCampaignId() int32 {
 	if x != nil {
-		return x.DonationId
+		return x.CampaignId
 	}
 	return 0
 }
 
-func (x *TransactionRequest) GetInvoiceId() string {
+func (x *GuestDonationRequest) GetAmount() float32 {
 	if x != nil {
-		return x.InvoiceId
+		return x.Amount
 	}
-	return ""
+	return 0
 }
 
-func (x *TransactionRequest) GetInvoiceUrl() string {
+func (x *GuestDonationRequest) GetMessage() string {
 	if x != nil {
-		return x.InvoiceUrl
+		return x.Message
 	}
 	return ""
 }
 
-func (x *TransactionRequest) GetInvoiceDescription() string {
+func (x *GuestDonationRequest) GetIsAnonymous() bool {
 	if x != nil {
-		return x.InvoiceDescription
+		return x.IsAnonymous
 	}
-	return ""
+	return false
 }
 
-func (x *TransactionRequest) GetPaymentMethod() string {
+func (x *GuestDonationRequest) GetPaymentMethod() string {
 	if x != nil {
 		return x.PaymentMethod
 	}
 	return ""
 }
 
-func (x *TransactionRequest) GetAmount() float32 {
+func (x *GuestDonationRequest) GetPaymentChannel() string {
 	if x != nil {
-		return x.Amount
+		return x.PaymentChannel
 	}
-	return 0
+	return ""
 }
 
-func (x *TransactionRequest) GetStatus() string {
+func (x *GuestDonationRequest) GetMobileNumber() string {
 	if x != nil {
-		return x.Status
+		return x.MobileNumber
 	}
 	return ""
 }
 
-type TransactionResponse struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Message            string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Error              string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Id                 int32                  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
-	DonationId         int32                  `protobuf:"varint,4,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
-	InvoiceId          string                 `protobuf:"bytes,5,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
-	InvoiceUrl         string                 `protobuf:"bytes,6,opt,name=invoice_url,json=invoiceUrl,proto3" json:"invoice_url,omitempty"`
-	InvoiceDescription string                 `protobuf:"bytes,7,opt,name=invoice_description,json=invoiceDescription,proto3" json:"invoice_description,omitempty"`
-	PaymentMethod      string                 `protobuf:"bytes,8,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
-	Amount             float32                `protobuf:"fixed32,9,opt,name=amount,proto3" json:"amount,omitempty"`
-	Status             string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt          string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt          string                 `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type GuestDonationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Donation      *Donation              `protobuf:"bytes,3,opt,name=donation,proto3" json:"donation,omitempty"`
+	Transaction   *Transaction           `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TransactionResponse) Reset() {
-	*x = TransactionResponse{}
+func (x *GuestDonationResponse) Reset() {
+	*x = GuestDonationResponse{}
 	mi := &file_pb_donation_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TransactionResponse) String() string {
+func (x *GuestDonationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransactionResponse) ProtoMessage() {}
+func (*GuestDonationResponse) ProtoMessage() {}
 
-func (x *TransactionResponse) ProtoReflect() protoreflect.Message {
+func (x *GuestDonationResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_donation_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -633,126 +857,3195 @@ func (x *TransactionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransactionResponse.ProtoReflect.Descriptor instead.
-func (*TransactionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GuestDonationResponse.ProtoReflect.Descriptor instead.
+func (*GuestDonationResponse) Descriptor() ([]byte, []int) {
 	return file_pb_donation_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *TransactionResponse) GetMessage() string {
+func (x *GuestDonationResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *TransactionResponse) GetError() string {
+func (x *GuestDonationResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-func (x *TransactionResponse) GetId() int32 {
+func (x *GuestDonationResponse) GetDonation() *Donation {
 	if x != nil {
-		return x.Id
+		return x.Donation
 	}
-	return 0
+	return nil
 }
 
-func (x *TransactionResponse) GetDonationId() int32 {
+func (x *GuestDonationResponse) GetTransaction() *Transaction {
 	if x != nil {
-		return x.DonationId
+		return x.Transaction
 	}
-	return 0
+	return nil
 }
 
-func (x *TransactionResponse) GetInvoiceId() string {
-	if x != nil {
-		return x.InvoiceId
-	}
-	return ""
-}
+type ClaimGuestDonationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimGuestDonationsRequest) Reset() {
+	*x = ClaimGuestDonationsRequest{}
+	mi := &file_pb_donation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimGuestDonationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimGuestDonationsRequest) ProtoMessage() {}
+
+func (x *ClaimGuestDonationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimGuestDonationsRequest.ProtoReflect.Descriptor instead.
+func (*ClaimGuestDonationsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ClaimGuestDonationsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ClaimGuestDonationsRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ClaimGuestDonationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ClaimedCount  int32                  `protobuf:"varint,3,opt,name=claimed_count,json=claimedCount,proto3" json:"claimed_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimGuestDonationsResponse) Reset() {
+	*x = ClaimGuestDonationsResponse{}
+	mi := &file_pb_donation_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimGuestDonationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimGuestDonationsResponse) ProtoMessage() {}
+
+func (x *ClaimGuestDonationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimGuestDonationsResponse.ProtoReflect.Descriptor instead.
+func (*ClaimGuestDonationsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ClaimGuestDonationsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ClaimGuestDonationsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ClaimGuestDonationsResponse) GetClaimedCount() int32 {
+	if x != nil {
+		return x.ClaimedCount
+	}
+	return 0
+}
+
+// PublicDonation is a COMPLETED donation as shown on a campaign page, the
+// donor is masked when the donation is anonymous.
+type PublicDonation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CampaignId    int32                  `protobuf:"varint,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DonorName     string                 `protobuf:"bytes,4,opt,name=donor_name,json=donorName,proto3" json:"donor_name,omitempty"`
+	Amount        float32                `protobuf:"fixed32,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	IsAnonymous   bool                   `protobuf:"varint,7,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicDonation) Reset() {
+	*x = PublicDonation{}
+	mi := &file_pb_donation_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicDonation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicDonation) ProtoMessage() {}
+
+func (x *PublicDonation) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicDonation.ProtoReflect.Descriptor instead.
+func (*PublicDonation) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PublicDonation) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PublicDonation) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *PublicDonation) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PublicDonation) GetDonorName() string {
+	if x != nil {
+		return x.DonorName
+	}
+	return ""
+}
+
+func (x *PublicDonation) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PublicDonation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PublicDonation) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
+func (x *PublicDonation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CampaignDonationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int32                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignDonationsRequest) Reset() {
+	*x = CampaignDonationsRequest{}
+	mi := &file_pb_donation_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignDonationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignDonationsRequest) ProtoMessage() {}
+
+func (x *CampaignDonationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignDonationsRequest.ProtoReflect.Descriptor instead.
+func (*CampaignDonationsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CampaignDonationsRequest) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignDonationsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *CampaignDonationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type CampaignDonationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Donations     []*PublicDonation      `protobuf:"bytes,1,rep,name=donations,proto3" json:"donations,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignDonationsResponse) Reset() {
+	*x = CampaignDonationsResponse{}
+	mi := &file_pb_donation_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignDonationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignDonationsResponse) ProtoMessage() {}
+
+func (x *CampaignDonationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignDonationsResponse.ProtoReflect.Descriptor instead.
+func (*CampaignDonationsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CampaignDonationsResponse) GetDonations() []*PublicDonation {
+	if x != nil {
+		return x.Donations
+	}
+	return nil
+}
+
+func (x *CampaignDonationsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *CampaignDonationsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *CampaignDonationsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type DonorTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DonorName     string                 `protobuf:"bytes,2,opt,name=donor_name,json=donorName,proto3" json:"donor_name,omitempty"`
+	IsAnonymous   bool                   `protobuf:"varint,3,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	TotalAmount   float32                `protobuf:"fixed32,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	DonationCount int32                  `protobuf:"varint,5,opt,name=donation_count,json=donationCount,proto3" json:"donation_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DonorTotal) Reset() {
+	*x = DonorTotal{}
+	mi := &file_pb_donation_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DonorTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DonorTotal) ProtoMessage() {}
+
+func (x *DonorTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DonorTotal.ProtoReflect.Descriptor instead.
+func (*DonorTotal) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DonorTotal) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DonorTotal) GetDonorName() string {
+	if x != nil {
+		return x.DonorName
+	}
+	return ""
+}
+
+func (x *DonorTotal) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
+func (x *DonorTotal) GetTotalAmount() float32 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *DonorTotal) GetDonationCount() int32 {
+	if x != nil {
+		return x.DonationCount
+	}
+	return 0
+}
+
+type CampaignTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int32                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	TotalAmount   float32                `protobuf:"fixed32,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	DonationCount int32                  `protobuf:"varint,3,opt,name=donation_count,json=donationCount,proto3" json:"donation_count,omitempty"`
+	DonorCount    int32                  `protobuf:"varint,4,opt,name=donor_count,json=donorCount,proto3" json:"donor_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignTotal) Reset() {
+	*x = CampaignTotal{}
+	mi := &file_pb_donation_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignTotal) ProtoMessage() {}
+
+func (x *CampaignTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignTotal.ProtoReflect.Descriptor instead.
+func (*CampaignTotal) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CampaignTotal) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignTotal) GetTotalAmount() float32 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *CampaignTotal) GetDonationCount() int32 {
+	if x != nil {
+		return x.DonationCount
+	}
+	return 0
+}
+
+func (x *CampaignTotal) GetDonorCount() int32 {
+	if x != nil {
+		return x.DonorCount
+	}
+	return 0
+}
+
+type CampaignTopDonorsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int32                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignTopDonorsRequest) Reset() {
+	*x = CampaignTopDonorsRequest{}
+	mi := &file_pb_donation_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignTopDonorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignTopDonorsRequest) ProtoMessage() {}
+
+func (x *CampaignTopDonorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignTopDonorsRequest.ProtoReflect.Descriptor instead.
+func (*CampaignTopDonorsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CampaignTopDonorsRequest) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignTopDonorsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TopDonorsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Donors        []*DonorTotal          `protobuf:"bytes,1,rep,name=donors,proto3" json:"donors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopDonorsResponse) Reset() {
+	*x = TopDonorsResponse{}
+	mi := &file_pb_donation_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopDonorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopDonorsResponse) ProtoMessage() {}
+
+func (x *TopDonorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopDonorsResponse.ProtoReflect.Descriptor instead.
+func (*TopDonorsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TopDonorsResponse) GetDonors() []*DonorTotal {
+	if x != nil {
+		return x.Donors
+	}
+	return nil
+}
+
+// LeaderboardRequest window is one of day, week, month or all. A donation
+// counts in the window it was first paid in.
+type LeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        string                 `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardRequest) Reset() {
+	*x = LeaderboardRequest{}
+	mi := &file_pb_donation_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardRequest) ProtoMessage() {}
+
+func (x *LeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LeaderboardRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LeaderboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        string                 `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	TopDonors     []*DonorTotal          `protobuf:"bytes,2,rep,name=top_donors,json=topDonors,proto3" json:"top_donors,omitempty"`
+	TopCampaigns  []*CampaignTotal       `protobuf:"bytes,3,rep,name=top_campaigns,json=topCampaigns,proto3" json:"top_campaigns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardResponse) Reset() {
+	*x = LeaderboardResponse{}
+	mi := &file_pb_donation_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardResponse) ProtoMessage() {}
+
+func (x *LeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*LeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LeaderboardResponse) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *LeaderboardResponse) GetTopDonors() []*DonorTotal {
+	if x != nil {
+		return x.TopDonors
+	}
+	return nil
+}
+
+func (x *LeaderboardResponse) GetTopCampaigns() []*CampaignTotal {
+	if x != nil {
+		return x.TopCampaigns
+	}
+	return nil
+}
+
+// TransactionIdRequest only finds transactions of donations of user_id, a
+// request without one finds none.
+type TransactionIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransactionIdRequest) Reset() {
+	*x = TransactionIdRequest{}
+	mi := &file_pb_donation_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionIdRequest) ProtoMessage() {}
+
+func (x *TransactionIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionIdRequest.ProtoReflect.Descriptor instead.
+func (*TransactionIdRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TransactionIdRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransactionIdRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// TransactionRequest creates a payment with payment_method INVOICE (the
+// default), VIRTUAL_ACCOUNT, EWALLET or QRIS. payment_channel is the bank of a
+// virtual account or the e-wallet, OVO also needs the donor's mobile_number.
+// The donation must be a pending donation of user_id without a payment under
+// way, guest donations are paid through CreateGuestDonation. The amount is
+// the donation amount, a different one is refused. An update with a version
+// fails with VERSION_MISMATCH when the transaction has another one.
+type TransactionRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DonationId         int32                  `protobuf:"varint,2,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
+	InvoiceId          string                 `protobuf:"bytes,3,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	InvoiceUrl         string                 `protobuf:"bytes,4,opt,name=invoice_url,json=invoiceUrl,proto3" json:"invoice_url,omitempty"`
+	InvoiceDescription string                 `protobuf:"bytes,5,opt,name=invoice_description,json=invoiceDescription,proto3" json:"invoice_description,omitempty"`
+	PaymentMethod      string                 `protobuf:"bytes,6,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	Amount             float32                `protobuf:"fixed32,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status             TransactionStatus      `protobuf:"varint,8,opt,name=status,proto3,enum=donation.TransactionStatus" json:"status,omitempty"`
+	PaymentChannel     string                 `protobuf:"bytes,9,opt,name=payment_channel,json=paymentChannel,proto3" json:"payment_channel,omitempty"`
+	MobileNumber       string                 `protobuf:"bytes,10,opt,name=mobile_number,json=mobileNumber,proto3" json:"mobile_number,omitempty"`
+	UserId             int32                  `protobuf:"varint,11,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Version            int32                  `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *TransactionRequest) Reset() {
+	*x = TransactionRequest{}
+	mi := &file_pb_donation_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRequest) ProtoMessage() {}
+
+func (x *TransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRequest.ProtoReflect.Descriptor instead.
+func (*TransactionRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TransactionRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransactionRequest) GetDonationId() int32 {
+	if x != nil {
+		return x.DonationId
+	}
+	return 0
+}
+
+func (x *TransactionRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *TransactionRequest) GetInvoiceUrl() string {
+	if x != nil {
+		return x.InvoiceUrl
+	}
+	return ""
+}
+
+func (x *TransactionRequest) GetInvoiceDescription() string {
+	if x != nil {
+		return x.InvoiceDescription
+	}
+	return ""
+}
+
+func (x *TransactionRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *TransactionRequest) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransactionRequest) GetStatus() TransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *TransactionRequest) GetPaymentChannel() string {
+	if x != nil {
+		return x.PaymentChannel
+	}
+	return ""
+}
+
+func (x *TransactionRequest) GetMobileNumber() string {
+	if x != nil {
+		return x.MobileNumber
+	}
+	return ""
+}
+
+func (x *TransactionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TransactionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// PaymentInstructions tell the donor how to pay, only the fields of the
+// payment method are set.
+type PaymentInstructions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CheckoutUrl   string                 `protobuf:"bytes,1,opt,name=checkout_url,json=checkoutUrl,proto3" json:"checkout_url,omitempty"`
+	VaNumber      string                 `protobuf:"bytes,2,opt,name=va_number,json=vaNumber,proto3" json:"va_number,omitempty"`
+	QrString      string                 `protobuf:"bytes,3,opt,name=qr_string,json=qrString,proto3" json:"qr_string,omitempty"`
+	DeeplinkUrl   string                 `protobuf:"bytes,4,opt,name=deeplink_url,json=deeplinkUrl,proto3" json:"deeplink_url,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentInstructions) Reset() {
+	*x = PaymentInstructions{}
+	mi := &file_pb_donation_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentInstructions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentInstructions) ProtoMessage() {}
+
+func (x *PaymentInstructions) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentInstructions.ProtoReflect.Descriptor instead.
+func (*PaymentInstructions) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PaymentInstructions) GetCheckoutUrl() string {
+	if x != nil {
+		return x.CheckoutUrl
+	}
+	return ""
+}
+
+func (x *PaymentInstructions) GetVaNumber() string {
+	if x != nil {
+		return x.VaNumber
+	}
+	return ""
+}
+
+func (x *PaymentInstructions) GetQrString() string {
+	if x != nil {
+		return x.QrString
+	}
+	return ""
+}
+
+func (x *PaymentInstructions) GetDeeplinkUrl() string {
+	if x != nil {
+		return x.DeeplinkUrl
+	}
+	return ""
+}
+
+func (x *PaymentInstructions) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type TransactionResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Message               string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error                 string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Id                    int32                  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	DonationId            int32                  `protobuf:"varint,4,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
+	InvoiceId             string                 `protobuf:"bytes,5,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	InvoiceUrl            string                 `protobuf:"bytes,6,opt,name=invoice_url,json=invoiceUrl,proto3" json:"invoice_url,omitempty"`
+	InvoiceDescription    string                 `protobuf:"bytes,7,opt,name=invoice_description,json=invoiceDescription,proto3" json:"invoice_description,omitempty"`
+	PaymentMethod         string                 `protobuf:"bytes,8,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	Amount                float32                `protobuf:"fixed32,9,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status                TransactionStatus      `protobuf:"varint,10,opt,name=status,proto3,enum=donation.TransactionStatus" json:"status,omitempty"`
+	CreatedAt             string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             string                 `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PaymentChannel        string                 `protobuf:"bytes,13,opt,name=payment_channel,json=paymentChannel,proto3" json:"payment_channel,omitempty"`
+	Instructions          *PaymentInstructions   `protobuf:"bytes,14,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	PreviousTransactionId int32                  `protobuf:"varint,15,opt,name=previous_transaction_id,json=previousTransactionId,proto3" json:"previous_transaction_id,omitempty"`
+	Version               int32                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TransactionResponse) Reset() {
+	*x = TransactionResponse{}
+	mi := &file_pb_donation_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionResponse) ProtoMessage() {}
+
+func (x *TransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionResponse.ProtoReflect.Descriptor instead.
+func (*TransactionResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TransactionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransactionResponse) GetDonationId() int32 {
+	if x != nil {
+		return x.DonationId
+	}
+	return 0
+}
+
+func (x *TransactionResponse) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetInvoiceUrl() string {
+	if x != nil {
+		return x.InvoiceUrl
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetInvoiceDescription() string {
+	if x != nil {
+		return x.InvoiceDescription
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransactionResponse) GetStatus() TransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *TransactionResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetPaymentChannel() string {
+	if x != nil {
+		return x.PaymentChannel
+	}
+	return ""
+}
+
+func (x *TransactionResponse) GetInstructions() *PaymentInstructions {
+	if x != nil {
+		return x.Instructions
+	}
+	return nil
+}
+
+func (x *TransactionResponse) GetPreviousTransactionId() int32 {
+	if x != nil {
+		return x.PreviousTransactionId
+	}
+	return 0
+}
+
+func (x *TransactionResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Transaction struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DonationId            int32                  `protobuf:"varint,2,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
+	InvoiceId             string                 `protobuf:"bytes,3,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	InvoiceUrl            string                 `protobuf:"bytes,4,opt,name=invoice_url,json=invoiceUrl,proto3" json:"invoice_url,omitempty"`
+	InvoiceDescription    string                 `protobuf:"bytes,5,opt,name=invoice_description,json=invoiceDescription,proto3" json:"invoice_description,omitempty"`
+	PaymentMethod         string                 `protobuf:"bytes,6,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	Amount                float32                `protobuf:"fixed32,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status                TransactionStatus      `protobuf:"varint,8,opt,name=status,proto3,enum=donation.TransactionStatus" json:"status,omitempty"`
+	CreatedAt             string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PaymentChannel        string                 `protobuf:"bytes,11,opt,name=payment_channel,json=paymentChannel,proto3" json:"payment_channel,omitempty"`
+	Instructions          *PaymentInstructions   `protobuf:"bytes,12,opt,name=instructions,proto3" json:"instructions,omitempty"`
+	PreviousTransactionId int32                  `protobuf:"varint,13,opt,name=previous_transaction_id,json=previousTransactionId,proto3" json:"previous_transaction_id,omitempty"`
+	Version               int32                  `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_pb_donation_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Transaction) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetDonationId() int32 {
+	if x != nil {
+		return x.DonationId
+	}
+	return 0
+}
+
+func (x *Transaction) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *Transaction) GetInvoiceUrl() string {
+	if x != nil {
+		return x.InvoiceUrl
+	}
+	return ""
+}
+
+func (x *Transaction) GetInvoiceDescription() string {
+	if x != nil {
+		return x.InvoiceDescription
+	}
+	return ""
+}
+
+func (x *Transaction) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetStatus() TransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *Transaction) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Transaction) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Transaction) GetPaymentChannel() string {
+	if x != nil {
+		return x.PaymentChannel
+	}
+	return ""
+}
+
+func (x *Transaction) GetInstructions() *PaymentInstructions {
+	if x != nil {
+		return x.Instructions
+	}
+	return nil
+}
+
+func (x *Transaction) GetPreviousTransactionId() int32 {
+	if x != nil {
+		return x.PreviousTransactionId
+	}
+	return 0
+}
+
+func (x *Transaction) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// GetTransactionsRequest lists the transactions of the donations of user_id.
+type GetTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionsRequest) Reset() {
+	*x = GetTransactionsRequest{}
+	mi := &file_pb_donation_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionsRequest) ProtoMessage() {}
+
+func (x *GetTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTransactionsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionsResponse) Reset() {
+	*x = GetTransactionsResponse{}
+	mi := &file_pb_donation_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionsResponse) ProtoMessage() {}
+
+func (x *GetTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// InvoiceCallbackRequest carries the invoice from a Xendit callback, the
+// invoice status is fetched again from Xendit before it is applied.
+// InvoiceCallbackRequest names the payment a provider callback is about.
+// invoice_id is the provider reference of any payment method, payment_id is
+// the single payment into a virtual account when the callback has one.
+type InvoiceCallbackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvoiceId     string                 `protobuf:"bytes,1,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceCallbackRequest) Reset() {
+	*x = InvoiceCallbackRequest{}
+	mi := &file_pb_donation_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceCallbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceCallbackRequest) ProtoMessage() {}
+
+func (x *InvoiceCallbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceCallbackRequest.ProtoReflect.Descriptor instead.
+func (*InvoiceCallbackRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *InvoiceCallbackRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *InvoiceCallbackRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+// ReissueInvoiceRequest opens a new payment for the donation of an expired or
+// failed transaction of the user, or of an unclaimed guest donation for the
+// guest_email it was made with and the invoice_id of the transaction. Without
+// a payment method the method of the previous transaction is used again.
+type ReissueInvoiceRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId  int32                  `protobuf:"varint,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	UserId         int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaymentMethod  string                 `protobuf:"bytes,3,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	PaymentChannel string                 `protobuf:"bytes,4,opt,name=payment_channel,json=paymentChannel,proto3" json:"payment_channel,omitempty"`
+	MobileNumber   string                 `protobuf:"bytes,5,opt,name=mobile_number,json=mobileNumber,proto3" json:"mobile_number,omitempty"`
+	GuestEmail     string                 `protobuf:"bytes,6,opt,name=guest_email,json=guestEmail,proto3" json:"guest_email,omitempty"`
+	InvoiceId      string                 `protobuf:"bytes,7,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReissueInvoiceRequest) Reset() {
+	*x = ReissueInvoiceRequest{}
+	mi := &file_pb_donation_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReissueInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReissueInvoiceRequest) ProtoMessage() {}
+
+func (x *ReissueInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReissueInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*ReissueInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReissueInvoiceRequest) GetTransactionId() int32 {
+	if x != nil {
+		return x.TransactionId
+	}
+	return 0
+}
+
+func (x *ReissueInvoiceRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReissueInvoiceRequest) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *ReissueInvoiceRequest) GetPaymentChannel() string {
+	if x != nil {
+		return x.PaymentChannel
+	}
+	return ""
+}
+
+func (x *ReissueInvoiceRequest) GetMobileNumber() string {
+	if x != nil {
+		return x.MobileNumber
+	}
+	return ""
+}
+
+func (x *ReissueInvoiceRequest) GetGuestEmail() string {
+	if x != nil {
+		return x.GuestEmail
+	}
+	return ""
+}
+
+func (x *ReissueInvoiceRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+// WatchCampaignProgressRequest resumes after last_event_id when it is set,
+// otherwise the stream starts with a snapshot of the campaign totals.
+type WatchCampaignProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int32                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	LastEventId   int64                  `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCampaignProgressRequest) Reset() {
+	*x = WatchCampaignProgressRequest{}
+	mi := &file_pb_donation_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCampaignProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCampaignProgressRequest) ProtoMessage() {}
+
+func (x *WatchCampaignProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCampaignProgressRequest.ProtoReflect.Descriptor instead.
+func (*WatchCampaignProgressRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WatchCampaignProgressRequest) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *WatchCampaignProgressRequest) GetLastEventId() int64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+type CampaignProgressEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	CampaignId    int32                  `protobuf:"varint,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	DonationId    int32                  `protobuf:"varint,3,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
+	Amount        float32                `protobuf:"fixed32,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	TotalRaised   float32                `protobuf:"fixed32,5,opt,name=total_raised,json=totalRaised,proto3" json:"total_raised,omitempty"`
+	DonationCount int32                  `protobuf:"varint,6,opt,name=donation_count,json=donationCount,proto3" json:"donation_count,omitempty"`
+	DonorCount    int32                  `protobuf:"varint,7,opt,name=donor_count,json=donorCount,proto3" json:"donor_count,omitempty"`
+	Source        string                 `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	OccurredAt    string                 `protobuf:"bytes,9,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignProgressEvent) Reset() {
+	*x = CampaignProgressEvent{}
+	mi := &file_pb_donation_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignProgressEvent) ProtoMessage() {}
+
+func (x *CampaignProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignProgressEvent.ProtoReflect.Descriptor instead.
+func (*CampaignProgressEvent) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CampaignProgressEvent) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *CampaignProgressEvent) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignProgressEvent) GetDonationId() int32 {
+	if x != nil {
+		return x.DonationId
+	}
+	return 0
+}
+
+func (x *CampaignProgressEvent) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CampaignProgressEvent) GetTotalRaised() float32 {
+	if x != nil {
+		return x.TotalRaised
+	}
+	return 0
+}
+
+func (x *CampaignProgressEvent) GetDonationCount() int32 {
+	if x != nil {
+		return x.DonationCount
+	}
+	return 0
+}
+
+func (x *CampaignProgressEvent) GetDonorCount() int32 {
+	if x != nil {
+		return x.DonorCount
+	}
+	return 0
+}
+
+func (x *CampaignProgressEvent) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *CampaignProgressEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+// CampaignStatsRequest is made for user_id, who must own the campaign unless
+// is_admin.
+type CampaignStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int32                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsAdmin       bool                   `protobuf:"varint,3,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignStatsRequest) Reset() {
+	*x = CampaignStatsRequest{}
+	mi := &file_pb_donation_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignStatsRequest) ProtoMessage() {}
+
+func (x *CampaignStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignStatsRequest.ProtoReflect.Descriptor instead.
+func (*CampaignStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CampaignStatsRequest) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignStatsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CampaignStatsRequest) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+// CampaignStatsResponse sums up the completed donations of a campaign.
+// repeat_donor_rate is the share of donors who gave more than once.
+type CampaignStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId      int32                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	TotalRaised     float64                `protobuf:"fixed64,2,opt,name=total_raised,json=totalRaised,proto3" json:"total_raised,omitempty"`
+	DonationCount   int32                  `protobuf:"varint,3,opt,name=donation_count,json=donationCount,proto3" json:"donation_count,omitempty"`
+	DonorCount      int32                  `protobuf:"varint,4,opt,name=donor_count,json=donorCount,proto3" json:"donor_count,omitempty"`
+	AverageDonation float64                `protobuf:"fixed64,5,opt,name=average_donation,json=averageDonation,proto3" json:"average_donation,omitempty"`
+	MedianDonation  float64                `protobuf:"fixed64,6,opt,name=median_donation,json=medianDonation,proto3" json:"median_donation,omitempty"`
+	RepeatDonorRate float64                `protobuf:"fixed64,7,opt,name=repeat_donor_rate,json=repeatDonorRate,proto3" json:"repeat_donor_rate,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CampaignStatsResponse) Reset() {
+	*x = CampaignStatsResponse{}
+	mi := &file_pb_donation_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignStatsResponse) ProtoMessage() {}
+
+func (x *CampaignStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignStatsResponse.ProtoReflect.Descriptor instead.
+func (*CampaignStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CampaignStatsResponse) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CampaignStatsResponse) GetTotalRaised() float64 {
+	if x != nil {
+		return x.TotalRaised
+	}
+	return 0
+}
+
+func (x *CampaignStatsResponse) GetDonationCount() int32 {
+	if x != nil {
+		return x.DonationCount
+	}
+	return 0
+}
+
+func (x *CampaignStatsResponse) GetDonorCount() int32 {
+	if x != nil {
+		return x.DonorCount
+	}
+	return 0
+}
+
+func (x *CampaignStatsResponse) GetAverageDonation() float64 {
+	if x != nil {
+		return x.AverageDonation
+	}
+	return 0
+}
+
+func (x *CampaignStatsResponse) GetMedianDonation() float64 {
+	if x != nil {
+		return x.MedianDonation
+	}
+	return 0
+}
+
+func (x *CampaignStatsResponse) GetRepeatDonorRate() float64 {
+	if x != nil {
+		return x.RepeatDonorRate
+	}
+	return 0
+}
+
+// DonationTimeSeriesRequest buckets the completed donations of a campaign by
+// the day or week (from Monday) they were paid in timezone, an IANA name, UTC
+// by default. from and to are dates, YYYY-MM-DD, both included; by default
+// the series ends today and covers 30 days or 12 weeks.
+type DonationTimeSeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int32                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsAdmin       bool                   `protobuf:"varint,3,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	Interval      string                 `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	From          string                 `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,7,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DonationTimeSeriesRequest) Reset() {
+	*x = DonationTimeSeriesRequest{}
+	mi := &file_pb_donation_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DonationTimeSeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DonationTimeSeriesRequest) ProtoMessage() {}
+
+func (x *DonationTimeSeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DonationTimeSeriesRequest.ProtoReflect.Descriptor instead.
+func (*DonationTimeSeriesRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DonationTimeSeriesRequest) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *DonationTimeSeriesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DonationTimeSeriesRequest) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+func (x *DonationTimeSeriesRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *DonationTimeSeriesRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *DonationTimeSeriesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *DonationTimeSeriesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type DonationTimeSeriesBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	TotalAmount   float64                `protobuf:"fixed64,2,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	DonationCount int32                  `protobuf:"varint,3,opt,name=donation_count,json=donationCount,proto3" json:"donation_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DonationTimeSeriesBucket) Reset() {
+	*x = DonationTimeSeriesBucket{}
+	mi := &file_pb_donation_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DonationTimeSeriesBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DonationTimeSeriesBucket) ProtoMessage() {}
+
+func (x *DonationTimeSeriesBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DonationTimeSeriesBucket.ProtoReflect.Descriptor instead.
+func (*DonationTimeSeriesBucket) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DonationTimeSeriesBucket) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *DonationTimeSeriesBucket) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *DonationTimeSeriesBucket) GetDonationCount() int32 {
+	if x != nil {
+		return x.DonationCount
+	}
+	return 0
+}
+
+// DonationTimeSeriesResponse has a bucket for every day or week of the range,
+// empty ones included.
+type DonationTimeSeriesResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	CampaignId    int32                       `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Interval      string                      `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Timezone      string                      `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Buckets       []*DonationTimeSeriesBucket `protobuf:"bytes,4,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DonationTimeSeriesResponse) Reset() {
+	*x = DonationTimeSeriesResponse{}
+	mi := &file_pb_donation_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DonationTimeSeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DonationTimeSeriesResponse) ProtoMessage() {}
+
+func (x *DonationTimeSeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DonationTimeSeriesResponse.ProtoReflect.Descriptor instead.
+func (*DonationTimeSeriesResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DonationTimeSeriesResponse) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *DonationTimeSeriesResponse) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *DonationTimeSeriesResponse) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *DonationTimeSeriesResponse) GetBuckets() []*DonationTimeSeriesBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type WatchDonationEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LastEventId   int64                  `protobuf:"varint,1,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchDonationEventsRequest) Reset() {
+	*x = WatchDonationEventsRequest{}
+	mi := &file_pb_donation_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchDonationEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDonationEventsRequest) ProtoMessage() {}
+
+func (x *WatchDonationEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDonationEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchDonationEventsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WatchDonationEventsRequest) GetLastEventId() int64 {
+	if x != nil {
+		return x.LastEventId
+	}
+	return 0
+}
+
+// DonationEvent tells what happened to a donation, without the donor details.
+type DonationEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	CampaignId    int32                  `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	DonationId    int32                  `protobuf:"varint,4,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OccurredAt    string                 `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DonationEvent) Reset() {
+	*x = DonationEvent{}
+	mi := &file_pb_donation_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DonationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DonationEvent) ProtoMessage() {}
+
+func (x *DonationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DonationEvent.ProtoReflect.Descriptor instead.
+func (*DonationEvent) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DonationEvent) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *DonationEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DonationEvent) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *DonationEvent) GetDonationId() int32 {
+	if x != nil {
+		return x.DonationId
+	}
+	return 0
+}
+
+func (x *DonationEvent) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DonationEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+// DonationReceiptRequest asks for the receipt of a donation on behalf of its
+// donor: user_id for a registered donor, or for a guest the email they
+// donated with and the invoice ID of one of its payments. Anyone else is told
+// the donation does not exist.
+type DonationReceiptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DonationId    int32                  `protobuf:"varint,1,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GuestEmail    string                 `protobuf:"bytes,3,opt,name=guest_email,json=guestEmail,proto3" json:"guest_email,omitempty"`
+	InvoiceId     string                 `protobuf:"bytes,4,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DonationReceiptRequest) Reset() {
+	*x = DonationReceiptRequest{}
+	mi := &file_pb_donation_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DonationReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DonationReceiptRequest) ProtoMessage() {}
+
+func (x *DonationReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DonationReceiptRequest.ProtoReflect.Descriptor instead.
+func (*DonationReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DonationReceiptRequest) GetDonationId() int32 {
+	if x != nil {
+		return x.DonationId
+	}
+	return 0
+}
+
+func (x *DonationReceiptRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DonationReceiptRequest) GetGuestEmail() string {
+	if x != nil {
+		return x.GuestEmail
+	}
+	return ""
+}
+
+func (x *DonationReceiptRequest) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+// AnnualReceiptRequest asks for the summary of every receipt issued to a
+// donor in a calendar year.
+type AnnualReceiptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Year          int32                  `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnnualReceiptRequest) Reset() {
+	*x = AnnualReceiptRequest{}
+	mi := &file_pb_donation_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnnualReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnnualReceiptRequest) ProtoMessage() {}
+
+func (x *AnnualReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnnualReceiptRequest.ProtoReflect.Descriptor instead.
+func (*AnnualReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AnnualReceiptRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AnnualReceiptRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+// ReceiptResponse carries a rendered PDF. user_id is the current owner of the
+// donation, callers check it before handing out the file.
+type ReceiptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ReceiptNumber string                 `protobuf:"bytes,3,opt,name=receipt_number,json=receiptNumber,proto3" json:"receipt_number,omitempty"`
+	DonationId    int32                  `protobuf:"varint,4,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileName      string                 `protobuf:"bytes,6,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Pdf           []byte                 `protobuf:"bytes,7,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	IssuedAt      string                 `protobuf:"bytes,8,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiptResponse) Reset() {
+	*x = ReceiptResponse{}
+	mi := &file_pb_donation_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptResponse) ProtoMessage() {}
+
+func (x *ReceiptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptResponse.ProtoReflect.Descriptor instead.
+func (*ReceiptResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReceiptResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReceiptResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ReceiptResponse) GetReceiptNumber() string {
+	if x != nil {
+		return x.ReceiptNumber
+	}
+	return ""
+}
+
+func (x *ReceiptResponse) GetDonationId() int32 {
+	if x != nil {
+		return x.DonationId
+	}
+	return 0
+}
+
+func (x *ReceiptResponse) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReceiptResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ReceiptResponse) GetPdf() []byte {
+	if x != nil {
+		return x.Pdf
+	}
+	return nil
+}
+
+func (x *ReceiptResponse) GetIssuedAt() string {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return ""
+}
+
+type NotificationPreferenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferenceRequest) Reset() {
+	*x = NotificationPreferenceRequest{}
+	mi := &file_pb_donation_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferenceRequest) ProtoMessage() {}
+
+func (x *NotificationPreferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferenceRequest.ProtoReflect.Descriptor instead.
+func (*NotificationPreferenceRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *NotificationPreferenceRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// UpdateNotificationPreferenceRequest replaces the whole preference.
+// muted_events lists event types the user does not want to hear about.
+type UpdateNotificationPreferenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,2,opt,name=locale,proto3" json:"locale,omitempty"`
+	EmailEnabled  bool                   `protobuf:"varint,3,opt,name=email_enabled,json=emailEnabled,proto3" json:"email_enabled,omitempty"`
+	WebhookUrl    string                 `protobuf:"bytes,4,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	MutedEvents   []string               `protobuf:"bytes,5,rep,name=muted_events,json=mutedEvents,proto3" json:"muted_events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNotificationPreferenceRequest) Reset() {
+	*x = UpdateNotificationPreferenceRequest{}
+	mi := &file_pb_donation_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNotificationPreferenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNotificationPreferenceRequest) ProtoMessage() {}
+
+func (x *UpdateNotificationPreferenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNotificationPreferenceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNotificationPreferenceRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UpdateNotificationPreferenceRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateNotificationPreferenceRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *UpdateNotificationPreferenceRequest) GetEmailEnabled() bool {
+	if x != nil {
+		return x.EmailEnabled
+	}
+	return false
+}
+
+func (x *UpdateNotificationPreferenceRequest) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *UpdateNotificationPreferenceRequest) GetMutedEvents() []string {
+	if x != nil {
+		return x.MutedEvents
+	}
+	return nil
+}
+
+type NotificationPreferenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	EmailEnabled  bool                   `protobuf:"varint,5,opt,name=email_enabled,json=emailEnabled,proto3" json:"email_enabled,omitempty"`
+	WebhookUrl    string                 `protobuf:"bytes,6,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	MutedEvents   []string               `protobuf:"bytes,7,rep,name=muted_events,json=mutedEvents,proto3" json:"muted_events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferenceResponse) Reset() {
+	*x = NotificationPreferenceResponse{}
+	mi := &file_pb_donation_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferenceResponse) ProtoMessage() {}
+
+func (x *NotificationPreferenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferenceResponse.ProtoReflect.Descriptor instead.
+func (*NotificationPreferenceResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *NotificationPreferenceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *NotificationPreferenceResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *NotificationPreferenceResponse) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *NotificationPreferenceResponse) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *NotificationPreferenceResponse) GetEmailEnabled() bool {
+	if x != nil {
+		return x.EmailEnabled
+	}
+	return false
+}
+
+func (x *NotificationPreferenceResponse) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *NotificationPreferenceResponse) GetMutedEvents() []string {
+	if x != nil {
+		return x.MutedEvents
+	}
+	return nil
+}
+
+// WebhookSubscription is only returned with its secret when it is created.
+type WebhookSubscription struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CampaignId    int32                  `protobuf:"varint,3,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Url           string                 `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Secret        string                 `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,6,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Active        bool                   `protobuf:"varint,7,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSubscription) Reset() {
+	*x = WebhookSubscription{}
+	mi := &file_pb_donation_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSubscription) ProtoMessage() {}
+
+func (x *WebhookSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSubscription.ProtoReflect.Descriptor instead.
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WebhookSubscription) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookSubscription) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WebhookSubscription) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *WebhookSubscription) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *WebhookSubscription) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubscriptionId int32                  `protobuf:"varint,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	EventType      string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Payload        string                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts       int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	ResponseStatus int32                  `protobuf:"varint,7,opt,name=response_status,json=responseStatus,proto3" json:"response_status,omitempty"`
+	LastError      string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt  string                 `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	DeliveredAt    string                 `protobuf:"bytes,10,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_pb_donation_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WebhookDelivery) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetResponseStatus() int32 {
+	if x != nil {
+		return x.ResponseStatus
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() string {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() string {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// CreateWebhookSubscriptionRequest is made on behalf of user_id, who has to
+// own the campaign.
+type CreateWebhookSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CampaignId    int32                  `protobuf:"varint,2,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,4,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookSubscriptionRequest) Reset() {
+	*x = CreateWebhookSubscriptionRequest{}
+	mi := &file_pb_donation_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetCampaignId() int32 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type WebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Subscription  *WebhookSubscription   `protobuf:"bytes,3,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSubscriptionResponse) Reset() {
+	*x = WebhookSubscriptionResponse{}
+	mi := &file_pb_donation_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *WebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WebhookSubscriptionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WebhookSubscriptionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookSubscriptionResponse) GetSubscription() *WebhookSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type WebhookSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-func (x *TransactionResponse) GetInvoiceUrl() string {
+func (x *WebhookSubscriptionsRequest) Reset() {
+	*x = WebhookSubscriptionsRequest{}
+	mi := &file_pb_donation_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSubscriptionsRequest) ProtoMessage() {}
+
+func (x *WebhookSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[48]
 	if x != nil {
-		return x.InvoiceUrl
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *TransactionResponse) GetInvoiceDescription() string {
+// Deprecated: Use WebhookSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *WebhookSubscriptionsRequest) GetUserId() int32 {
 	if x != nil {
-		return x.InvoiceDescription
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *TransactionResponse) GetPaymentMethod() string {
+type WebhookSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*WebhookSubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSubscriptionsResponse) Reset() {
+	*x = WebhookSubscriptionsResponse{}
+	mi := &file_pb_donation_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSubscriptionsResponse) ProtoMessage() {}
+
+func (x *WebhookSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[49]
 	if x != nil {
-		return x.PaymentMethod
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *TransactionResponse) GetAmount() float32 {
+// Deprecated: Use WebhookSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *WebhookSubscriptionsResponse) GetSubscriptions() []*WebhookSubscription {
 	if x != nil {
-		return x.Amount
+		return x.Subscriptions
 	}
-	return 0
+	return nil
+}
+
+type WebhookSubscriptionIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSubscriptionIdRequest) Reset() {
+	*x = WebhookSubscriptionIdRequest{}
+	mi := &file_pb_donation_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscriptionIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *TransactionResponse) GetStatus() string {
+func (*WebhookSubscriptionIdRequest) ProtoMessage() {}
+
+func (x *WebhookSubscriptionIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[50]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *TransactionResponse) GetCreatedAt() string {
+// Deprecated: Use WebhookSubscriptionIdRequest.ProtoReflect.Descriptor instead.
+func (*WebhookSubscriptionIdRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *WebhookSubscriptionIdRequest) GetId() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *TransactionResponse) GetUpdatedAt() string {
+func (x *WebhookSubscriptionIdRequest) GetUserId() int32 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-type Transaction struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	DonationId         int32                  `protobuf:"varint,2,opt,name=donation_id,json=donationId,proto3" json:"donation_id,omitempty"`
-	InvoiceId          string                 `protobuf:"bytes,3,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
-	InvoiceUrl         string                 `protobuf:"bytes,4,opt,name=invoice_url,json=invoiceUrl,proto3" json:"invoice_url,omitempty"`
-	InvoiceDescription string                 `protobuf:"bytes,5,opt,name=invoice_description,json=invoiceDescription,proto3" json:"invoice_description,omitempty"`
-	PaymentMethod      string                 `protobuf:"bytes,6,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
-	Amount             float32                `protobuf:"fixed32,7,opt,name=amount,proto3" json:"amount,omitempty"`
-	Status             string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt          string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt          string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type WebhookDeliveriesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId int32                  `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	UserId         int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page           int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize       int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *Transaction) Reset() {
-	*x = Transaction{}
-	mi := &file_pb_donation_proto_msgTypes[9]
+func (x *WebhookDeliveriesRequest) Reset() {
+	*x = WebhookDeliveriesRequest{}
+	mi := &file_pb_donation_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Transaction) String() string {
+func (x *WebhookDeliveriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Transaction) ProtoMessage() {}
+func (*WebhookDeliveriesRequest) ProtoMessage() {}
 
-func (x *Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_donation_proto_msgTypes[9]
+func (x *WebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,102 +4056,131 @@ func (x *Transaction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
-func (*Transaction) Descriptor() ([]byte, []int) {
-	return file_pb_donation_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use WebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *Transaction) GetId() int32 {
+func (x *WebhookDeliveriesRequest) GetSubscriptionId() int32 {
 	if x != nil {
-		return x.Id
+		return x.SubscriptionId
 	}
 	return 0
 }
 
-func (x *Transaction) GetDonationId() int32 {
+func (x *WebhookDeliveriesRequest) GetUserId() int32 {
 	if x != nil {
-		return x.DonationId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *Transaction) GetInvoiceId() string {
+func (x *WebhookDeliveriesRequest) GetPage() int32 {
 	if x != nil {
-		return x.InvoiceId
+		return x.Page
 	}
-	return ""
+	return 0
 }
 
-func (x *Transaction) GetInvoiceUrl() string {
+func (x *WebhookDeliveriesRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.InvoiceUrl
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *Transaction) GetInvoiceDescription() string {
-	if x != nil {
-		return x.InvoiceDescription
-	}
-	return ""
+type WebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Transaction) GetPaymentMethod() string {
+func (x *WebhookDeliveriesResponse) Reset() {
+	*x = WebhookDeliveriesResponse{}
+	mi := &file_pb_donation_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *WebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[52]
 	if x != nil {
-		return x.PaymentMethod
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Transaction) GetAmount() float32 {
+// Deprecated: Use WebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *WebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
 	if x != nil {
-		return x.Amount
+		return x.Deliveries
 	}
-	return 0
+	return nil
 }
 
-func (x *Transaction) GetStatus() string {
+func (x *WebhookDeliveriesResponse) GetPage() int32 {
 	if x != nil {
-		return x.Status
+		return x.Page
 	}
-	return ""
+	return 0
 }
 
-func (x *Transaction) GetCreatedAt() string {
+func (x *WebhookDeliveriesResponse) GetPageSize() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *Transaction) GetUpdatedAt() string {
+func (x *WebhookDeliveriesResponse) GetTotal() int64 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-type GetTransactionsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type RedeliverWebhookRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId int32                  `protobuf:"varint,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	DeliveryId     int32                  `protobuf:"varint,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	UserId         int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetTransactionsRequest) Reset() {
-	*x = GetTransactionsRequest{}
-	mi := &file_pb_donation_proto_msgTypes[10]
+func (x *RedeliverWebhookRequest) Reset() {
+	*x = RedeliverWebhookRequest{}
+	mi := &file_pb_donation_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionsRequest) String() string {
+func (x *RedeliverWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionsRequest) ProtoMessage() {}
+func (*RedeliverWebhookRequest) ProtoMessage() {}
 
-func (x *GetTransactionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_donation_proto_msgTypes[10]
+func (x *RedeliverWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -869,33 +4191,56 @@ func (x *GetTransactionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionsRequest.ProtoReflect.Descriptor instead.
-func (*GetTransactionsRequest) Descriptor() ([]byte, []int) {
-	return file_pb_donation_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RedeliverWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{53}
 }
 
-type GetTransactionsResponse struct {
+func (x *RedeliverWebhookRequest) GetSubscriptionId() int32 {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return 0
+}
+
+func (x *RedeliverWebhookRequest) GetDeliveryId() int32 {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return 0
+}
+
+func (x *RedeliverWebhookRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type WebhookDeliveryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Delivery      *WebhookDelivery       `protobuf:"bytes,3,opt,name=delivery,proto3" json:"delivery,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTransactionsResponse) Reset() {
-	*x = GetTransactionsResponse{}
-	mi := &file_pb_donation_proto_msgTypes[11]
+func (x *WebhookDeliveryResponse) Reset() {
+	*x = WebhookDeliveryResponse{}
+	mi := &file_pb_donation_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionsResponse) String() string {
+func (x *WebhookDeliveryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionsResponse) ProtoMessage() {}
+func (*WebhookDeliveryResponse) ProtoMessage() {}
 
-func (x *GetTransactionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_donation_proto_msgTypes[11]
+func (x *WebhookDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_donation_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -906,14 +4251,28 @@ func (x *GetTransactionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionsResponse.ProtoReflect.Descriptor instead.
-func (*GetTransactionsResponse) Descriptor() ([]byte, []int) {
-	return file_pb_donation_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use WebhookDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*WebhookDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_pb_donation_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *GetTransactionsResponse) GetTransactions() []*Transaction {
+func (x *WebhookDeliveryResponse) GetMessage() string {
 	if x != nil {
-		return x.Transactions
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WebhookDeliveryResponse) GetDelivery() *WebhookDelivery {
+	if x != nil {
+		return x.Delivery
 	}
 	return nil
 }
@@ -922,17 +4281,21 @@ var File_pb_donation_proto protoreflect.FileDescriptor
 
 const file_pb_donation_proto_rawDesc = "" +
 	"\n" +
-	"\x11pb/donation.proto\x12\bdonation\"#\n" +
+	"\x11pb/donation.proto\x12\bdonation\x1a google/protobuf/field_mask.proto\"#\n" +
 	"\x11DonationIdRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"\xa5\x01\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\xb9\x02\n" +
 	"\x0fDonationRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1f\n" +
 	"\vcampaign_id\x18\x03 \x01(\x05R\n" +
 	"campaignId\x12\x16\n" +
 	"\x06amount\x18\x04 \x01(\x02R\x06amount\x12\x18\n" +
-	"\amessage\x18\x05 \x01(\tR\amessage\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\"\x9b\x02\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x120\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x18.donation.DonationStatusR\x06status\x12!\n" +
+	"\fis_anonymous\x18\a \x01(\bR\visAnonymous\x12;\n" +
+	"\vupdate_mask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\"\x93\x03\n" +
 	"\x10DonationResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x0e\n" +
@@ -941,26 +4304,115 @@ const file_pb_donation_proto_rawDesc = "" +
 	"\vcampaign_id\x18\x05 \x01(\x05R\n" +
 	"campaignId\x12\x16\n" +
 	"\x06amount\x18\x06 \x01(\x02R\x06amount\x12!\n" +
-	"\fmessage_text\x18\a \x01(\tR\vmessageText\x12\x16\n" +
-	"\x06status\x18\b \x01(\tR\x06status\x12\x1c\n" +
+	"\fmessage_text\x18\a \x01(\tR\vmessageText\x120\n" +
+	"\x06status\x18\b \x01(\x0e2\x18.donation.DonationStatusR\x06status\x12\x1c\n" +
 	"\tcreatedAt\x18\t \x01(\tR\tcreatedAt\x12\x1c\n" +
 	"\tupdatedAt\x18\n" +
-	" \x01(\tR\tupdatedAt\"\xda\x01\n" +
+	" \x01(\tR\tupdatedAt\x12!\n" +
+	"\fis_anonymous\x18\v \x01(\bR\visAnonymous\x12\x1f\n" +
+	"\vguest_email\x18\f \x01(\tR\n" +
+	"guestEmail\x12\x18\n" +
+	"\aversion\x18\r \x01(\x05R\aversion\"\x80\x03\n" +
 	"\bDonation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1f\n" +
 	"\vcampaign_id\x18\x03 \x01(\x05R\n" +
 	"campaignId\x12\x16\n" +
 	"\x06amount\x18\x04 \x01(\x02R\x06amount\x12\x18\n" +
-	"\amessage\x18\x05 \x01(\tR\amessage\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1c\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x120\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x18.donation.DonationStatusR\x06status\x12\x1c\n" +
 	"\tcreatedAt\x18\a \x01(\tR\tcreatedAt\x12\x1c\n" +
-	"\tupdatedAt\x18\b \x01(\tR\tupdatedAt\"\x15\n" +
+	"\tupdatedAt\x18\b \x01(\tR\tupdatedAt\x12!\n" +
+	"\fis_anonymous\x18\t \x01(\bR\visAnonymous\x12\x1f\n" +
+	"\vguest_email\x18\n" +
+	" \x01(\tR\n" +
+	"guestEmail\x12\x18\n" +
+	"\aversion\x18\v \x01(\x05R\aversion\x12,\n" +
+	"\x05donor\x18\f \x01(\v2\x16.donation.DonorSummaryR\x05donor\"t\n" +
+	"\fDonorSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\x12!\n" +
+	"\fmasked_email\x18\x04 \x01(\tR\vmaskedEmail\"\x15\n" +
 	"\x13GetDonationsRequest\"H\n" +
 	"\x14GetDonationsResponse\x120\n" +
-	"\tdonations\x18\x01 \x03(\v2\x12.donation.DonationR\tdonations\"&\n" +
+	"\tdonations\x18\x01 \x03(\v2\x12.donation.DonationR\tdonations\"\x97\x02\n" +
+	"\x14GuestDonationRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\x05R\n" +
+	"campaignId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x02R\x06amount\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12!\n" +
+	"\fis_anonymous\x18\x05 \x01(\bR\visAnonymous\x12%\n" +
+	"\x0epayment_method\x18\x06 \x01(\tR\rpaymentMethod\x12'\n" +
+	"\x0fpayment_channel\x18\a \x01(\tR\x0epaymentChannel\x12#\n" +
+	"\rmobile_number\x18\b \x01(\tR\fmobileNumber\"\xb0\x01\n" +
+	"\x15GuestDonationResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12.\n" +
+	"\bdonation\x18\x03 \x01(\v2\x12.donation.DonationR\bdonation\x127\n" +
+	"\vtransaction\x18\x04 \x01(\v2\x15.donation.TransactionR\vtransaction\"K\n" +
+	"\x1aClaimGuestDonationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"r\n" +
+	"\x1bClaimGuestDonationsResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12#\n" +
+	"\rclaimed_count\x18\x03 \x01(\x05R\fclaimedCount\"\xec\x01\n" +
+	"\x0ePublicDonation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\x05R\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x1d\n" +
+	"\n" +
+	"donor_name\x18\x04 \x01(\tR\tdonorName\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x02R\x06amount\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12!\n" +
+	"\fis_anonymous\x18\a \x01(\bR\visAnonymous\x12\x1c\n" +
+	"\tcreatedAt\x18\b \x01(\tR\tcreatedAt\"l\n" +
+	"\x18CampaignDonationsRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x05R\n" +
+	"campaignId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x9a\x01\n" +
+	"\x19CampaignDonationsResponse\x126\n" +
+	"\tdonations\x18\x01 \x03(\v2\x18.donation.PublicDonationR\tdonations\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"\xb1\x01\n" +
+	"\n" +
+	"DonorTotal\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1d\n" +
+	"\n" +
+	"donor_name\x18\x02 \x01(\tR\tdonorName\x12!\n" +
+	"\fis_anonymous\x18\x03 \x01(\bR\visAnonymous\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x02R\vtotalAmount\x12%\n" +
+	"\x0edonation_count\x18\x05 \x01(\x05R\rdonationCount\"\x9b\x01\n" +
+	"\rCampaignTotal\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x05R\n" +
+	"campaignId\x12!\n" +
+	"\ftotal_amount\x18\x02 \x01(\x02R\vtotalAmount\x12%\n" +
+	"\x0edonation_count\x18\x03 \x01(\x05R\rdonationCount\x12\x1f\n" +
+	"\vdonor_count\x18\x04 \x01(\x05R\n" +
+	"donorCount\"Q\n" +
+	"\x18CampaignTopDonorsRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x05R\n" +
+	"campaignId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"A\n" +
+	"\x11TopDonorsResponse\x12,\n" +
+	"\x06donors\x18\x01 \x03(\v2\x14.donation.DonorTotalR\x06donors\"B\n" +
+	"\x12LeaderboardRequest\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\tR\x06window\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xa0\x01\n" +
+	"\x13LeaderboardResponse\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\tR\x06window\x123\n" +
+	"\n" +
+	"top_donors\x18\x02 \x03(\v2\x14.donation.DonorTotalR\ttopDonors\x12<\n" +
+	"\rtop_campaigns\x18\x03 \x03(\v2\x17.donation.CampaignTotalR\ftopCampaigns\"?\n" +
 	"\x14TransactionIdRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"\x8d\x02\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\xab\x03\n" +
 	"\x12TransactionRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
 	"\vdonation_id\x18\x02 \x01(\x05R\n" +
@@ -971,8 +4423,20 @@ const file_pb_donation_proto_rawDesc = "" +
 	"invoiceUrl\x12/\n" +
 	"\x13invoice_description\x18\x05 \x01(\tR\x12invoiceDescription\x12%\n" +
 	"\x0epayment_method\x18\x06 \x01(\tR\rpaymentMethod\x12\x16\n" +
-	"\x06amount\x18\a \x01(\x02R\x06amount\x12\x16\n" +
-	"\x06status\x18\b \x01(\tR\x06status\"\xfc\x02\n" +
+	"\x06amount\x18\a \x01(\x02R\x06amount\x123\n" +
+	"\x06status\x18\b \x01(\x0e2\x1b.donation.TransactionStatusR\x06status\x12'\n" +
+	"\x0fpayment_channel\x18\t \x01(\tR\x0epaymentChannel\x12#\n" +
+	"\rmobile_number\x18\n" +
+	" \x01(\tR\fmobileNumber\x12\x17\n" +
+	"\auser_id\x18\v \x01(\x05R\x06userId\x12\x18\n" +
+	"\aversion\x18\f \x01(\x05R\aversion\"\xb4\x01\n" +
+	"\x13PaymentInstructions\x12!\n" +
+	"\fcheckout_url\x18\x01 \x01(\tR\vcheckoutUrl\x12\x1b\n" +
+	"\tva_number\x18\x02 \x01(\tR\bvaNumber\x12\x1b\n" +
+	"\tqr_string\x18\x03 \x01(\tR\bqrString\x12!\n" +
+	"\fdeeplink_url\x18\x04 \x01(\tR\vdeeplinkUrl\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt\"\xd7\x04\n" +
 	"\x13TransactionResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x0e\n" +
@@ -985,13 +4449,17 @@ const file_pb_donation_proto_rawDesc = "" +
 	"invoiceUrl\x12/\n" +
 	"\x13invoice_description\x18\a \x01(\tR\x12invoiceDescription\x12%\n" +
 	"\x0epayment_method\x18\b \x01(\tR\rpaymentMethod\x12\x16\n" +
-	"\x06amount\x18\t \x01(\x02R\x06amount\x12\x16\n" +
+	"\x06amount\x18\t \x01(\x02R\x06amount\x123\n" +
 	"\x06status\x18\n" +
-	" \x01(\tR\x06status\x12\x1d\n" +
+	" \x01(\x0e2\x1b.donation.TransactionStatusR\x06status\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\v \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\f \x01(\tR\tupdatedAt\"\xc4\x02\n" +
+	"updated_at\x18\f \x01(\tR\tupdatedAt\x12'\n" +
+	"\x0fpayment_channel\x18\r \x01(\tR\x0epaymentChannel\x12A\n" +
+	"\finstructions\x18\x0e \x01(\v2\x1d.donation.PaymentInstructionsR\finstructions\x126\n" +
+	"\x17previous_transaction_id\x18\x0f \x01(\x05R\x15previousTransactionId\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x05R\aversion\"\x9f\x04\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
 	"\vdonation_id\x18\x02 \x01(\x05R\n" +
@@ -1002,26 +4470,248 @@ const file_pb_donation_proto_rawDesc = "" +
 	"invoiceUrl\x12/\n" +
 	"\x13invoice_description\x18\x05 \x01(\tR\x12invoiceDescription\x12%\n" +
 	"\x0epayment_method\x18\x06 \x01(\tR\rpaymentMethod\x12\x16\n" +
-	"\x06amount\x18\a \x01(\x02R\x06amount\x12\x16\n" +
-	"\x06status\x18\b \x01(\tR\x06status\x12\x1d\n" +
+	"\x06amount\x18\a \x01(\x02R\x06amount\x123\n" +
+	"\x06status\x18\b \x01(\x0e2\x1b.donation.TransactionStatusR\x06status\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\tR\tupdatedAt\"\x18\n" +
-	"\x16GetTransactionsRequest\"T\n" +
+	" \x01(\tR\tupdatedAt\x12'\n" +
+	"\x0fpayment_channel\x18\v \x01(\tR\x0epaymentChannel\x12A\n" +
+	"\finstructions\x18\f \x01(\v2\x1d.donation.PaymentInstructionsR\finstructions\x126\n" +
+	"\x17previous_transaction_id\x18\r \x01(\x05R\x15previousTransactionId\x12\x18\n" +
+	"\aversion\x18\x0e \x01(\x05R\aversion\"1\n" +
+	"\x16GetTransactionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"T\n" +
 	"\x17GetTransactionsResponse\x129\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x15.donation.TransactionR\ftransactions2\xe7\x05\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x15.donation.TransactionR\ftransactions\"V\n" +
+	"\x16InvoiceCallbackRequest\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x01 \x01(\tR\tinvoiceId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\"\x8c\x02\n" +
+	"\x15ReissueInvoiceRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\x05R\rtransactionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12%\n" +
+	"\x0epayment_method\x18\x03 \x01(\tR\rpaymentMethod\x12'\n" +
+	"\x0fpayment_channel\x18\x04 \x01(\tR\x0epaymentChannel\x12#\n" +
+	"\rmobile_number\x18\x05 \x01(\tR\fmobileNumber\x12\x1f\n" +
+	"\vguest_email\x18\x06 \x01(\tR\n" +
+	"guestEmail\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\a \x01(\tR\tinvoiceId\"c\n" +
+	"\x1cWatchCampaignProgressRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x05R\n" +
+	"campaignId\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\x03R\vlastEventId\"\xb0\x02\n" +
+	"\x15CampaignProgressEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\x05R\n" +
+	"campaignId\x12\x1f\n" +
+	"\vdonation_id\x18\x03 \x01(\x05R\n" +
+	"donationId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x02R\x06amount\x12!\n" +
+	"\ftotal_raised\x18\x05 \x01(\x02R\vtotalRaised\x12%\n" +
+	"\x0edonation_count\x18\x06 \x01(\x05R\rdonationCount\x12\x1f\n" +
+	"\vdonor_count\x18\a \x01(\x05R\n" +
+	"donorCount\x12\x16\n" +
+	"\x06source\x18\b \x01(\tR\x06source\x12\x1f\n" +
+	"\voccurred_at\x18\t \x01(\tR\n" +
+	"occurredAt\"k\n" +
+	"\x14CampaignStatsRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x05R\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bis_admin\x18\x03 \x01(\bR\aisAdmin\"\xa3\x02\n" +
+	"\x15CampaignStatsResponse\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x05R\n" +
+	"campaignId\x12!\n" +
+	"\ftotal_raised\x18\x02 \x01(\x01R\vtotalRaised\x12%\n" +
+	"\x0edonation_count\x18\x03 \x01(\x05R\rdonationCount\x12\x1f\n" +
+	"\vdonor_count\x18\x04 \x01(\x05R\n" +
+	"donorCount\x12)\n" +
+	"\x10average_donation\x18\x05 \x01(\x01R\x0faverageDonation\x12'\n" +
+	"\x0fmedian_donation\x18\x06 \x01(\x01R\x0emedianDonation\x12*\n" +
+	"\x11repeat_donor_rate\x18\a \x01(\x01R\x0frepeatDonorRate\"\xcc\x01\n" +
+	"\x19DonationTimeSeriesRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x05R\n" +
+	"campaignId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x19\n" +
+	"\bis_admin\x18\x03 \x01(\bR\aisAdmin\x12\x1a\n" +
+	"\binterval\x18\x04 \x01(\tR\binterval\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12\x12\n" +
+	"\x04from\x18\x06 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\a \x01(\tR\x02to\"z\n" +
+	"\x18DonationTimeSeriesBucket\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12!\n" +
+	"\ftotal_amount\x18\x02 \x01(\x01R\vtotalAmount\x12%\n" +
+	"\x0edonation_count\x18\x03 \x01(\x05R\rdonationCount\"\xb3\x01\n" +
+	"\x1aDonationTimeSeriesResponse\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x05R\n" +
+	"campaignId\x12\x1a\n" +
+	"\binterval\x18\x02 \x01(\tR\binterval\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12<\n" +
+	"\abuckets\x18\x04 \x03(\v2\".donation.DonationTimeSeriesBucketR\abuckets\"@\n" +
+	"\x1aWatchDonationEventsRequest\x12\"\n" +
+	"\rlast_event_id\x18\x01 \x01(\x03R\vlastEventId\"\xba\x01\n" +
+	"\rDonationEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1f\n" +
+	"\vcampaign_id\x18\x03 \x01(\x05R\n" +
+	"campaignId\x12\x1f\n" +
+	"\vdonation_id\x18\x04 \x01(\x05R\n" +
+	"donationId\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\voccurred_at\x18\x06 \x01(\tR\n" +
+	"occurredAt\"\x92\x01\n" +
+	"\x16DonationReceiptRequest\x12\x1f\n" +
+	"\vdonation_id\x18\x01 \x01(\x05R\n" +
+	"donationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\vguest_email\x18\x03 \x01(\tR\n" +
+	"guestEmail\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\x04 \x01(\tR\tinvoiceId\"C\n" +
+	"\x14AnnualReceiptRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\x05R\x04year\"\xee\x01\n" +
+	"\x0fReceiptResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12%\n" +
+	"\x0ereceipt_number\x18\x03 \x01(\tR\rreceiptNumber\x12\x1f\n" +
+	"\vdonation_id\x18\x04 \x01(\x05R\n" +
+	"donationId\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tfile_name\x18\x06 \x01(\tR\bfileName\x12\x10\n" +
+	"\x03pdf\x18\a \x01(\fR\x03pdf\x12\x1b\n" +
+	"\tissued_at\x18\b \x01(\tR\bissuedAt\"8\n" +
+	"\x1dNotificationPreferenceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"\xbf\x01\n" +
+	"#UpdateNotificationPreferenceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06locale\x18\x02 \x01(\tR\x06locale\x12#\n" +
+	"\remail_enabled\x18\x03 \x01(\bR\femailEnabled\x12\x1f\n" +
+	"\vwebhook_url\x18\x04 \x01(\tR\n" +
+	"webhookUrl\x12!\n" +
+	"\fmuted_events\x18\x05 \x03(\tR\vmutedEvents\"\xea\x01\n" +
+	"\x1eNotificationPreferenceResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12#\n" +
+	"\remail_enabled\x18\x05 \x01(\bR\femailEnabled\x12\x1f\n" +
+	"\vwebhook_url\x18\x06 \x01(\tR\n" +
+	"webhookUrl\x12!\n" +
+	"\fmuted_events\x18\a \x03(\tR\vmutedEvents\"\xe1\x01\n" +
+	"\x13WebhookSubscription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\vcampaign_id\x18\x03 \x01(\x05R\n" +
+	"campaignId\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x05 \x01(\tR\x06secret\x12\x1f\n" +
+	"\vevent_types\x18\x06 \x03(\tR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06active\x18\a \x01(\bR\x06active\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"\xe9\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\x05R\x0esubscriptionId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12'\n" +
+	"\x0fresponse_status\x18\a \x01(\x05R\x0eresponseStatus\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12&\n" +
+	"\x0fnext_attempt_at\x18\t \x01(\tR\rnextAttemptAt\x12!\n" +
+	"\fdelivered_at\x18\n" +
+	" \x01(\tR\vdeliveredAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\tR\tcreatedAt\"\x8f\x01\n" +
+	" CreateWebhookSubscriptionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\vcampaign_id\x18\x02 \x01(\x05R\n" +
+	"campaignId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x04 \x03(\tR\n" +
+	"eventTypes\"\x90\x01\n" +
+	"\x1bWebhookSubscriptionResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12A\n" +
+	"\fsubscription\x18\x03 \x01(\v2\x1d.donation.WebhookSubscriptionR\fsubscription\"6\n" +
+	"\x1bWebhookSubscriptionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"c\n" +
+	"\x1cWebhookSubscriptionsResponse\x12C\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x1d.donation.WebhookSubscriptionR\rsubscriptions\"G\n" +
+	"\x1cWebhookSubscriptionIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"\x8d\x01\n" +
+	"\x18WebhookDeliveriesRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\x05R\x0esubscriptionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x9d\x01\n" +
+	"\x19WebhookDeliveriesResponse\x129\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x19.donation.WebhookDeliveryR\n" +
+	"deliveries\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"|\n" +
+	"\x17RedeliverWebhookRequest\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\x05R\x0esubscriptionId\x12\x1f\n" +
+	"\vdelivery_id\x18\x02 \x01(\x05R\n" +
+	"deliveryId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"\x80\x01\n" +
+	"\x17WebhookDeliveryResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x125\n" +
+	"\bdelivery\x18\x03 \x01(\v2\x19.donation.WebhookDeliveryR\bdelivery*\x8c\x01\n" +
+	"\x0eDonationStatus\x12\x1f\n" +
+	"\x1bDONATION_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17DONATION_STATUS_PENDING\x10\x01\x12\x1d\n" +
+	"\x19DONATION_STATUS_COMPLETED\x10\x02\x12\x1d\n" +
+	"\x19DONATION_STATUS_ABANDONED\x10\x03*\xd3\x01\n" +
+	"\x11TransactionStatus\x12\"\n" +
+	"\x1eTRANSACTION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aTRANSACTION_STATUS_PENDING\x10\x01\x12\x1b\n" +
+	"\x17TRANSACTION_STATUS_PAID\x10\x02\x12\x1e\n" +
+	"\x1aTRANSACTION_STATUS_SETTLED\x10\x03\x12\x1e\n" +
+	"\x1aTRANSACTION_STATUS_EXPIRED\x10\x04\x12\x1d\n" +
+	"\x19TRANSACTION_STATUS_FAILED\x10\x052\xdb\x14\n" +
 	"\x0fDonationService\x12J\n" +
 	"\x0fGetDonationByID\x12\x1b.donation.DonationIdRequest\x1a\x1a.donation.DonationResponse\x12P\n" +
 	"\x0fGetAllDonations\x12\x1d.donation.GetDonationsRequest\x1a\x1e.donation.GetDonationsResponse\x12G\n" +
 	"\x0eCreateDonation\x12\x19.donation.DonationRequest\x1a\x1a.donation.DonationResponse\x12G\n" +
-	"\x0eUpdateDonation\x12\x19.donation.DonationRequest\x1a\x1a.donation.DonationResponse\x12S\n" +
+	"\x0eUpdateDonation\x12\x19.donation.DonationRequest\x1a\x1a.donation.DonationResponse\x12V\n" +
+	"\x13CreateGuestDonation\x12\x1e.donation.GuestDonationRequest\x1a\x1f.donation.GuestDonationResponse\x12b\n" +
+	"\x13ClaimGuestDonations\x12$.donation.ClaimGuestDonationsRequest\x1a%.donation.ClaimGuestDonationsResponse\x12_\n" +
+	"\x14GetCampaignDonations\x12\".donation.CampaignDonationsRequest\x1a#.donation.CampaignDonationsResponse\x12W\n" +
+	"\x14GetCampaignTopDonors\x12\".donation.CampaignTopDonorsRequest\x1a\x1b.donation.TopDonorsResponse\x12M\n" +
+	"\x0eGetLeaderboard\x12\x1c.donation.LeaderboardRequest\x1a\x1d.donation.LeaderboardResponse\x12S\n" +
+	"\x10GetCampaignStats\x12\x1e.donation.CampaignStatsRequest\x1a\x1f.donation.CampaignStatsResponse\x12b\n" +
+	"\x15GetDonationTimeSeries\x12#.donation.DonationTimeSeriesRequest\x1a$.donation.DonationTimeSeriesResponse\x12S\n" +
 	"\x12GetTransactionByID\x12\x1e.donation.TransactionIdRequest\x1a\x1d.donation.TransactionResponse\x12Y\n" +
 	"\x12GetAllTransactions\x12 .donation.GetTransactionsRequest\x1a!.donation.GetTransactionsResponse\x12P\n" +
 	"\x11CreateTransaction\x12\x1c.donation.TransactionRequest\x1a\x1d.donation.TransactionResponse\x12P\n" +
 	"\x11UpdateTransaction\x12\x1c.donation.TransactionRequest\x1a\x1d.donation.TransactionResponse\x12P\n" +
-	"\x0fSyncTransaction\x12\x1e.donation.TransactionIdRequest\x1a\x1d.donation.TransactionResponseB\x05Z\x03/pbb\x06proto3"
+	"\x0fSyncTransaction\x12\x1e.donation.TransactionIdRequest\x1a\x1d.donation.TransactionResponse\x12X\n" +
+	"\x15HandleInvoiceCallback\x12 .donation.InvoiceCallbackRequest\x1a\x1d.donation.TransactionResponse\x12P\n" +
+	"\x0eReissueInvoice\x12\x1f.donation.ReissueInvoiceRequest\x1a\x1d.donation.TransactionResponse\x12b\n" +
+	"\x15WatchCampaignProgress\x12&.donation.WatchCampaignProgressRequest\x1a\x1f.donation.CampaignProgressEvent0\x01\x12V\n" +
+	"\x13WatchDonationEvents\x12$.donation.WatchDonationEventsRequest\x1a\x17.donation.DonationEvent0\x01\x12Q\n" +
+	"\x12GetDonationReceipt\x12 .donation.DonationReceiptRequest\x1a\x19.donation.ReceiptResponse\x12M\n" +
+	"\x10GetAnnualReceipt\x12\x1e.donation.AnnualReceiptRequest\x1a\x19.donation.ReceiptResponse\x12o\n" +
+	"\x1aGetNotificationPreferences\x12'.donation.NotificationPreferenceRequest\x1a(.donation.NotificationPreferenceResponse\x12x\n" +
+	"\x1dUpdateNotificationPreferences\x12-.donation.UpdateNotificationPreferenceRequest\x1a(.donation.NotificationPreferenceResponse\x12n\n" +
+	"\x19CreateWebhookSubscription\x12*.donation.CreateWebhookSubscriptionRequest\x1a%.donation.WebhookSubscriptionResponse\x12h\n" +
+	"\x17GetWebhookSubscriptions\x12%.donation.WebhookSubscriptionsRequest\x1a&.donation.WebhookSubscriptionsResponse\x12j\n" +
+	"\x19DeleteWebhookSubscription\x12&.donation.WebhookSubscriptionIdRequest\x1a%.donation.WebhookSubscriptionResponse\x12_\n" +
+	"\x14GetWebhookDeliveries\x12\".donation.WebhookDeliveriesRequest\x1a#.donation.WebhookDeliveriesResponse\x12X\n" +
+	"\x10RedeliverWebhook\x12!.donation.RedeliverWebhookRequest\x1a!.donation.WebhookDeliveryResponseB\x05Z\x03/pbb\x06proto3"
 
 var (
 	file_pb_donation_proto_rawDescOnce sync.Once
@@ -1035,47 +4725,155 @@ func file_pb_donation_proto_rawDescGZIP() []byte {
 	return file_pb_donation_proto_rawDescData
 }
 
-var file_pb_donation_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_pb_donation_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_pb_donation_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
 var file_pb_donation_proto_goTypes = []any{
-	(*DonationIdRequest)(nil),       // 0: donation.DonationIdRequest
-	(*DonationRequest)(nil),         // 1: donation.DonationRequest
-	(*DonationResponse)(nil),        // 2: donation.DonationResponse
-	(*Donation)(nil),                // 3: donation.Donation
-	(*GetDonationsRequest)(nil),     // 4: donation.GetDonationsRequest
-	(*GetDonationsResponse)(nil),    // 5: donation.GetDonationsResponse
-	(*TransactionIdRequest)(nil),    // 6: donation.TransactionIdRequest
-	(*TransactionRequest)(nil),      // 7: donation.TransactionRequest
-	(*TransactionResponse)(nil),     // 8: donation.TransactionResponse
-	(*Transaction)(nil),             // 9: donation.Transaction
-	(*GetTransactionsRequest)(nil),  // 10: donation.GetTransactionsRequest
-	(*GetTransactionsResponse)(nil), // 11: donation.GetTransactionsResponse
+	(DonationStatus)(0),                         // 0: donation.DonationStatus
+	(TransactionStatus)(0),                      // 1: donation.TransactionStatus
+	(*DonationIdRequest)(nil),                   // 2: donation.DonationIdRequest
+	(*DonationRequest)(nil),                     // 3: donation.DonationRequest
+	(*DonationResponse)(nil),                    // 4: donation.DonationResponse
+	(*Donation)(nil),                            // 5: donation.Donation
+	(*DonorSummary)(nil),                        // 6: donation.DonorSummary
+	(*GetDonationsRequest)(nil),                 // 7: donation.GetDonationsRequest
+	(*GetDonationsResponse)(nil),                // 8: donation.GetDonationsResponse
+	(*GuestDonationRequest)(nil),                // 9: donation.GuestDonationRequest
+	(*GuestDonationResponse)(nil),               // 10: donation.GuestDonationResponse
+	(*ClaimGuestDonationsRequest)(nil),          // 11: donation.ClaimGuestDonationsRequest
+	(*ClaimGuestDonationsResponse)(nil),         // 12: donation.ClaimGuestDonationsResponse
+	(*PublicDonation)(nil),                      // 13: donation.PublicDonation
+	(*CampaignDonationsRequest)(nil),            // 14: donation.CampaignDonationsRequest
+	(*CampaignDonationsResponse)(nil),           // 15: donation.CampaignDonationsResponse
+	(*DonorTotal)(nil),                          // 16: donation.DonorTotal
+	(*CampaignTotal)(nil),                       // 17: donation.CampaignTotal
+	(*CampaignTopDonorsRequest)(nil),            // 18: donation.CampaignTopDonorsRequest
+	(*TopDonorsResponse)(nil),                   // 19: donation.TopDonorsResponse
+	(*LeaderboardRequest)(nil),                  // 20: donation.LeaderboardRequest
+	(*LeaderboardResponse)(nil),                 // 21: donation.LeaderboardResponse
+	(*TransactionIdRequest)(nil),                // 22: donation.TransactionIdRequest
+	(*TransactionRequest)(nil),                  // 23: donation.TransactionRequest
+	(*PaymentInstructions)(nil),                 // 24: donation.PaymentInstructions
+	(*TransactionResponse)(nil),                 // 25: donation.TransactionResponse
+	(*Transaction)(nil),                         // 26: donation.Transaction
+	(*GetTransactionsRequest)(nil),              // 27: donation.GetTransactionsRequest
+	(*GetTransactionsResponse)(nil),             // 28: donation.GetTransactionsResponse
+	(*InvoiceCallbackRequest)(nil),              // 29: donation.InvoiceCallbackRequest
+	(*ReissueInvoiceRequest)(nil),               // 30: donation.ReissueInvoiceRequest
+	(*WatchCampaignProgressRequest)(nil),        // 31: donation.WatchCampaignProgressRequest
+	(*CampaignProgressEvent)(nil),               // 32: donation.CampaignProgressEvent
+	(*CampaignStatsRequest)(nil),                // 33: donation.CampaignStatsRequest
+	(*CampaignStatsResponse)(nil),               // 34: donation.CampaignStatsResponse
+	(*DonationTimeSeriesRequest)(nil),           // 35: donation.DonationTimeSeriesRequest
+	(*DonationTimeSeriesBucket)(nil),            // 36: donation.DonationTimeSeriesBucket
+	(*DonationTimeSeriesResponse)(nil),          // 37: donation.DonationTimeSeriesResponse
+	(*WatchDonationEventsRequest)(nil),          // 38: donation.WatchDonationEventsRequest
+	(*DonationEvent)(nil),                       // 39: donation.DonationEvent
+	(*DonationReceiptRequest)(nil),              // 40: donation.DonationReceiptRequest
+	(*AnnualReceiptRequest)(nil),                // 41: donation.AnnualReceiptRequest
+	(*ReceiptResponse)(nil),                     // 42: donation.ReceiptResponse
+	(*NotificationPreferenceRequest)(nil),       // 43: donation.NotificationPreferenceRequest
+	(*UpdateNotificationPreferenceRequest)(nil), // 44: donation.UpdateNotificationPreferenceRequest
+	(*NotificationPreferenceResponse)(nil),      // 45: donation.NotificationPreferenceResponse
+	(*WebhookSubscription)(nil),                 // 46: donation.WebhookSubscription
+	(*WebhookDelivery)(nil),                     // 47: donation.WebhookDelivery
+	(*CreateWebhookSubscriptionRequest)(nil),    // 48: donation.CreateWebhookSubscriptionRequest
+	(*WebhookSubscriptionResponse)(nil),         // 49: donation.WebhookSubscriptionResponse
+	(*WebhookSubscriptionsRequest)(nil),         // 50: donation.WebhookSubscriptionsRequest
+	(*WebhookSubscriptionsResponse)(nil),        // 51: donation.WebhookSubscriptionsResponse
+	(*WebhookSubscriptionIdRequest)(nil),        // 52: donation.WebhookSubscriptionIdRequest
+	(*WebhookDeliveriesRequest)(nil),            // 53: donation.WebhookDeliveriesRequest
+	(*WebhookDeliveriesResponse)(nil),           // 54: donation.WebhookDeliveriesResponse
+	(*RedeliverWebhookRequest)(nil),             // 55: donation.RedeliverWebhookRequest
+	(*WebhookDeliveryResponse)(nil),             // 56: donation.WebhookDeliveryResponse
+	(*fieldmaskpb.FieldMask)(nil),               // 57: google.protobuf.FieldMask
 }
 var file_pb_donation_proto_depIdxs = []int32{
-	3,  // 0: donation.GetDonationsResponse.donations:type_name -> donation.Donation
-	9,  // 1: donation.GetTransactionsResponse.transactions:type_name -> donation.Transaction
-	0,  // 2: donation.DonationService.GetDonationByID:input_type -> donation.DonationIdRequest
-	4,  // 3: donation.DonationService.GetAllDonations:input_type -> donation.GetDonationsRequest
-	1,  // 4: donation.DonationService.CreateDonation:input_type -> donation.DonationRequest
-	1,  // 5: donation.DonationService.UpdateDonation:input_type -> donation.DonationRequest
-	6,  // 6: donation.DonationService.GetTransactionByID:input_type -> donation.TransactionIdRequest
-	10, // 7: donation.DonationService.GetAllTransactions:input_type -> donation.GetTransactionsRequest
-	7,  // 8: donation.DonationService.CreateTransaction:input_type -> donation.TransactionRequest
-	7,  // 9: donation.DonationService.UpdateTransaction:input_type -> donation.TransactionRequest
-	6,  // 10: donation.DonationService.SyncTransaction:input_type -> donation.TransactionIdRequest
-	2,  // 11: donation.DonationService.GetDonationByID:output_type -> donation.DonationResponse
-	5,  // 12: donation.DonationService.GetAllDonations:output_type -> donation.GetDonationsResponse
-	2,  // 13: donation.DonationService.CreateDonation:output_type -> donation.DonationResponse
-	2,  // 14: donation.DonationService.UpdateDonation:output_type -> donation.DonationResponse
-	8,  // 15: donation.DonationService.GetTransactionByID:output_type -> donation.TransactionResponse
-	11, // 16: donation.DonationService.GetAllTransactions:output_type -> donation.GetTransactionsResponse
-	8,  // 17: donation.DonationService.CreateTransaction:output_type -> donation.TransactionResponse
-	8,  // 18: donation.DonationService.UpdateTransaction:output_type -> donation.TransactionResponse
-	8,  // 19: donation.DonationService.SyncTransaction:output_type -> donation.TransactionResponse
-	11, // [11:20] is the sub-list for method output_type
-	2,  // [2:11] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	0,  // 0: donation.DonationRequest.status:type_name -> donation.DonationStatus
+	57, // 1: donation.DonationRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 2: donation.DonationResponse.status:type_name -> donation.DonationStatus
+	0,  // 3: donation.Donation.status:type_name -> donation.DonationStatus
+	6,  // 4: donation.Donation.donor:type_name -> donation.DonorSummary
+	5,  // 5: donation.GetDonationsResponse.donations:type_name -> donation.Donation
+	5,  // 6: donation.GuestDonationResponse.donation:type_name -> donation.Donation
+	26, // 7: donation.GuestDonationResponse.transaction:type_name -> donation.Transaction
+	13, // 8: donation.CampaignDonationsResponse.donations:type_name -> donation.PublicDonation
+	16, // 9: donation.TopDonorsResponse.donors:type_name -> donation.DonorTotal
+	16, // 10: donation.LeaderboardResponse.top_donors:type_name -> donation.DonorTotal
+	17, // 11: donation.LeaderboardResponse.top_campaigns:type_name -> donation.CampaignTotal
+	1,  // 12: donation.TransactionRequest.status:type_name -> donation.TransactionStatus
+	1,  // 13: donation.TransactionResponse.status:type_name -> donation.TransactionStatus
+	24, // 14: donation.TransactionResponse.instructions:type_name -> donation.PaymentInstructions
+	1,  // 15: donation.Transaction.status:type_name -> donation.TransactionStatus
+	24, // 16: donation.Transaction.instructions:type_name -> donation.PaymentInstructions
+	26, // 17: donation.GetTransactionsResponse.transactions:type_name -> donation.Transaction
+	36, // 18: donation.DonationTimeSeriesResponse.buckets:type_name -> donation.DonationTimeSeriesBucket
+	46, // 19: donation.WebhookSubscriptionResponse.subscription:type_name -> donation.WebhookSubscription
+	46, // 20: donation.WebhookSubscriptionsResponse.subscriptions:type_name -> donation.WebhookSubscription
+	47, // 21: donation.WebhookDeliveriesResponse.deliveries:type_name -> donation.WebhookDelivery
+	47, // 22: donation.WebhookDeliveryResponse.delivery:type_name -> donation.WebhookDelivery
+	2,  // 23: donation.DonationService.GetDonationByID:input_type -> donation.DonationIdRequest
+	7,  // 24: donation.DonationService.GetAllDonations:input_type -> donation.GetDonationsRequest
+	3,  // 25: donation.DonationService.CreateDonation:input_type -> donation.DonationRequest
+	3,  // 26: donation.DonationService.UpdateDonation:input_type -> donation.DonationRequest
+	9,  // 27: donation.DonationService.CreateGuestDonation:input_type -> donation.GuestDonationRequest
+	11, // 28: donation.DonationService.ClaimGuestDonations:input_type -> donation.ClaimGuestDonationsRequest
+	14, // 29: donation.DonationService.GetCampaignDonations:input_type -> donation.CampaignDonationsRequest
+	18, // 30: donation.DonationService.GetCampaignTopDonors:input_type -> donation.CampaignTopDonorsRequest
+	20, // 31: donation.DonationService.GetLeaderboard:input_type -> donation.LeaderboardRequest
+	33, // 32: donation.DonationService.GetCampaignStats:input_type -> donation.CampaignStatsRequest
+	35, // 33: donation.DonationService.GetDonationTimeSeries:input_type -> donation.DonationTimeSeriesRequest
+	22, // 34: donation.DonationService.GetTransactionByID:input_type -> donation.TransactionIdRequest
+	27, // 35: donation.DonationService.GetAllTransactions:input_type -> donation.GetTransactionsRequest
+	23, // 36: donation.DonationService.CreateTransaction:input_type -> donation.TransactionRequest
+	23, // 37: donation.DonationService.UpdateTransaction:input_type -> donation.TransactionRequest
+	22, // 38: donation.DonationService.SyncTransaction:input_type -> donation.TransactionIdRequest
+	29, // 39: donation.DonationService.HandleInvoiceCallback:input_type -> donation.InvoiceCallbackRequest
+	30, // 40: donation.DonationService.ReissueInvoice:input_type -> donation.ReissueInvoiceRequest
+	31, // 41: donation.DonationService.WatchCampaignProgress:input_type -> donation.WatchCampaignProgressRequest
+	38, // 42: donation.DonationService.WatchDonationEvents:input_type -> donation.WatchDonationEventsRequest
+	40, // 43: donation.DonationService.GetDonationReceipt:input_type -> donation.DonationReceiptRequest
+	41, // 44: donation.DonationService.GetAnnualReceipt:input_type -> donation.AnnualReceiptRequest
+	43, // 45: donation.DonationService.GetNotificationPreferences:input_type -> donation.NotificationPreferenceRequest
+	44, // 46: donation.DonationService.UpdateNotificationPreferences:input_type -> donation.UpdateNotificationPreferenceRequest
+	48, // 47: donation.DonationService.CreateWebhookSubscription:input_type -> donation.CreateWebhookSubscriptionRequest
+	50, // 48: donation.DonationService.GetWebhookSubscriptions:input_type -> donation.WebhookSubscriptionsRequest
+	52, // 49: donation.DonationService.DeleteWebhookSubscription:input_type -> donation.WebhookSubscriptionIdRequest
+	53, // 50: donation.DonationService.GetWebhookDeliveries:input_type -> donation.WebhookDeliveriesRequest
+	55, // 51: donation.DonationService.RedeliverWebhook:input_type -> donation.RedeliverWebhookRequest
+	4,  // 52: donation.DonationService.GetDonationByID:output_type -> donation.DonationResponse
+	8,  // 53: donation.DonationService.GetAllDonations:output_type -> donation.GetDonationsResponse
+	4,  // 54: donation.DonationService.CreateDonation:output_type -> donation.DonationResponse
+	4,  // 55: donation.DonationService.UpdateDonation:output_type -> donation.DonationResponse
+	10, // 56: donation.DonationService.CreateGuestDonation:output_type -> donation.GuestDonationResponse
+	12, // 57: donation.DonationService.ClaimGuestDonations:output_type -> donation.ClaimGuestDonationsResponse
+	15, // 58: donation.DonationService.GetCampaignDonations:output_type -> donation.CampaignDonationsResponse
+	19, // 59: donation.DonationService.GetCampaignTopDonors:output_type -> donation.TopDonorsResponse
+	21, // 60: donation.DonationService.GetLeaderboard:output_type -> donation.LeaderboardResponse
+	34, // 61: donation.DonationService.GetCampaignStats:output_type -> donation.CampaignStatsResponse
+	37, // 62: donation.DonationService.GetDonationTimeSeries:output_type -> donation.DonationTimeSeriesResponse
+	25, // 63: donation.DonationService.GetTransactionByID:output_type -> donation.TransactionResponse
+	28, // 64: donation.DonationService.GetAllTransactions:output_type -> donation.GetTransactionsResponse
+	25, // 65: donation.DonationService.CreateTransaction:output_type -> donation.TransactionResponse
+	25, // 66: donation.DonationService.UpdateTransaction:output_type -> donation.TransactionResponse
+	25, // 67: donation.DonationService.SyncTransaction:output_type -> donation.TransactionResponse
+	25, // 68: donation.DonationService.HandleInvoiceCallback:output_type -> donation.TransactionResponse
+	25, // 69: donation.DonationService.ReissueInvoice:output_type -> donation.TransactionResponse
+	32, // 70: donation.DonationService.WatchCampaignProgress:output_type -> donation.CampaignProgressEvent
+	39, // 71: donation.DonationService.WatchDonationEvents:output_type -> donation.DonationEvent
+	42, // 72: donation.DonationService.GetDonationReceipt:output_type -> donation.ReceiptResponse
+	42, // 73: donation.DonationService.GetAnnualReceipt:output_type -> donation.ReceiptResponse
+	45, // 74: donation.DonationService.GetNotificationPreferences:output_type -> donation.NotificationPreferenceResponse
+	45, // 75: donation.DonationService.UpdateNotificationPreferences:output_type -> donation.NotificationPreferenceResponse
+	49, // 76: donation.DonationService.CreateWebhookSubscription:output_type -> donation.WebhookSubscriptionResponse
+	51, // 77: donation.DonationService.GetWebhookSubscriptions:output_type -> donation.WebhookSubscriptionsResponse
+	49, // 78: donation.DonationService.DeleteWebhookSubscription:output_type -> donation.WebhookSubscriptionResponse
+	54, // 79: donation.DonationService.GetWebhookDeliveries:output_type -> donation.WebhookDeliveriesResponse
+	56, // 80: donation.DonationService.RedeliverWebhook:output_type -> donation.WebhookDeliveryResponse
+	52, // [52:81] is the sub-list for method output_type
+	23, // [23:52] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_pb_donation_proto_init() }
@@ -1088,13 +4886,14 @@ func file_pb_donation_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_donation_proto_rawDesc), len(file_pb_donation_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_pb_donation_proto_goTypes,
 		DependencyIndexes: file_pb_donation_proto_depIdxs,
+		EnumInfos:         file_pb_donation_proto_enumTypes,
 		MessageInfos:      file_pb_donation_proto_msgTypes,
 	}.Build()
 	File_pb_donation_proto = out.File
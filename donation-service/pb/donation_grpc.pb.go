@@ -20,15 +20,35 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DonationService_GetDonationByID_FullMethodName    = "/donation.DonationService/GetDonationByID"
-	DonationService_GetAllDonations_FullMethodName    = "/donation.DonationService/GetAllDonations"
-	DonationService_CreateDonation_FullMethodName     = "/donation.DonationService/CreateDonation"
-	DonationService_UpdateDonation_FullMethodName     = "/donation.DonationService/UpdateDonation"
-	DonationService_GetTransactionByID_FullMethodName = "/donation.DonationService/GetTransactionByID"
-	DonationService_GetAllTransactions_FullMethodName = "/donation.DonationService/GetAllTransactions"
-	DonationService_CreateTransaction_FullMethodName  = "/donation.DonationService/CreateTransaction"
-	DonationService_UpdateTransaction_FullMethodName  = "/donation.DonationService/UpdateTransaction"
-	DonationService_SyncTransaction_FullMethodName    = "/donation.DonationService/SyncTransaction"
+	DonationService_GetDonationByID_FullMethodName               = "/donation.DonationService/GetDonationByID"
+	DonationService_GetAllDonations_FullMethodName               = "/donation.DonationService/GetAllDonations"
+	DonationService_CreateDonation_FullMethodName                = "/donation.DonationService/CreateDonation"
+	DonationService_UpdateDonation_FullMethodName                = "/donation.DonationService/UpdateDonation"
+	DonationService_CreateGuestDonation_FullMethodName           = "/donation.DonationService/CreateGuestDonation"
+	DonationService_ClaimGuestDonations_FullMethodName           = "/donation.DonationService/ClaimGuestDonations"
+	DonationService_GetCampaignDonations_FullMethodName          = "/donation.DonationService/GetCampaignDonations"
+	DonationService_GetCampaignTopDonors_FullMethodName          = "/donation.DonationService/GetCampaignTopDonors"
+	DonationService_GetLeaderboard_FullMethodName                = "/donation.DonationService/GetLeaderboard"
+	DonationService_GetCampaignStats_FullMethodName              = "/donation.DonationService/GetCampaignStats"
+	DonationService_GetDonationTimeSeries_FullMethodName         = "/donation.DonationService/GetDonationTimeSeries"
+	DonationService_GetTransactionByID_FullMethodName            = "/donation.DonationService/GetTransactionByID"
+	DonationService_GetAllTransactions_FullMethodName            = "/donation.DonationService/GetAllTransactions"
+	DonationService_CreateTransaction_FullMethodName             = "/donation.DonationService/CreateTransaction"
+	DonationService_UpdateTransaction_FullMethodName             = "/donation.DonationService/UpdateTransaction"
+	DonationService_SyncTransaction_FullMethodName               = "/donation.DonationService/SyncTransaction"
+	DonationService_HandleInvoiceCallback_FullMethodName         = "/donation.DonationService/HandleInvoiceCallback"
+	DonationService_ReissueInvoice_FullMethodName                = "/donation.DonationService/ReissueInvoice"
+	DonationService_WatchCampaignProgress_FullMethodName         = "/donation.DonationService/WatchCampaignProgress"
+	DonationService_WatchDonationEvents_FullMethodName           = "/donation.DonationService/WatchDonationEvents"
+	DonationService_GetDonationReceipt_FullMethodName            = "/donation.DonationService/GetDonationReceipt"
+	DonationService_GetAnnualReceipt_FullMethodName              = "/donation.DonationService/GetAnnualReceipt"
+	DonationService_GetNotificationPreferences_FullMethodName    = "/donation.DonationService/GetNotificationPreferences"
+	DonationService_UpdateNotificationPreferences_FullMethodName = "/donation.DonationService/UpdateNotificationPreferences"
+	DonationService_CreateWebhookSubscription_FullMethodName     = "/donation.DonationService/CreateWebhookSubscription"
+	DonationService_GetWebhookSubscriptions_FullMethodName       = "/donation.DonationService/GetWebhookSubscriptions"
+	DonationService_DeleteWebhookSubscription_FullMethodName     = "/donation.DonationService/DeleteWebhookSubscription"
+	DonationService_GetWebhookDeliveries_FullMethodName          = "/donation.DonationService/GetWebhookDeliveries"
+	DonationService_RedeliverWebhook_FullMethodName              = "/donation.DonationService/RedeliverWebhook"
 )
 
 // DonationServiceClient is the client API for DonationService service.
@@ -39,11 +59,31 @@ type DonationServiceClient interface {
 	GetAllDonations(ctx context.Context, in *GetDonationsRequest, opts ...grpc.CallOption) (*GetDonationsResponse, error)
 	CreateDonation(ctx context.Context, in *DonationRequest, opts ...grpc.CallOption) (*DonationResponse, error)
 	UpdateDonation(ctx context.Context, in *DonationRequest, opts ...grpc.CallOption) (*DonationResponse, error)
+	CreateGuestDonation(ctx context.Context, in *GuestDonationRequest, opts ...grpc.CallOption) (*GuestDonationResponse, error)
+	ClaimGuestDonations(ctx context.Context, in *ClaimGuestDonationsRequest, opts ...grpc.CallOption) (*ClaimGuestDonationsResponse, error)
+	GetCampaignDonations(ctx context.Context, in *CampaignDonationsRequest, opts ...grpc.CallOption) (*CampaignDonationsResponse, error)
+	GetCampaignTopDonors(ctx context.Context, in *CampaignTopDonorsRequest, opts ...grpc.CallOption) (*TopDonorsResponse, error)
+	GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error)
+	GetCampaignStats(ctx context.Context, in *CampaignStatsRequest, opts ...grpc.CallOption) (*CampaignStatsResponse, error)
+	GetDonationTimeSeries(ctx context.Context, in *DonationTimeSeriesRequest, opts ...grpc.CallOption) (*DonationTimeSeriesResponse, error)
 	GetTransactionByID(ctx context.Context, in *TransactionIdRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 	GetAllTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
 	CreateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 	UpdateTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
 	SyncTransaction(ctx context.Context, in *TransactionIdRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	HandleInvoiceCallback(ctx context.Context, in *InvoiceCallbackRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	ReissueInvoice(ctx context.Context, in *ReissueInvoiceRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	WatchCampaignProgress(ctx context.Context, in *WatchCampaignProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CampaignProgressEvent], error)
+	WatchDonationEvents(ctx context.Context, in *WatchDonationEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DonationEvent], error)
+	GetDonationReceipt(ctx context.Context, in *DonationReceiptRequest, opts ...grpc.CallOption) (*ReceiptResponse, error)
+	GetAnnualReceipt(ctx context.Context, in *AnnualReceiptRequest, opts ...grpc.CallOption) (*ReceiptResponse, error)
+	GetNotificationPreferences(ctx context.Context, in *NotificationPreferenceRequest, opts ...grpc.CallOption) (*NotificationPreferenceResponse, error)
+	UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferenceRequest, opts ...grpc.CallOption) (*NotificationPreferenceResponse, error)
+	CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscriptionResponse, error)
+	GetWebhookSubscriptions(ctx context.Context, in *WebhookSubscriptionsRequest, opts ...grpc.CallOption) (*WebhookSubscriptionsResponse, error)
+	DeleteWebhookSubscription(ctx context.Context, in *WebhookSubscriptionIdRequest, opts ...grpc.CallOption) (*WebhookSubscriptionResponse, error)
+	GetWebhookDeliveries(ctx context.Context, in *WebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveriesResponse, error)
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDeliveryResponse, error)
 }
 
 type donationServiceClient struct {
@@ -94,6 +134,76 @@ func (c *donationServiceClient) UpdateDonation(ctx context.Context, in *Donation
 	return out, nil
 }
 
+func (c *donationServiceClient) CreateGuestDonation(ctx context.Context, in *GuestDonationRequest, opts ...grpc.CallOption) (*GuestDonationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GuestDonationResponse)
+	err := c.cc.Invoke(ctx, DonationService_CreateGuestDonation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) ClaimGuestDonations(ctx context.Context, in *ClaimGuestDonationsRequest, opts ...grpc.CallOption) (*ClaimGuestDonationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClaimGuestDonationsResponse)
+	err := c.cc.Invoke(ctx, DonationService_ClaimGuestDonations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetCampaignDonations(ctx context.Context, in *CampaignDonationsRequest, opts ...grpc.CallOption) (*CampaignDonationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CampaignDonationsResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetCampaignDonations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetCampaignTopDonors(ctx context.Context, in *CampaignTopDonorsRequest, opts ...grpc.CallOption) (*TopDonorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopDonorsResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetCampaignTopDonors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetCampaignStats(ctx context.Context, in *CampaignStatsRequest, opts ...grpc.CallOption) (*CampaignStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CampaignStatsResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetCampaignStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetDonationTimeSeries(ctx context.Context, in *DonationTimeSeriesRequest, opts ...grpc.CallOption) (*DonationTimeSeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DonationTimeSeriesResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetDonationTimeSeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *donationServiceClient) GetTransactionByID(ctx context.Context, in *TransactionIdRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TransactionResponse)
@@ -144,6 +254,154 @@ func (c *donationServiceClient) SyncTransaction(ctx context.Context, in *Transac
 	return out, nil
 }
 
+func (c *donationServiceClient) HandleInvoiceCallback(ctx context.Context, in *InvoiceCallbackRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, DonationService_HandleInvoiceCallback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) ReissueInvoice(ctx context.Context, in *ReissueInvoiceRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, DonationService_ReissueInvoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) WatchCampaignProgress(ctx context.Context, in *WatchCampaignProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CampaignProgressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DonationService_ServiceDesc.Streams[0], DonationService_WatchCampaignProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCampaignProgressRequest, CampaignProgressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DonationService_WatchCampaignProgressClient = grpc.ServerStreamingClient[CampaignProgressEvent]
+
+func (c *donationServiceClient) WatchDonationEvents(ctx context.Context, in *WatchDonationEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DonationEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DonationService_ServiceDesc.Streams[1], DonationService_WatchDonationEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchDonationEventsRequest, DonationEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DonationService_WatchDonationEventsClient = grpc.ServerStreamingClient[DonationEvent]
+
+func (c *donationServiceClient) GetDonationReceipt(ctx context.Context, in *DonationReceiptRequest, opts ...grpc.CallOption) (*ReceiptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReceiptResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetDonationReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetAnnualReceipt(ctx context.Context, in *AnnualReceiptRequest, opts ...grpc.CallOption) (*ReceiptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReceiptResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetAnnualReceipt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetNotificationPreferences(ctx context.Context, in *NotificationPreferenceRequest, opts ...grpc.CallOption) (*NotificationPreferenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferenceResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetNotificationPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) UpdateNotificationPreferences(ctx context.Context, in *UpdateNotificationPreferenceRequest, opts ...grpc.CallOption) (*NotificationPreferenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationPreferenceResponse)
+	err := c.cc.Invoke(ctx, DonationService_UpdateNotificationPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSubscriptionResponse)
+	err := c.cc.Invoke(ctx, DonationService_CreateWebhookSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetWebhookSubscriptions(ctx context.Context, in *WebhookSubscriptionsRequest, opts ...grpc.CallOption) (*WebhookSubscriptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetWebhookSubscriptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) DeleteWebhookSubscription(ctx context.Context, in *WebhookSubscriptionIdRequest, opts ...grpc.CallOption) (*WebhookSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSubscriptionResponse)
+	err := c.cc.Invoke(ctx, DonationService_DeleteWebhookSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) GetWebhookDeliveries(ctx context.Context, in *WebhookDeliveriesRequest, opts ...grpc.CallOption) (*WebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, DonationService_GetWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *donationServiceClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDeliveryResponse)
+	err := c.cc.Invoke(ctx, DonationService_RedeliverWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DonationServiceServer is the server API for DonationService service.
 // All implementations must embed UnimplementedDonationServiceServer
 // for forward compatibility.
@@ -152,11 +410,31 @@ type DonationServiceServer interface {
 	GetAllDonations(context.Context, *GetDonationsRequest) (*GetDonationsResponse, error)
 	CreateDonation(context.Context, *DonationRequest) (*DonationResponse, error)
 	UpdateDonation(context.Context, *DonationRequest) (*DonationResponse, error)
+	CreateGuestDonation(context.Context, *GuestDonationRequest) (*GuestDonationResponse, error)
+	ClaimGuestDonations(context.Context, *ClaimGuestDonationsRequest) (*ClaimGuestDonationsResponse, error)
+	GetCampaignDonations(context.Context, *CampaignDonationsRequest) (*CampaignDonationsResponse, error)
+	GetCampaignTopDonors(context.Context, *CampaignTopDonorsRequest) (*TopDonorsResponse, error)
+	GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error)
+	GetCampaignStats(context.Context, *CampaignStatsRequest) (*CampaignStatsResponse, error)
+	GetDonationTimeSeries(context.Context, *DonationTimeSeriesRequest) (*DonationTimeSeriesResponse, error)
 	GetTransactionByID(context.Context, *TransactionIdRequest) (*TransactionResponse, error)
 	GetAllTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
 	CreateTransaction(context.Context, *TransactionRequest) (*TransactionResponse, error)
 	UpdateTransaction(context.Context, *TransactionRequest) (*TransactionResponse, error)
 	SyncTransaction(context.Context, *TransactionIdRequest) (*TransactionResponse, error)
+	HandleInvoiceCallback(context.Context, *InvoiceCallbackRequest) (*TransactionResponse, error)
+	ReissueInvoice(context.Context, *ReissueInvoiceRequest) (*TransactionResponse, error)
+	WatchCampaignProgress(*WatchCampaignProgressRequest, grpc.ServerStreamingServer[CampaignProgressEvent]) error
+	WatchDonationEvents(*WatchDonationEventsRequest, grpc.ServerStreamingServer[DonationEvent]) error
+	GetDonationReceipt(context.Context, *DonationReceiptRequest) (*ReceiptResponse, error)
+	GetAnnualReceipt(context.Context, *AnnualReceiptRequest) (*ReceiptResponse, error)
+	GetNotificationPreferences(context.Context, *NotificationPreferenceRequest) (*NotificationPreferenceResponse, error)
+	UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferenceRequest) (*NotificationPreferenceResponse, error)
+	CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*WebhookSubscriptionResponse, error)
+	GetWebhookSubscriptions(context.Context, *WebhookSubscriptionsRequest) (*WebhookSubscriptionsResponse, error)
+	DeleteWebhookSubscription(context.Context, *WebhookSubscriptionIdRequest) (*WebhookSubscriptionResponse, error)
+	GetWebhookDeliveries(context.Context, *WebhookDeliveriesRequest) (*WebhookDeliveriesResponse, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDeliveryResponse, error)
 	mustEmbedUnimplementedDonationServiceServer()
 }
 
@@ -179,6 +457,27 @@ func (UnimplementedDonationServiceServer) CreateDonation(context.Context, *Donat
 func (UnimplementedDonationServiceServer) UpdateDonation(context.Context, *DonationRequest) (*DonationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateDonation not implemented")
 }
+func (UnimplementedDonationServiceServer) CreateGuestDonation(context.Context, *GuestDonationRequest) (*GuestDonationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGuestDonation not implemented")
+}
+func (UnimplementedDonationServiceServer) ClaimGuestDonations(context.Context, *ClaimGuestDonationsRequest) (*ClaimGuestDonationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimGuestDonations not implemented")
+}
+func (UnimplementedDonationServiceServer) GetCampaignDonations(context.Context, *CampaignDonationsRequest) (*CampaignDonationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCampaignDonations not implemented")
+}
+func (UnimplementedDonationServiceServer) GetCampaignTopDonors(context.Context, *CampaignTopDonorsRequest) (*TopDonorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCampaignTopDonors not implemented")
+}
+func (UnimplementedDonationServiceServer) GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedDonationServiceServer) GetCampaignStats(context.Context, *CampaignStatsRequest) (*CampaignStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCampaignStats not implemented")
+}
+func (UnimplementedDonationServiceServer) GetDonationTimeSeries(context.Context, *DonationTimeSeriesRequest) (*DonationTimeSeriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDonationTimeSeries not implemented")
+}
 func (UnimplementedDonationServiceServer) GetTransactionByID(context.Context, *TransactionIdRequest) (*TransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionByID not implemented")
 }
@@ -194,6 +493,45 @@ func (UnimplementedDonationServiceServer) UpdateTransaction(context.Context, *Tr
 func (UnimplementedDonationServiceServer) SyncTransaction(context.Context, *TransactionIdRequest) (*TransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncTransaction not implemented")
 }
+func (UnimplementedDonationServiceServer) HandleInvoiceCallback(context.Context, *InvoiceCallbackRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleInvoiceCallback not implemented")
+}
+func (UnimplementedDonationServiceServer) ReissueInvoice(context.Context, *ReissueInvoiceRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReissueInvoice not implemented")
+}
+func (UnimplementedDonationServiceServer) WatchCampaignProgress(*WatchCampaignProgressRequest, grpc.ServerStreamingServer[CampaignProgressEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCampaignProgress not implemented")
+}
+func (UnimplementedDonationServiceServer) WatchDonationEvents(*WatchDonationEventsRequest, grpc.ServerStreamingServer[DonationEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDonationEvents not implemented")
+}
+func (UnimplementedDonationServiceServer) GetDonationReceipt(context.Context, *DonationReceiptRequest) (*ReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDonationReceipt not implemented")
+}
+func (UnimplementedDonationServiceServer) GetAnnualReceipt(context.Context, *AnnualReceiptRequest) (*ReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnnualReceipt not implemented")
+}
+func (UnimplementedDonationServiceServer) GetNotificationPreferences(context.Context, *NotificationPreferenceRequest) (*NotificationPreferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationPreferences not implemented")
+}
+func (UnimplementedDonationServiceServer) UpdateNotificationPreferences(context.Context, *UpdateNotificationPreferenceRequest) (*NotificationPreferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNotificationPreferences not implemented")
+}
+func (UnimplementedDonationServiceServer) CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*WebhookSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookSubscription not implemented")
+}
+func (UnimplementedDonationServiceServer) GetWebhookSubscriptions(context.Context, *WebhookSubscriptionsRequest) (*WebhookSubscriptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookSubscriptions not implemented")
+}
+func (UnimplementedDonationServiceServer) DeleteWebhookSubscription(context.Context, *WebhookSubscriptionIdRequest) (*WebhookSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookSubscription not implemented")
+}
+func (UnimplementedDonationServiceServer) GetWebhookDeliveries(context.Context, *WebhookDeliveriesRequest) (*WebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhookDeliveries not implemented")
+}
+func (UnimplementedDonationServiceServer) RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDeliveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
 func (UnimplementedDonationServiceServer) mustEmbedUnimplementedDonationServiceServer() {}
 func (UnimplementedDonationServiceServer) testEmbeddedByValue()                         {}
 
@@ -287,6 +625,132 @@ func _DonationService_UpdateDonation_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DonationService_CreateGuestDonation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GuestDonationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).CreateGuestDonation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_CreateGuestDonation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).CreateGuestDonation(ctx, req.(*GuestDonationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_ClaimGuestDonations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimGuestDonationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).ClaimGuestDonations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_ClaimGuestDonations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).ClaimGuestDonations(ctx, req.(*ClaimGuestDonationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetCampaignDonations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CampaignDonationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetCampaignDonations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetCampaignDonations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetCampaignDonations(ctx, req.(*CampaignDonationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetCampaignTopDonors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CampaignTopDonorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetCampaignTopDonors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetCampaignTopDonors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetCampaignTopDonors(ctx, req.(*CampaignTopDonorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetLeaderboard(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetCampaignStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CampaignStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetCampaignStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetCampaignStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetCampaignStats(ctx, req.(*CampaignStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetDonationTimeSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DonationTimeSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetDonationTimeSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetDonationTimeSeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetDonationTimeSeries(ctx, req.(*DonationTimeSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DonationService_GetTransactionByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TransactionIdRequest)
 	if err := dec(in); err != nil {
@@ -377,6 +841,226 @@ func _DonationService_SyncTransaction_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DonationService_HandleInvoiceCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvoiceCallbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).HandleInvoiceCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_HandleInvoiceCallback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).HandleInvoiceCallback(ctx, req.(*InvoiceCallbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_ReissueInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReissueInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).ReissueInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_ReissueInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).ReissueInvoice(ctx, req.(*ReissueInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_WatchCampaignProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCampaignProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DonationServiceServer).WatchCampaignProgress(m, &grpc.GenericServerStream[WatchCampaignProgressRequest, CampaignProgressEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DonationService_WatchCampaignProgressServer = grpc.ServerStreamingServer[CampaignProgressEvent]
+
+func _DonationService_WatchDonationEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDonationEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DonationServiceServer).WatchDonationEvents(m, &grpc.GenericServerStream[WatchDonationEventsRequest, DonationEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DonationService_WatchDonationEventsServer = grpc.ServerStreamingServer[DonationEvent]
+
+func _DonationService_GetDonationReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DonationReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetDonationReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetDonationReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetDonationReceipt(ctx, req.(*DonationReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetAnnualReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnualReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetAnnualReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetAnnualReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetAnnualReceipt(ctx, req.(*AnnualReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationPreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetNotificationPreferences(ctx, req.(*NotificationPreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_UpdateNotificationPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNotificationPreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).UpdateNotificationPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_UpdateNotificationPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).UpdateNotificationPreferences(ctx, req.(*UpdateNotificationPreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_CreateWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).CreateWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_CreateWebhookSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).CreateWebhookSubscription(ctx, req.(*CreateWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetWebhookSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetWebhookSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetWebhookSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetWebhookSubscriptions(ctx, req.(*WebhookSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_DeleteWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookSubscriptionIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).DeleteWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_DeleteWebhookSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).DeleteWebhookSubscription(ctx, req.(*WebhookSubscriptionIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_GetWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).GetWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_GetWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).GetWebhookDeliveries(ctx, req.(*WebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DonationService_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DonationServiceServer).RedeliverWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DonationService_RedeliverWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DonationServiceServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DonationService_ServiceDesc is the grpc.ServiceDesc for DonationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -400,6 +1084,34 @@ var DonationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDonation",
 			Handler:    _DonationService_UpdateDonation_Handler,
 		},
+		{
+			MethodName: "CreateGuestDonation",
+			Handler:    _DonationService_CreateGuestDonation_Handler,
+		},
+		{
+			MethodName: "ClaimGuestDonations",
+			Handler:    _DonationService_ClaimGuestDonations_Handler,
+		},
+		{
+			MethodName: "GetCampaignDonations",
+			Handler:    _DonationService_GetCampaignDonations_Handler,
+		},
+		{
+			MethodName: "GetCampaignTopDonors",
+			Handler:    _DonationService_GetCampaignTopDonors_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _DonationService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetCampaignStats",
+			Handler:    _DonationService_GetCampaignStats_Handler,
+		},
+		{
+			MethodName: "GetDonationTimeSeries",
+			Handler:    _DonationService_GetDonationTimeSeries_Handler,
+		},
 		{
 			MethodName: "GetTransactionByID",
 			Handler:    _DonationService_GetTransactionByID_Handler,
@@ -420,7 +1132,62 @@ var DonationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncTransaction",
 			Handler:    _DonationService_SyncTransaction_Handler,
 		},
+		{
+			MethodName: "HandleInvoiceCallback",
+			Handler:    _DonationService_HandleInvoiceCallback_Handler,
+		},
+		{
+			MethodName: "ReissueInvoice",
+			Handler:    _DonationService_ReissueInvoice_Handler,
+		},
+		{
+			MethodName: "GetDonationReceipt",
+			Handler:    _DonationService_GetDonationReceipt_Handler,
+		},
+		{
+			MethodName: "GetAnnualReceipt",
+			Handler:    _DonationService_GetAnnualReceipt_Handler,
+		},
+		{
+			MethodName: "GetNotificationPreferences",
+			Handler:    _DonationService_GetNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "UpdateNotificationPreferences",
+			Handler:    _DonationService_UpdateNotificationPreferences_Handler,
+		},
+		{
+			MethodName: "CreateWebhookSubscription",
+			Handler:    _DonationService_CreateWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "GetWebhookSubscriptions",
+			Handler:    _DonationService_GetWebhookSubscriptions_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookSubscription",
+			Handler:    _DonationService_DeleteWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "GetWebhookDeliveries",
+			Handler:    _DonationService_GetWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _DonationService_RedeliverWebhook_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCampaignProgress",
+			Handler:       _DonationService_WatchCampaignProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchDonationEvents",
+			Handler:       _DonationService_WatchDonationEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb/donation.proto",
 }
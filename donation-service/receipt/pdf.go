@@ -0,0 +1,125 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page size in PDF points.
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// Fonts available on every PDF reader without embedding.
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// document is a minimal PDF writer for text-only documents. It only uses the
+// standard Helvetica fonts so nothing has to be embedded, which is all the
+// receipts need.
+type document struct {
+	pages []*bytes.Buffer
+}
+
+func newDocument() *document {
+	return &document{}
+}
+
+// addPage starts a new A4 page and returns its content stream.
+func (d *document) addPage() *page {
+	content := new(bytes.Buffer)
+	d.pages = append(d.pages, content)
+	return &page{content: content}
+}
+
+// bytes writes the document with its cross-reference table.
+func (d *document) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	// objects are numbered from 1 in the order they are written
+	writeObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 page tree, 3 and 4 fonts, then a page and its content per page
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range d.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, fontRegular, fontBold, 6+i*2))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// page is the content stream of a single page. Coordinates are in points from
+// the top left corner, unlike PDF which counts from the bottom.
+type page struct {
+	content *bytes.Buffer
+}
+
+// text writes a line of text with its baseline at y.
+func (p *page) text(x, y, size float64, font string, s string) {
+	fmt.Fprintf(p.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, pageHeight-y, escapeText(s))
+}
+
+// textRight writes a line of text ending at x. Widths are estimated from the
+// average Helvetica glyph width, close enough to right align numbers.
+func (p *page) textRight(x, y, size float64, font string, s string) {
+	p.text(x-textWidth(s, size), y, size, font, s)
+}
+
+// line draws a thin horizontal rule.
+func (p *page) line(x1, x2, y float64) {
+	fmt.Fprintf(p.content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, pageHeight-y, x2, pageHeight-y)
+}
+
+func textWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * size * 0.556
+}
+
+// escapeText encodes s for a PDF string literal in WinAnsiEncoding. Characters
+// outside Latin-1 have no glyph in the standard fonts and become "?".
+func escapeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20:
+			b.WriteByte(' ')
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
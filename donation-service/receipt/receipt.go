@@ -0,0 +1,164 @@
+// Package receipt renders donation receipts to PDF.
+package receipt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+)
+
+const (
+	marginLeft  = 56.0
+	marginRight = pageWidth - 56.0
+	// rows of the annual summary that fit below the header of a page
+	rowsPerPage = 32
+)
+
+var monthNames = [...]string{
+	"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember",
+}
+
+// Location is where receipts are issued, Asia/Jakarta. WIB has been UTC+7
+// without daylight saving time since 1964.
+var Location = time.FixedZone("WIB", 7*60*60)
+
+// Year is the year a payment made at paidAt counts in, the receipt numbers
+// start over every year in Jakarta.
+func Year(paidAt time.Time) int {
+	return paidAt.In(Location).Year()
+}
+
+// issuerName is the organization printed on the receipts.
+func issuerName() string {
+	return config.App.ReceiptIssuerName
+}
+
+// FormatRupiah formats an amount the Indonesian way, e.g. "Rp 1.250.000".
+func FormatRupiah(amount float64) string {
+	digits := strconv.FormatInt(int64(amount+0.5), 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	return "Rp " + b.String()
+}
+
+// FormatDate formats a date in Indonesian, e.g. "17 Agustus 2025".
+func FormatDate(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), monthNames[t.Month()-1], t.Year())
+}
+
+func header(p *page, title string, subtitle string) {
+	p.text(marginLeft, 70, 18, fontBold, issuerName())
+	p.text(marginLeft, 100, 14, fontBold, title)
+	p.text(marginLeft, 116, 10, fontRegular, subtitle)
+	p.line(marginLeft, marginRight, 128)
+}
+
+func footer(p *page, generatedAt time.Time) {
+	p.line(marginLeft, marginRight, 780)
+	p.text(marginLeft, 796, 8, fontRegular, "Dokumen ini dibuat secara elektronik dan sah tanpa tanda tangan.")
+	p.text(marginLeft, 808, 8, fontRegular, "This document is generated electronically and is valid without a signature.")
+	p.textRight(marginRight, 808, 8, fontRegular, "Dibuat "+FormatDate(generatedAt.In(Location)))
+}
+
+// RenderDonation renders the receipt of a single paid donation.
+func RenderDonation(r *model.Receipt) []byte {
+	doc := newDocument()
+	p := doc.addPage()
+	header(p, "KUITANSI DONASI", "Donation Receipt")
+
+	p.text(marginLeft, 156, 11, fontBold, "No. "+r.Number)
+	p.textRight(marginRight, 156, 11, fontRegular, FormatDate(r.PaidAt.In(Location)))
+
+	paymentMethod := r.PaymentMethod
+	if paymentMethod == "" {
+		paymentMethod = "-"
+	}
+	rows := [][2]string{
+		{"Nama Donatur / Donor", r.DonorName},
+		{"Email", r.DonorEmail},
+		{"Kampanye / Campaign", r.CampaignTitle},
+		{"ID Donasi / Donation ID", strconv.Itoa(r.DonationID)},
+		{"Metode Pembayaran / Payment Method", paymentMethod},
+		{"ID Invoice / Invoice ID", r.InvoiceID},
+		{"Tanggal Pembayaran / Payment Date", r.PaidAt.In(Location).Format("2006-01-02 15:04 MST")},
+	}
+	y := 196.0
+	for _, row := range rows {
+		p.text(marginLeft, y, 10, fontRegular, row[0])
+		p.text(marginLeft+200, y, 10, fontRegular, ": "+row[1])
+		y += 20
+	}
+
+	y += 10
+	p.line(marginLeft, marginRight, y)
+	y += 24
+	p.text(marginLeft, y, 12, fontBold, "Jumlah / Amount")
+	p.textRight(marginRight, y, 12, fontBold, FormatRupiah(r.Amount))
+	y += 10
+	p.line(marginLeft, marginRight, y)
+
+	p.text(marginLeft, y+36, 10, fontRegular, "Terima kasih atas donasi Anda. Thank you for your donation.")
+
+	footer(p, r.CreatedAt)
+	return doc.bytes()
+}
+
+// RenderAnnual renders the summary of every receipt issued to a donor in a
+// year, with the total at the end.
+func RenderAnnual(a *model.AnnualReceipt, generatedAt time.Time) []byte {
+	doc := newDocument()
+
+	var p *page
+	var y float64
+	newPage := func() {
+		p = doc.addPage()
+		header(p, fmt.Sprintf("RINGKASAN DONASI TAHUN %d", a.Year), fmt.Sprintf("Annual Donation Summary %d", a.Year))
+		p.text(marginLeft, 150, 10, fontRegular, "Nama Donatur / Donor : "+a.DonorName)
+		p.text(marginLeft, 166, 10, fontRegular, "Email : "+a.DonorEmail)
+
+		p.text(marginLeft, 196, 9, fontBold, "No. Kuitansi")
+		p.text(marginLeft+100, 196, 9, fontBold, "Tanggal")
+		p.text(marginLeft+190, 196, 9, fontBold, "Kampanye")
+		p.textRight(marginRight, 196, 9, fontBold, "Jumlah")
+		p.line(marginLeft, marginRight, 202)
+		y = 218
+		footer(p, generatedAt)
+	}
+
+	newPage()
+	for i, r := range a.Receipts {
+		if i > 0 && i%rowsPerPage == 0 {
+			newPage()
+		}
+		title := r.CampaignTitle
+		if len([]rune(title)) > 40 {
+			title = string([]rune(title)[:39]) + "..."
+		}
+		p.text(marginLeft, y, 9, fontRegular, r.Number)
+		p.text(marginLeft+100, y, 9, fontRegular, r.PaidAt.In(Location).Format("2006-01-02"))
+		p.text(marginLeft+190, y, 9, fontRegular, title)
+		p.textRight(marginRight, y, 9, fontRegular, FormatRupiah(r.Amount))
+		y += 16
+	}
+	if len(a.Receipts) == 0 {
+		p.text(marginLeft, y, 9, fontRegular, "Tidak ada donasi pada tahun ini. No donations in this year.")
+		y += 16
+	}
+
+	p.line(marginLeft, marginRight, y-6)
+	y += 12
+	p.text(marginLeft, y, 11, fontBold, fmt.Sprintf("Total %d donasi / donations", len(a.Receipts)))
+	p.textRight(marginRight, y, 11, fontBold, FormatRupiah(a.Total))
+
+	return doc.bytes()
+}
@@ -2,39 +2,40 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	campaign_pb "github.com/rayhanadri/crowdfunding-app-campaign-service/campaign-service/gen/go/campaign/v1"
 	campaign_model "github.com/rayhanadri/crowdfunding-app-campaign-service/campaign-service/models" // corrected the import path
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/fieldmask"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+	"github.com/rayhanadri/crowdfunding/common/validation"
 	user_model "github.com/rayhanadri/crowdfunding/user-service/model"
 	user_pb "github.com/rayhanadri/crowdfunding/user-service/pb"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/rayhanadri/crowdfunding/donation-service/config" // corrected the import path
-	"github.com/rayhanadri/crowdfunding/donation-service/external"
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
 	"github.com/rayhanadri/crowdfunding/donation-service/model" // corrected the import path
-	"github.com/rayhanadri/crowdfunding/donation-service/pb"    // corrected the import path
+	"github.com/rayhanadri/crowdfunding/donation-service/payment"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb" // corrected the import path
 )
 
 type DonationService struct {
 	pb.UnimplementedDonationServiceServer
 }
 
-func GetUserByID(userId int32) (userModel *user_model.User, error error) {
-	address := "user-service-273575294549.asia-southeast2.run.app:443"
-	conn, err := grpc.Dial(
-		address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+func GetUserByID(ctx context.Context, userId int32) (userModel *user_model.User, error error) {
+	conn, err := dial(config.App.UserServiceAddr)
 
 	if err != nil {
-		log.Fatalf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -43,14 +44,14 @@ func GetUserByID(userId int32) (userModel *user_model.User, error error) {
 	// Create a new client
 	client := user_pb.NewUserServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	// Create a request
 	req := &user_pb.UserIdRequest{Id: userId}
 	// Call the GetUserByID method
 	res, err := client.GetUserByID(ctx, req)
 	if err != nil {
-		log.Fatalf("Error calling GetUserByID: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "GetUserByID", "error", err)
 		return nil, err
 	}
 
@@ -72,18 +73,21 @@ func GetUserByID(userId int32) (userModel *user_model.User, error error) {
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
 	}
+	if res.GetEmailVerifiedAt() != "" {
+		verifiedAt, err := time.Parse(time.RFC3339, res.GetEmailVerifiedAt())
+		if err != nil {
+			return nil, fmt.Errorf("error parsing EmailVerifiedAt: %v", err)
+		}
+		userModel.EmailVerifiedAt = &verifiedAt
+	}
 	return userModel, nil
 }
 
-func GetCampaignByID(campaignId string) (campaignModel *campaign_model.CampaignDB, error error) {
-	address := "user-service-273575294549.asia-southeast2.run.app:443"
-	conn, err := grpc.Dial(
-		address,
-		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")), // for secure TLS
-	)
+func GetCampaignByID(ctx context.Context, campaignId string) (campaignModel *campaign_model.CampaignDB, error error) {
+	conn, err := dial(config.App.CampaignServiceAddr)
 
 	if err != nil {
-		log.Fatalf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
 		return nil, err
 	}
 
@@ -92,20 +96,20 @@ func GetCampaignByID(campaignId string) (campaignModel *campaign_model.CampaignD
 	// Create a new client
 	client := campaign_pb.NewCampaignServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	// Create a request
 	req := &campaign_pb.GetCampaignByIDRequest{Id: campaignId}
 	// Call the GetCampaignByID method
 	res, err := client.GetCampaignByID(ctx, req) // Updated method call
 	if err != nil {
-		log.Fatalf("Error calling GetCampaignByID: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "GetCampaignByID", "error", err)
 		return nil, err
 	}
 
 	resCampaign := res.Campaign
 	if len(resCampaign) == 0 {
-		return nil, fmt.Errorf("campaign with ID %s not found", campaignId)
+		return nil, apperror.NotFound("CAMPAIGN_NOT_FOUND", fmt.Sprintf("campaign with ID %s not found", campaignId))
 	}
 
 	campaignModel = &campaign_model.CampaignDB{
@@ -125,17 +129,18 @@ func GetCampaignByID(campaignId string) (campaignModel *campaign_model.CampaignD
 	return campaignModel, nil
 }
 
-func UpdateCampaignByID(campaignId string, user_id int32, title string, description string, target_amount int32, deadline time.Time, campaign_status string, campaign_category string, min_donation int32) (campaignModel *campaign_model.CampaignDB, error error) {
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+func UpdateCampaignByID(ctx context.Context, campaignId string, user_id int32, title string, description string, target_amount int32, deadline time.Time, campaign_status string, campaign_category string, min_donation int32) (campaignModel *campaign_model.CampaignDB, error error) {
+	conn, err := dial(config.App.CampaignServiceAddr)
 	if err != nil {
-		log.Fatalf("Did not connect: %v", err)
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return nil, err
 	}
 	defer conn.Close()
 
 	// Create a new client
 	client := campaign_pb.NewCampaignServiceClient(conn)
 	// Set a timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 	// Create a request
 	req := &campaign_pb.UpdateCampaignByIDRequest{
@@ -152,13 +157,13 @@ func UpdateCampaignByID(campaignId string, user_id int32, title string, descript
 	// Call the GetCampaignByID method
 	res, err := client.UpdateCampaignByID(ctx, req) // Updated method call
 	if err != nil {
-		log.Fatalf("Error calling UpdateCampaignByID: %v", err)
+		slog.DebugContext(ctx, "rpc failed", "method", "UpdateCampaignByID", "error", err)
 		return nil, err
 	}
 
 	resCampaign := res.GetUpdatedCampaign()
 	if resCampaign == nil || len(resCampaign) == 0 {
-		return nil, fmt.Errorf("campaign with ID %s not found", campaignId)
+		return nil, apperror.NotFound("CAMPAIGN_NOT_FOUND", fmt.Sprintf("campaign with ID %s not found", campaignId))
 	}
 
 	campaignModel = &campaign_model.CampaignDB{
@@ -181,8 +186,8 @@ func UpdateCampaignByID(campaignId string, user_id int32, title string, descript
 func (s *DonationService) GetAllDonations(ctx context.Context, req *pb.GetDonationsRequest) (*pb.GetDonationsResponse, error) {
 
 	var donations []model.Donation
-	if err := config.DB.Find(&donations).Error; err != nil {
-		return nil, err
+	if err := config.DB.WithContext(ctx).Find(&donations).Error; err != nil {
+		return nil, apperror.FromDB(err, "donation")
 	}
 
 	// Create a donation response
@@ -190,24 +195,29 @@ func (s *DonationService) GetAllDonations(ctx context.Context, req *pb.GetDonati
 		Donations: make([]*pb.Donation, 0, len(donations)),
 	}
 
+	// look up the donors in one call, not one per donation
+	userIDs := make([]int, 0, len(donations))
 	for _, donation := range donations {
-		userModel, err := GetUserByID(int32(donation.UserID))
-		if err != nil {
-			return nil, err
-		}
-		if userModel == nil {
-			return nil, fmt.Errorf("user with ID %d not found", donation.UserID)
-		}
+		userIDs = append(userIDs, donation.UserID)
+	}
+	donors := donorSummaries(ctx, userIDs)
 
+	for _, donation := range donations {
 		donationResponse := &pb.Donation{
-			Id:         int32(donation.ID),
-			UserId:     int32(donation.UserID),
-			CampaignId: int32(donation.CampaignID),
-			Amount:     float32(donation.Amount),
-			Message:    donation.Message,
-			Status:     donation.Status,
-			CreatedAt:  donation.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:  donation.UpdatedAt.Format(time.RFC3339),
+			Id:          int32(donation.ID),
+			UserId:      int32(donation.UserID),
+			CampaignId:  int32(donation.CampaignID),
+			Amount:      float32(donation.Amount),
+			Message:     donation.Message,
+			Status:      donation.Status.ToPb(),
+			IsAnonymous: donation.IsAnonymous,
+			GuestEmail:  donation.GuestEmail,
+			CreatedAt:   donation.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:   donation.UpdatedAt.Format(time.RFC3339),
+			Version:     int32(donation.Version),
+		}
+		if donor, ok := donors[donation.UserID]; ok {
+			donationResponse.Donor = donorSummaryToPb(donor)
 		}
 		response.Donations = append(response.Donations, donationResponse)
 	}
@@ -220,18 +230,10 @@ func (s *DonationService) GetDonationByID(ctx context.Context, req *pb.DonationI
 	id := req.GetId()
 
 	var donation model.Donation
-	if err := config.DB.First(&donation, id).Error; err != nil {
-		return nil, err
+	if err := config.DB.WithContext(ctx).First(&donation, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "donation")
 	}
 
-	// userModel, err := GetUserByID(int32(donation.UserID))
-	// if err != nil {
-	// 	return nil, err
-	// }
-	// if userModel == nil {
-	// 	return nil, fmt.Errorf("user with ID %d not found", donation.UserID)
-	// }
-
 	// Create a donation response
 	response := &pb.DonationResponse{
 		Id:          int32(donation.ID),
@@ -239,55 +241,73 @@ func (s *DonationService) GetDonationByID(ctx context.Context, req *pb.DonationI
 		CampaignId:  int32(donation.CampaignID),
 		Amount:      float32(donation.Amount),
 		MessageText: donation.Message,
-		Status:      donation.Status,
+		Status:      donation.Status.ToPb(),
+		IsAnonymous: donation.IsAnonymous,
+		GuestEmail:  donation.GuestEmail,
 		CreatedAt:   donation.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   donation.UpdatedAt.Format(time.RFC3339),
+		Version:     int32(donation.Version),
 	}
 
-	log.Println(donation.Message)
-
-	log.Printf("Donation ID: %d, User ID: %d, Campaign ID: %d, Amount: %.2f, Message: %s, CreatedAt: %s, UpdatedAt: %s\n",
-		response.GetId(), response.GetUserId(), response.GetCampaignId(), response.GetAmount(), response.GetMessageText(), response.GetCreatedAt(), response.GetUpdatedAt())
+	// the message and the amount are the donor's, only IDs are logged
+	slog.DebugContext(ctx, "donation fetched", "donation_id", donation.ID, "campaign_id", donation.CampaignID)
 
 	return response, nil
 }
 
 func (r *DonationService) CreateDonation(ctx context.Context, req *pb.DonationRequest) (*pb.DonationResponse, error) {
 	donation := &model.Donation{
-		UserID:     int(req.GetUserId()),
-		CampaignID: int(req.GetCampaignId()),
-		Amount:     float64(req.GetAmount()),
-		Message:    req.GetMessage(),
-		Status:     req.GetStatus(),
+		UserID:      int(req.GetUserId()),
+		CampaignID:  int(req.GetCampaignId()),
+		Amount:      float64(req.GetAmount()),
+		Message:     req.GetMessage(),
+		Status:      model.DonationPending,
+		IsAnonymous: req.GetIsAnonymous(),
 	}
 
 	//validate user data
-	if donation.UserID == 0 || donation.CampaignID == 0 || donation.Amount <= 0 {
-		err := errors.New("user ID, campaign ID, and amount are required")
-		response := &pb.DonationResponse{
-			Message: "Failed to create donation",
-			Error:   err.Error(),
-		}
-
-		return response, err
+	var violations []apperror.FieldViolation
+	if donation.UserID == 0 {
+		violations = append(violations, apperror.FieldViolation{Field: "user_id", Description: "user ID is required"})
+	}
+	if donation.CampaignID == 0 {
+		violations = append(violations, apperror.FieldViolation{Field: "campaign_id", Description: "campaign ID is required"})
+	}
+	if err := validation.Amount(donation.Amount); err != nil {
+		violations = append(violations, apperror.FieldViolation{Field: "amount", Description: err.Error()})
+	}
+	if err := validation.Message(donation.Message); err != nil {
+		violations = append(violations, apperror.FieldViolation{Field: "message", Description: err.Error()})
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("invalid donation", violations...)
 	}
 
-	if err := config.DB.Omit("id").Create(donation).Error; err != nil {
-		response := &pb.DonationResponse{
-			Message: "Failed to create donation",
-			Error:   err.Error(),
-		}
-
-		return response, err
+	// a donation starts pending, its payment completes it
+	if next := model.DonationStatusFromPb(req.GetStatus()); next != "" && next != model.DonationPending {
+		return nil, apperror.InvalidArgument(fmt.Sprintf("a new donation cannot be %s", next),
+			apperror.FieldViolation{Field: "status", Description: "a new donation is always PENDING"})
 	}
 
-	if err := config.DB.Last(donation).Error; err != nil {
-		response := &pb.DonationResponse{
-			Message: "Failed to create donation",
-			Error:   err.Error(),
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("id").Create(donation).Error; err != nil {
+			return err
 		}
+		return storeEvent(tx, event.Event{
+			Type:        event.DonationCreated,
+			CampaignID:  donation.CampaignID,
+			DonationID:  donation.ID,
+			UserID:      donation.UserID,
+			IsAnonymous: donation.IsAnonymous,
+			Amount:      donation.Amount,
+		})
+	})
+	if err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
 
-		return response, err
+	if err := config.DB.WithContext(ctx).Last(donation).Error; err != nil {
+		return nil, apperror.FromDB(err, "donation")
 	}
 
 	// Create a donation response
@@ -298,30 +318,146 @@ func (r *DonationService) CreateDonation(ctx context.Context, req *pb.DonationRe
 		CampaignId:  int32(donation.CampaignID),
 		Amount:      float32(donation.Amount),
 		MessageText: donation.Message,
-		Status:      donation.Status,
+		Status:      donation.Status.ToPb(),
+		IsAnonymous: donation.IsAnonymous,
+		GuestEmail:  donation.GuestEmail,
 		CreatedAt:   donation.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   donation.UpdatedAt.Format(time.RFC3339),
+		Version:     int32(donation.Version),
 	}
 
 	return response, nil
 }
 
+// updatableDonationFields maps the update_mask paths of UpdateDonation to
+// their columns.
+var updatableDonationFields = map[string]string{
+	"amount":       "amount",
+	"message":      "message",
+	"status":       "status",
+	"is_anonymous": "is_anonymous",
+}
+
+// setDonationColumns returns the columns of the updatable fields set in
+// donation, the ones an update without a mask changes.
+func setDonationColumns(donation *model.Donation) []string {
+	var columns []string
+	if donation.Amount != 0 {
+		columns = append(columns, "amount")
+	}
+	if donation.Message != "" {
+		columns = append(columns, "message")
+	}
+	if donation.Status != "" {
+		columns = append(columns, "status")
+	}
+	if donation.IsAnonymous {
+		columns = append(columns, "is_anonymous")
+	}
+	return columns
+}
+
+// checkDonationUpdate reports why columns of a donation cannot be changed. A
+// completed donation is final, and the amount is what its payment charges, so
+// it only changes while the donation is pending without a payment under way.
+func checkDonationUpdate(current *model.Donation, columns []string, amount float64, open bool) error {
+	if current.Status == model.DonationCompleted {
+		return apperror.FailedPrecondition(ReasonDonationPaid, "a paid donation cannot be changed")
+	}
+	if slices.Contains(columns, "amount") && amount != current.Amount && (current.Status != model.DonationPending || open) {
+		return apperror.FailedPrecondition(ReasonAmountLocked, "the amount only changes while the donation is pending without a payment")
+	}
+	return nil
+}
+
 func (r *DonationService) UpdateDonation(ctx context.Context, req *pb.DonationRequest) (*pb.DonationResponse, error) {
 	donation := &model.Donation{
-		ID:         int(req.GetId()),
-		UserID:     int(req.GetUserId()),
-		CampaignID: int(req.GetCampaignId()),
-		Amount:     float64(req.GetAmount()),
-		Message:    req.GetMessage(),
-		Status:     req.GetStatus(),
+		ID:          int(req.GetId()),
+		Amount:      float64(req.GetAmount()),
+		Message:     req.GetMessage(),
+		Status:      model.DonationStatusFromPb(req.GetStatus()),
+		IsAnonymous: req.GetIsAnonymous(),
 	}
 
-	if err := config.DB.Model(donation).Updates(donation).Error; err != nil {
+	// with a mask only the named fields change, zero values included. Without
+	// one zero values keep the current ones
+	mask := req.GetUpdateMask()
+	columns, err := fieldmask.Columns(mask, updatableDonationFields)
+	if err != nil {
 		return nil, err
 	}
+	if mask == nil {
+		columns = setDonationColumns(donation)
+	}
 
-	if err := config.DB.First(donation, donation.ID).Error; err != nil {
-		return nil, err
+	//validate the fields being changed
+	var violations []apperror.FieldViolation
+	if (mask == nil && donation.Amount != 0) || slices.Contains(columns, "amount") {
+		if err := validation.Amount(donation.Amount); err != nil {
+			violations = append(violations, apperror.FieldViolation{Field: "amount", Description: err.Error()})
+		}
+	}
+	if fieldmask.Contains(mask, "message") {
+		if err := validation.Message(donation.Message); err != nil {
+			violations = append(violations, apperror.FieldViolation{Field: "message", Description: err.Error()})
+		}
+	}
+	if slices.Contains(columns, "status") && donation.Status == "" {
+		violations = append(violations, apperror.FieldViolation{Field: "status", Description: "status is required"})
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("invalid donation", violations...)
+	}
+
+	// the donation is locked so no payment is opened for it meanwhile
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.Donation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, donation.ID).Error; err != nil {
+			return err
+		}
+		// donations of other users and of guests are reported as missing
+		if current.UserID == 0 || current.UserID != int(req.GetUserId()) {
+			return apperror.NotFound("DONATION_NOT_FOUND", "donation not found")
+		}
+		version, err := optimistic.Check("donation", int(req.GetVersion()), current.Version)
+		if err != nil {
+			return err
+		}
+
+		open, err := hasOpenTransaction(tx, current.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkDonationUpdate(&current, columns, donation.Amount, open); err != nil {
+			return err
+		}
+
+		// an unspecified status keeps the current one
+		if donation.Status != "" && fieldmask.Contains(mask, "status") {
+			if err := checkDonationTransition(current.Status, donation.Status); err != nil {
+				return err
+			}
+		}
+
+		// the version guards against a concurrent update, status changes included
+		donation.Version = version + 1
+		// only the updatable columns are written, never the donor or the campaign
+		result := tx.Model(donation).Where("version = ?", version).
+			Select(append(columns, "version")).Updates(donation)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return optimistic.Mismatch("donation")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	if err := config.DB.WithContext(ctx).First(donation, donation.ID).Error; err != nil {
+		return nil, apperror.FromDB(err, "donation")
 	}
 
 	// Create a user response
@@ -331,19 +467,49 @@ func (r *DonationService) UpdateDonation(ctx context.Context, req *pb.DonationRe
 		CampaignId:  int32(donation.CampaignID),
 		Amount:      float32(donation.Amount),
 		MessageText: donation.Message,
-		Status:      donation.Status,
+		Status:      donation.Status.ToPb(),
+		IsAnonymous: donation.IsAnonymous,
+		GuestEmail:  donation.GuestEmail,
 		CreatedAt:   donation.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   donation.UpdatedAt.Format(time.RFC3339),
+		Version:     int32(donation.Version),
 	}
 
 	return response, nil
 }
 
-// Function Transaction
+// paymentInstructionsToPb returns how to pay a transaction, nil once there is
+// nothing left to pay.
+func paymentInstructionsToPb(transaction *model.Transaction) *pb.PaymentInstructions {
+	if transaction.Status != model.TransactionPending {
+		return nil
+	}
+	instructions := &pb.PaymentInstructions{
+		CheckoutUrl: transaction.InvoiceURL,
+		VaNumber:    transaction.VANumber,
+		QrString:    transaction.QRString,
+		DeeplinkUrl: transaction.DeeplinkURL,
+	}
+	if transaction.ExpiresAt != nil {
+		instructions.ExpiresAt = transaction.ExpiresAt.Format(time.RFC3339)
+	}
+	return instructions
+}
+
+// GetAllTransactions lists the transactions of the donations of a user,
+// invoice URLs included, so never the ones of anyone else.
 func (s *DonationService) GetAllTransactions(ctx context.Context, req *pb.GetTransactionsRequest) (*pb.GetTransactionsResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, invalidField("user_id", "user ID is required")
+	}
+
 	var transactions []model.Transaction
-	if err := config.DB.Find(&transactions).Error; err != nil {
-		return nil, err
+	err := config.DB.WithContext(ctx).
+		Where("donation_id IN (?)", config.DB.Model(&model.Donation{}).Select("id").Where("user_id = ?", req.GetUserId())).
+		Order("id").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, apperror.FromDB(err, "transaction")
 	}
 
 	// Create a donation response
@@ -354,16 +520,20 @@ func (s *DonationService) GetAllTransactions(ctx context.Context, req *pb.GetTra
 	// Iterate through each transaction and fetch the associated donation
 	for _, transaction := range transactions {
 		transactionResponse := &pb.Transaction{
-			Id:                 int32(transaction.ID),
-			DonationId:         int32(transaction.DonationID),
-			InvoiceId:          transaction.InvoiceID,
-			InvoiceUrl:         transaction.InvoiceURL,
-			InvoiceDescription: transaction.InvoiceDescription,
-			PaymentMethod:      transaction.PaymentMethod,
-			Amount:             float32(transaction.Amount),
-			Status:             transaction.Status,
-			CreatedAt:          transaction.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:          transaction.UpdatedAt.Format(time.RFC3339),
+			Id:                    int32(transaction.ID),
+			DonationId:            int32(transaction.DonationID),
+			InvoiceId:             transaction.InvoiceID,
+			InvoiceUrl:            transaction.InvoiceURL,
+			InvoiceDescription:    transaction.InvoiceDescription,
+			PaymentMethod:         transaction.PaymentMethod,
+			PaymentChannel:        transaction.PaymentChannel,
+			Instructions:          paymentInstructionsToPb(&transaction),
+			PreviousTransactionId: previousTransactionIDToPb(&transaction),
+			Amount:                float32(transaction.Amount),
+			Status:                transaction.Status.ToPb(),
+			CreatedAt:             transaction.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:             transaction.UpdatedAt.Format(time.RFC3339),
+			Version:               int32(transaction.Version),
 		}
 		response.Transactions = append(response.Transactions, transactionResponse)
 	}
@@ -376,141 +546,249 @@ func (s *DonationService) GetTransactionByID(ctx context.Context, req *pb.Transa
 	id := req.GetId()
 
 	var transaction model.Transaction
-	if err := config.DB.First(&transaction, id).Error; err != nil {
-		return nil, err
+	if err := config.DB.WithContext(ctx).First(&transaction, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "transaction")
+	}
+	if !isDonor(ctx, transaction.DonationID, req.GetUserId()) {
+		return nil, errTransactionNotFound
 	}
 
 	// Create a donation response
 	response := &pb.TransactionResponse{
-		Id:                 int32(transaction.ID),
-		DonationId:         int32(transaction.DonationID),
-		InvoiceId:          transaction.InvoiceID,
-		InvoiceUrl:         transaction.InvoiceURL,
-		InvoiceDescription: transaction.InvoiceDescription,
-		PaymentMethod:      transaction.PaymentMethod,
-		Amount:             float32(transaction.Amount),
-		Status:             transaction.Status,
-		CreatedAt:          transaction.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:          transaction.UpdatedAt.Format(time.RFC3339),
+		Id:                    int32(transaction.ID),
+		DonationId:            int32(transaction.DonationID),
+		InvoiceId:             transaction.InvoiceID,
+		InvoiceUrl:            transaction.InvoiceURL,
+		InvoiceDescription:    transaction.InvoiceDescription,
+		PaymentMethod:         transaction.PaymentMethod,
+		PaymentChannel:        transaction.PaymentChannel,
+		Instructions:          paymentInstructionsToPb(&transaction),
+		PreviousTransactionId: previousTransactionIDToPb(&transaction),
+		Amount:                float32(transaction.Amount),
+		Status:                transaction.Status.ToPb(),
+		CreatedAt:             transaction.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             transaction.UpdatedAt.Format(time.RFC3339),
+		Version:               int32(transaction.Version),
 	}
 
 	return response, nil
 }
 
 func (r *DonationService) CreateTransaction(ctx context.Context, req *pb.TransactionRequest) (*pb.TransactionResponse, error) {
-	transaction := &model.Transaction{
-		ID:                 int(req.GetId()),
-		DonationID:         int(req.GetDonationId()),
-		InvoiceID:          "",
-		InvoiceURL:         "",
-		InvoiceDescription: "",
-		PaymentMethod:      "",
-		Amount:             float64(req.GetAmount()),
-		Status:             "",
+	if req.GetDonationId() == 0 {
+		return nil, invalidField("donation_id", "donation ID is required")
+	}
+	// donations of other users and of guests are reported as missing
+	if !isDonor(ctx, int(req.GetDonationId()), req.GetUserId()) {
+		return nil, apperror.NotFound("DONATION_NOT_FOUND", "donation not found")
 	}
 
-	//validate transaction data
-	if transaction.DonationID == 0 || transaction.Amount <= 0 {
-		err := errors.New("donation ID and amount are required")
-		response := &pb.TransactionResponse{
-			Message: "Failed to create transaction",
-			Error:   err.Error(),
-		}
+	transaction := &model.Transaction{DonationID: int(req.GetDonationId())}
+	paymentRequest := payment.Request{
+		Method:       req.GetPaymentMethod(),
+		Channel:      req.GetPaymentChannel(),
+		MobileNumber: strings.TrimSpace(req.GetMobileNumber()),
+	}
 
-		return response, err
+	//validate transaction data
+	if err := payment.Validate(&paymentRequest); err != nil {
+		return nil, invalidField("payment_method", err.Error())
 	}
 
-	// Get User ID from Donation ID
-	donation, err := r.GetDonationByID(ctx, &pb.DonationIdRequest{Id: int32(transaction.DonationID)})
-	if err != nil {
-		response := &pb.TransactionResponse{
-			Message: "Failed to get donation",
-			Error:   err.Error(),
+	// The donation is locked while it is checked and the transaction
+	// reserved, so two requests never open two payments for it.
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.Donation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, transaction.DonationID).Error; err != nil {
+			return err
 		}
-		return response, err
-	}
-
-	donationUserID := donation.GetUserId()
-
-	//Check campaign
-	// Get the campaign by ID
-	// campaignModel, err := GetCampaignByID(fmt.Sprintf("%d", donation.CampaignId))
-	// if err != nil {
-	// 	response := &pb.TransactionResponse{
-	// 		Message: "Failed to get campaign",
-	// 		Error:   err.Error(),
-	// 	}
-	// 	return response, err
-	// }
-	// if campaignModel.Status != "active" {
-	// 	response := &pb.TransactionResponse{
-	// 		Message: "Campaign is not active",
-	// 		Error:   "Campaign is not active",
-	// 	}
-	// 	return response, errors.New("campaign is not active")
-	// }
-
-	// Get User details
-	userModel, err := GetUserByID(donationUserID)
-	if err != nil {
-		response := &pb.TransactionResponse{
-			Message: "Failed to get user",
-			Error:   err.Error(),
+		open, err := hasOpenTransaction(tx, current.ID)
+		if err != nil {
+			return err
 		}
-		return response, err
+		if err := checkPayable(&current, open, req.GetAmount()); err != nil {
+			return err
+		}
+		// the donor pays what they pledged, the campaign is credited with it
+		transaction.Amount = current.Amount
+		return reserveTransaction(tx, transaction)
+	})
+	if err != nil {
+		return nil, apperror.FromDB(err, "donation")
 	}
 
-	// Create an invoice using the external service
-	invoice, err := external.CreateInvoice(
-		fmt.Sprintf("donation-%d", transaction.DonationID),
-		int(transaction.Amount),
-		userModel.Email,
-		fmt.Sprintf("Donation for campaign %d by %s", transaction.DonationID, userModel.Name),
-	)
+	// Get User ID from Donation ID
+	donation, err := r.GetDonationByID(ctx, &pb.DonationIdRequest{Id: int32(transaction.DonationID)})
 	if err != nil {
-		response := &pb.TransactionResponse{
-			Message: "Failed to create invoice",
-			Error:   err.Error(),
-		}
-		return response, err
+		failReservation(ctx, transaction)
+		return nil, err
 	}
 
-	transaction.InvoiceID = invoice.ID
-	transaction.InvoiceURL = invoice.InvoiceURL
-	transaction.InvoiceDescription = invoice.Description
-	transaction.Status = invoice.Status
+	return r.openPayment(ctx, transaction, donation, paymentRequest)
+}
+
+// openTransactionStatuses are the statuses of a transaction a donation is
+// paid, or being paid, with.
+var openTransactionStatuses = []model.TransactionStatus{model.TransactionPending, model.TransactionPaid, model.TransactionSettled}
+
+// hasOpenTransaction reports whether a donation is paid or has a payment
+// under way.
+func hasOpenTransaction(db *gorm.DB, donationID int) (bool, error) {
+	var count int64
+	err := db.Model(&model.Transaction{}).Where("donation_id = ? AND status IN ?", donationID, openTransactionStatuses).Count(&count).Error
+	return count > 0, err
+}
+
+// checkPayable reports why a new payment cannot be opened for a donation.
+// Only a pending donation without an open transaction takes one, and a
+// requested amount must be the amount of the donation.
+func checkPayable(donation *model.Donation, open bool, amount float32) error {
+	switch {
+	case donation.Status == model.DonationCompleted:
+		return apperror.FailedPrecondition(ReasonDonationPaid, "donation is already paid")
+	case donation.Status == model.DonationAbandoned:
+		return apperror.FailedPrecondition(ReasonDonationAbandoned, "donation was abandoned, reissue the invoice of its last transaction instead")
+	case open:
+		return apperror.FailedPrecondition(ReasonPaymentOpen, "donation already has a payment, pay or sync it")
+	case amount != 0 && amount != float32(donation.Amount):
+		return invalidField("amount", fmt.Sprintf("amount must be the donation amount %.2f", donation.Amount))
+	}
+	return nil
+}
+
+// staleReservation is how long a reserved transaction may wait for its
+// payment before the reconciler gives up on it.
+const staleReservation = 10 * time.Minute
+
+// reserveTransaction stores a new transaction as a PENDING placeholder without
+// a payment, in tx. openPayment opens the payment once tx committed, so the
+// provider is never asked for a payment that has no row to land in.
+func reserveTransaction(tx *gorm.DB, transaction *model.Transaction) error {
+	transaction.Status = model.TransactionPending
+	return tx.Omit("id").Create(transaction).Error
+}
 
-	if err := config.DB.Omit("id").Create(transaction).Error; err != nil {
-		response := &pb.TransactionResponse{
-			Message: "Failed to create transaction",
-			Error:   err.Error(),
+// failReservation marks a reserved transaction whose payment could not be
+// opened as FAILED and abandons its donation, like a payment that failed, so
+// the donor can reissue it. The context of a failed request may be done
+// already, the update runs without it.
+func failReservation(ctx context.Context, transaction *model.Transaction) {
+	err := config.DB.WithContext(context.WithoutCancel(ctx)).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Transaction{}).
+			Where("id = ? AND status = ? AND invoice_id = ''", transaction.ID, model.TransactionPending).
+			Updates(map[string]interface{}{"status": model.TransactionFailed, "version": optimistic.Increment})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
 		}
+		return tx.Model(&model.Donation{}).
+			Where("id = ? AND status = ?", transaction.DonationID, model.DonationPending).
+			Updates(map[string]interface{}{"status": model.DonationAbandoned, "version": optimistic.Increment}).Error
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to release reserved transaction", "transaction_id", transaction.ID, "error", err)
+	}
+}
 
-		return response, err
+// openPayment creates the payment of a transaction reserved by
+// reserveTransaction with the provider of the chosen method and stores it in
+// the transaction. A reissued transaction gets its own external ID, some
+// providers refuse to reuse one. When no payment can be opened the
+// reservation fails, see failReservation.
+func (r *DonationService) openPayment(ctx context.Context, transaction *model.Transaction, donation *pb.DonationResponse, paymentRequest payment.Request) (*pb.TransactionResponse, error) {
+	// Get donor details, guests only have an email
+	payerEmail, payerName, err := donorContact(ctx, donation)
+	if err != nil {
+		failReservation(ctx, transaction)
+		return nil, err
 	}
 
-	if err := config.DB.Last(transaction).Error; err != nil {
-		response := &pb.TransactionResponse{
-			Message: "Failed to create transaction",
-			Error:   err.Error(),
+	// Create the payment with the provider of the chosen method
+	paymentRequest.ExternalID = fmt.Sprintf("donation-%d", transaction.DonationID)
+	if transaction.PreviousTransactionID != nil {
+		paymentRequest.ExternalID = fmt.Sprintf("donation-%d-%d", transaction.DonationID, *transaction.PreviousTransactionID)
+	}
+	paymentRequest.Amount = int(transaction.Amount)
+	paymentRequest.PayerName = payerName
+	paymentRequest.PayerEmail = payerEmail
+	paymentRequest.Description = fmt.Sprintf("Donation for campaign %d by %s", transaction.DonationID, payerName)
+	p, err := payment.Default.Create(ctx, paymentRequest)
+	if err != nil {
+		failReservation(ctx, transaction)
+		return nil, paymentProviderError(err)
+	}
+
+	updates := map[string]interface{}{
+		"invoice_id":          p.Reference,
+		"invoice_url":         p.CheckoutURL,
+		"invoice_description": p.Description,
+		"payment_method":      p.Method,
+		"payment_channel":     p.Channel,
+		"va_number":           p.VANumber,
+		"qr_string":           p.QRString,
+		"deeplink_url":        p.DeeplinkURL,
+		"status":              model.TransactionStatus(p.Status),
+		"version":             optimistic.Increment,
+	}
+	if !p.ExpiresAt.IsZero() {
+		updates["expires_at"] = p.ExpiresAt
+	}
+
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the reconciler fails reservations left without a payment for too long
+		result := tx.Model(&model.Transaction{}).
+			Where("id = ? AND status = ? AND invoice_id = ''", transaction.ID, model.TransactionPending).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
 		}
+		return storeEvent(tx, event.Event{
+			Type:          event.InvoiceIssued,
+			CampaignID:    int(donation.GetCampaignId()),
+			DonationID:    transaction.DonationID,
+			TransactionID: transaction.ID,
+			UserID:        int(donation.GetUserId()),
+			GuestEmail:    donation.GetGuestEmail(),
+			IsAnonymous:   donation.GetIsAnonymous(),
+			Amount:        transaction.Amount,
+			InvoiceURL:    p.CheckoutURL,
+			PaymentMethod: payment.Label(p.Method, p.Channel),
+			VANumber:      p.VANumber,
+		})
+	})
+	if err != nil {
+		// the donor must not be able to pay a payment nothing refers to
+		slog.ErrorContext(ctx, "payment opened for a transaction that was not stored", "transaction_id", transaction.ID, "reference", p.Reference, "error", err)
+		if err := payment.Default.Expire(context.WithoutCancel(ctx), p.Method, p.Reference); err != nil {
+			slog.ErrorContext(ctx, "failed to expire payment", "reference", p.Reference, "error", err)
+		}
+		failReservation(ctx, transaction)
+		return nil, apperror.FromDB(err, "transaction")
+	}
 
-		return response, err
+	if err := config.DB.WithContext(ctx).First(transaction, transaction.ID).Error; err != nil {
+		return nil, apperror.FromDB(err, "transaction")
 	}
 
 	// Create a transaction response
 	response := &pb.TransactionResponse{
-		Message:            "Transaction created successfully",
-		Id:                 int32(transaction.ID),
-		DonationId:         int32(transaction.DonationID),
-		InvoiceId:          transaction.InvoiceID,
-		InvoiceUrl:         transaction.InvoiceURL,
-		InvoiceDescription: transaction.InvoiceDescription,
-		PaymentMethod:      transaction.PaymentMethod,
-		Amount:             float32(transaction.Amount),
-		Status:             transaction.Status,
-		CreatedAt:          transaction.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:          transaction.UpdatedAt.Format(time.RFC3339),
+		Message:               "Transaction created successfully",
+		Id:                    int32(transaction.ID),
+		DonationId:            int32(transaction.DonationID),
+		InvoiceId:             transaction.InvoiceID,
+		InvoiceUrl:            transaction.InvoiceURL,
+		InvoiceDescription:    transaction.InvoiceDescription,
+		PaymentMethod:         transaction.PaymentMethod,
+		PaymentChannel:        transaction.PaymentChannel,
+		Instructions:          paymentInstructionsToPb(transaction),
+		PreviousTransactionId: previousTransactionIDToPb(transaction),
+		Amount:                float32(transaction.Amount),
+		Status:                transaction.Status.ToPb(),
+		CreatedAt:             transaction.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             transaction.UpdatedAt.Format(time.RFC3339),
+		Version:               int32(transaction.Version),
 	}
 
 	return response, nil
@@ -518,29 +796,61 @@ func (r *DonationService) CreateTransaction(ctx context.Context, req *pb.Transac
 
 func (r *DonationService) UpdateTransaction(ctx context.Context, req *pb.TransactionRequest) (*pb.TransactionResponse, error) {
 	transaction := &model.Transaction{
-		ID: int(req.GetId()),
+		ID:     int(req.GetId()),
+		Status: model.TransactionStatusFromPb(req.GetStatus()),
 	}
 
-	if err := config.DB.Model(transaction).Updates(transaction).Error; err != nil {
-		return nil, err
+	var current model.Transaction
+	if err := config.DB.WithContext(ctx).First(&current, transaction.ID).Error; err != nil {
+		return nil, apperror.FromDB(err, "transaction")
+	}
+	if !isDonor(ctx, current.DonationID, req.GetUserId()) {
+		return nil, errTransactionNotFound
 	}
 
-	if err := config.DB.First(transaction, transaction.ID).Error; err != nil {
+	version, err := optimistic.Check("transaction", int(req.GetVersion()), current.Version)
+	if err != nil {
 		return nil, err
 	}
 
+	// an unspecified status keeps the current one
+	if transaction.Status != "" {
+		if err := checkTransactionTransition(current.Status, transaction.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	// the version guards against a concurrent update, such as a payment
+	// callback settling the transaction
+	transaction.Version = version + 1
+	result := config.DB.WithContext(ctx).Model(transaction).Where("version = ?", version).Updates(transaction)
+	if result.Error != nil {
+		return nil, apperror.FromDB(result.Error, "transaction")
+	}
+	if result.RowsAffected == 0 {
+		return nil, optimistic.Mismatch("transaction")
+	}
+
+	if err := config.DB.WithContext(ctx).First(transaction, transaction.ID).Error; err != nil {
+		return nil, apperror.FromDB(err, "transaction")
+	}
+
 	// Create a user response
 	response := &pb.TransactionResponse{
-		Id:                 int32(transaction.ID),
-		DonationId:         int32(transaction.DonationID),
-		InvoiceId:          transaction.InvoiceID,
-		InvoiceUrl:         transaction.InvoiceURL,
-		InvoiceDescription: transaction.InvoiceDescription,
-		PaymentMethod:      transaction.PaymentMethod,
-		Amount:             float32(transaction.Amount),
-		Status:             transaction.Status,
-		CreatedAt:          transaction.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:          transaction.UpdatedAt.Format(time.RFC3339),
+		Id:                    int32(transaction.ID),
+		DonationId:            int32(transaction.DonationID),
+		InvoiceId:             transaction.InvoiceID,
+		InvoiceUrl:            transaction.InvoiceURL,
+		InvoiceDescription:    transaction.InvoiceDescription,
+		PaymentMethod:         transaction.PaymentMethod,
+		PaymentChannel:        transaction.PaymentChannel,
+		Instructions:          paymentInstructionsToPb(transaction),
+		PreviousTransactionId: previousTransactionIDToPb(transaction),
+		Amount:                float32(transaction.Amount),
+		Status:                transaction.Status.ToPb(),
+		CreatedAt:             transaction.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             transaction.UpdatedAt.Format(time.RFC3339),
+		Version:               int32(transaction.Version),
 	}
 
 	return response, nil
@@ -551,98 +861,46 @@ func (r *DonationService) SyncTransaction(ctx context.Context, req *pb.Transacti
 	id := req.GetId()
 
 	var transaction model.Transaction
-	if err := config.DB.First(&transaction, id).Error; err != nil {
-		return nil, err
+	if err := config.DB.WithContext(ctx).First(&transaction, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "transaction")
+	}
+	if !isDonor(ctx, transaction.DonationID, req.GetUserId()) {
+		return nil, errTransactionNotFound
 	}
 
-	if transaction.Status == "PENDING" {
-		// Get Invoice details from external service
-		invoice, err := external.GetInvoice(transaction.InvoiceID)
+	// a reserved transaction has no payment to ask the provider about yet
+	if transaction.Status == model.TransactionPending && transaction.InvoiceID != "" {
+		// Get the payment details from the provider
+		p, err := fetchPayment(ctx, &transaction, "")
 		if err != nil {
-			response := &pb.TransactionResponse{
-				Message: "Failed to get invoice",
-				Error:   err.Error(),
-			}
-			return response, err
+			return nil, paymentProviderError(err)
 		}
-		if invoice.Status != "PENDING" {
-			transaction.Status = invoice.Status
-			transaction.InvoiceURL = invoice.InvoiceURL
-			transaction.InvoiceDescription = invoice.Description
-			transaction.UpdatedAt = time.Now()
-
-			if err := config.DB.Save(&transaction).Error; err != nil {
-				response := &pb.TransactionResponse{
-					Message: "Failed to update transaction",
-					Error:   err.Error(),
-				}
-				return response, err
-			}
-
-			if transaction.Status == "PAID" || transaction.Status == "SETTLED" {
-				// Update the donation status to "COMPLETED"
-				var donation model.Donation
-				if err := config.DB.First(&donation, transaction.DonationID).Error; err != nil {
-					response := &pb.TransactionResponse{
-						Message: "Failed to get donation",
-						Error:   err.Error(),
-					}
-					return response, err
-				}
-
-				donation.Status = "COMPLETED"
-				if err := config.DB.Save(&donation).Error; err != nil {
-					response := &pb.TransactionResponse{
-						Message: "Failed to update donation",
-						Error:   err.Error(),
-					}
-					return response, err
-				}
-
-				// Update the campaign
-				//get campaign by ID
-				campaignModel, err := GetCampaignByID(fmt.Sprintf("%d", donation.CampaignID))
-				if err != nil {
-					response := &pb.TransactionResponse{
-						Message: "Failed to get campaign",
-						Error:   err.Error(),
-					}
-					return response, err
-				}
-
-				// update the campaign collected amount
-				campaignModel.CollectedAmount += int32(donation.Amount)
-				campaignModel.UpdatedAt = time.Now()
-				if _, err := UpdateCampaignByID(fmt.Sprintf("%d", campaignModel.ID), int32(campaignModel.UserID), campaignModel.Title, campaignModel.Description, int32(campaignModel.TargetAmount), campaignModel.Deadline, campaignModel.Status, campaignModel.Category, int32(campaignModel.MinDonation)); err != nil {
-					response := &pb.TransactionResponse{
-						Message: "Failed to update campaign",
-						Error:   err.Error(),
-					}
-					return response, err
-				}
-
-			}
 
+		if err := settleTransaction(ctx, &transaction, p, SettledBySync); err != nil {
+			return nil, apperror.Internal(err)
 		}
-
 	}
 
-	if err := config.DB.First(transaction, transaction.ID).Error; err != nil {
-		return nil, err
+	if err := config.DB.WithContext(ctx).First(&transaction, transaction.ID).Error; err != nil {
+		return nil, apperror.FromDB(err, "transaction")
 	}
 
 	// Create a transaction response
 	response := &pb.TransactionResponse{
-		Id:                 int32(transaction.ID),
-		DonationId:         int32(transaction.DonationID),
-		InvoiceId:          transaction.InvoiceID,
-		InvoiceUrl:         transaction.InvoiceURL,
-		InvoiceDescription: transaction.InvoiceDescription,
-		PaymentMethod:      transaction.PaymentMethod,
-		Amount:             float32(transaction.Amount),
-		Status:             transaction.Status,
-		CreatedAt:          transaction.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:          transaction.UpdatedAt.Format(time.RFC3339),
+		Id:                    int32(transaction.ID),
+		DonationId:            int32(transaction.DonationID),
+		InvoiceId:             transaction.InvoiceID,
+		InvoiceUrl:            transaction.InvoiceURL,
+		InvoiceDescription:    transaction.InvoiceDescription,
+		PaymentMethod:         transaction.PaymentMethod,
+		PaymentChannel:        transaction.PaymentChannel,
+		Instructions:          paymentInstructionsToPb(&transaction),
+		PreviousTransactionId: previousTransactionIDToPb(&transaction),
+		Amount:                float32(transaction.Amount),
+		Status:                transaction.Status.ToPb(),
+		CreatedAt:             transaction.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             transaction.UpdatedAt.Format(time.RFC3339),
+		Version:               int32(transaction.Version),
 	}
 
 	return response, nil
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+)
+
+func TestSetDonationColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		donation model.Donation
+		want     []string
+	}{
+		{"nothing set", model.Donation{ID: 1}, nil},
+		{"donor and campaign are never columns", model.Donation{ID: 1, UserID: 2, CampaignID: 3}, nil},
+		{"amount", model.Donation{Amount: 50000}, []string{"amount"}},
+		{"every field", model.Donation{Amount: 50000, Message: "semangat", Status: model.DonationAbandoned, IsAnonymous: true},
+			[]string{"amount", "message", "status", "is_anonymous"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := setDonationColumns(&tt.donation)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setDonationColumns() = %v, want %v", got, tt.want)
+			}
+			for _, column := range got {
+				if _, ok := updatableDonationFields[column]; !ok {
+					t.Errorf("column %q is not updatable", column)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPayable(t *testing.T) {
+	pending := &model.Donation{Status: model.DonationPending, Amount: 50000}
+
+	tests := []struct {
+		name     string
+		donation *model.Donation
+		open     bool
+		amount   float32
+		code     codes.Code
+		reason   string
+	}{
+		{"pending donation", pending, false, 0, codes.OK, ""},
+		{"amount of the donation", pending, false, 50000, codes.OK, ""},
+		{"another amount", pending, false, 10000, codes.InvalidArgument, ""},
+		{"open transaction", pending, true, 0, codes.FailedPrecondition, ReasonPaymentOpen},
+		{"completed donation", &model.Donation{Status: model.DonationCompleted, Amount: 50000}, true, 0, codes.FailedPrecondition, ReasonDonationPaid},
+		{"abandoned donation", &model.Donation{Status: model.DonationAbandoned, Amount: 50000}, false, 0, codes.FailedPrecondition, ReasonDonationAbandoned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPayable(tt.donation, tt.open, tt.amount)
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("checkPayable() = %v, want %s", err, tt.code)
+			}
+			if got := apperror.Reason(err); tt.reason != "" && got != tt.reason {
+				t.Errorf("reason = %q, want %q", got, tt.reason)
+			}
+		})
+	}
+}
+
+func TestCheckDonationUpdate(t *testing.T) {
+	pending := &model.Donation{Status: model.DonationPending, Amount: 50000}
+	abandoned := &model.Donation{Status: model.DonationAbandoned, Amount: 50000}
+	completed := &model.Donation{Status: model.DonationCompleted, Amount: 50000}
+
+	tests := []struct {
+		name    string
+		current *model.Donation
+		columns []string
+		amount  float64
+		open    bool
+		code    codes.Code
+		reason  string
+	}{
+		{"amount of a pending donation without payment", pending, []string{"amount"}, 75000, false, codes.OK, ""},
+		{"amount with a payment under way", pending, []string{"amount"}, 75000, true, codes.FailedPrecondition, ReasonAmountLocked},
+		{"amount of an abandoned donation", abandoned, []string{"amount"}, 75000, false, codes.FailedPrecondition, ReasonAmountLocked},
+		{"same amount with a payment under way", pending, []string{"amount", "message"}, 50000, true, codes.OK, ""},
+		{"message with a payment under way", pending, []string{"message"}, 0, true, codes.OK, ""},
+		{"message of a completed donation", completed, []string{"message"}, 0, true, codes.FailedPrecondition, ReasonDonationPaid},
+		{"amount of a completed donation", completed, []string{"amount"}, 75000, true, codes.FailedPrecondition, ReasonDonationPaid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDonationUpdate(tt.current, tt.columns, tt.amount, tt.open)
+			if got := status.Code(err); got != tt.code {
+				t.Fatalf("checkDonationUpdate() = %v, want %s", err, tt.code)
+			}
+			if got := apperror.Reason(err); got != tt.reason {
+				t.Errorf("reason = %q, want %q", got, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	user_pb "github.com/rayhanadri/crowdfunding/user-service/pb"
+	"google.golang.org/grpc"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+// userConn is the connection to user-service shared by the donor lookups,
+// dialed on first use.
+var userConn struct {
+	once sync.Once
+	conn *grpc.ClientConn
+	err  error
+}
+
+func userServiceClient() (user_pb.UserServiceClient, error) {
+	userConn.once.Do(func() {
+		userConn.conn, userConn.err = dial(config.App.UserServiceAddr)
+	})
+	if userConn.err != nil {
+		return nil, userConn.err
+	}
+	return user_pb.NewUserServiceClient(userConn.conn), nil
+}
+
+const (
+	// maxDonorsPerLookup is the most IDs user-service takes in a
+	// GetUsersByIDs request.
+	maxDonorsPerLookup = 500
+	// maxCachedDonors bounds the cache. Expired donors are swept once it is
+	// full, and the oldest live ones evicted when that is not enough.
+	maxCachedDonors = 1000
+)
+
+type cachedDonor struct {
+	donor     model.DonorSummary
+	expiresAt time.Time
+}
+
+// donorCache keeps the donors looked up for USER_CACHE_TTL, listings show
+// the same donors over and over.
+var donorCache = struct {
+	mu     sync.Mutex
+	donors map[int]cachedDonor
+}{donors: make(map[int]cachedDonor)}
+
+// donorSummaries looks up the donors among userIDs in one GetUsersByIDs call,
+// skipping guests (0) and the donors still cached. Donors user-service does
+// not know, or cannot return, are missing from the result; the failure is
+// logged and the listing is shown without them.
+func donorSummaries(ctx context.Context, userIDs []int) map[int]model.DonorSummary {
+	donors := make(map[int]model.DonorSummary, len(userIDs))
+	seen := make(map[int]bool, len(userIDs))
+	var missing []int32
+
+	now := time.Now()
+	donorCache.mu.Lock()
+	for _, id := range userIDs {
+		if seen[id] || id == 0 {
+			continue
+		}
+		seen[id] = true
+		if cached, ok := donorCache.donors[id]; ok && now.Before(cached.expiresAt) {
+			donors[id] = cached.donor
+			continue
+		}
+		missing = append(missing, int32(id))
+	}
+	donorCache.mu.Unlock()
+	if len(missing) == 0 {
+		return donors
+	}
+
+	client, err := userServiceClient()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to connect", "error", err)
+		return donors
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	for start := 0; start < len(missing); start += maxDonorsPerLookup {
+		ids := missing[start:min(start+maxDonorsPerLookup, len(missing))]
+		res, err := client.GetUsersByIDs(ctx, &user_pb.UsersByIDsRequest{Ids: ids})
+		if err != nil {
+			slog.WarnContext(ctx, "failed to get donors", "count", len(ids), "error", err)
+			return donors
+		}
+		for _, u := range res.GetUsers() {
+			donor := model.DonorSummary{
+				ID:          int(u.GetId()),
+				Name:        u.GetName(),
+				AvatarURL:   u.GetAvatarUrl(),
+				MaskedEmail: u.GetMaskedEmail(),
+			}
+			donors[donor.ID] = donor
+			cacheDonor(donor, now)
+		}
+	}
+	return donors
+}
+
+// cacheDonor keeps donor for USER_CACHE_TTL from now.
+func cacheDonor(donor model.DonorSummary, now time.Time) {
+	if config.App.UserCacheTTL <= 0 {
+		return
+	}
+	donorCache.mu.Lock()
+	defer donorCache.mu.Unlock()
+
+	// drop expired donors so the cache does not grow with old ones, then the
+	// one expiring first while it is still full
+	if _, ok := donorCache.donors[donor.ID]; !ok && len(donorCache.donors) >= maxCachedDonors {
+		for id, cached := range donorCache.donors {
+			if !now.Before(cached.expiresAt) {
+				delete(donorCache.donors, id)
+			}
+		}
+		for len(donorCache.donors) >= maxCachedDonors {
+			oldest := 0
+			for id, cached := range donorCache.donors {
+				if oldest == 0 || cached.expiresAt.Before(donorCache.donors[oldest].expiresAt) {
+					oldest = id
+				}
+			}
+			delete(donorCache.donors, oldest)
+		}
+	}
+	donorCache.donors[donor.ID] = cachedDonor{donor: donor, expiresAt: now.Add(config.App.UserCacheTTL)}
+}
+
+func donorSummaryToPb(donor model.DonorSummary) *pb.DonorSummary {
+	return &pb.DonorSummary{
+		Id:          int32(donor.ID),
+		Name:        donor.Name,
+		AvatarUrl:   donor.AvatarURL,
+		MaskedEmail: donor.MaskedEmail,
+	}
+}
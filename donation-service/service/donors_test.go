@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	user_pb "github.com/rayhanadri/crowdfunding/user-service/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+)
+
+// fakeUsers answers GetUsersByIDs for every ID but 99, and records the IDs
+// of each call.
+type fakeUsers struct {
+	user_pb.UnimplementedUserServiceServer
+	mu    sync.Mutex
+	calls [][]int32
+	fail  bool
+}
+
+func (f *fakeUsers) GetUsersByIDs(ctx context.Context, req *user_pb.UsersByIDsRequest) (*user_pb.UsersByIDsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, req.GetIds())
+	if f.fail {
+		return nil, errors.New("user-service is down")
+	}
+	res := &user_pb.UsersByIDsResponse{}
+	for _, id := range req.GetIds() {
+		if id != 99 {
+			res.Users = append(res.Users, &user_pb.UserSummary{Id: id, Name: fmt.Sprintf("donor %d", id)})
+		}
+	}
+	return res, nil
+}
+
+var users = struct {
+	once sync.Once
+	fake *fakeUsers
+}{fake: &fakeUsers{}}
+
+// useFakeUsers connects the donor lookups to a fake user-service with an
+// empty cache, keeping donors for ttl.
+func useFakeUsers(t *testing.T, ttl time.Duration) *fakeUsers {
+	t.Helper()
+	users.once.Do(func() {
+		listener := bufconn.Listen(1 << 20)
+		server := grpc.NewServer()
+		user_pb.RegisterUserServiceServer(server, users.fake)
+		go server.Serve(listener)
+
+		userConn.once.Do(func() {
+			userConn.conn, userConn.err = grpc.NewClient("passthrough:///bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+			)
+		})
+	})
+
+	previous := config.App.UserCacheTTL
+	config.App.UserCacheTTL = ttl
+	users.fake.mu.Lock()
+	users.fake.calls, users.fake.fail = nil, false
+	users.fake.mu.Unlock()
+	donorCache.mu.Lock()
+	donorCache.donors = make(map[int]cachedDonor)
+	donorCache.mu.Unlock()
+	t.Cleanup(func() { config.App.UserCacheTTL = previous })
+	return users.fake
+}
+
+func donorIDs(donors map[int]model.DonorSummary) []int {
+	var ids []int
+	for id := range donors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestDonorSummaries(t *testing.T) {
+	// more donors than one lookup takes, from 1000 on
+	many := make([]int, 0, maxDonorsPerLookup+1)
+	for id := 1000; id <= 1000+maxDonorsPerLookup; id++ {
+		many = append(many, id)
+	}
+
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		// lookups are made in order, the calls of the last one are checked
+		lookups   [][]int
+		fail      bool
+		want      []int
+		wantCalls [][]int32
+	}{
+		{
+			name:      "guests and repeated donors skipped",
+			ttl:       time.Minute,
+			lookups:   [][]int{{1, 0, 2, 1, 0}},
+			want:      []int{1, 2},
+			wantCalls: [][]int32{{1, 2}},
+		},
+		{
+			name:      "cached donors not looked up again",
+			ttl:       time.Minute,
+			lookups:   [][]int{{1, 2}, {2, 3, 1}},
+			want:      []int{1, 2, 3},
+			wantCalls: [][]int32{{3}},
+		},
+		{
+			name:      "everyone cached",
+			ttl:       time.Minute,
+			lookups:   [][]int{{1, 2}, {1, 2}},
+			want:      []int{1, 2},
+			wantCalls: nil,
+		},
+		{
+			name:      "unknown donors left out",
+			ttl:       time.Minute,
+			lookups:   [][]int{{1, 99}},
+			want:      []int{1},
+			wantCalls: [][]int32{{1, 99}},
+		},
+		{
+			name:      "no cache without a ttl",
+			ttl:       0,
+			lookups:   [][]int{{1, 2}, {1, 2}},
+			want:      []int{1, 2},
+			wantCalls: [][]int32{{1, 2}},
+		},
+		{
+			name:      "failure shows the cached donors",
+			ttl:       time.Minute,
+			lookups:   [][]int{{1}, {1, 2}},
+			fail:      true,
+			want:      []int{1},
+			wantCalls: [][]int32{{2}},
+		},
+		{
+			name:    "lookups batched",
+			ttl:     time.Minute,
+			lookups: [][]int{many},
+			want:    many,
+			wantCalls: [][]int32{
+				func() []int32 {
+					ids := make([]int32, maxDonorsPerLookup)
+					for i := range ids {
+						ids[i] = int32(1000 + i)
+					}
+					return ids
+				}(),
+				{1000 + maxDonorsPerLookup},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeUsers(t, tt.ttl)
+
+			var donors map[int]model.DonorSummary
+			for i, lookup := range tt.lookups {
+				last := i == len(tt.lookups)-1
+				if last {
+					fake.mu.Lock()
+					fake.calls, fake.fail = nil, tt.fail
+					fake.mu.Unlock()
+				}
+				donors = donorSummaries(context.Background(), lookup)
+			}
+
+			if got := donorIDs(donors); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("donorSummaries() = %v, want %v", got, tt.want)
+			}
+			if first := tt.want[0]; donors[first].Name != fmt.Sprintf("donor %d", first) {
+				t.Errorf("donor %d = %+v", first, donors[first])
+			}
+			if !reflect.DeepEqual(fake.calls, tt.wantCalls) {
+				t.Errorf("GetUsersByIDs calls = %v, want %v", fake.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDonorSummariesExpire(t *testing.T) {
+	fake := useFakeUsers(t, time.Minute)
+	donorSummaries(context.Background(), []int{1, 2})
+
+	// donor 1 was cached a minute ago
+	donorCache.mu.Lock()
+	expired := donorCache.donors[1]
+	expired.expiresAt = time.Now().Add(-time.Second)
+	donorCache.donors[1] = expired
+	donorCache.mu.Unlock()
+
+	fake.mu.Lock()
+	fake.calls = nil
+	fake.mu.Unlock()
+	donorSummaries(context.Background(), []int{1, 2})
+	if want := [][]int32{{1}}; !reflect.DeepEqual(fake.calls, want) {
+		t.Errorf("GetUsersByIDs calls = %v, want %v", fake.calls, want)
+	}
+}
+
+func TestCacheDonorSweepsExpired(t *testing.T) {
+	useFakeUsers(t, time.Minute)
+	now := time.Now()
+
+	donorCache.mu.Lock()
+	for id := 1; id <= maxCachedDonors; id++ {
+		expiresAt := now.Add(-time.Second)
+		if id == 1 {
+			expiresAt = now.Add(time.Minute)
+		}
+		donorCache.donors[id] = cachedDonor{donor: model.DonorSummary{ID: id}, expiresAt: expiresAt}
+	}
+	donorCache.mu.Unlock()
+
+	cacheDonor(model.DonorSummary{ID: maxCachedDonors + 1}, now)
+
+	donorCache.mu.Lock()
+	defer donorCache.mu.Unlock()
+	var ids []int
+	for id := range donorCache.donors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	if want := []int{1, maxCachedDonors + 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("cached donors = %v, want %v", ids, want)
+	}
+}
+
+func TestCacheDonorEvictsOldest(t *testing.T) {
+	useFakeUsers(t, time.Minute)
+	now := time.Now()
+
+	// a cache full of donors that are all still valid, 1 expiring first
+	donorCache.mu.Lock()
+	for id := 1; id <= maxCachedDonors; id++ {
+		expiresAt := now.Add(time.Minute + time.Duration(id)*time.Millisecond)
+		donorCache.donors[id] = cachedDonor{donor: model.DonorSummary{ID: id}, expiresAt: expiresAt}
+	}
+	donorCache.mu.Unlock()
+
+	cacheDonor(model.DonorSummary{ID: maxCachedDonors + 1}, now)
+	cacheDonor(model.DonorSummary{ID: 2}, now)
+
+	donorCache.mu.Lock()
+	defer donorCache.mu.Unlock()
+	if len(donorCache.donors) != maxCachedDonors {
+		t.Errorf("cached donors = %d, want %d", len(donorCache.donors), maxCachedDonors)
+	}
+	if _, ok := donorCache.donors[1]; ok {
+		t.Error("oldest donor 1 is still cached")
+	}
+	for _, id := range []int{2, maxCachedDonors, maxCachedDonors + 1} {
+		if _, ok := donorCache.donors[id]; !ok {
+			t.Errorf("donor %d is not cached", id)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"log/slog"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+)
+
+// Error reasons of the donation service, sent in the ErrorInfo of its errors.
+const (
+	ReasonTransactionNotFound        = "TRANSACTION_NOT_FOUND"
+	ReasonSubscriptionNotFound       = "WEBHOOK_SUBSCRIPTION_NOT_FOUND"
+	ReasonDeliveryNotFound           = "WEBHOOK_DELIVERY_NOT_FOUND"
+	ReasonInvalidStatusTransition    = "INVALID_STATUS_TRANSITION"
+	ReasonNotReissuable              = "TRANSACTION_NOT_REISSUABLE"
+	ReasonDonationPaid               = "DONATION_ALREADY_PAID"
+	ReasonDonationNotPaid            = "DONATION_NOT_PAID"
+	ReasonDonationAbandoned          = "DONATION_ABANDONED"
+	ReasonPaymentOpen                = "PAYMENT_ALREADY_OPEN"
+	ReasonAmountLocked               = "DONATION_AMOUNT_LOCKED"
+	ReasonDonorUnknown               = "DONOR_UNKNOWN"
+	ReasonCampaignNotOwned           = "CAMPAIGN_NOT_OWNED"
+	ReasonEmailNotVerified           = "EMAIL_NOT_VERIFIED"
+	ReasonPaymentProviderUnavailable = "PAYMENT_PROVIDER_UNAVAILABLE"
+)
+
+// invalidField is an InvalidArgument error for a single field.
+func invalidField(field string, description string) error {
+	return apperror.InvalidArgument(description, apperror.FieldViolation{Field: field, Description: description})
+}
+
+// paymentProviderError hides a failed call to the payment provider from
+// clients, they can retry later.
+func paymentProviderError(err error) error {
+	slog.Error("payment provider error", "error", err)
+	return apperror.Unavailable(ReasonPaymentProviderUnavailable, "payment provider is unavailable, try again later")
+}
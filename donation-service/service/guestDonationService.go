@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+	"github.com/rayhanadri/crowdfunding/common/validation"
+	"gorm.io/gorm"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/payment"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+// donorContact returns the email and name the invoice is addressed to. Guest
+// donations have no user, so the invoice goes to the guest email.
+func donorContact(ctx context.Context, donation *pb.DonationResponse) (email string, name string, err error) {
+	if donation.GetUserId() == 0 {
+		if donation.GetGuestEmail() == "" {
+			return "", "", apperror.FailedPrecondition(ReasonDonorUnknown, "donation has no user and no guest email")
+		}
+		return donation.GetGuestEmail(), model.GuestDonorName, nil
+	}
+
+	userModel, err := GetUserByID(ctx, donation.GetUserId())
+	if err != nil {
+		return "", "", err
+	}
+	return userModel.Email, userModel.Name, nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if err := validation.Email(email); err != nil {
+		return "", invalidField("email", err.Error())
+	}
+	return strings.ToLower(email), nil
+}
+
+// CreateGuestDonation creates a donation and its transaction for a donor
+// without an account. The invoice is sent to the guest email.
+func (r *DonationService) CreateGuestDonation(ctx context.Context, req *pb.GuestDonationRequest) (*pb.GuestDonationResponse, error) {
+	email, err := normalizeEmail(req.GetEmail())
+	if err != nil {
+		return nil, err
+	}
+
+	donation := &model.Donation{
+		CampaignID:  int(req.GetCampaignId()),
+		Amount:      float64(req.GetAmount()),
+		Message:     req.GetMessage(),
+		Status:      model.DonationPending,
+		IsAnonymous: req.GetIsAnonymous(),
+		GuestEmail:  email,
+	}
+
+	//validate donation data, and the payment method before the donation exists
+	paymentRequest := payment.Request{
+		Method:       req.GetPaymentMethod(),
+		Channel:      req.GetPaymentChannel(),
+		MobileNumber: strings.TrimSpace(req.GetMobileNumber()),
+	}
+	if donation.CampaignID == 0 {
+		return nil, invalidField("campaign_id", "campaign ID is required")
+	}
+	if err := validation.Amount(donation.Amount); err != nil {
+		return nil, invalidField("amount", err.Error())
+	}
+	if err := validation.Message(donation.Message); err != nil {
+		return nil, invalidField("message", err.Error())
+	}
+	if err := payment.Validate(&paymentRequest); err != nil {
+		return nil, invalidField("payment_method", err.Error())
+	}
+
+	// user_id is left NULL until the donation is claimed by an account. The
+	// transaction is reserved along with the donation, so a donation is never
+	// left pending without a payment.
+	pending := &model.Transaction{Amount: donation.Amount}
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("id", "user_id").Create(donation).Error; err != nil {
+			return err
+		}
+		err := storeEvent(tx, event.Event{
+			Type:        event.DonationCreated,
+			CampaignID:  donation.CampaignID,
+			DonationID:  donation.ID,
+			GuestEmail:  donation.GuestEmail,
+			IsAnonymous: donation.IsAnonymous,
+			Amount:      donation.Amount,
+		})
+		if err != nil {
+			return err
+		}
+		pending.DonationID = donation.ID
+		return reserveTransaction(tx, pending)
+	})
+	if err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	donationResponse, err := r.GetDonationByID(ctx, &pb.DonationIdRequest{Id: int32(donation.ID)})
+	if err != nil {
+		failReservation(ctx, pending)
+		return nil, err
+	}
+
+	// a payment the provider refuses abandons the donation, see openPayment
+	transaction, err := r.openPayment(ctx, pending, donationResponse, paymentRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GuestDonationResponse{
+		Message: "Guest donation created successfully",
+		Donation: &pb.Donation{
+			Id:          int32(donation.ID),
+			CampaignId:  int32(donation.CampaignID),
+			Amount:      float32(donation.Amount),
+			Message:     donation.Message,
+			Status:      donation.Status.ToPb(),
+			IsAnonymous: donation.IsAnonymous,
+			GuestEmail:  donation.GuestEmail,
+			CreatedAt:   donation.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:   donation.UpdatedAt.Format(time.RFC3339),
+			Version:     int32(donation.Version),
+		},
+		Transaction: &pb.Transaction{
+			Id:                 transaction.GetId(),
+			DonationId:         transaction.GetDonationId(),
+			InvoiceId:          transaction.GetInvoiceId(),
+			InvoiceUrl:         transaction.GetInvoiceUrl(),
+			InvoiceDescription: transaction.GetInvoiceDescription(),
+			PaymentMethod:      transaction.GetPaymentMethod(),
+			PaymentChannel:     transaction.GetPaymentChannel(),
+			Instructions:       transaction.GetInstructions(),
+			Amount:             transaction.GetAmount(),
+			Status:             transaction.GetStatus(),
+			CreatedAt:          transaction.GetCreatedAt(),
+			UpdatedAt:          transaction.GetUpdatedAt(),
+			Version:            transaction.GetVersion(),
+		},
+	}
+
+	return response, nil
+}
+
+// checkVerifiedEmail asks user-service whether email is the verified email of
+// the user. The email of a login token may be stale, or never verified, and
+// anyone can register with the email of a guest donor.
+func checkVerifiedEmail(ctx context.Context, userID int32, email string) error {
+	user, err := GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.EmailVerified() || !strings.EqualFold(user.Email, email) {
+		return apperror.FailedPrecondition(ReasonEmailNotVerified, "verify the email of the account before claiming guest donations")
+	}
+	return nil
+}
+
+// ClaimGuestDonations moves guest donations made with the given email to the
+// user account, once the user has verified they own the email.
+func (r *DonationService) ClaimGuestDonations(ctx context.Context, req *pb.ClaimGuestDonationsRequest) (*pb.ClaimGuestDonationsResponse, error) {
+	email, err := normalizeEmail(req.GetEmail())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetUserId() == 0 {
+		return nil, invalidField("user_id", "user ID is required")
+	}
+
+	if err := checkVerifiedEmail(ctx, req.GetUserId(), email); err != nil {
+		return nil, err
+	}
+
+	result := config.DB.WithContext(ctx).Model(&model.Donation{}).
+		Where("user_id IS NULL AND LOWER(guest_email) = ?", email).
+		Updates(map[string]interface{}{"user_id": req.GetUserId(), "version": optimistic.Increment})
+	if result.Error != nil {
+		return nil, apperror.FromDB(result.Error, "donation")
+	}
+
+	response := &pb.ClaimGuestDonationsResponse{
+		Message:      "Guest donations claimed successfully",
+		ClaimedCount: int32(result.RowsAffected),
+	}
+
+	return response, nil
+}
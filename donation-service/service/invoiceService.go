@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/payment"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+// errTransactionNotFound is also returned for transactions of other users, so
+// their IDs are not revealed.
+var errTransactionNotFound = apperror.NotFound(ReasonTransactionNotFound, "transaction not found")
+
+// isDonor reports whether userID is the donor of a donation. Guest donations
+// have no donor to match, a request without a user never passes.
+func isDonor(ctx context.Context, donationID int, userID int32) bool {
+	if userID == 0 {
+		return false
+	}
+	var donation model.Donation
+	err := config.DB.WithContext(ctx).Select("user_id").First(&donation, donationID).Error
+	return err == nil && donation.UserID == int(userID)
+}
+
+func previousTransactionIDToPb(transaction *model.Transaction) int32 {
+	if transaction.PreviousTransactionID == nil {
+		return 0
+	}
+	return int32(*transaction.PreviousTransactionID)
+}
+
+// ownsTransaction reports whether the caller of a reissue is the donor: the
+// user the donation belongs to, or the guest who made it while it is
+// unclaimed, with the invoice ID of the transaction they were given.
+func ownsTransaction(req *pb.ReissueInvoiceRequest, previous *model.Transaction, donation *model.Donation) bool {
+	if req.GetUserId() != 0 {
+		return donation.UserID == int(req.GetUserId())
+	}
+	return donation.UserID == 0 && donation.GuestEmail != "" && strings.EqualFold(donation.GuestEmail, strings.TrimSpace(req.GetGuestEmail())) &&
+		previous.InvoiceID != "" && previous.InvoiceID == req.GetInvoiceId()
+}
+
+// ReissueInvoice opens a new payment for a donation whose payment expired or
+// failed. The new transaction links to the attempt it replaces, only the
+// latest attempt of a donation can be reissued, and the abandoned donation is
+// pending again. Guests reissue with their email and the invoice ID of the
+// transaction, like they get their receipt.
+func (r *DonationService) ReissueInvoice(ctx context.Context, req *pb.ReissueInvoiceRequest) (*pb.TransactionResponse, error) {
+	if req.GetUserId() == 0 && (req.GetGuestEmail() == "" || req.GetInvoiceId() == "") {
+		return nil, invalidField("user_id", "user ID, or guest email and invoice ID, are required")
+	}
+
+	var previous model.Transaction
+	var donation model.Donation
+	err := config.DB.WithContext(ctx).First(&previous, req.GetTransactionId()).Error
+	if err == nil {
+		err = config.DB.WithContext(ctx).First(&donation, previous.DonationID).Error
+	}
+	if err != nil || !ownsTransaction(req, &previous, &donation) {
+		return nil, errTransactionNotFound
+	}
+
+	// pay the same way as last time unless the donor picks another method
+	paymentRequest := payment.Request{
+		Method:       req.GetPaymentMethod(),
+		Channel:      req.GetPaymentChannel(),
+		MobileNumber: strings.TrimSpace(req.GetMobileNumber()),
+	}
+	if paymentRequest.Method == "" {
+		paymentRequest.Method = previous.PaymentMethod
+		paymentRequest.Channel = previous.PaymentChannel
+	}
+	if err := payment.Validate(&paymentRequest); err != nil {
+		return nil, invalidField("payment_method", err.Error())
+	}
+
+	// The new transaction is reserved, and the donation pending again, before
+	// the provider is asked for a payment. A reissue that loses the race to
+	// another one never opens a payment.
+	transaction := &model.Transaction{
+		DonationID:            previous.DonationID,
+		PreviousTransactionID: &previous.ID,
+		Amount:                previous.Amount,
+	}
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&donation, donation.ID).Error; err != nil {
+			return err
+		}
+		if err := checkReissuable(tx, &previous, &donation); err != nil {
+			return err
+		}
+		if err := reserveTransaction(tx, transaction); err != nil {
+			return err
+		}
+		return tx.Model(&model.Donation{}).
+			Where("id = ? AND status = ?", donation.ID, model.DonationAbandoned).
+			Updates(map[string]interface{}{"status": model.DonationPending, "version": optimistic.Increment}).Error
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// lost the race against another reissue of the same transaction
+		return nil, apperror.FailedPrecondition(ReasonNotReissuable, "transaction was already reissued")
+	}
+	if err != nil {
+		return nil, apperror.FromDB(err, "transaction")
+	}
+
+	donationResponse, err := r.GetDonationByID(ctx, &pb.DonationIdRequest{Id: int32(donation.ID)})
+	if err != nil {
+		failReservation(ctx, transaction)
+		return nil, err
+	}
+
+	// a payment the provider refuses abandons the donation again
+	response, err := r.openPayment(ctx, transaction, donationResponse, paymentRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Message = "Invoice reissued successfully"
+	return response, nil
+}
+
+// checkReissuable reports why a transaction cannot be reissued, in the
+// transaction db that locked the donation. Concurrent reissues of the same
+// transaction are also stopped by the unique index on
+// previous_transaction_id.
+func checkReissuable(db *gorm.DB, previous *model.Transaction, donation *model.Donation) error {
+	if previous.Status != model.TransactionExpired && previous.Status != model.TransactionFailed {
+		return apperror.FailedPrecondition(ReasonNotReissuable, "only expired or failed transactions can be reissued")
+	}
+	if donation.Status == model.DonationCompleted {
+		return apperror.FailedPrecondition(ReasonDonationPaid, "donation is already paid")
+	}
+
+	var next model.Transaction
+	err := db.
+		Where("donation_id = ? AND (previous_transaction_id = ? OR status = ?)", donation.ID, previous.ID, model.TransactionPending).
+		First(&next).Error
+	if err == nil {
+		return apperror.FailedPrecondition(ReasonNotReissuable, fmt.Sprintf("donation already has a newer transaction %d", next.ID))
+	}
+	return nil
+}
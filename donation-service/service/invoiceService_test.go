@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+func TestOwnsTransaction(t *testing.T) {
+	registered := &model.Donation{UserID: 7}
+	guest := &model.Donation{GuestEmail: "guest@example.com"}
+	claimed := &model.Donation{UserID: 7, GuestEmail: "guest@example.com"}
+	expired := &model.Transaction{InvoiceID: "inv-1"}
+	reserved := &model.Transaction{}
+
+	tests := []struct {
+		name     string
+		req      *pb.ReissueInvoiceRequest
+		previous *model.Transaction
+		donation *model.Donation
+		want     bool
+	}{
+		{"donor", &pb.ReissueInvoiceRequest{UserId: 7}, expired, registered, true},
+		{"another user", &pb.ReissueInvoiceRequest{UserId: 8}, expired, registered, false},
+		{"guest", &pb.ReissueInvoiceRequest{GuestEmail: "Guest@Example.com ", InvoiceId: "inv-1"}, expired, guest, true},
+		{"guest with another invoice", &pb.ReissueInvoiceRequest{GuestEmail: "guest@example.com", InvoiceId: "inv-2"}, expired, guest, false},
+		{"another guest", &pb.ReissueInvoiceRequest{GuestEmail: "other@example.com", InvoiceId: "inv-1"}, expired, guest, false},
+		{"guest of a transaction without invoice", &pb.ReissueInvoiceRequest{GuestEmail: "guest@example.com"}, reserved, guest, false},
+		{"guest after the claim", &pb.ReissueInvoiceRequest{GuestEmail: "guest@example.com", InvoiceId: "inv-1"}, expired, claimed, false},
+		{"user who claimed it", &pb.ReissueInvoiceRequest{UserId: 7}, expired, claimed, true},
+		{"user asking for a guest donation", &pb.ReissueInvoiceRequest{UserId: 7}, expired, guest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ownsTransaction(tt.req, tt.previous, tt.donation); got != tt.want {
+				t.Errorf("ownsTransaction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
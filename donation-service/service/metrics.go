@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+)
+
+// Business metrics of donations and payments. Amounts are in rupiah.
+var (
+	donationsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "donations_created_total",
+		Help: "Donations created, by registered or guest donor.",
+	}, []string{"donor"})
+	invoicesIssued = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "invoices_issued_total",
+		Help: "Payment invoices issued, by payment method.",
+	}, []string{"payment_method"})
+	settlements = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "payment_settlements_total",
+		Help: "Pending transactions settled, by the status the provider reported and the path that settled them.",
+	}, []string{"status", "source"})
+	settlementLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "payment_settlement_lag_seconds",
+		Help:    "Time between the payment at the provider and its settlement here, by the path that settled it.",
+		Buckets: []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600, 6 * 3600, 24 * 3600},
+	}, []string{"source"})
+	refunds = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "donation_refunds_total",
+		Help: "Donations refunded.",
+	})
+	refundVolume = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "donation_refund_amount_total",
+		Help: "Amount refunded to donors.",
+	})
+	payouts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "campaign_payouts_total",
+		Help: "Payouts sent to campaign owners.",
+	})
+	payoutVolume = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "campaign_payout_amount_total",
+		Help: "Amount paid out to campaign owners.",
+	})
+	_ = promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name: "event_bus_dropped_total",
+		Help: "Events the bus dropped for subscribers that fell behind.",
+	}, func() float64 { return float64(event.Default.Dropped()) })
+)
+
+// recordSettlement counts a transaction settled by source, with the lag since
+// the provider saw the payment when it was paid.
+func recordSettlement(status string, source string, paidAt *time.Time) {
+	settlements.WithLabelValues(status, source).Inc()
+	if paidAt != nil {
+		settlementLag.WithLabelValues(source).Observe(time.Since(*paidAt).Seconds())
+	}
+}
+
+// recordEvent counts the business events published on the bus.
+func recordEvent(e event.Event) {
+	switch e.Type {
+	case event.DonationCreated:
+		donor := "registered"
+		if e.UserID == 0 {
+			donor = "guest"
+		}
+		donationsCreated.WithLabelValues(donor).Inc()
+	case event.InvoiceIssued:
+		invoicesIssued.WithLabelValues(e.PaymentMethod).Inc()
+	case event.DonationRefunded:
+		refunds.Inc()
+		refundVolume.Add(e.Amount)
+	case event.PayoutSent:
+		payouts.Inc()
+		payoutVolume.Add(e.Amount)
+	}
+}
+
+// StartMetrics counts the events published on the bus until the context is
+// cancelled. Every replica publishes every event, so each counts all of them.
+func StartMetrics(ctx context.Context) {
+	events, _, _, unsubscribe := event.Subscribe(0)
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-events:
+				if !ok {
+					return
+				}
+				recordEvent(e)
+			}
+		}
+	}()
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/notification"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+// NotificationDirectory looks up notification recipients in the user and
+// campaign services.
+type NotificationDirectory struct{}
+
+func (NotificationDirectory) User(ctx context.Context, userID int) (notification.Recipient, error) {
+	userModel, err := GetUserByID(ctx, int32(userID))
+	if err != nil {
+		return notification.Recipient{}, err
+	}
+	return notification.Recipient{UserID: userModel.ID, Name: userModel.Name, Email: userModel.Email}, nil
+}
+
+func (d NotificationDirectory) Campaign(ctx context.Context, campaignID int) (string, notification.Recipient, error) {
+	campaignModel, err := GetCampaignByID(ctx, strconv.Itoa(campaignID))
+	if err != nil {
+		return "", notification.Recipient{}, err
+	}
+	owner, err := d.User(ctx, int(campaignModel.UserID))
+	if err != nil {
+		return "", notification.Recipient{}, err
+	}
+	return campaignModel.Title, owner, nil
+}
+
+func notificationPreferenceResponse(message string, preference *model.NotificationPreference) *pb.NotificationPreferenceResponse {
+	return &pb.NotificationPreferenceResponse{
+		Message:      message,
+		UserId:       int32(preference.UserID),
+		Locale:       preference.Locale,
+		EmailEnabled: preference.EmailEnabled,
+		WebhookUrl:   preference.WebhookURL,
+		MutedEvents:  preference.MutedEventList(),
+	}
+}
+
+func (r *DonationService) GetNotificationPreferences(ctx context.Context, req *pb.NotificationPreferenceRequest) (*pb.NotificationPreferenceResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, invalidField("user_id", "user ID is required")
+	}
+
+	preference, err := notification.LoadPreference(ctx, int(req.GetUserId()))
+	if err != nil {
+		return nil, err
+	}
+
+	return notificationPreferenceResponse("Notification preferences retrieved successfully", preference), nil
+}
+
+func (r *DonationService) UpdateNotificationPreferences(ctx context.Context, req *pb.UpdateNotificationPreferenceRequest) (*pb.NotificationPreferenceResponse, error) {
+	preference := &model.NotificationPreference{
+		UserID:       int(req.GetUserId()),
+		Locale:       req.GetLocale(),
+		EmailEnabled: req.GetEmailEnabled(),
+		WebhookURL:   strings.TrimSpace(req.GetWebhookUrl()),
+		MutedEvents:  strings.Join(req.GetMutedEvents(), ","),
+	}
+	if preference.Locale == "" {
+		preference.Locale = notification.DefaultLocale
+	}
+
+	//validate preference data
+	var err error
+	switch {
+	case preference.UserID == 0:
+		err = invalidField("user_id", "user ID is required")
+	case !notification.SupportedLocale(preference.Locale):
+		err = invalidField("locale", "locale must be id or en")
+	case preference.WebhookURL != "" && !validWebhookURL(preference.WebhookURL):
+		err = invalidField("webhook_url", "webhook URL must be an absolute https URL")
+	case len(preference.MutedEvents) > 255:
+		err = invalidField("muted_events", "too many muted events")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.DB.WithContext(ctx).Save(preference).Error; err != nil {
+		return nil, apperror.FromDB(err, "notification preference")
+	}
+
+	return notificationPreferenceResponse("Notification preferences updated successfully", preference), nil
+}
+
+func validWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+// campaignProgress sums the completed donations of a campaign.
+func campaignProgress(ctx context.Context, campaignID int) (model.CampaignTotal, error) {
+	var total model.CampaignTotal
+	err := config.DB.WithContext(ctx).Model(&model.Donation{}).
+		Select("campaign_id, COALESCE(SUM(amount), 0) AS total_amount, COUNT(*) AS donation_count, "+
+			"COUNT(DISTINCT COALESCE(user_id::text, LOWER(guest_email))) AS donor_count").
+		Where("campaign_id = ?", campaignID).
+		Where(completedDonations).
+		Group("campaign_id").
+		Scan(&total).Error
+	total.CampaignID = campaignID
+	return total, err
+}
+
+func progressEvent(eventID int64, e event.Event, total model.CampaignTotal) *pb.CampaignProgressEvent {
+	occurredAt := e.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	return &pb.CampaignProgressEvent{
+		EventId:       eventID,
+		CampaignId:    int32(total.CampaignID),
+		DonationId:    int32(e.DonationID),
+		Amount:        float32(e.Amount),
+		TotalRaised:   float32(total.TotalAmount),
+		DonationCount: int32(total.DonationCount),
+		DonorCount:    int32(total.DonorCount),
+		Source:        e.Source,
+		OccurredAt:    occurredAt.Format(time.RFC3339),
+	}
+}
+
+// WatchCampaignProgress streams the campaign totals every time a donation to
+// the campaign settles. A new watcher first gets a snapshot, a resuming one
+// gets the settlements it missed as long as they are still in the event bus
+// history.
+func (s *DonationService) WatchCampaignProgress(req *pb.WatchCampaignProgressRequest, stream pb.DonationService_WatchCampaignProgressServer) error {
+	campaignID := int(req.GetCampaignId())
+	if campaignID <= 0 {
+		return invalidField("campaign_id", "campaign ID is required")
+	}
+	ctx := stream.Context()
+
+	events, missed, lastID, unsubscribe := event.Subscribe(req.GetLastEventId())
+	defer unsubscribe()
+
+	send := func(eventID int64, e event.Event) error {
+		total, err := campaignProgress(ctx, campaignID)
+		if err != nil {
+			return err
+		}
+		return stream.Send(progressEvent(eventID, e, total))
+	}
+
+	// Start with a snapshot when the watcher is new, when the missed events
+	// are no longer in history, or when the IDs restarted with the service.
+	afterID := req.GetLastEventId()
+	historyGap := len(missed) > 0 && missed[0].ID > afterID+1 || len(missed) == 0 && lastID > afterID
+	if afterID == 0 || afterID > lastID || historyGap {
+		missed = nil
+		if err := send(lastID, event.Event{}); err != nil {
+			return err
+		}
+	}
+	for _, e := range missed {
+		if e.Type == event.DonationSettled && e.CampaignID == campaignID {
+			if err := send(e.ID, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if e.Type != event.DonationSettled || e.CampaignID != campaignID {
+				continue
+			}
+			if err := send(e.ID, e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func donationEventToPb(e event.Event) *pb.DonationEvent {
+	return &pb.DonationEvent{
+		EventId:    e.ID,
+		Type:       e.Type,
+		CampaignId: int32(e.CampaignID),
+		DonationId: int32(e.DonationID),
+		UserId:     int32(e.UserID),
+		OccurredAt: e.OccurredAt.Format(time.RFC3339),
+	}
+}
+
+// WatchDonationEvents streams every donation event, so the gateway can drop
+// what it cached about a donation once it settles. A resuming watcher first
+// gets the events it missed as long as they are still in the event bus
+// history, a new one only gets the events published from now on.
+func (s *DonationService) WatchDonationEvents(req *pb.WatchDonationEventsRequest, stream pb.DonationService_WatchDonationEventsServer) error {
+	events, missed, _, unsubscribe := event.Subscribe(req.GetLastEventId())
+	defer unsubscribe()
+
+	if req.GetLastEventId() > 0 {
+		for _, e := range missed {
+			if err := stream.Send(donationEventToPb(e)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(donationEventToPb(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"gorm.io/gorm"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+const (
+	defaultPageSize    = 20
+	maxPageSize        = 100
+	defaultBoardLimit  = 10
+	maxBoardLimit      = 50
+	completedDonations = "status = 'COMPLETED'"
+	// firstPayments is the time each donation was first paid, joined as paid.
+	// A donation paid twice counts on the first payment.
+	firstPayments = "(SELECT donation_id, MIN(paid_at) AS paid_at FROM donations.transactions " +
+		"WHERE paid_at IS NOT NULL GROUP BY donation_id) paid"
+)
+
+// paidSince keeps the donations of query first paid at or after since. A
+// donation belongs to the window it was paid in, not the one it was pledged
+// in, the same as the time series. paid_at is UTC without a zone.
+func paidSince(query *gorm.DB, since time.Time) *gorm.DB {
+	return query.Joins("JOIN "+firstPayments+" ON paid.donation_id = donations.id").
+		Where("paid.paid_at >= ?", since.UTC())
+}
+
+// leaderboardWindows maps the window name to how far back it looks, zero
+// means all time.
+var leaderboardWindows = map[string]time.Duration{
+	"day":   24 * time.Hour,
+	"week":  7 * 24 * time.Hour,
+	"month": 30 * 24 * time.Hour,
+	"all":   0,
+}
+
+func clampLimit(value int32, def int, max int) int {
+	if value <= 0 {
+		return def
+	}
+	if int(value) > max {
+		return max
+	}
+	return int(value)
+}
+
+// donorNames looks up the display name of every distinct user, see
+// donorSummaries.
+func donorNames(ctx context.Context, userIDs []int) map[int]string {
+	donors := donorSummaries(ctx, userIDs)
+	names := make(map[int]string, len(donors))
+	for id, donor := range donors {
+		names[id] = donor.Name
+	}
+	return names
+}
+
+// publicDonorName returns the name shown publicly for a donor.
+func publicDonorName(userID int, isAnonymous bool, names map[int]string) string {
+	if isAnonymous {
+		return model.AnonymousDonorName
+	}
+	if name := names[userID]; userID != 0 && name != "" {
+		return name
+	}
+	return model.GuestDonorName
+}
+
+func donorTotalsToPb(ctx context.Context, totals []model.DonorTotal) []*pb.DonorTotal {
+	userIDs := make([]int, 0, len(totals))
+	for _, total := range totals {
+		if !total.IsAnonymous {
+			userIDs = append(userIDs, total.UserID)
+		}
+	}
+	names := donorNames(ctx, userIDs)
+
+	donors := make([]*pb.DonorTotal, 0, len(totals))
+	for _, total := range totals {
+		userID := total.UserID
+		if total.IsAnonymous {
+			userID = 0
+		}
+		donors = append(donors, &pb.DonorTotal{
+			UserId:        int32(userID),
+			DonorName:     publicDonorName(total.UserID, total.IsAnonymous, names),
+			IsAnonymous:   total.IsAnonymous,
+			TotalAmount:   float32(total.TotalAmount),
+			DonationCount: int32(total.DonationCount),
+		})
+	}
+	return donors
+}
+
+// topDonors sums completed donations per donor, paid since since unless it is
+// zero. Anonymous donations are kept
+// apart from named ones of the same donor so the total does not reveal them.
+func topDonors(ctx context.Context, campaignID int, since time.Time, limit int) ([]model.DonorTotal, error) {
+	var totals []model.DonorTotal
+	query := config.DB.WithContext(ctx).Model(&model.Donation{}).
+		Select("COALESCE(user_id, 0) AS user_id, " +
+			"CASE WHEN user_id IS NULL THEN LOWER(guest_email) ELSE '' END AS guest_email, " +
+			"is_anonymous, SUM(amount) AS total_amount, COUNT(*) AS donation_count").
+		Where(completedDonations)
+	if campaignID != 0 {
+		query = query.Where("campaign_id = ?", campaignID)
+	}
+	if !since.IsZero() {
+		query = paidSince(query, since)
+	}
+	err := query.Group("1, 2, 3").Order("total_amount DESC").Limit(limit).Scan(&totals).Error
+	return totals, err
+}
+
+// GetCampaignDonations returns the completed donations of a campaign, newest
+// first, for the public donor wall.
+func (s *DonationService) GetCampaignDonations(ctx context.Context, req *pb.CampaignDonationsRequest) (*pb.CampaignDonationsResponse, error) {
+	if req.GetCampaignId() <= 0 {
+		return nil, invalidField("campaign_id", "campaign ID is required")
+	}
+
+	page := int(req.GetPage())
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := clampLimit(req.GetPageSize(), defaultPageSize, maxPageSize)
+
+	query := config.DB.WithContext(ctx).Model(&model.Donation{}).
+		Where("campaign_id = ?", req.GetCampaignId()).
+		Where(completedDonations)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	var donations []model.Donation
+	if err := query.Order("created_at DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&donations).Error; err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	userIDs := make([]int, 0, len(donations))
+	for _, donation := range donations {
+		if !donation.IsAnonymous {
+			userIDs = append(userIDs, donation.UserID)
+		}
+	}
+	names := donorNames(ctx, userIDs)
+
+	response := &pb.CampaignDonationsResponse{
+		Donations: make([]*pb.PublicDonation, 0, len(donations)),
+		Page:      int32(page),
+		PageSize:  int32(pageSize),
+		Total:     total,
+	}
+
+	for _, donation := range donations {
+		donorName := publicDonorName(donation.UserID, donation.IsAnonymous, names)
+		donation.MaskDonor()
+		response.Donations = append(response.Donations, &pb.PublicDonation{
+			Id:          int32(donation.ID),
+			CampaignId:  int32(donation.CampaignID),
+			UserId:      int32(donation.UserID),
+			DonorName:   donorName,
+			Amount:      float32(donation.Amount),
+			Message:     donation.Message,
+			IsAnonymous: donation.IsAnonymous,
+			CreatedAt:   donation.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return response, nil
+}
+
+// GetCampaignTopDonors returns the donors that gave the most to a campaign.
+func (s *DonationService) GetCampaignTopDonors(ctx context.Context, req *pb.CampaignTopDonorsRequest) (*pb.TopDonorsResponse, error) {
+	if req.GetCampaignId() <= 0 {
+		return nil, invalidField("campaign_id", "campaign ID is required")
+	}
+
+	totals, err := topDonors(ctx, int(req.GetCampaignId()), time.Time{}, clampLimit(req.GetLimit(), defaultBoardLimit, maxBoardLimit))
+	if err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	return &pb.TopDonorsResponse{Donors: donorTotalsToPb(ctx, totals)}, nil
+}
+
+// GetLeaderboard returns the top donors and top campaigns over all campaigns
+// for the requested time window.
+func (s *DonationService) GetLeaderboard(ctx context.Context, req *pb.LeaderboardRequest) (*pb.LeaderboardResponse, error) {
+	window := req.GetWindow()
+	if window == "" {
+		window = "all"
+	}
+	duration, ok := leaderboardWindows[window]
+	if !ok {
+		return nil, invalidField("window", "window must be one of day, week, month or all")
+	}
+
+	var since time.Time
+	if duration > 0 {
+		since = time.Now().Add(-duration)
+	}
+	limit := clampLimit(req.GetLimit(), defaultBoardLimit, maxBoardLimit)
+
+	donorTotals, err := topDonors(ctx, 0, since, limit)
+	if err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	var campaignTotals []model.CampaignTotal
+	query := config.DB.WithContext(ctx).Model(&model.Donation{}).
+		Select("campaign_id, SUM(amount) AS total_amount, COUNT(*) AS donation_count, " +
+			"COUNT(DISTINCT COALESCE(user_id::text, LOWER(guest_email))) AS donor_count").
+		Where(completedDonations)
+	if !since.IsZero() {
+		query = paidSince(query, since)
+	}
+	if err := query.Group("campaign_id").Order("total_amount DESC").Limit(limit).Scan(&campaignTotals).Error; err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	response := &pb.LeaderboardResponse{
+		Window:       window,
+		TopDonors:    donorTotalsToPb(ctx, donorTotals),
+		TopCampaigns: make([]*pb.CampaignTotal, 0, len(campaignTotals)),
+	}
+	for _, total := range campaignTotals {
+		response.TopCampaigns = append(response.TopCampaigns, &pb.CampaignTotal{
+			CampaignId:    int32(total.CampaignID),
+			TotalAmount:   float32(total.TotalAmount),
+			DonationCount: int32(total.DonationCount),
+			DonorCount:    int32(total.DonorCount),
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/payment"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+	"github.com/rayhanadri/crowdfunding/donation-service/receipt"
+)
+
+// errReceiptExists stops the receipt transaction when another path issued
+// the receipt while this one waited for the sequence lock.
+var errReceiptExists = errors.New("receipt already issued")
+
+// receiptDonor returns the name and email printed on the receipt. Anonymous
+// donations still carry the real donor, the receipt is only given to them.
+func receiptDonor(ctx context.Context, donation *model.Donation) (name string, email string, err error) {
+	if donation.UserID == 0 {
+		return model.GuestDonorName, donation.GuestEmail, nil
+	}
+
+	userModel, err := GetUserByID(ctx, int32(donation.UserID))
+	if err != nil {
+		return "", "", err
+	}
+	return userModel.Name, userModel.Email, nil
+}
+
+// issueReceipt creates the receipt of a paid transaction, or returns the one
+// already issued for the donation. The yearly sequence row is locked until the
+// receipt is stored, so numbers stay gap-free even when the insert fails.
+func issueReceipt(ctx context.Context, transaction *model.Transaction, donation *model.Donation) (*model.Receipt, error) {
+	var existing model.Receipt
+	err := config.DB.WithContext(ctx).Where("donation_id = ?", donation.ID).First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	donorName, donorEmail, err := receiptDonor(ctx, donation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get donor: %w", err)
+	}
+
+	campaignModel, err := GetCampaignByID(ctx, strconv.Itoa(donation.CampaignID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	paidAt := transaction.UpdatedAt
+	if transaction.PaidAt != nil {
+		paidAt = *transaction.PaidAt
+	}
+
+	record := &model.Receipt{
+		Year:          receipt.Year(paidAt),
+		DonationID:    donation.ID,
+		TransactionID: transaction.ID,
+		DonorName:     donorName,
+		DonorEmail:    donorEmail,
+		CampaignID:    donation.CampaignID,
+		CampaignTitle: campaignModel.Title,
+		Amount:        transaction.Amount,
+		PaymentMethod: payment.Label(transaction.PaymentMethod, transaction.PaymentChannel),
+		InvoiceID:     transaction.InvoiceID,
+		PaidAt:        paidAt,
+		CreatedAt:     time.Now(),
+	}
+
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("INSERT INTO donations.receipt_sequences (year, last_value) VALUES (?, 0) ON CONFLICT (year) DO NOTHING", record.Year).Error; err != nil {
+			return err
+		}
+
+		var sequence model.ReceiptSequence
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("year = ?", record.Year).First(&sequence).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&model.Receipt{}).Where("donation_id = ?", donation.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errReceiptExists
+		}
+
+		sequence.LastValue++
+		if err := tx.Model(&sequence).Update("last_value", sequence.LastValue).Error; err != nil {
+			return err
+		}
+
+		record.Sequence = sequence.LastValue
+		record.Number = model.ReceiptNumber(record.Year, record.Sequence)
+		record.PDF = receipt.RenderDonation(record)
+		return tx.Create(record).Error
+	})
+	if errors.Is(err, errReceiptExists) {
+		if err := config.DB.WithContext(ctx).Where("donation_id = ?", donation.ID).First(&existing).Error; err != nil {
+			return nil, err
+		}
+		return &existing, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue receipt: %w", err)
+	}
+
+	slog.InfoContext(ctx, "issued receipt", "receipt_number", record.Number, "donation_id", donation.ID)
+	return record, nil
+}
+
+// ownsDonation reports whether the caller of a receipt request is the donor:
+// the user the donation belongs to, or the guest who made it while it is
+// unclaimed.
+func ownsDonation(req *pb.DonationReceiptRequest, donation *model.Donation) bool {
+	if req.GetUserId() != 0 {
+		return donation.UserID == int(req.GetUserId())
+	}
+	return donation.UserID == 0 && donation.GuestEmail != "" && strings.EqualFold(donation.GuestEmail, strings.TrimSpace(req.GetGuestEmail()))
+}
+
+// GetDonationReceipt returns the PDF receipt of a paid donation to its donor.
+// A receipt that failed to be issued at settlement is issued now. Callers
+// other than the donor are told the donation does not exist, whatever its
+// status, and never use up a receipt number.
+func (r *DonationService) GetDonationReceipt(ctx context.Context, req *pb.DonationReceiptRequest) (*pb.ReceiptResponse, error) {
+	if req.GetUserId() == 0 && (req.GetGuestEmail() == "" || req.GetInvoiceId() == "") {
+		return nil, invalidField("user_id", "user ID, or guest email and invoice ID, are required")
+	}
+
+	var donation model.Donation
+	if err := config.DB.WithContext(ctx).First(&donation, req.GetDonationId()).Error; err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	notFound := apperror.FromDB(gorm.ErrRecordNotFound, "donation")
+	if !ownsDonation(req, &donation) {
+		return nil, notFound
+	}
+	if req.GetUserId() == 0 {
+		// guests prove they paid with an invoice they were given
+		var count int64
+		if err := config.DB.WithContext(ctx).Model(&model.Transaction{}).Where("donation_id = ? AND invoice_id = ?", donation.ID, req.GetInvoiceId()).Count(&count).Error; err != nil {
+			return nil, apperror.FromDB(err, "transaction")
+		}
+		if count == 0 {
+			return nil, notFound
+		}
+	}
+
+	if donation.Status != model.DonationCompleted {
+		return nil, apperror.FailedPrecondition(ReasonDonationNotPaid, "donation is not paid yet")
+	}
+
+	var transaction model.Transaction
+	if err := config.DB.WithContext(ctx).Where("donation_id = ? AND status IN ?", donation.ID, []model.TransactionStatus{model.TransactionPaid, model.TransactionSettled}).First(&transaction).Error; err != nil {
+		return nil, apperror.FromDB(err, "transaction")
+	}
+
+	record, err := issueReceipt(ctx, &transaction, &donation)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	response := &pb.ReceiptResponse{
+		Message:       "Receipt retrieved successfully",
+		ReceiptNumber: record.Number,
+		DonationId:    int32(donation.ID),
+		UserId:        int32(donation.UserID),
+		FileName:      record.FileName(),
+		Pdf:           record.PDF,
+		IssuedAt:      record.CreatedAt.Format(time.RFC3339),
+	}
+
+	return response, nil
+}
+
+// GetAnnualReceipt renders the summary of the receipts issued to a donor in a
+// year. It is rendered on request from the stored receipts and has no number
+// of its own.
+func (r *DonationService) GetAnnualReceipt(ctx context.Context, req *pb.AnnualReceiptRequest) (*pb.ReceiptResponse, error) {
+	userID := int(req.GetUserId())
+	year := int(req.GetYear())
+	if userID == 0 {
+		return nil, invalidField("user_id", "user ID is required")
+	}
+	if year < 2000 || year > time.Now().Year() {
+		return nil, invalidField("year", fmt.Sprintf("year must be between 2000 and %d", time.Now().Year()))
+	}
+
+	summary := &model.AnnualReceipt{UserID: userID, Year: year}
+	err := config.DB.WithContext(ctx).Omit("pdf").
+		Where("year = ?", year).
+		Where("donation_id IN (?)", config.DB.WithContext(ctx).Model(&model.Donation{}).Select("id").Where("user_id = ?", userID)).
+		Order("sequence").
+		Find(&summary.Receipts).Error
+	if err != nil {
+		return nil, apperror.FromDB(err, "receipt")
+	}
+
+	for _, record := range summary.Receipts {
+		summary.Total += record.Amount
+	}
+
+	userModel, err := GetUserByID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+	summary.DonorName = userModel.Name
+	summary.DonorEmail = userModel.Email
+
+	issuedAt := time.Now()
+	response := &pb.ReceiptResponse{
+		Message:  "Annual receipt retrieved successfully",
+		UserId:   int32(userID),
+		FileName: summary.FileName(),
+		Pdf:      receipt.RenderAnnual(summary, issuedAt),
+		IssuedAt: issuedAt.Format(time.RFC3339),
+	}
+
+	return response, nil
+}
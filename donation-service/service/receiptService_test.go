@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+	"github.com/rayhanadri/crowdfunding/donation-service/receipt"
+)
+
+func TestOwnsDonation(t *testing.T) {
+	registered := &model.Donation{UserID: 7}
+	guest := &model.Donation{GuestEmail: "guest@example.com"}
+	claimed := &model.Donation{UserID: 7, GuestEmail: "guest@example.com"}
+
+	tests := []struct {
+		name     string
+		req      *pb.DonationReceiptRequest
+		donation *model.Donation
+		want     bool
+	}{
+		{"donor", &pb.DonationReceiptRequest{UserId: 7}, registered, true},
+		{"another user", &pb.DonationReceiptRequest{UserId: 8}, registered, false},
+		{"guest", &pb.DonationReceiptRequest{GuestEmail: "Guest@Example.com "}, guest, true},
+		{"another guest", &pb.DonationReceiptRequest{GuestEmail: "other@example.com"}, guest, false},
+		{"guest email of a registered donation", &pb.DonationReceiptRequest{GuestEmail: ""}, registered, false},
+		{"guest after the claim", &pb.DonationReceiptRequest{GuestEmail: "guest@example.com"}, claimed, false},
+		{"user who claimed it", &pb.DonationReceiptRequest{UserId: 7}, claimed, true},
+		{"user asking for a guest donation", &pb.DonationReceiptRequest{UserId: 7}, guest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ownsDonation(tt.req, tt.donation); got != tt.want {
+				t.Errorf("ownsDonation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReceiptSequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		paidAt   time.Time
+		sequence int
+		want     string
+	}{
+		{"first of the year", time.Date(2025, 3, 1, 5, 0, 0, 0, time.UTC), 1, "RCP-2025-000001"},
+		{"new year in Jakarta before UTC", time.Date(2024, 12, 31, 17, 30, 0, 0, time.UTC), 1, "RCP-2025-000001"},
+		{"last hours of the year in Jakarta", time.Date(2024, 12, 31, 16, 59, 0, 0, time.UTC), 4821, "RCP-2024-004821"},
+		{"paid at in another zone", time.Date(2025, 1, 1, 1, 0, 0, 0, time.FixedZone("UTC+10", 10*60*60)), 12, "RCP-2024-000012"},
+		{"beyond six digits", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), 1234567, "RCP-2025-1234567"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := model.ReceiptNumber(receipt.Year(tt.paidAt), tt.sequence); got != tt.want {
+				t.Errorf("receipt number = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/outbox"
+	"github.com/rayhanadri/crowdfunding/donation-service/payment"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+	"github.com/rayhanadri/crowdfunding/donation-service/webhook"
+)
+
+// Sources of a settlement, sent along with the progress events.
+const (
+	SettledBySync           = "sync"
+	SettledByWebhook        = "webhook"
+	SettledByReconciliation = "reconciliation"
+)
+
+// storeEvent records an event in the outbox and stores its webhook
+// deliveries, in the transaction of the change it describes.
+func storeEvent(tx *gorm.DB, e event.Event) error {
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+	if err := outbox.Record(tx, e); err != nil {
+		return err
+	}
+	return webhook.Enqueue(tx, e)
+}
+
+// fetchPayment gets the payment of a transaction from the provider again.
+func fetchPayment(ctx context.Context, transaction *model.Transaction, paymentID string) (*payment.Payment, error) {
+	return payment.Default.Get(ctx, transaction.PaymentMethod, transaction.InvoiceID, paymentID)
+}
+
+// settlementUpdates returns the status a pending transaction moves to with
+// payment p and the columns to update, or an empty status when there is
+// nothing to settle.
+func settlementUpdates(transaction *model.Transaction, p *payment.Payment, now time.Time) (model.TransactionStatus, map[string]interface{}, error) {
+	next := model.TransactionStatus(p.Status)
+	if transaction.Status != model.TransactionPending || next == model.TransactionPending || next == "" {
+		return "", nil, nil
+	}
+	if !transaction.Status.CanTransitionTo(next) {
+		return "", nil, fmt.Errorf("unexpected payment status %q", p.Status)
+	}
+
+	updates := map[string]interface{}{
+		"status":     next,
+		"updated_at": now,
+		"version":    optimistic.Increment,
+	}
+	if p.Description != "" {
+		updates["invoice_description"] = p.Description
+	}
+	if p.Paid() {
+		// keep the method the donor actually paid with, the receipt shows it
+		paidAt := p.PaidAt
+		if paidAt.IsZero() {
+			paidAt = now
+		}
+		updates["paid_at"] = paidAt
+		if p.Method != "" {
+			updates["payment_method"] = p.Method
+			updates["payment_channel"] = p.Channel
+		}
+	}
+	return next, updates, nil
+}
+
+// settleTransaction applies the payment status to a pending transaction. It is
+// the only place a payment is settled, whether it comes from a manual sync,
+// a provider callback or the reconciler. The status change is made with a
+// conditional update, so when two paths race only one of them settles. It is
+// also the only place PAID, SETTLED and COMPLETED are set.
+//
+// The transaction, the donation, the campaign credit and the settled event
+// are written in one database transaction. Campaign-service is only called
+// after it commits, a failed call is retried by the campaign credits.
+func settleTransaction(ctx context.Context, transaction *model.Transaction, p *payment.Payment, source string) error {
+	next, updates, err := settlementUpdates(transaction, p, time.Now())
+	if err != nil || next == "" {
+		return err
+	}
+	paid := p.Paid()
+
+	var donation model.Donation
+	settled := false
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Transaction{}).
+			Where("id = ? AND status = ?", transaction.ID, model.TransactionPending).
+			Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("failed to update transaction: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			// settled by another path in the meantime
+			return nil
+		}
+		settled = true
+
+		if next == model.TransactionExpired {
+			// the donor walked away, reissuing the invoice brings the donation back
+			if err := tx.Model(&model.Donation{}).
+				Where("id = ? AND status = ?", transaction.DonationID, model.DonationPending).
+				Updates(map[string]interface{}{"status": model.DonationAbandoned, "version": optimistic.Increment}).Error; err != nil {
+				return fmt.Errorf("failed to update donation: %w", err)
+			}
+		}
+
+		if !paid {
+			return nil
+		}
+
+		// Update the donation status to "COMPLETED"
+		if err := tx.First(&donation, transaction.DonationID).Error; err != nil {
+			return fmt.Errorf("failed to get donation: %w", err)
+		}
+		if !donation.Status.CanTransitionTo(model.DonationCompleted) {
+			return fmt.Errorf("donation %d cannot be completed from %s", donation.ID, donation.Status)
+		}
+		result = tx.Model(&donation).
+			Where("status = ?", donation.Status).
+			Updates(map[string]interface{}{"status": model.DonationCompleted, "version": optimistic.Increment})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update donation: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("donation %d changed status while settling", donation.ID)
+		}
+		donation.Status = model.DonationCompleted
+		donation.Version++
+
+		credit := &model.CampaignCredit{
+			DonationID:    donation.ID,
+			CampaignID:    donation.CampaignID,
+			Amount:        donation.Amount,
+			Source:        source,
+			NextAttemptAt: time.Now(),
+		}
+		if err := tx.Create(credit).Error; err != nil {
+			return fmt.Errorf("failed to store campaign credit: %w", err)
+		}
+
+		return storeEvent(tx, event.Event{
+			Type:          event.DonationSettled,
+			CampaignID:    donation.CampaignID,
+			DonationID:    donation.ID,
+			TransactionID: transaction.ID,
+			UserID:        donation.UserID,
+			GuestEmail:    donation.GuestEmail,
+			IsAnonymous:   donation.IsAnonymous,
+			Amount:        donation.Amount,
+			Source:        source,
+		})
+	})
+	if err != nil || !settled {
+		return err
+	}
+
+	transaction.Status = next
+	if paidAt, ok := updates["paid_at"].(time.Time); ok {
+		transaction.PaidAt = &paidAt
+	}
+	if method, ok := updates["payment_method"].(string); ok {
+		transaction.PaymentMethod = method
+		transaction.PaymentChannel = updates["payment_channel"].(string)
+	}
+	recordSettlement(string(next), source, transaction.PaidAt)
+
+	if !paid {
+		return nil
+	}
+
+	// credited right away when campaign-service answers, later otherwise
+	if err := creditCampaign(ctx, donation.ID); err != nil {
+		slog.ErrorContext(ctx, "failed to credit campaign", "donation_id", donation.ID, "error", err)
+	}
+
+	// The payment is settled either way, a missing receipt is issued again
+	// when the donor asks for it.
+	if _, err := issueReceipt(ctx, transaction, &donation); err != nil {
+		slog.ErrorContext(ctx, "failed to issue receipt", "donation_id", donation.ID, "error", err)
+	}
+
+	return nil
+}
+
+// fundsCampaign reports whether raising the collected amount of a campaign
+// from before to after crosses its target.
+func fundsCampaign(before int32, after int32, target int32) bool {
+	return target > 0 && before < target && after >= target
+}
+
+// creditBackoff doubles the wait after every failed credit, from 30 seconds
+// up to an hour. Credits are never given up, the money was raised.
+func creditBackoff(attempts int) time.Duration {
+	wait := 30 * time.Second << (attempts - 1)
+	if wait > time.Hour || wait <= 0 {
+		return time.Hour
+	}
+	return wait
+}
+
+// creditCampaign adds a settled donation to the collected amount of its
+// campaign, and records the funded event when it crosses the target. The
+// credit stays locked during the call so two instances never add it at once,
+// a failed call is kept for the next attempt.
+func creditCampaign(ctx context.Context, donationID int) error {
+	return config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var credit model.CampaignCredit
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("donation_id = ? AND credited_at IS NULL", donationID).
+			First(&credit).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// credited already, or by another instance right now
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		before, after, target, err := addToCampaign(ctx, &credit)
+		now := time.Now()
+		if err != nil {
+			credit.Attempts++
+			credit.LastError = err.Error()
+			credit.NextAttemptAt = now.Add(creditBackoff(credit.Attempts))
+			slog.WarnContext(ctx, "campaign credit failed", "donation_id", credit.DonationID, "campaign_id", credit.CampaignID, "attempts", credit.Attempts, "error", err)
+			return tx.Save(&credit).Error
+		}
+
+		credit.CreditedAt = &now
+		credit.LastError = ""
+		if err := tx.Save(&credit).Error; err != nil {
+			return err
+		}
+
+		// the donation that crosses the target funds the campaign
+		if !fundsCampaign(before, after, target) {
+			return nil
+		}
+		return storeEvent(tx, event.Event{
+			Type:       event.CampaignFunded,
+			CampaignID: credit.CampaignID,
+			DonationID: credit.DonationID,
+			Amount:     float64(after),
+			Source:     credit.Source,
+		})
+	})
+}
+
+// addToCampaign raises the collected amount of the campaign of a credit, it
+// returns the amount before and after, and the target.
+func addToCampaign(ctx context.Context, credit *model.CampaignCredit) (int32, int32, int32, error) {
+	campaignModel, err := GetCampaignByID(ctx, strconv.Itoa(credit.CampaignID))
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to get campaign: %w", err)
+	}
+
+	before := campaignModel.CollectedAmount
+	campaignModel.CollectedAmount += int32(credit.Amount)
+	campaignModel.UpdatedAt = time.Now()
+	if _, err := UpdateCampaignByID(ctx, campaignModel.ID, int32(campaignModel.UserID), campaignModel.Title, campaignModel.Description, int32(campaignModel.TargetAmount), campaignModel.Deadline, campaignModel.Status, campaignModel.Category, int32(campaignModel.MinDonation)); err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to update campaign: %w", err)
+	}
+	return before, campaignModel.CollectedAmount, campaignModel.TargetAmount, nil
+}
+
+// CreditCampaigns retries the campaign credits that are due.
+func CreditCampaigns(ctx context.Context) {
+	var donationIDs []int
+	err := config.DB.WithContext(ctx).Model(&model.CampaignCredit{}).
+		Where("credited_at IS NULL AND next_attempt_at <= ?", time.Now()).
+		Order("next_attempt_at").
+		Limit(100).
+		Pluck("donation_id", &donationIDs).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get campaign credits to retry", "error", err)
+		return
+	}
+
+	for _, donationID := range donationIDs {
+		if err := creditCampaign(ctx, donationID); err != nil {
+			slog.ErrorContext(ctx, "failed to credit campaign", "donation_id", donationID, "error", err)
+		}
+	}
+}
+
+// HandleInvoiceCallback settles the transaction of a payment Xendit told us
+// about, whatever its method. The callback body is not trusted, the payment is
+// fetched again.
+func (r *DonationService) HandleInvoiceCallback(ctx context.Context, req *pb.InvoiceCallbackRequest) (*pb.TransactionResponse, error) {
+	// reserved transactions have no invoice ID yet
+	if req.GetInvoiceId() == "" {
+		return nil, invalidField("invoice_id", "invoice ID is required")
+	}
+
+	var transaction model.Transaction
+	if err := config.DB.WithContext(ctx).Where("invoice_id = ?", req.GetInvoiceId()).First(&transaction).Error; err != nil {
+		return nil, apperror.FromDB(err, "transaction")
+	}
+
+	if transaction.Status == model.TransactionPending {
+		p, err := fetchPayment(ctx, &transaction, req.GetPaymentId())
+		if err != nil {
+			return nil, paymentProviderError(err)
+		}
+
+		if err := settleTransaction(ctx, &transaction, p, SettledByWebhook); err != nil {
+			return nil, apperror.Internal(err)
+		}
+	}
+
+	response := &pb.TransactionResponse{
+		Message:    "Invoice callback handled",
+		Id:         int32(transaction.ID),
+		DonationId: int32(transaction.DonationID),
+		InvoiceId:  transaction.InvoiceID,
+		Amount:     float32(transaction.Amount),
+		Status:     transaction.Status.ToPb(),
+	}
+
+	return response, nil
+}
+
+// ReconcilePendingTransactions checks every pending payment with Xendit and
+// settles the ones that changed, in case a callback never arrived. Reserved
+// transactions that never got their payment, because the service stopped
+// before opening it, fail.
+func ReconcilePendingTransactions(ctx context.Context) {
+	var reserved []model.Transaction
+	if err := config.DB.WithContext(ctx).
+		Where("status = ? AND invoice_id = '' AND created_at < ?", model.TransactionPending, time.Now().Add(-staleReservation)).
+		Find(&reserved).Error; err != nil {
+		slog.ErrorContext(ctx, "reconciliation failed to get reserved transactions", "error", err)
+	}
+	for i := range reserved {
+		failReservation(ctx, &reserved[i])
+	}
+
+	var transactions []model.Transaction
+	if err := config.DB.WithContext(ctx).Where("status = ? AND invoice_id <> ''", model.TransactionPending).Find(&transactions).Error; err != nil {
+		slog.ErrorContext(ctx, "reconciliation failed to get pending transactions", "error", err)
+		return
+	}
+
+	for i := range transactions {
+		p, err := fetchPayment(ctx, &transactions[i], "")
+		if err != nil {
+			slog.WarnContext(ctx, "reconciliation failed to get payment", "invoice_id", transactions[i].InvoiceID, "error", err)
+			continue
+		}
+		if err := settleTransaction(ctx, &transactions[i], p, SettledByReconciliation); err != nil {
+			slog.ErrorContext(ctx, "reconciliation failed to settle transaction", "transaction_id", transactions[i].ID, "error", err)
+		}
+	}
+}
+
+// StartCampaignCredits runs CreditCampaigns every interval until the context
+// is cancelled.
+func StartCampaignCredits(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				CreditCampaigns(ctx)
+			}
+		}
+	}()
+}
+
+// StartReconciler runs ReconcilePendingTransactions every interval until the
+// context is cancelled.
+func StartReconciler(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ReconcilePendingTransactions(ctx)
+			}
+		}
+	}()
+}
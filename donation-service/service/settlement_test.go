@@ -0,0 +1,145 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/payment"
+)
+
+func TestSettlementUpdates(t *testing.T) {
+	now := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	paidAt := time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		status  model.TransactionStatus
+		payment payment.Payment
+		next    model.TransactionStatus
+		paidAt  interface{}
+		method  interface{}
+		wantErr bool
+	}{
+		{
+			name:    "paid with the time and method of the provider",
+			status:  model.TransactionPending,
+			payment: payment.Payment{Status: payment.StatusPaid, PaidAt: paidAt, Method: "VIRTUAL_ACCOUNT", Channel: "BCA"},
+			next:    model.TransactionPaid,
+			paidAt:  paidAt,
+			method:  "VIRTUAL_ACCOUNT",
+		},
+		{
+			name:    "settled without a payment time",
+			status:  model.TransactionPending,
+			payment: payment.Payment{Status: payment.StatusSettled},
+			next:    model.TransactionSettled,
+			paidAt:  now,
+		},
+		{
+			name:    "expired",
+			status:  model.TransactionPending,
+			payment: payment.Payment{Status: payment.StatusExpired},
+			next:    model.TransactionExpired,
+		},
+		{
+			name:    "still pending",
+			status:  model.TransactionPending,
+			payment: payment.Payment{Status: payment.StatusPending},
+		},
+		{
+			name:    "no status",
+			status:  model.TransactionPending,
+			payment: payment.Payment{},
+		},
+		{
+			name:    "settled already",
+			status:  model.TransactionPaid,
+			payment: payment.Payment{Status: payment.StatusSettled},
+		},
+		{
+			name:    "unknown status",
+			status:  model.TransactionPending,
+			payment: payment.Payment{Status: "REFUNDED"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &model.Transaction{Status: tt.status}
+			next, updates, err := settlementUpdates(transaction, &tt.payment, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, want error %v", err, tt.wantErr)
+			}
+			if next != tt.next {
+				t.Fatalf("next = %q, want %q", next, tt.next)
+			}
+			if next == "" {
+				if updates != nil {
+					t.Errorf("updates = %v, want none", updates)
+				}
+				return
+			}
+			if updates["status"] != tt.next {
+				t.Errorf("status = %v, want %q", updates["status"], tt.next)
+			}
+			if !reflect.DeepEqual(updates["version"], optimistic.Increment) {
+				t.Errorf("version = %v, want an increment", updates["version"])
+			}
+			if updates["paid_at"] != tt.paidAt {
+				t.Errorf("paid_at = %v, want %v", updates["paid_at"], tt.paidAt)
+			}
+			if updates["payment_method"] != tt.method {
+				t.Errorf("payment_method = %v, want %v", updates["payment_method"], tt.method)
+			}
+			if transaction.Status != tt.status {
+				t.Errorf("transaction changed to %q before the update committed", transaction.Status)
+			}
+		})
+	}
+}
+
+func TestFundsCampaign(t *testing.T) {
+	tests := []struct {
+		name                  string
+		before, after, target int32
+		want                  bool
+	}{
+		{"crosses the target", 900, 1000, 1000, true},
+		{"goes past the target", 900, 1500, 1000, true},
+		{"below the target", 100, 900, 1000, false},
+		{"funded before", 1000, 1200, 1000, false},
+		{"no target", 0, 500, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fundsCampaign(tt.before, tt.after, tt.target); got != tt.want {
+				t.Errorf("fundsCampaign(%d, %d, %d) = %v, want %v", tt.before, tt.after, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreditBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{5, 8 * time.Minute},
+		{8, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := creditBackoff(tt.attempts); got != tt.want {
+			t.Errorf("creditBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+	_ "time/tzdata" // timezones of the series, the image has no zoneinfo
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+const (
+	dateLayout         = "2006-01-02"
+	defaultSeriesDays  = 30
+	defaultSeriesWeeks = 12
+	maxSeriesBuckets   = 366
+)
+
+// checkCampaignOwner lets admins and the owner of the campaign through.
+func checkCampaignOwner(ctx context.Context, campaignID int32, userID int32, isAdmin bool) error {
+	if campaignID <= 0 {
+		return invalidField("campaign_id", "campaign ID is required")
+	}
+	if isAdmin {
+		return nil
+	}
+	campaignModel, err := GetCampaignByID(ctx, strconv.Itoa(int(campaignID)))
+	if err != nil {
+		return err
+	}
+	if userID == 0 || campaignModel.UserID != userID {
+		return apperror.PermissionDenied(ReasonCampaignNotOwned, "campaign is not owned by the user")
+	}
+	return nil
+}
+
+// gift is a completed donation, donors are told apart like the top donors,
+// by user or by guest email.
+type gift struct {
+	Amount float64
+	Donor  string
+}
+
+// summarizeGifts computes the stats of a campaign from its gifts. The median
+// of an even number of gifts is the mean of the middle two.
+func summarizeGifts(campaignID int, gifts []gift) *model.CampaignStats {
+	stats := &model.CampaignStats{CampaignID: campaignID, DonationCount: len(gifts)}
+	if len(gifts) == 0 {
+		return stats
+	}
+
+	amounts := make([]float64, len(gifts))
+	giftsByDonor := make(map[string]int)
+	for i, g := range gifts {
+		amounts[i] = g.Amount
+		stats.TotalRaised += g.Amount
+		giftsByDonor[g.Donor]++
+	}
+	stats.AverageDonation = stats.TotalRaised / float64(len(gifts))
+
+	slices.Sort(amounts)
+	middle := len(amounts) / 2
+	stats.MedianDonation = amounts[middle]
+	if len(amounts)%2 == 0 {
+		stats.MedianDonation = (amounts[middle-1] + amounts[middle]) / 2
+	}
+
+	repeatDonors := 0
+	for _, count := range giftsByDonor {
+		if count > 1 {
+			repeatDonors++
+		}
+	}
+	stats.DonorCount = len(giftsByDonor)
+	stats.RepeatDonorRate = float64(repeatDonors) / float64(stats.DonorCount)
+	return stats
+}
+
+// campaignStats sums up the completed donations of a campaign.
+func campaignStats(ctx context.Context, campaignID int) (*model.CampaignStats, error) {
+	var gifts []gift
+	err := config.DB.WithContext(ctx).Model(&model.Donation{}).
+		Select("amount, COALESCE(user_id::text, LOWER(guest_email)) AS donor").
+		Where("campaign_id = ?", campaignID).
+		Where(completedDonations).
+		Scan(&gifts).Error
+	if err != nil {
+		return nil, err
+	}
+	return summarizeGifts(campaignID, gifts), nil
+}
+
+// GetCampaignStats returns the totals of a campaign to its owner or an admin.
+func (s *DonationService) GetCampaignStats(ctx context.Context, req *pb.CampaignStatsRequest) (*pb.CampaignStatsResponse, error) {
+	if err := checkCampaignOwner(ctx, req.GetCampaignId(), req.GetUserId(), req.GetIsAdmin()); err != nil {
+		return nil, err
+	}
+
+	stats, err := campaignStats(ctx, int(req.GetCampaignId()))
+	if err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	return &pb.CampaignStatsResponse{
+		CampaignId:      int32(stats.CampaignID),
+		TotalRaised:     stats.TotalRaised,
+		DonationCount:   int32(stats.DonationCount),
+		DonorCount:      int32(stats.DonorCount),
+		AverageDonation: stats.AverageDonation,
+		MedianDonation:  stats.MedianDonation,
+		RepeatDonorRate: stats.RepeatDonorRate,
+	}, nil
+}
+
+// bucketStart is the start of the day, or of the week from Monday, holding t.
+func bucketStart(t time.Time, interval string) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if interval == "week" {
+		start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	}
+	return start
+}
+
+// nextBucket is the start of the bucket after start. Days are added on the
+// calendar, they are not always 24 hours long.
+func nextBucket(start time.Time, interval string) time.Time {
+	if interval == "week" {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// seriesRange parses the dates of req in loc, it returns the start of the
+// first bucket and the end of the last day.
+func seriesRange(req *pb.DonationTimeSeriesRequest, interval string, loc *time.Location) (time.Time, time.Time, error) {
+	to := bucketStart(time.Now().In(loc), "day")
+	if req.GetTo() != "" {
+		parsed, err := time.ParseInLocation(dateLayout, req.GetTo(), loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, invalidField("to", "to must be a date, YYYY-MM-DD")
+		}
+		to = parsed
+	}
+
+	var from time.Time
+	if req.GetFrom() != "" {
+		parsed, err := time.ParseInLocation(dateLayout, req.GetFrom(), loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, invalidField("from", "from must be a date, YYYY-MM-DD")
+		}
+		from = parsed
+	} else if interval == "week" {
+		from = to.AddDate(0, 0, -7*(defaultSeriesWeeks-1))
+	} else {
+		from = to.AddDate(0, 0, -(defaultSeriesDays - 1))
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, invalidField("from", "from must not be after to")
+	}
+
+	from = bucketStart(from, interval)
+	end := to.AddDate(0, 0, 1)
+	buckets := 0
+	for start := from; start.Before(end); start = nextBucket(start, interval) {
+		if buckets++; buckets > maxSeriesBuckets {
+			return time.Time{}, time.Time{}, invalidField("from", "the range must not have more than 366 buckets")
+		}
+	}
+	return from, end, nil
+}
+
+// seriesRow is the total of a bucket, Start is the wall clock of midnight in
+// the timezone of the series.
+type seriesRow struct {
+	Start         time.Time
+	TotalAmount   float64
+	DonationCount int
+}
+
+// fillBuckets lays out the buckets between from and end with the totals of
+// rows, empty buckets included.
+func fillBuckets(rows []seriesRow, interval string, from time.Time, end time.Time) []model.TimeSeriesBucket {
+	// the database answers with the wall clock of loc, keyed here by date
+	totals := make(map[string]model.TimeSeriesBucket, len(rows))
+	for _, row := range rows {
+		totals[row.Start.Format(dateLayout)] = model.TimeSeriesBucket{
+			TotalAmount:   row.TotalAmount,
+			DonationCount: row.DonationCount,
+		}
+	}
+
+	var buckets []model.TimeSeriesBucket
+	for start := from; start.Before(end); start = nextBucket(start, interval) {
+		bucket := totals[start.Format(dateLayout)]
+		bucket.Start = start
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
+
+// donationTimeSeries totals the completed donations of a campaign per bucket
+// of the day they were paid, between from and end. paid_at is UTC without a
+// zone, it is moved to loc before being truncated. A donation paid twice
+// counts on the first payment.
+func donationTimeSeries(ctx context.Context, campaignID int, interval string, loc *time.Location, from time.Time, end time.Time) ([]model.TimeSeriesBucket, error) {
+	var rows []seriesRow
+	err := config.DB.WithContext(ctx).Table("donations.donations AS d").
+		Joins("JOIN "+firstPayments+" ON paid.donation_id = d.id").
+		Select("date_trunc(?, (paid.paid_at AT TIME ZONE 'UTC') AT TIME ZONE ?) AS start, "+
+			"SUM(d.amount) AS total_amount, COUNT(*) AS donation_count", interval, loc.String()).
+		Where("d.campaign_id = ?", campaignID).
+		Where("d."+completedDonations).
+		Where("paid.paid_at >= ? AND paid.paid_at < ?", from.UTC(), end.UTC()).
+		Group("1").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return fillBuckets(rows, interval, from, end), nil
+}
+
+// GetDonationTimeSeries returns the completed donations of a campaign per day
+// or week to its owner or an admin.
+func (s *DonationService) GetDonationTimeSeries(ctx context.Context, req *pb.DonationTimeSeriesRequest) (*pb.DonationTimeSeriesResponse, error) {
+	interval := req.GetInterval()
+	if interval == "" {
+		interval = "day"
+	}
+	if interval != "day" && interval != "week" {
+		return nil, invalidField("interval", "interval must be day or week")
+	}
+	timezone := req.GetTimezone()
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, invalidField("timezone", "timezone must be an IANA timezone, such as Asia/Jakarta")
+	}
+	from, end, err := seriesRange(req, interval, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCampaignOwner(ctx, req.GetCampaignId(), req.GetUserId(), req.GetIsAdmin()); err != nil {
+		return nil, err
+	}
+
+	buckets, err := donationTimeSeries(ctx, int(req.GetCampaignId()), interval, loc, from, end)
+	if err != nil {
+		return nil, apperror.FromDB(err, "donation")
+	}
+
+	response := &pb.DonationTimeSeriesResponse{
+		CampaignId: req.GetCampaignId(),
+		Interval:   interval,
+		Timezone:   loc.String(),
+		Buckets:    make([]*pb.DonationTimeSeriesBucket, 0, len(buckets)),
+	}
+	for _, bucket := range buckets {
+		response.Buckets = append(response.Buckets, &pb.DonationTimeSeriesBucket{
+			Start:         bucket.Start.Format(time.RFC3339),
+			TotalAmount:   bucket.TotalAmount,
+			DonationCount: int32(bucket.DonationCount),
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+)
+
+func TestSummarizeGifts(t *testing.T) {
+	tests := []struct {
+		name  string
+		gifts []gift
+		want  model.CampaignStats
+	}{
+		{
+			name: "no gifts",
+			want: model.CampaignStats{CampaignID: 1},
+		},
+		{
+			name:  "one gift",
+			gifts: []gift{{50000, "1"}},
+			want:  model.CampaignStats{CampaignID: 1, TotalRaised: 50000, DonationCount: 1, DonorCount: 1, AverageDonation: 50000, MedianDonation: 50000},
+		},
+		{
+			name:  "odd count median is the middle gift",
+			gifts: []gift{{300000, "1"}, {10000, "2"}, {50000, "3"}},
+			want:  model.CampaignStats{CampaignID: 1, TotalRaised: 360000, DonationCount: 3, DonorCount: 3, AverageDonation: 120000, MedianDonation: 50000},
+		},
+		{
+			name:  "even count median is the mean of the middle two",
+			gifts: []gift{{10000, "1"}, {40000, "2"}, {20000, "3"}, {1000000, "4"}},
+			want:  model.CampaignStats{CampaignID: 1, TotalRaised: 1070000, DonationCount: 4, DonorCount: 4, AverageDonation: 267500, MedianDonation: 30000},
+		},
+		{
+			name:  "repeat donors, users and guests",
+			gifts: []gift{{10000, "1"}, {10000, "1"}, {20000, "guest@example.com"}, {20000, "guest@example.com"}, {30000, "2"}},
+			want:  model.CampaignStats{CampaignID: 1, TotalRaised: 90000, DonationCount: 5, DonorCount: 3, AverageDonation: 18000, MedianDonation: 20000, RepeatDonorRate: 2.0 / 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := *summarizeGifts(1, tt.gifts)
+			floats := []struct {
+				name      string
+				got, want float64
+			}{
+				{"TotalRaised", got.TotalRaised, tt.want.TotalRaised},
+				{"AverageDonation", got.AverageDonation, tt.want.AverageDonation},
+				{"MedianDonation", got.MedianDonation, tt.want.MedianDonation},
+				{"RepeatDonorRate", got.RepeatDonorRate, tt.want.RepeatDonorRate},
+			}
+			for _, f := range floats {
+				if math.Abs(f.got-f.want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", f.name, f.got, f.want)
+				}
+			}
+			if got.CampaignID != tt.want.CampaignID || got.DonationCount != tt.want.DonationCount || got.DonorCount != tt.want.DonorCount {
+				t.Errorf("summarizeGifts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestBucketStart(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	tests := []struct {
+		name     string
+		t        time.Time
+		interval string
+		want     time.Time
+	}{
+		{"day", time.Date(2025, 6, 4, 15, 30, 0, 0, jakarta), "day", time.Date(2025, 6, 4, 0, 0, 0, 0, jakarta)},
+		{"midnight", time.Date(2025, 6, 4, 0, 0, 0, 0, jakarta), "day", time.Date(2025, 6, 4, 0, 0, 0, 0, jakarta)},
+		// 2025-06-01 is a Sunday, 2025-06-02 a Monday
+		{"monday", time.Date(2025, 6, 2, 9, 0, 0, 0, jakarta), "week", time.Date(2025, 6, 2, 0, 0, 0, 0, jakarta)},
+		{"wednesday", time.Date(2025, 6, 4, 9, 0, 0, 0, jakarta), "week", time.Date(2025, 6, 2, 0, 0, 0, 0, jakarta)},
+		{"sunday ends the week", time.Date(2025, 6, 1, 23, 59, 0, 0, jakarta), "week", time.Date(2025, 5, 26, 0, 0, 0, 0, jakarta)},
+		{"week across the year", time.Date(2026, 1, 1, 9, 0, 0, 0, jakarta), "week", time.Date(2025, 12, 29, 0, 0, 0, 0, jakarta)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bucketStart(tt.t, tt.interval); !got.Equal(tt.want) {
+				t.Errorf("bucketStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextBucket(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	tests := []struct {
+		name     string
+		start    time.Time
+		interval string
+		want     time.Time
+		hours    float64
+	}{
+		{"day", time.Date(2025, 6, 4, 0, 0, 0, 0, berlin), "day", time.Date(2025, 6, 5, 0, 0, 0, 0, berlin), 24},
+		// clocks go forward on 2025-03-30 and back on 2025-10-26 in Berlin
+		{"short day", time.Date(2025, 3, 30, 0, 0, 0, 0, berlin), "day", time.Date(2025, 3, 31, 0, 0, 0, 0, berlin), 23},
+		{"long day", time.Date(2025, 10, 26, 0, 0, 0, 0, berlin), "day", time.Date(2025, 10, 27, 0, 0, 0, 0, berlin), 25},
+		{"week", time.Date(2025, 3, 24, 0, 0, 0, 0, berlin), "week", time.Date(2025, 3, 31, 0, 0, 0, 0, berlin), 167},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextBucket(tt.start, tt.interval)
+			if !got.Equal(tt.want) {
+				t.Errorf("nextBucket() = %v, want %v", got, tt.want)
+			}
+			if hours := got.Sub(tt.start).Hours(); hours != tt.hours {
+				t.Errorf("bucket lasts %v hours, want %v", hours, tt.hours)
+			}
+		})
+	}
+}
+
+func TestSeriesRange(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	tests := []struct {
+		name      string
+		req       *pb.DonationTimeSeriesRequest
+		interval  string
+		wantFrom  time.Time
+		wantEnd   time.Time
+		wantField string
+	}{
+		{
+			name:     "days, both included",
+			req:      &pb.DonationTimeSeriesRequest{From: "2025-06-01", To: "2025-06-07"},
+			interval: "day",
+			wantFrom: time.Date(2025, 6, 1, 0, 0, 0, 0, jakarta),
+			wantEnd:  time.Date(2025, 6, 8, 0, 0, 0, 0, jakarta),
+		},
+		{
+			name:     "weeks start on the monday before from",
+			req:      &pb.DonationTimeSeriesRequest{From: "2025-06-04", To: "2025-06-20"},
+			interval: "week",
+			wantFrom: time.Date(2025, 6, 2, 0, 0, 0, 0, jakarta),
+			wantEnd:  time.Date(2025, 6, 21, 0, 0, 0, 0, jakarta),
+		},
+		{
+			name:     "default days before to",
+			req:      &pb.DonationTimeSeriesRequest{To: "2025-06-30"},
+			interval: "day",
+			wantFrom: time.Date(2025, 6, 1, 0, 0, 0, 0, jakarta),
+			wantEnd:  time.Date(2025, 7, 1, 0, 0, 0, 0, jakarta),
+		},
+		{
+			// 2025-06-30 is a Monday, twelve weeks end with its week
+			name:     "default weeks before to",
+			req:      &pb.DonationTimeSeriesRequest{To: "2025-06-30"},
+			interval: "week",
+			wantFrom: time.Date(2025, 4, 14, 0, 0, 0, 0, jakarta),
+			wantEnd:  time.Date(2025, 7, 1, 0, 0, 0, 0, jakarta),
+		},
+		{
+			name:     "a year of days",
+			req:      &pb.DonationTimeSeriesRequest{From: "2024-01-01", To: "2024-12-31"},
+			interval: "day",
+			wantFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta),
+			wantEnd:  time.Date(2025, 1, 1, 0, 0, 0, 0, jakarta),
+		},
+		{"too many days", &pb.DonationTimeSeriesRequest{From: "2024-01-01", To: "2025-01-01"}, "day", time.Time{}, time.Time{}, "from"},
+		{"from after to", &pb.DonationTimeSeriesRequest{From: "2025-06-08", To: "2025-06-07"}, "day", time.Time{}, time.Time{}, "from"},
+		{"invalid from", &pb.DonationTimeSeriesRequest{From: "01/06/2025", To: "2025-06-07"}, "day", time.Time{}, time.Time{}, "from"},
+		{"invalid to", &pb.DonationTimeSeriesRequest{To: "2025-6-7"}, "day", time.Time{}, time.Time{}, "to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, end, err := seriesRange(tt.req, tt.interval, jakarta)
+			if tt.wantField != "" {
+				if err == nil {
+					t.Fatal("seriesRange() error = nil")
+				}
+				violations := apperror.FieldViolations(err)
+				if len(violations) != 1 || violations[0].Field != tt.wantField {
+					t.Errorf("seriesRange() error = %v, want one on %q", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("seriesRange() error = %v", err)
+			}
+			if !from.Equal(tt.wantFrom) || !end.Equal(tt.wantEnd) {
+				t.Errorf("seriesRange() = %v, %v, want %v, %v", from, end, tt.wantFrom, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestFillBuckets(t *testing.T) {
+	jakarta := mustLoad(t, "Asia/Jakarta")
+	// the database answers with the wall clock of the timezone, without a zone
+	wallClock := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		rows     []seriesRow
+		interval string
+		from     time.Time
+		end      time.Time
+		want     []model.TimeSeriesBucket
+	}{
+		{
+			name:     "days with empty ones",
+			rows:     []seriesRow{{wallClock(2025, 6, 1), 50000, 1}, {wallClock(2025, 6, 3), 75000, 2}},
+			interval: "day",
+			from:     time.Date(2025, 6, 1, 0, 0, 0, 0, jakarta),
+			end:      time.Date(2025, 6, 4, 0, 0, 0, 0, jakarta),
+			want: []model.TimeSeriesBucket{
+				{Start: time.Date(2025, 6, 1, 0, 0, 0, 0, jakarta), TotalAmount: 50000, DonationCount: 1},
+				{Start: time.Date(2025, 6, 2, 0, 0, 0, 0, jakarta)},
+				{Start: time.Date(2025, 6, 3, 0, 0, 0, 0, jakarta), TotalAmount: 75000, DonationCount: 2},
+			},
+		},
+		{
+			name:     "weeks",
+			rows:     []seriesRow{{wallClock(2025, 6, 9), 100000, 3}},
+			interval: "week",
+			from:     time.Date(2025, 6, 2, 0, 0, 0, 0, jakarta),
+			end:      time.Date(2025, 6, 21, 0, 0, 0, 0, jakarta),
+			want: []model.TimeSeriesBucket{
+				{Start: time.Date(2025, 6, 2, 0, 0, 0, 0, jakarta)},
+				{Start: time.Date(2025, 6, 9, 0, 0, 0, 0, jakarta), TotalAmount: 100000, DonationCount: 3},
+				{Start: time.Date(2025, 6, 16, 0, 0, 0, 0, jakarta)},
+			},
+		},
+		{
+			name:     "no donations",
+			interval: "day",
+			from:     time.Date(2025, 6, 1, 0, 0, 0, 0, jakarta),
+			end:      time.Date(2025, 6, 3, 0, 0, 0, 0, jakarta),
+			want: []model.TimeSeriesBucket{
+				{Start: time.Date(2025, 6, 1, 0, 0, 0, 0, jakarta)},
+				{Start: time.Date(2025, 6, 2, 0, 0, 0, 0, jakarta)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fillBuckets(tt.rows, tt.interval, tt.from, tt.end)
+			if len(got) != len(tt.want) {
+				t.Fatalf("fillBuckets() = %d buckets, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !got[i].Start.Equal(tt.want[i].Start) || got[i].TotalAmount != tt.want[i].TotalAmount || got[i].DonationCount != tt.want[i].DonationCount {
+					t.Errorf("bucket %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+				if got[i].Start.Location() != jakarta {
+					t.Errorf("bucket %d starts in %v, want Asia/Jakarta", i, got[i].Start.Location())
+				}
+			}
+		})
+	}
+}
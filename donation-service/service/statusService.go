@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+)
+
+// checkDonationTransition reports why a client may not move a donation from
+// current to next. COMPLETED is only set by settleTransaction, and an abandoned
+// donation is only pending again through ReissueInvoice.
+func checkDonationTransition(current model.DonationStatus, next model.DonationStatus) error {
+	switch {
+	case next == current:
+		return nil
+	case !next.Valid():
+		return invalidField("status", fmt.Sprintf("unknown donation status %q", next))
+	case next.SettlementOnly():
+		return invalidField("status", fmt.Sprintf("donation status %s is only set when its payment settles", next))
+	case current.ReissueOnly(next):
+		return apperror.FailedPrecondition(ReasonInvalidStatusTransition, fmt.Sprintf("donation cannot move from %s to %s, reissue its invoice instead", current, next))
+	case !current.CanTransitionTo(next):
+		return apperror.FailedPrecondition(ReasonInvalidStatusTransition, fmt.Sprintf("donation cannot move from %s to %s", current, next))
+	}
+	return nil
+}
+
+// checkTransactionTransition reports why a client may not move a transaction
+// from current to next. PAID and SETTLED are only set by settleTransaction.
+func checkTransactionTransition(current model.TransactionStatus, next model.TransactionStatus) error {
+	switch {
+	case next == current:
+		return nil
+	case !next.Valid():
+		return invalidField("status", fmt.Sprintf("unknown transaction status %q", next))
+	case next.SettlementOnly():
+		return invalidField("status", fmt.Sprintf("transaction status %s is only set when its payment settles", next))
+	case !current.CanTransitionTo(next):
+		return apperror.FailedPrecondition(ReasonInvalidStatusTransition, fmt.Sprintf("transaction cannot move from %s to %s", current, next))
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+)
+
+func TestCheckDonationTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		current model.DonationStatus
+		next    model.DonationStatus
+		want    codes.Code
+	}{
+		{"unchanged", model.DonationPending, model.DonationPending, codes.OK},
+		{"abandon a pending donation", model.DonationPending, model.DonationAbandoned, codes.OK},
+		{"complete without a payment", model.DonationPending, model.DonationCompleted, codes.InvalidArgument},
+		{"reopen an abandoned donation", model.DonationAbandoned, model.DonationPending, codes.FailedPrecondition},
+		{"reopen a completed donation", model.DonationCompleted, model.DonationPending, codes.FailedPrecondition},
+		{"unknown status", model.DonationPending, "REFUNDED", codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(checkDonationTransition(tt.current, tt.next)); got != tt.want {
+				t.Errorf("checkDonationTransition(%s, %s) = %s, want %s", tt.current, tt.next, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+	"github.com/rayhanadri/crowdfunding/donation-service/pb"
+	"github.com/rayhanadri/crowdfunding/donation-service/webhook"
+)
+
+// errSubscriptionNotFound is also returned for subscriptions of other users,
+// so their IDs are not revealed.
+var errSubscriptionNotFound = apperror.NotFound(ReasonSubscriptionNotFound, "webhook subscription not found")
+
+func webhookSubscriptionToPb(subscription *model.WebhookSubscription, withSecret bool) *pb.WebhookSubscription {
+	response := &pb.WebhookSubscription{
+		Id:         int32(subscription.ID),
+		UserId:     int32(subscription.UserID),
+		CampaignId: int32(subscription.CampaignID),
+		Url:        subscription.URL,
+		EventTypes: subscription.EventTypeList(),
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt.Format(time.RFC3339),
+	}
+	if withSecret {
+		response.Secret = subscription.Secret
+	}
+	return response
+}
+
+func webhookDeliveryToPb(delivery *model.WebhookDelivery) *pb.WebhookDelivery {
+	response := &pb.WebhookDelivery{
+		Id:             int32(delivery.ID),
+		SubscriptionId: int32(delivery.SubscriptionID),
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       int32(delivery.Attempts),
+		ResponseStatus: int32(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == webhook.StatusPending || delivery.Status == webhook.StatusRetrying {
+		response.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.DeliveredAt != nil {
+		response.DeliveredAt = delivery.DeliveredAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+// ownedSubscription returns an active subscription of the user.
+func ownedSubscription(ctx context.Context, subscriptionID int32, userID int32) (*model.WebhookSubscription, error) {
+	var subscription model.WebhookSubscription
+	err := config.DB.WithContext(ctx).Where("id = ? AND user_id = ? AND active", subscriptionID, userID).First(&subscription).Error
+	if err != nil {
+		return nil, errSubscriptionNotFound
+	}
+	return &subscription, nil
+}
+
+func (r *DonationService) CreateWebhookSubscription(ctx context.Context, req *pb.CreateWebhookSubscriptionRequest) (*pb.WebhookSubscriptionResponse, error) {
+	subscription := &model.WebhookSubscription{
+		UserID:     int(req.GetUserId()),
+		CampaignID: int(req.GetCampaignId()),
+		URL:        strings.TrimSpace(req.GetUrl()),
+		EventTypes: strings.Join(req.GetEventTypes(), ","),
+		Active:     true,
+	}
+
+	//validate subscription data
+	var violations []apperror.FieldViolation
+	if subscription.UserID == 0 {
+		violations = append(violations, apperror.FieldViolation{Field: "user_id", Description: "user ID is required"})
+	}
+	if subscription.CampaignID == 0 {
+		violations = append(violations, apperror.FieldViolation{Field: "campaign_id", Description: "campaign ID is required"})
+	}
+	if len(req.GetEventTypes()) == 0 {
+		violations = append(violations, apperror.FieldViolation{Field: "event_types", Description: "at least one event type is required"})
+	}
+	for _, eventType := range req.GetEventTypes() {
+		if !webhook.SupportedEventType(eventType) {
+			violations = append(violations, apperror.FieldViolation{Field: "event_types", Description: fmt.Sprintf("unsupported event type %q", eventType)})
+		}
+	}
+	if u, parseErr := url.Parse(subscription.URL); parseErr != nil || u.Scheme != "https" || u.Host == "" {
+		violations = append(violations, apperror.FieldViolation{Field: "url", Description: "webhook URL must be an absolute https URL"})
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("invalid webhook subscription", violations...)
+	}
+
+	// only the campaign owner can subscribe to its events
+	campaignModel, err := GetCampaignByID(ctx, strconv.Itoa(subscription.CampaignID))
+	if err != nil {
+		return nil, err
+	}
+	if int(campaignModel.UserID) != subscription.UserID {
+		return nil, apperror.PermissionDenied(ReasonCampaignNotOwned, "campaign is not owned by the user")
+	}
+
+	subscription.Secret, err = webhook.NewSecret()
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	if err := config.DB.WithContext(ctx).Create(subscription).Error; err != nil {
+		return nil, apperror.FromDB(err, "webhook subscription")
+	}
+
+	response := &pb.WebhookSubscriptionResponse{
+		Message:      "Webhook subscription created successfully, store the secret, it is not shown again",
+		Subscription: webhookSubscriptionToPb(subscription, true),
+	}
+
+	return response, nil
+}
+
+func (r *DonationService) GetWebhookSubscriptions(ctx context.Context, req *pb.WebhookSubscriptionsRequest) (*pb.WebhookSubscriptionsResponse, error) {
+	var subscriptions []model.WebhookSubscription
+	if err := config.DB.WithContext(ctx).Where("user_id = ? AND active", req.GetUserId()).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, apperror.FromDB(err, "webhook subscription")
+	}
+
+	response := &pb.WebhookSubscriptionsResponse{
+		Subscriptions: make([]*pb.WebhookSubscription, 0, len(subscriptions)),
+	}
+	for i := range subscriptions {
+		response.Subscriptions = append(response.Subscriptions, webhookSubscriptionToPb(&subscriptions[i], false))
+	}
+
+	return response, nil
+}
+
+// DeleteWebhookSubscription deactivates a subscription. It is kept, with its
+// deliveries, so the delivery log stays complete.
+func (r *DonationService) DeleteWebhookSubscription(ctx context.Context, req *pb.WebhookSubscriptionIdRequest) (*pb.WebhookSubscriptionResponse, error) {
+	subscription, err := ownedSubscription(ctx, req.GetId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Active = false
+	if err := config.DB.WithContext(ctx).Model(subscription).Update("active", false).Error; err != nil {
+		return nil, apperror.FromDB(err, "webhook subscription")
+	}
+
+	response := &pb.WebhookSubscriptionResponse{
+		Message:      "Webhook subscription deleted successfully",
+		Subscription: webhookSubscriptionToPb(subscription, false),
+	}
+
+	return response, nil
+}
+
+func (r *DonationService) GetWebhookDeliveries(ctx context.Context, req *pb.WebhookDeliveriesRequest) (*pb.WebhookDeliveriesResponse, error) {
+	var subscription model.WebhookSubscription
+	if err := config.DB.WithContext(ctx).Where("id = ? AND user_id = ?", req.GetSubscriptionId(), req.GetUserId()).First(&subscription).Error; err != nil {
+		return nil, errSubscriptionNotFound
+	}
+
+	page := int(req.GetPage())
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := clampLimit(req.GetPageSize(), defaultPageSize, maxPageSize)
+
+	query := config.DB.WithContext(ctx).Model(&model.WebhookDelivery{}).Where("subscription_id = ?", subscription.ID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, apperror.FromDB(err, "webhook delivery")
+	}
+
+	var deliveries []model.WebhookDelivery
+	if err := query.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&deliveries).Error; err != nil {
+		return nil, apperror.FromDB(err, "webhook delivery")
+	}
+
+	response := &pb.WebhookDeliveriesResponse{
+		Deliveries: make([]*pb.WebhookDelivery, 0, len(deliveries)),
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+		Total:      total,
+	}
+	for i := range deliveries {
+		response.Deliveries = append(response.Deliveries, webhookDeliveryToPb(&deliveries[i]))
+	}
+
+	return response, nil
+}
+
+// RedeliverWebhook sends a delivery again right away, typically a dead one
+// after the partner fixed their endpoint.
+func (r *DonationService) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.WebhookDeliveryResponse, error) {
+	subscription, err := ownedSubscription(ctx, req.GetSubscriptionId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	var delivery model.WebhookDelivery
+	if err := config.DB.WithContext(ctx).Where("id = ? AND subscription_id = ?", req.GetDeliveryId(), subscription.ID).First(&delivery).Error; err != nil {
+		return nil, apperror.NotFound(ReasonDeliveryNotFound, "webhook delivery not found")
+	}
+
+	webhook.Default.Redeliver(ctx, subscription, &delivery)
+
+	response := &pb.WebhookDeliveryResponse{
+		Message:  "Webhook redelivered",
+		Delivery: webhookDeliveryToPb(&delivery),
+	}
+
+	return response, nil
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Headers sent with every delivery. Partners verify a delivery by computing
+// HMAC-SHA256 over "<timestamp>.<body>" with their secret and comparing it to
+// the signature, and should reject timestamps older than a few minutes.
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// Sign returns the signature of a payload sent at timestamp, as put in the
+// signature header.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret generates the signing secret of a subscription.
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+var errPrivateAddress = errors.New("webhook URL resolves to a private address")
+
+// denyPrivateAddresses stops deliveries to the service's own network. Partner
+// URLs are user input, and the check runs after DNS resolution so a public
+// name pointing at a private address is refused too.
+func denyPrivateAddresses(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return errPrivateAddress
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+// Package webhook delivers donation events to partner URLs. Deliveries are
+// stored with the change the event describes, signed, retried with
+// exponential backoff and end up dead after too many failures, from where the
+// owner can redeliver them.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/config"
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+	"github.com/rayhanadri/crowdfunding/donation-service/model"
+)
+
+// Delivery statuses.
+const (
+	StatusPending   = "PENDING"
+	StatusDelivered = "DELIVERED"
+	StatusRetrying  = "RETRYING"
+	StatusDead      = "DEAD"
+)
+
+// EventTypes are the events partners can subscribe to.
+var EventTypes = []string{
+	event.DonationSettled,
+	event.CampaignFunded,
+	event.DonationRefunded,
+	event.PayoutSent,
+	event.CampaignExpired,
+}
+
+// SupportedEventType reports whether partners can subscribe to an event type.
+func SupportedEventType(eventType string) bool {
+	for _, supported := range EventTypes {
+		if supported == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is the JSON body of a delivery. ID is the delivery ID, it stays the
+// same across retries and redeliveries so partners can drop duplicates.
+type Payload struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	CreatedAt  time.Time   `json:"created_at"`
+	CampaignID int         `json:"campaign_id"`
+	Data       PayloadData `json:"data"`
+}
+
+// PayloadData carries no donor identity, partners only learn whether the
+// donation was anonymous.
+type PayloadData struct {
+	DonationID    int     `json:"donation_id,omitempty"`
+	TransactionID int     `json:"transaction_id,omitempty"`
+	Amount        float64 `json:"amount"`
+	IsAnonymous   bool    `json:"is_anonymous"`
+	Source        string  `json:"source,omitempty"`
+}
+
+// Dispatcher sends the stored deliveries and retries the failed ones.
+type Dispatcher struct {
+	client        *http.Client
+	MaxAttempts   int
+	PollInterval  time.Duration
+	RetryInterval time.Duration
+}
+
+// NewClient returns an HTTP client for URLs given by users. It refuses to
+// connect to private addresses, goes through no proxy and does not follow
+// redirects.
+func NewClient() *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: denyPrivateAddresses}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		// a redirect could point anywhere, users must give the final URL
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		client:        NewClient(),
+		MaxAttempts:   8,
+		PollInterval:  2 * time.Second,
+		RetryInterval: 30 * time.Second,
+	}
+}
+
+// Default is the dispatcher started by main, the redelivery RPC uses it too.
+var Default = NewDispatcher()
+
+// Start sends the deliveries that are due until the context is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.SendDue(ctx)
+			}
+		}
+	}()
+}
+
+// encodePayload is the body of the delivery with ID id of an event.
+func encodePayload(id int, e event.Event) (string, error) {
+	payload, err := json.Marshal(Payload{
+		ID:         strconv.Itoa(id),
+		Type:       e.Type,
+		CreatedAt:  e.OccurredAt,
+		CampaignID: e.CampaignID,
+		Data: PayloadData{
+			DonationID:    e.DonationID,
+			TransactionID: e.TransactionID,
+			Amount:        e.Amount,
+			IsAnonymous:   e.IsAnonymous,
+			Source:        e.Source,
+		},
+	})
+	return string(payload), err
+}
+
+// Enqueue stores a delivery of e for every active subscription of its
+// campaign that asked for it. It runs in the transaction of the change the
+// event describes, so deliveries are kept exactly when that commits, and the
+// dispatcher sends them from the table.
+func Enqueue(tx *gorm.DB, e event.Event) error {
+	if !SupportedEventType(e.Type) {
+		return nil
+	}
+
+	var subscriptions []model.WebhookSubscription
+	if err := tx.Where("campaign_id = ? AND active", e.CampaignID).Find(&subscriptions).Error; err != nil {
+		return err
+	}
+
+	for i := range subscriptions {
+		if !subscriptions[i].Wants(e.Type) {
+			continue
+		}
+
+		delivery := &model.WebhookDelivery{
+			SubscriptionID: subscriptions[i].ID,
+			EventType:      e.Type,
+			Status:         StatusPending,
+			NextAttemptAt:  time.Now(),
+		}
+		if err := tx.Create(delivery).Error; err != nil {
+			return err
+		}
+
+		// the payload carries the delivery ID, known once it is stored
+		payload, err := encodePayload(delivery.ID, e)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(delivery).Update("payload", payload).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliver sends one attempt of a delivery and records the outcome.
+func (d *Dispatcher) Deliver(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery) {
+	statusCode, err := d.send(ctx, subscription, delivery)
+
+	delivery.Attempts++
+	delivery.ResponseStatus = statusCode
+	now := time.Now()
+	switch {
+	case err == nil:
+		delivery.Status = StatusDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	case delivery.Attempts >= d.MaxAttempts:
+		delivery.Status = StatusDead
+		delivery.LastError = err.Error()
+		slog.WarnContext(ctx, "webhook delivery is dead", "delivery_id", delivery.ID, "attempts", delivery.Attempts, "error", err)
+	default:
+		delivery.Status = StatusRetrying
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(backoff(delivery.Attempts))
+	}
+
+	if err := config.DB.WithContext(ctx).Save(delivery).Error; err != nil {
+		slog.ErrorContext(ctx, "failed to update webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	// signed per attempt, so the timestamp is always fresh
+	timestamp := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "crowdfunding-webhooks/1")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("partner responded with status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff doubles the wait after every failed attempt, from 30 seconds up to
+// six hours.
+func backoff(attempts int) time.Duration {
+	wait := 30 * time.Second << (attempts - 1)
+	if wait > 6*time.Hour || wait <= 0 {
+		return 6 * time.Hour
+	}
+	return wait
+}
+
+// SendDue sends the deliveries whose next attempt is due, new ones and
+// retries. Deliveries of subscriptions that were deleted or disabled in the
+// meantime are dropped.
+func (d *Dispatcher) SendDue(ctx context.Context) {
+	var due []model.WebhookDelivery
+	err := config.DB.WithContext(ctx).
+		Where("status IN ? AND next_attempt_at <= ?", []string{StatusPending, StatusRetrying}, time.Now()).
+		Order("next_attempt_at").
+		Limit(100).
+		Find(&due).Error
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to get due webhook deliveries", "error", err)
+		return
+	}
+
+	for i := range due {
+		// Push the next attempt back while this instance sends it, so other
+		// instances skip it. Only the instance that moved it sends.
+		claim := config.DB.WithContext(ctx).Model(&model.WebhookDelivery{}).
+			Where("id = ? AND next_attempt_at = ?", due[i].ID, due[i].NextAttemptAt).
+			Update("next_attempt_at", time.Now().Add(d.RetryInterval*2))
+		if claim.Error != nil {
+			slog.ErrorContext(ctx, "failed to claim webhook delivery", "delivery_id", due[i].ID, "error", claim.Error)
+			continue
+		}
+		if claim.RowsAffected == 0 {
+			continue
+		}
+
+		var subscription model.WebhookSubscription
+		if err := config.DB.WithContext(ctx).Where("id = ? AND active", due[i].SubscriptionID).First(&subscription).Error; err != nil {
+			due[i].Status = StatusDead
+			due[i].LastError = "subscription is no longer active"
+			config.DB.WithContext(ctx).Save(&due[i])
+			continue
+		}
+		d.Deliver(ctx, &subscription, &due[i])
+	}
+}
+
+// Redeliver sends a delivery again right away, whatever its state. The attempt
+// count starts over, so a dead delivery gets the full retry schedule again.
+func (d *Dispatcher) Redeliver(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery) {
+	delivery.Attempts = 0
+	delivery.Status = StatusPending
+	d.Deliver(ctx, subscription, delivery)
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/donation-service/event"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		payload   string
+		want      string
+	}{
+		{"delivery", "whsec_test", 1700000000, `{"id":"1"}`, "v1=11bf4466ea17c3df3fd743af0b435368e16b7a05eb8eced85e8c4670767bdec5"},
+		{"other secret and time", "secret", 1735689600, "hello", "v1=e6270278b5499b1a2314167f84d23476fba6779a3f71a75cc547f635620a1dc3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, time.Unix(tt.timestamp, 0), []byte(tt.payload)); got != tt.want {
+				t.Errorf("Sign() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// the timestamp is signed, a replay with a new one does not verify
+	now := time.Unix(1700000000, 0)
+	if Sign("whsec_test", now, []byte("{}")) == Sign("whsec_test", now.Add(time.Second), []byte("{}")) {
+		t.Error("signatures of different timestamps are equal")
+	}
+}
+
+func TestNewSecret(t *testing.T) {
+	first, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := NewSecret()
+	if !strings.HasPrefix(first, "whsec_") || len(first) != len("whsec_")+64 {
+		t.Errorf("NewSecret() = %q, want whsec_ and 64 hex digits", first)
+	}
+	if first == second {
+		t.Error("NewSecret() returned the same secret twice")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{10, 4*time.Hour + 16*time.Minute},
+		{11, 6 * time.Hour},
+		{64, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestDenyPrivateAddresses(t *testing.T) {
+	tests := []struct {
+		address string
+		denied  bool
+	}{
+		{"127.0.0.1:443", true},
+		{"10.1.2.3:80", true},
+		{"172.16.0.1:443", true},
+		{"192.168.1.10:8080", true},
+		{"169.254.169.254:80", true},
+		{"0.0.0.0:443", true},
+		{"[::1]:443", true},
+		{"[fd00::1]:443", true},
+		{"8.8.8.8:443", false},
+		{"[2606:4700:4700::1111]:443", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := denyPrivateAddresses("tcp", tt.address, nil)
+			if denied := errors.Is(err, errPrivateAddress); denied != tt.denied {
+				t.Errorf("denyPrivateAddresses(%q) = %v, want denied %v", tt.address, err, tt.denied)
+			}
+		})
+	}
+}
+
+func TestEncodePayload(t *testing.T) {
+	e := event.Event{
+		Type:          event.DonationSettled,
+		CampaignID:    3,
+		DonationID:    10,
+		TransactionID: 11,
+		UserID:        7,
+		GuestEmail:    "guest@example.com",
+		IsAnonymous:   true,
+		Amount:        50000,
+		Source:        "webhook",
+		OccurredAt:    time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	encoded, err := encodePayload(42, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload Payload
+	if err := json.Unmarshal([]byte(encoded), &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.ID != "42" || payload.Type != event.DonationSettled || payload.CampaignID != 3 {
+		t.Errorf("payload = %+v, want delivery 42 of donation.settled for campaign 3", payload)
+	}
+	if payload.Data.DonationID != 10 || payload.Data.Amount != 50000 || !payload.Data.IsAnonymous {
+		t.Errorf("data = %+v, want donation 10 of 50000, anonymous", payload.Data)
+	}
+	// partners never learn who donated
+	if strings.Contains(encoded, "guest@example.com") || strings.Contains(encoded, "user_id") {
+		t.Errorf("payload %s carries the donor", encoded)
+	}
+}
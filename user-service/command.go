@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rayhanadri/crowdfunding/common/migrate"
+
+	"github.com/rayhanadri/crowdfunding/user-service/config"
+	"github.com/rayhanadri/crowdfunding/user-service/migrations"
+)
+
+const usage = `usage: main [--print-config] [command]
+  (none)              serve gRPC
+  migrate <command>   change the database schema, see migrate help
+  seed                insert the sample data for development`
+
+// runCommand runs the maintenance command given by args instead of the server.
+// Canceling ctx stops migrations between two of them, the lock is released.
+func runCommand(ctx context.Context, args []string) error {
+	switch args[0] {
+	case "migrate":
+		if len(args) == 2 && args[1] == "help" {
+			fmt.Println(migrate.Usage)
+			return nil
+		}
+		return migrate.Run(ctx, config.Migrator(), args[1:], os.Stdout)
+	case "seed":
+		if len(args) != 1 {
+			return fmt.Errorf("seed takes no arguments")
+		}
+		return config.Migrator().Seed(ctx, migrations.Seed)
+	}
+	return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+}
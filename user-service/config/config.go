@@ -1,66 +1,156 @@
 package config
 
 import (
-	"log"
-	"os"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/joho/godotenv"
+	"github.com/rayhanadri/crowdfunding/common/envconfig"
+	"github.com/rayhanadri/crowdfunding/common/logging"
+	"github.com/rayhanadri/crowdfunding/common/migrate"
+	"github.com/rayhanadri/crowdfunding/common/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+
+	"github.com/rayhanadri/crowdfunding/user-service/migrations"
 )
 
+// Config is the configuration of user-service, read from the environment and
+// an optional dotenv file, see the envconfig package.
+type Config struct {
+	Port           int  `env:"PORT" default:"50051"`
+	MigrateOnStart bool `env:"MIGRATE_ON_START"`
+	Postgres       Postgres
+
+	// ShutdownTimeout bounds the drain of in-flight requests on SIGTERM, Cloud
+	// Run kills the container 10 seconds after it.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" default:"8s"`
+
+	// MetricsPort serves /metrics for Prometheus, 0 turns it off.
+	MetricsPort int `env:"METRICS_PORT" default:"9090"`
+
+	Log     logging.Config
+	Tracing tracing.Config
+
+	EmailVerification EmailVerification
+	// Email goes over SMTP when SMTP_HOST is set, otherwise to MAIL_FILE when
+	// that is set. Without either verification emails are not sent.
+	SMTP     SMTP
+	MailFile string `env:"MAIL_FILE"`
+}
+
+// EmailVerification sends users a link proving they own their email.
+type EmailVerification struct {
+	// URL is the page verifying the token, the token is appended as ?token=.
+	URL string `env:"EMAIL_VERIFICATION_URL" default:"http://localhost:8080/api/v1/users/verify-email"`
+	// TTL is how long a link can be used.
+	TTL time.Duration `env:"EMAIL_VERIFICATION_TTL" default:"24h"`
+}
+
+// SMTP configures the email server.
+type SMTP struct {
+	Host     string `env:"SMTP_HOST"`
+	Port     string `env:"SMTP_PORT" default:"587"`
+	Username string `env:"SMTP_USERNAME"`
+	Password string `env:"SMTP_PASSWORD" secret:"true"`
+	From     string `env:"SMTP_FROM"`
+}
+
+// Postgres locates the database.
+type Postgres struct {
+	Host     string `env:"POSTGRES_HOST" required:"true"`
+	Port     int    `env:"POSTGRES_PORT" default:"5432"`
+	User     string `env:"POSTGRES_USER" required:"true"`
+	Password string `env:"POSTGRES_PASSWORD" required:"true" secret:"true"`
+	DB       string `env:"POSTGRES_DB" required:"true"`
+	SSLMode  string `env:"POSTGRES_SSLMODE" default:"require"`
+}
+
+// DSN is the connection string of the database.
+func (p Postgres) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		quote(p.Host), p.Port, quote(p.User), quote(p.Password), quote(p.DB), quote(p.SSLMode))
+}
+
+// quote quotes a connection string value, so spaces and quotes survive.
+func quote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// Validate checks the rules across fields.
+func (c *Config) Validate() error {
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("PORT must be between 1 and 65535")
+	}
+	if c.Postgres.Port < 1 || c.Postgres.Port > 65535 {
+		return fmt.Errorf("POSTGRES_PORT must be between 1 and 65535")
+	}
+	if c.MetricsPort < 0 || c.MetricsPort > 65535 || c.MetricsPort == c.Port {
+		return fmt.Errorf("METRICS_PORT must be between 0 and 65535 and differ from PORT")
+	}
+	if c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.SMTP.Host != "" && c.SMTP.From == "" {
+		return fmt.Errorf("SMTP_FROM is required when SMTP_HOST is set")
+	}
+	if c.EmailVerification.TTL <= 0 {
+		return fmt.Errorf("EMAIL_VERIFICATION_TTL must be positive")
+	}
+	if u, err := url.Parse(c.EmailVerification.URL); err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("EMAIL_VERIFICATION_URL must be an absolute http or https URL")
+	}
+	if err := c.Log.Validate(); err != nil {
+		return err
+	}
+	return c.Tracing.Validate()
+}
+
+// App is the configuration loaded by Load.
+var App Config
+
 var DB *gorm.DB
 
+// Load reads and validates the configuration into App.
+func Load() error {
+	return envconfig.Load(&App)
+}
+
+// Print writes the configuration with its secrets redacted.
+func Print(w io.Writer) error {
+	return envconfig.Print(w, &App)
+}
+
 // Connect initializes the database connection using GORM and PostgreSQL.
 func Connect() {
-	// Get database connection details from environment variables
-	dsn := "host=" + os.Getenv("POSTGRES_HOST") +
-		" user=" + os.Getenv("POSTGRES_USER") +
-		" password=" + os.Getenv("POSTGRES_PASSWORD") +
-		" dbname=" + os.Getenv("POSTGRES_DB") +
-		" port=" + os.Getenv("POSTGRES_PORT") +
-		" sslmode=require"
-	if os.Getenv("POSTGRES_HOST") == "" || os.Getenv("POSTGRES_USER") == "" || os.Getenv("POSTGRES_PASSWORD") == "" || os.Getenv("POSTGRES_DB") == "" || os.Getenv("POSTGRES_PORT") == "" {
-		log.Fatal("One or more required environment variables are not set")
-	}
-
-	// Connect to PostgreSQL database
+	// TranslateError turns driver errors such as unique violations into gorm errors
 	var err error
+	DB, err = gorm.Open(postgres.Open(App.Postgres.DSN()), &gorm.Config{TranslateError: true, Logger: logging.GORM()})
+	if err != nil {
+		logging.Fatal("failed to connect to the database", "error", err)
+	}
+	slog.Info("database connection established")
+}
 
-	// Set Connection Pooling
-	// sqlDB, err := sql.Open("pgx", dsn)
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
-	// defer sqlDB.Close()
-
-	// // Set pool settings
-	// sqlDB.SetMaxOpenConns(20)
-	// sqlDB.SetMaxIdleConns(10)
-	// sqlDB.SetConnMaxLifetime(2 * time.Hour)
-
-	// // var db *gorm.DB
-	// DB, err = gorm.Open(postgres.New(postgres.Config{
-	// 	Conn: sqlDB,
-	// }), &gorm.Config{})
-	// if err != nil {
-	// 	log.Fatalf("Failed to connect to the database: %v", err)
-	// }
-	// log.Println("Database connection established")
-
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// SQLDB returns the database/sql handle of DB, Connect must be called first.
+func SQLDB() *sql.DB {
+	sqlDB, err := DB.DB()
 	if err != nil {
-		log.Fatalf("Failed to connect to the database: %v", err)
+		logging.Fatal("failed to get the database handle", "error", err)
 	}
-	log.Println("Database connection established")
+	return sqlDB
 }
 
-func LoadEnv() {
-	// Load environment variables from .env file
-	err := godotenv.Load()
+// Migrator returns the migrator of the service schema, Connect must be called first.
+func Migrator() *migrate.Migrator {
+	all, err := migrations.All()
 	if err != nil {
-		log.Fatalf("Error loading .env file: %v", err)
+		logging.Fatal("failed to load the migrations", "error", err)
 	}
-	log.Println("Environment variables loaded successfully")
+	return migrate.New(SQLDB(), migrations.Schema, all)
 }
@@ -0,0 +1,123 @@
+// Package mail sends the emails of user-service, such as the link verifying
+// the email of a user.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/user-service/config"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Sender delivers messages.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTP sends messages through a mail server.
+type SMTP struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", s.From)
+	fmt.Fprintf(&body, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	body.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	// net/smtp has no context support, the send runs to completion
+	return smtp.SendMail(net.JoinHostPort(s.Host, s.Port), auth, s.From, []string{msg.To}, body.Bytes())
+}
+
+// File appends messages to a file as JSON lines, for local development
+// without a mail server.
+type File struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (f *File) Send(ctx context.Context, msg Message) error {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Memory keeps messages in memory, for tests.
+type Memory struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (m *Memory) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// FromConfig builds the sender of the configuration, SMTP when SMTP_HOST is
+// set, otherwise MAIL_FILE when that is set. It returns nil without either.
+func FromConfig() Sender {
+	if smtpConfig := config.App.SMTP; smtpConfig.Host != "" {
+		return &SMTP{
+			Host:     smtpConfig.Host,
+			Port:     smtpConfig.Port,
+			Username: smtpConfig.Username,
+			Password: smtpConfig.Password,
+			From:     smtpConfig.From,
+		}
+	}
+	if path := config.App.MailFile; path != "" {
+		return &File{Path: path}
+	}
+	return nil
+}
@@ -1,43 +1,172 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/health"
+	"github.com/rayhanadri/crowdfunding/common/logging"
+	"github.com/rayhanadri/crowdfunding/common/metrics"
+	"github.com/rayhanadri/crowdfunding/common/tracing"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/rayhanadri/crowdfunding/user-service/config"
+	"github.com/rayhanadri/crowdfunding/user-service/mail"
 	"github.com/rayhanadri/crowdfunding/user-service/pb"
 	"github.com/rayhanadri/crowdfunding/user-service/service"
 )
 
 func main() {
-	// Load environment variables from .env file
-	config.LoadEnv()
+	printConfig := flag.Bool("print-config", false, "print the configuration, secrets redacted, and exit")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Load the configuration from the environment and the optional .env file
+	err := config.Load()
+	if *printConfig {
+		config.Print(os.Stdout)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	if *printConfig {
+		return
+	}
+
+	// Log structured and redacted records from here on
+	logging.Setup("user-service", config.App.Log)
+
+	// Stop on SIGTERM from Cloud Run or on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Connect to the database
 	config.Connect()
 
-	// Create a new gRPC server
-	grpcServer := grpc.NewServer()
+	// Run a maintenance command such as migrate or seed instead of serving
+	if flag.NArg() > 0 {
+		if err := runCommand(ctx, flag.Args()); err != nil {
+			logging.Fatal("command failed", "error", err)
+		}
+		return
+	}
+
+	// Single-replica deployments may migrate on start, the migration lock
+	// makes replicas starting together wait for each other
+	if config.App.MigrateOnStart {
+		if err := config.Migrator().Up(ctx); err != nil {
+			logging.Fatal("failed to migrate the database", "error", err)
+		}
+	}
+
+	// Trace requests from the gateway through the RPCs and queries here,
+	// pending spans are flushed on the way out
+	shutdownTracing, err := tracing.Setup(ctx, "user-service", config.App.Tracing)
+	if err != nil {
+		logging.Fatal("failed to set up tracing", "error", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Warn("failed to flush traces", "error", err)
+		}
+	}()
+	if err := tracing.InstrumentGORM(config.DB); err != nil {
+		logging.Fatal("failed to trace the database", "error", err)
+	}
+
+	// Create a new gRPC server, traced and logged with the request ID of the
+	// gateway, errors leave it as status errors with details, which the metrics
+	// count by code
+	grpcServer := grpc.NewServer(
+		tracing.ServerOption(),
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), metrics.UnaryServerInterceptor(), apperror.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), metrics.StreamServerInterceptor(), apperror.StreamServerInterceptor()),
+	)
 
 	// Register the UserService with the gRPC server
-	pb.RegisterUserServiceServer(grpcServer, &service.UserService{})
+	// Verification emails go over SMTP or to MAIL_FILE
+	mailer := mail.FromConfig()
+	if mailer == nil {
+		slog.Warn("no mail sender configured, verification emails are not sent")
+	}
+	pb.RegisterUserServiceServer(grpcServer, &service.UserService{Mailer: mailer})
 
 	// Register reflection service on gRPC server
 	reflection.Register(grpcServer)
 
+	// Report the health of the database on grpc.health.v1
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	monitor := health.NewMonitor(healthServer, pb.UserService_ServiceDesc.ServiceName)
+	monitor.Require("database", health.DB(config.SQLDB()))
+	monitor.Run(ctx)
+
+	// Serve Prometheus metrics, the pool stats of the database included
+	if config.App.MetricsPort != 0 {
+		metrics.RegisterDB(config.SQLDB(), "users")
+		metrics.Serve(ctx, fmt.Sprintf(":%d", config.App.MetricsPort))
+	}
+
 	// Start listening for incoming connections
-	listener, err := net.Listen("tcp", ":50051")
+	address := fmt.Sprintf(":%d", config.App.Port)
+	listener, err := net.Listen("tcp", address)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		logging.Fatal("failed to listen", "address", address, "error", err)
 	}
 
-	fmt.Println("Server is running on port :50051...")
+	slog.Info("server is running", "address", address)
+
+	// Serve gRPC server until stopped, then drain in-flight requests
+	if err := serve(ctx, grpcServer, healthServer, listener, config.App.ShutdownTimeout); err != nil {
+		logging.Fatal("failed to serve", "error", err)
+	}
+	slog.Info("server stopped")
+}
+
+// serve runs grpcServer on listener until ctx is done. It then reports
+// NOT_SERVING, so no new requests are routed to it, and waits up to timeout
+// for in-flight requests before closing them.
+func serve(ctx context.Context, grpcServer *grpc.Server, healthServer *grpchealth.Server, listener net.Listener, timeout time.Duration) error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
 
-	// Serve gRPC server
-	if err := grpcServer.Serve(listener); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+	slog.Info("shutting down, draining in-flight requests")
+	healthServer.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		slog.Warn("drain timed out, closing the remaining requests")
+		grpcServer.Stop()
 	}
+	return <-errs
 }
@@ -0,0 +1,11 @@
+-- Users of the platform, passwords are bcrypt hashes. IF NOT EXISTS adopts
+-- databases created by hand before migrations existed, they have these
+-- columns; version and email_verified_at come with the migrations adding them.
+CREATE TABLE IF NOT EXISTS users.users (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(150) UNIQUE NOT NULL,
+    password VARCHAR(255),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
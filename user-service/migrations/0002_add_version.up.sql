@@ -0,0 +1,3 @@
+-- The version is incremented by every update, writes made with an expected
+-- version are only applied while the user still has it.
+ALTER TABLE users.users ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
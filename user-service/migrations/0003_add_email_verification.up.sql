@@ -0,0 +1,14 @@
+-- A user proves they own their email with a link sent to it. Changing the
+-- email clears email_verified_at until the new one is verified.
+ALTER TABLE users.users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP;
+
+-- Pending verifications, only a hash of the token is kept. A token verifies
+-- the email it was sent to, not whatever email the user has later.
+CREATE TABLE IF NOT EXISTS users.email_verifications (
+    token_hash CHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users.users (id) ON DELETE CASCADE,
+    email VARCHAR(150) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_email_verifications_user ON users.email_verifications (user_id);
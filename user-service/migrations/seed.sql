@@ -0,0 +1,15 @@
+-- Sample users for development, every one signs in with password123 and has
+-- a verified email. Run
+-- with the seed command, it is never applied by migrations. Users already
+-- present are kept.
+INSERT INTO users.users (id, name, email, password, email_verified_at)
+VALUES
+(1, 'Andi Wijaya', 'andi@example.com', '$2a$10$tdi9.CWU7ZEmz8.Z6yoEt.KT6aokXLMF3kpV0UoFuAMT4fhIH2Jam', CURRENT_TIMESTAMP),
+(2, 'Siti Rahma', 'siti@example.com', '$2a$10$tdi9.CWU7ZEmz8.Z6yoEt.KT6aokXLMF3kpV0UoFuAMT4fhIH2Jam', CURRENT_TIMESTAMP),
+(3, 'Budi Santoso', 'budi@example.com', '$2a$10$tdi9.CWU7ZEmz8.Z6yoEt.KT6aokXLMF3kpV0UoFuAMT4fhIH2Jam', CURRENT_TIMESTAMP),
+(4, 'Dewi Lestari', 'dewi@example.com', '$2a$10$tdi9.CWU7ZEmz8.Z6yoEt.KT6aokXLMF3kpV0UoFuAMT4fhIH2Jam', CURRENT_TIMESTAMP),
+(5, 'Rizky Maulana', 'rizky@example.com', '$2a$10$tdi9.CWU7ZEmz8.Z6yoEt.KT6aokXLMF3kpV0UoFuAMT4fhIH2Jam', CURRENT_TIMESTAMP)
+ON CONFLICT DO NOTHING;
+
+-- the IDs were given, move the sequence past them
+SELECT setval(pg_get_serial_sequence('users.users', 'id'), GREATEST((SELECT MAX(id) FROM users.users), 1));
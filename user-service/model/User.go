@@ -1,6 +1,8 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"strings"
 	"time"
@@ -13,6 +15,11 @@ type User struct {
 	Password  string    `json:"password,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// Version is incremented by every update, see the optimistic package.
+	Version int `gorm:"not null;default:1" json:"version"`
+	// EmailVerifiedAt is when the user proved they own Email, nil until then
+	// and again after the email changes.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 }
 
 func (User) TableName() string {
@@ -29,6 +36,42 @@ func (u *User) Validate() error {
 	return nil
 }
 
+// EmailVerified tells whether the user proved they own their email.
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// MaskedEmail shows the first letter and the domain of the email,
+// j***@example.com, so a user can be recognized without revealing it.
+func (u *User) MaskedEmail() string {
+	local, domain, ok := strings.Cut(u.Email, "@")
+	if !ok || local == "" {
+		return ""
+	}
+	return local[:1] + "***@" + domain
+}
+
+// AvatarURL is the Gravatar of the email, a generated pattern for emails
+// without one.
+func (u *User) AvatarURL() string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(u.Email))))
+	return "https://www.gravatar.com/avatar/" + hex.EncodeToString(sum[:]) + "?d=identicon"
+}
+
+// EmailVerification is a pending link sent to Email, only the hash of its
+// token is kept.
+type EmailVerification struct {
+	TokenHash string `gorm:"primaryKey"`
+	UserID    int
+	Email     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func (EmailVerification) TableName() string {
+	return "users.email_verifications"
+}
+
 type UserRegister struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
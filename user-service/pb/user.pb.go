@@ -7,11 +7,13 @@
 package pb
 
 import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 const (
@@ -117,12 +119,18 @@ func (x *UserLoginRequest) GetPassword() string {
 	return ""
 }
 
+// UserRequest creates a user, or updates the fields named in update_mask,
+// name and email. Without update_mask an update replaces both. Passwords are
+// changed with ChangePassword. An update with a version fails with
+// VERSION_MISMATCH when the user has another one.
 type UserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
 	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Version       int32                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -185,23 +193,101 @@ func (x *UserRequest) GetPassword() string {
 	return ""
 }
 
+func (x *UserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UserRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// ChangePasswordRequest needs the current password of the user.
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CurrentPassword string                 `protobuf:"bytes,2,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_pb_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_pb_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ChangePasswordRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
 type UserResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Id            int32                  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Message   string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error     string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Id        int32                  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Email     string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	Password  string                 `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
+	CreatedAt string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version   int32                  `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	// email_verified_at is empty until the user verifies their email.
+	EmailVerifiedAt string `protobuf:"bytes,10,opt,name=email_verified_at,json=emailVerifiedAt,proto3" json:"email_verified_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UserResponse) Reset() {
 	*x = UserResponse{}
-	mi := &file_pb_user_proto_msgTypes[3]
+	mi := &file_pb_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -213,7 +299,7 @@ func (x *UserResponse) String() string {
 func (*UserResponse) ProtoMessage() {}
 
 func (x *UserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_user_proto_msgTypes[3]
+	mi := &file_pb_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -226,7 +312,7 @@ func (x *UserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
 func (*UserResponse) Descriptor() ([]byte, []int) {
-	return file_pb_user_proto_rawDescGZIP(), []int{3}
+	return file_pb_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UserResponse) GetMessage() string {
@@ -285,21 +371,247 @@ func (x *UserResponse) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *UserResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UserResponse) GetEmailVerifiedAt() string {
+	if x != nil {
+		return x.EmailVerifiedAt
+	}
+	return ""
+}
+
+// VerifyEmailRequest carries the token of the link sent by
+// SendEmailVerification.
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_pb_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_pb_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// UsersByIDsRequest asks for up to 500 users at once.
+type UsersByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int32                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsersByIDsRequest) Reset() {
+	*x = UsersByIDsRequest{}
+	mi := &file_pb_user_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsersByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsersByIDsRequest) ProtoMessage() {}
+
+func (x *UsersByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_user_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsersByIDsRequest.ProtoReflect.Descriptor instead.
+func (*UsersByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_user_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UsersByIDsRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// UserSummary is what other services may show of a user, the email is masked.
+type UserSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	MaskedEmail   string                 `protobuf:"bytes,4,opt,name=masked_email,json=maskedEmail,proto3" json:"masked_email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSummary) Reset() {
+	*x = UserSummary{}
+	mi := &file_pb_user_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSummary) ProtoMessage() {}
+
+func (x *UserSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_user_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSummary.ProtoReflect.Descriptor instead.
+func (*UserSummary) Descriptor() ([]byte, []int) {
+	return file_pb_user_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UserSummary) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UserSummary) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *UserSummary) GetMaskedEmail() string {
+	if x != nil {
+		return x.MaskedEmail
+	}
+	return ""
+}
+
+// UsersByIDsResponse has the users found, unknown IDs are left out.
+type UsersByIDsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserSummary         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UsersByIDsResponse) Reset() {
+	*x = UsersByIDsResponse{}
+	mi := &file_pb_user_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UsersByIDsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsersByIDsResponse) ProtoMessage() {}
+
+func (x *UsersByIDsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_user_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsersByIDsResponse.ProtoReflect.Descriptor instead.
+func (*UsersByIDsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_user_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UsersByIDsResponse) GetUsers() []*UserSummary {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
 var File_pb_user_proto protoreflect.FileDescriptor
 
 const file_pb_user_proto_rawDesc = "" +
 	"\n" +
-	"\rpb/user.proto\x12\x04user\"\x1f\n" +
+	"\rpb/user.proto\x12\x04user\x1a google/protobuf/field_mask.proto\"\x1f\n" +
 	"\rUserIdRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"D\n" +
 	"\x10UserLoginRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"c\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\xba\x01\n" +
 	"\vUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x04 \x01(\tR\bpassword\"\xd2\x01\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x05R\aversion\"u\n" +
+	"\x15ChangePasswordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12)\n" +
+	"\x10current_password\x18\x02 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"\x98\x02\n" +
 	"\fUserResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x0e\n" +
@@ -310,14 +622,33 @@ const file_pb_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt2\xe8\x01\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\x12*\n" +
+	"\x11email_verified_at\x18\n" +
+	" \x01(\tR\x0femailVerifiedAt\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"%\n" +
+	"\x11UsersByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x05R\x03ids\"s\n" +
+	"\vUserSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\x12!\n" +
+	"\fmasked_email\x18\x04 \x01(\tR\vmaskedEmail\"=\n" +
+	"\x12UsersByIDsResponse\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.user.UserSummaryR\x05users2\xee\x03\n" +
 	"\vUserService\x126\n" +
 	"\vGetUserByID\x12\x13.user.UserIdRequest\x1a\x12.user.UserResponse\x123\n" +
 	"\n" +
 	"CreateUser\x12\x11.user.UserRequest\x1a\x12.user.UserResponse\x123\n" +
 	"\n" +
 	"UpdateUser\x12\x11.user.UserRequest\x1a\x12.user.UserResponse\x127\n" +
-	"\tLoginUser\x12\x16.user.UserLoginRequest\x1a\x12.user.UserResponseB\x05Z\x03/pbb\x06proto3"
+	"\tLoginUser\x12\x16.user.UserLoginRequest\x1a\x12.user.UserResponse\x12A\n" +
+	"\x0eChangePassword\x12\x1b.user.ChangePasswordRequest\x1a\x12.user.UserResponse\x12B\n" +
+	"\rGetUsersByIDs\x12\x17.user.UsersByIDsRequest\x1a\x18.user.UsersByIDsResponse\x12@\n" +
+	"\x15SendEmailVerification\x12\x13.user.UserIdRequest\x1a\x12.user.UserResponse\x12;\n" +
+	"\vVerifyEmail\x12\x18.user.VerifyEmailRequest\x1a\x12.user.UserResponseB\x05Z\x03/pbb\x06proto3"
 
 var (
 	file_pb_user_proto_rawDescOnce sync.Once
@@ -331,27 +662,43 @@ func file_pb_user_proto_rawDescGZIP() []byte {
 	return file_pb_user_proto_rawDescData
 }
 
-var file_pb_user_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pb_user_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_pb_user_proto_goTypes = []any{
-	(*UserIdRequest)(nil),    // 0: user.UserIdRequest
-	(*UserLoginRequest)(nil), // 1: user.UserLoginRequest
-	(*UserRequest)(nil),      // 2: user.UserRequest
-	(*UserResponse)(nil),     // 3: user.UserResponse
+	(*UserIdRequest)(nil),         // 0: user.UserIdRequest
+	(*UserLoginRequest)(nil),      // 1: user.UserLoginRequest
+	(*UserRequest)(nil),           // 2: user.UserRequest
+	(*ChangePasswordRequest)(nil), // 3: user.ChangePasswordRequest
+	(*UserResponse)(nil),          // 4: user.UserResponse
+	(*VerifyEmailRequest)(nil),    // 5: user.VerifyEmailRequest
+	(*UsersByIDsRequest)(nil),     // 6: user.UsersByIDsRequest
+	(*UserSummary)(nil),           // 7: user.UserSummary
+	(*UsersByIDsResponse)(nil),    // 8: user.UsersByIDsResponse
+	(*fieldmaskpb.FieldMask)(nil), // 9: google.protobuf.FieldMask
 }
 var file_pb_user_proto_depIdxs = []int32{
-	0, // 0: user.UserService.GetUserByID:input_type -> user.UserIdRequest
-	2, // 1: user.UserService.CreateUser:input_type -> user.UserRequest
-	2, // 2: user.UserService.UpdateUser:input_type -> user.UserRequest
-	1, // 3: user.UserService.LoginUser:input_type -> user.UserLoginRequest
-	3, // 4: user.UserService.GetUserByID:output_type -> user.UserResponse
-	3, // 5: user.UserService.CreateUser:output_type -> user.UserResponse
-	3, // 6: user.UserService.UpdateUser:output_type -> user.UserResponse
-	3, // 7: user.UserService.LoginUser:output_type -> user.UserResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	9,  // 0: user.UserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	7,  // 1: user.UsersByIDsResponse.users:type_name -> user.UserSummary
+	0,  // 2: user.UserService.GetUserByID:input_type -> user.UserIdRequest
+	2,  // 3: user.UserService.CreateUser:input_type -> user.UserRequest
+	2,  // 4: user.UserService.UpdateUser:input_type -> user.UserRequest
+	1,  // 5: user.UserService.LoginUser:input_type -> user.UserLoginRequest
+	3,  // 6: user.UserService.ChangePassword:input_type -> user.ChangePasswordRequest
+	6,  // 7: user.UserService.GetUsersByIDs:input_type -> user.UsersByIDsRequest
+	0,  // 8: user.UserService.SendEmailVerification:input_type -> user.UserIdRequest
+	5,  // 9: user.UserService.VerifyEmail:input_type -> user.VerifyEmailRequest
+	4,  // 10: user.UserService.GetUserByID:output_type -> user.UserResponse
+	4,  // 11: user.UserService.CreateUser:output_type -> user.UserResponse
+	4,  // 12: user.UserService.UpdateUser:output_type -> user.UserResponse
+	4,  // 13: user.UserService.LoginUser:output_type -> user.UserResponse
+	4,  // 14: user.UserService.ChangePassword:output_type -> user.UserResponse
+	8,  // 15: user.UserService.GetUsersByIDs:output_type -> user.UsersByIDsResponse
+	4,  // 16: user.UserService.SendEmailVerification:output_type -> user.UserResponse
+	4,  // 17: user.UserService.VerifyEmail:output_type -> user.UserResponse
+	10, // [10:18] is the sub-list for method output_type
+	2,  // [2:10] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_pb_user_proto_init() }
@@ -365,7 +712,7 @@ func file_pb_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pb_user_proto_rawDesc), len(file_pb_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
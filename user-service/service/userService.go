@@ -3,29 +3,72 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/fieldmask"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+	"github.com/rayhanadri/crowdfunding/common/validation"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 
 	"github.com/rayhanadri/crowdfunding/user-service/config"
+	"github.com/rayhanadri/crowdfunding/user-service/mail"
 	"github.com/rayhanadri/crowdfunding/user-service/model"
 	"github.com/rayhanadri/crowdfunding/user-service/pb"
 )
 
 type UserService struct {
 	pb.UnimplementedUserServiceServer
+
+	// Mailer sends the links verifying emails, they are not sent while it is
+	// nil.
+	Mailer mail.Sender
 }
 
-func (s *UserService) GetUserByID(ctx context.Context, req *pb.UserIdRequest) (*pb.UserResponse, error) {
-	// Extract the ID from the request
-	id := req.GetId()
+// ReasonEmailTaken is the error reason of a registration with an email that
+// already has an account.
+const ReasonEmailTaken = "EMAIL_TAKEN"
 
-	var user model.User
-	if err := config.DB.First(&user, id).Error; err != nil {
-		return nil, err
+var errInvalidCredentials = apperror.Unauthenticated("INVALID_CREDENTIALS", "invalid email or password")
+
+// updatableFields maps the update_mask paths of UpdateUser to their columns.
+var updatableFields = map[string]string{
+	"name":  "name",
+	"email": "email",
+}
+
+// checkUser lists the invalid fields of a user, with the rules the gateway
+// applies. Only the named fields are checked, all of them when none is named.
+func checkUser(user *model.User, fields ...string) []apperror.FieldViolation {
+	checks := []struct {
+		field string
+		value string
+		check func(string) error
+	}{
+		{"name", user.Name, validation.Name},
+		{"email", user.Email, validation.Email},
+		{"password", user.Password, validation.Password},
 	}
 
-	// Create a user response
+	var violations []apperror.FieldViolation
+	for _, c := range checks {
+		if len(fields) > 0 && !slices.Contains(fields, c.field) {
+			continue
+		}
+		if err := c.check(c.value); err != nil {
+			violations = append(violations, apperror.FieldViolation{Field: c.field, Description: err.Error()})
+		}
+	}
+	return violations
+}
+
+// userResponse is the response of the RPCs answering with a user.
+func userResponse(user *model.User) *pb.UserResponse {
 	response := &pb.UserResponse{
 		Id:        int32(user.ID),
 		Name:      user.Name,
@@ -33,11 +76,65 @@ func (s *UserService) GetUserByID(ctx context.Context, req *pb.UserIdRequest) (*
 		Password:  user.Password,
 		CreatedAt: user.CreatedAt.Format(time.RFC3339),
 		UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
+		Version:   int32(user.Version),
+	}
+	if user.EmailVerifiedAt != nil {
+		response.EmailVerifiedAt = user.EmailVerifiedAt.Format(time.RFC3339)
 	}
+	return response
+}
+
+func (s *UserService) GetUserByID(ctx context.Context, req *pb.UserIdRequest) (*pb.UserResponse, error) {
+	// Extract the ID from the request
+	id := req.GetId()
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, apperror.FromDB(err, "user")
+	}
+
+	// Create a user response
+	response := userResponse(&user)
 
 	return response, nil
 }
 
+// maxUsersByIDs bounds the IDs of a GetUsersByIDs request.
+const maxUsersByIDs = 500
+
+// GetUsersByIDs returns the summaries of many users in one query, for the
+// listings of other services.
+func (s *UserService) GetUsersByIDs(ctx context.Context, req *pb.UsersByIDsRequest) (*pb.UsersByIDsResponse, error) {
+	ids := req.GetIds()
+	if len(ids) > maxUsersByIDs {
+		return nil, apperror.InvalidArgument("too many users requested", apperror.FieldViolation{
+			Field:       "ids",
+			Description: fmt.Sprintf("at most %d IDs per request", maxUsersByIDs),
+		})
+	}
+
+	response := &pb.UsersByIDsResponse{}
+	if len(ids) == 0 {
+		return response, nil
+	}
+
+	var users []model.User
+	if err := config.DB.WithContext(ctx).Select("id", "name", "email").Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, apperror.FromDB(err, "user")
+	}
+
+	response.Users = make([]*pb.UserSummary, 0, len(users))
+	for _, user := range users {
+		response.Users = append(response.Users, &pb.UserSummary{
+			Id:          int32(user.ID),
+			Name:        user.Name,
+			AvatarUrl:   user.AvatarURL(),
+			MaskedEmail: user.MaskedEmail(),
+		})
+	}
+	return response, nil
+}
+
 func (r *UserService) CreateUser(ctx context.Context, req *pb.UserRequest) (*pb.UserResponse, error) {
 	user := &model.User{
 		Name:     req.GetName(),
@@ -46,96 +143,158 @@ func (r *UserService) CreateUser(ctx context.Context, req *pb.UserRequest) (*pb.
 	}
 
 	//validate user data
-	if user.Name == "" || user.Email == "" || user.Password == "" {
-		err := errors.New("name, email, and password are required")
-		response := &pb.UserResponse{
-			Message: "Failed to create user",
-			Error:   err.Error(),
-		}
-
-		return response, err
-	}
-	if len(user.Password) < 6 {
-		err := errors.New("password must be at least 6 characters long")
-		response := &pb.UserResponse{
-			Message: "Failed to create user",
-			Error:   err.Error(),
-		}
-		return response, err
+	violations := checkUser(user)
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("invalid user", violations...)
 	}
 
 	userPass := user.Password
 	userPassHash, err := bcrypt.GenerateFromPassword([]byte(userPass), bcrypt.DefaultCost)
 	if err != nil {
-		response := &pb.UserResponse{
-			Message: "Failed to create user",
-			Error:   err.Error(),
-		}
-
-		return response, err
+		return nil, apperror.Internal(err)
 	}
 	user.Password = string(userPassHash)
 
-	if err := config.DB.Omit("id").Create(user).Error; err != nil {
-		response := &pb.UserResponse{
-			Message: "Failed to create user",
-			Error:   err.Error(),
+	if err := config.DB.WithContext(ctx).Omit("id").Create(user).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, apperror.AlreadyExists(ReasonEmailTaken, "email is already registered")
 		}
-
-		return response, err
+		return nil, apperror.FromDB(err, "user")
 	}
 
-	if err := config.DB.Last(user).Error; err != nil {
-		response := &pb.UserResponse{
-			Message: "Failed to create user",
-			Error:   err.Error(),
-		}
+	if err := config.DB.WithContext(ctx).Last(user).Error; err != nil {
+		return nil, apperror.FromDB(err, "user")
+	}
 
-		return response, err
+	// the account works without a verified email, the link can be sent again
+	if err := r.sendEmailVerification(ctx, user); err != nil {
+		slog.WarnContext(ctx, "verification email not sent", "user_id", user.ID, "error", err)
 	}
 
 	// Create a user response
-	response := &pb.UserResponse{
-		Message:   "User created successfully",
-		Id:        int32(user.ID),
-		Name:      user.Name,
-		Email:     user.Email,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
-	}
+	response := userResponse(user)
+	response.Message = "User created successfully"
 
 	return response, nil
 }
 
 func (r *UserService) UpdateUser(ctx context.Context, req *pb.UserRequest) (*pb.UserResponse, error) {
 	user := &model.User{
-		ID:       int(req.GetId()),
-		Name:     req.GetName(),
-		Email:    req.GetEmail(),
-		Password: req.GetPassword(),
+		ID:    int(req.GetId()),
+		Name:  req.GetName(),
+		Email: req.GetEmail(),
 	}
 
-	userPass := user.Password
-	userPassHash, err := bcrypt.GenerateFromPassword([]byte(userPass), bcrypt.DefaultCost)
+	if req.GetPassword() != "" {
+		return nil, apperror.InvalidArgument("invalid user",
+			apperror.FieldViolation{Field: "password", Description: "password is changed with ChangePassword"},
+		)
+	}
+
+	// only the fields named in the mask change, without one both are replaced
+	columns, err := fieldmask.Columns(req.GetUpdateMask(), updatableFields)
 	if err != nil {
 		return nil, err
 	}
-	user.Password = string(userPassHash)
+	if columns == nil {
+		columns = []string{"name", "email"}
+	}
 
-	if err := config.DB.Model(user).Updates(user).Error; err != nil {
+	//validate the fields being changed
+	violations := checkUser(user, columns...)
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("invalid user", violations...)
+	}
+
+	var current model.User
+	if err := config.DB.WithContext(ctx).Select("id", "email", "version").First(&current, user.ID).Error; err != nil {
+		return nil, apperror.FromDB(err, "user")
+	}
+	// a new email has to be verified again
+	emailChanged := slices.Contains(columns, "email") && !strings.EqualFold(current.Email, user.Email)
+	if emailChanged {
+		columns = append(columns, "email_verified_at")
+	}
+	version, err := optimistic.Check("user", int(req.GetVersion()), current.Version)
+	if err != nil {
 		return nil, err
 	}
+	user.Version = version + 1
+
+	// the version guards against a concurrent update
+	result := config.DB.WithContext(ctx).Model(user).Where("version = ?", version).Select(append(columns, "version")).Updates(user)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, apperror.AlreadyExists(ReasonEmailTaken, "email is already registered")
+		}
+		return nil, apperror.FromDB(err, "user")
+	}
+	if result.RowsAffected == 0 {
+		return nil, optimistic.Mismatch("user")
+	}
+
+	if err := config.DB.WithContext(ctx).First(user, user.ID).Error; err != nil {
+		return nil, apperror.FromDB(err, "user")
+	}
+
+	if emailChanged {
+		if err := r.sendEmailVerification(ctx, user); err != nil {
+			slog.WarnContext(ctx, "verification email not sent", "user_id", user.ID, "error", err)
+		}
+	}
 
 	// Create a user response
-	response := &pb.UserResponse{
-		Id:        int32(user.ID),
-		Name:      user.Name,
-		Email:     user.Email,
-		Password:  user.Password,
-		CreatedAt: user.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
+	response := userResponse(user)
+	response.Message = "User updated successfully"
+
+	return response, nil
+}
+
+// ChangePassword replaces the password of a user who knows the current one.
+func (r *UserService) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.UserResponse, error) {
+	var violations []apperror.FieldViolation
+	if req.GetCurrentPassword() == "" {
+		violations = append(violations, apperror.FieldViolation{Field: "current_password", Description: "current_password is required"})
+	}
+	if err := validation.Password(req.GetNewPassword()); err != nil {
+		violations = append(violations, apperror.FieldViolation{Field: "new_password", Description: err.Error()})
+	}
+	if len(violations) > 0 {
+		return nil, apperror.InvalidArgument("invalid password change", violations...)
+	}
+
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, req.GetId()).Error; err != nil {
+		return nil, apperror.FromDB(err, "user")
 	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.GetCurrentPassword())); err != nil {
+		return nil, apperror.InvalidArgument("invalid password change",
+			apperror.FieldViolation{Field: "current_password", Description: "current password is incorrect"},
+		)
+	}
+
+	userPassHash, err := bcrypt.GenerateFromPassword([]byte(req.GetNewPassword()), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+
+	// the current hash guards against a concurrent change
+	result := config.DB.WithContext(ctx).Model(&user).Where("password = ?", user.Password).Updates(map[string]interface{}{
+		"password": string(userPassHash),
+		"version":  optimistic.Increment,
+	})
+	if err := result.Error; err != nil {
+		return nil, apperror.FromDB(err, "user")
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperror.Aborted("password was changed concurrently, try again")
+	}
+	user.Version++
+
+	// Create a user response
+	response := userResponse(&user)
+	response.Message = "Password changed successfully"
+	response.Password = ""
 
 	return response, nil
 }
@@ -144,27 +303,24 @@ func (r *UserService) LoginUser(ctx context.Context, req *pb.UserLoginRequest) (
 	email := req.GetEmail()
 	password := req.GetPassword()
 	if email == "" || password == "" {
-		return nil, errors.New("email and password are required")
+		return nil, apperror.InvalidArgument("email and password are required")
 	}
 
-	// get pass from database and compare with user input
+	// get pass from database and compare with user input, an unknown email
+	// and a wrong password look the same
 	var userDb model.User
-	if err := config.DB.Where("email = ?", req.GetEmail()).First(&userDb).Error; err != nil {
-		return nil, err
+	if err := config.DB.WithContext(ctx).Where("email = ?", req.GetEmail()).First(&userDb).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInvalidCredentials
+		}
+		return nil, apperror.FromDB(err, "user")
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(userDb.Password), []byte(password)); err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, errInvalidCredentials
 	}
 
 	// Create a user response
-	response := &pb.UserResponse{
-		Id:        int32(userDb.ID),
-		Name:      userDb.Name,
-		Email:     userDb.Email,
-		Password:  userDb.Password,
-		CreatedAt: userDb.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: userDb.UpdatedAt.Format(time.RFC3339),
-	}
+	response := userResponse(&userDb)
 
 	return response, nil
 }
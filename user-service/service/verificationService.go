@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rayhanadri/crowdfunding/common/apperror"
+	"github.com/rayhanadri/crowdfunding/common/optimistic"
+	"gorm.io/gorm"
+
+	"github.com/rayhanadri/crowdfunding/user-service/config"
+	"github.com/rayhanadri/crowdfunding/user-service/mail"
+	"github.com/rayhanadri/crowdfunding/user-service/model"
+	"github.com/rayhanadri/crowdfunding/user-service/pb"
+)
+
+// Error reasons of email verification.
+const (
+	ReasonEmailAlreadyVerified = "EMAIL_ALREADY_VERIFIED"
+	ReasonVerificationInvalid  = "VERIFICATION_TOKEN_INVALID"
+	ReasonMailUnavailable      = "MAIL_UNAVAILABLE"
+)
+
+var errVerificationInvalid = apperror.FailedPrecondition(ReasonVerificationInvalid, "verification link is invalid or expired")
+
+// newVerificationToken returns a random token for the link and the hash it is
+// stored as.
+func newVerificationToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashVerificationToken(token), nil
+}
+
+func hashVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// verificationLink adds token to the query of the verification page.
+func verificationLink(page string, token string) (string, error) {
+	u, err := url.Parse(page)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// sendEmailVerification mails a link verifying the current email of user. It
+// replaces the links sent before, only the latest one works.
+func (s *UserService) sendEmailVerification(ctx context.Context, user *model.User) error {
+	if s.Mailer == nil {
+		return apperror.Unavailable(ReasonMailUnavailable, "verification emails cannot be sent, try again later")
+	}
+
+	token, hash, err := newVerificationToken()
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	link, err := verificationLink(config.App.EmailVerification.URL, token)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+
+	verification := &model.EmailVerification{
+		TokenHash: hash,
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(config.App.EmailVerification.TTL),
+	}
+	err = config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", user.ID).Delete(&model.EmailVerification{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(verification).Error
+	})
+	if err != nil {
+		return apperror.FromDB(err, "email verification")
+	}
+
+	err = s.Mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen this link to verify your email:\n\n%s\n\nThe link expires in %s. If you did not sign up, ignore this email.\n",
+			user.Name, link, config.App.EmailVerification.TTL),
+	})
+	if err != nil {
+		return apperror.Unavailable(ReasonMailUnavailable, "verification email could not be sent, try again later")
+	}
+	return nil
+}
+
+// SendEmailVerification mails the user a new link verifying their email.
+func (s *UserService) SendEmailVerification(ctx context.Context, req *pb.UserIdRequest) (*pb.UserResponse, error) {
+	var user model.User
+	if err := config.DB.WithContext(ctx).First(&user, req.GetId()).Error; err != nil {
+		return nil, apperror.FromDB(err, "user")
+	}
+	if user.EmailVerified() {
+		return nil, apperror.FailedPrecondition(ReasonEmailAlreadyVerified, "email is already verified")
+	}
+
+	if err := s.sendEmailVerification(ctx, &user); err != nil {
+		return nil, err
+	}
+
+	response := userResponse(&user)
+	response.Message = "Verification email sent"
+	response.Password = ""
+	return response, nil
+}
+
+// VerifyEmail marks the email a link was sent to as verified, as long as the
+// user still has it.
+func (s *UserService) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.UserResponse, error) {
+	if req.GetToken() == "" {
+		return nil, apperror.InvalidArgument("invalid email verification",
+			apperror.FieldViolation{Field: "token", Description: "token is required"},
+		)
+	}
+
+	var user model.User
+	err := config.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var verification model.EmailVerification
+		err := tx.Where("token_hash = ? AND expires_at > ?", hashVerificationToken(req.GetToken()), time.Now()).First(&verification).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errVerificationInvalid
+		}
+		if err != nil {
+			return err
+		}
+
+		// a link sent to an email the user changed since does not verify the new one
+		result := tx.Model(&model.User{}).
+			Where("id = ? AND LOWER(email) = LOWER(?)", verification.UserID, verification.Email).
+			Updates(map[string]interface{}{"email_verified_at": time.Now(), "version": optimistic.Increment})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errVerificationInvalid
+		}
+
+		if err := tx.Where("user_id = ?", verification.UserID).Delete(&model.EmailVerification{}).Error; err != nil {
+			return err
+		}
+		return tx.First(&user, verification.UserID).Error
+	})
+	if errors.Is(err, errVerificationInvalid) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, apperror.FromDB(err, "user")
+	}
+
+	response := userResponse(&user)
+	response.Message = "Email verified successfully"
+	response.Password = ""
+	return response, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestVerificationLink(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want string
+	}{
+		{"gateway endpoint", "http://localhost:8080/api/v1/users/verify-email", "http://localhost:8080/api/v1/users/verify-email?token=abc-_1"},
+		{"page with a query", "https://app.example.com/verify?lang=id", "https://app.example.com/verify?lang=id&token=abc-_1"},
+		{"token already set", "https://app.example.com/verify?token=old", "https://app.example.com/verify?token=abc-_1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verificationLink(tt.page, "abc-_1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("verificationLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewVerificationToken(t *testing.T) {
+	token, hash, err := newVerificationToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url.QueryEscape(token) != token {
+		t.Errorf("token %q is not safe in a URL", token)
+	}
+	if hash != hashVerificationToken(token) || len(hash) != 64 {
+		t.Errorf("hash %q is not the SHA-256 of the token", hash)
+	}
+
+	other, _, err := newVerificationToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == token {
+		t.Error("two tokens are the same")
+	}
+}